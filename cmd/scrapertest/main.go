@@ -0,0 +1,304 @@
+// scrapertest runs a single scraper outside of the stash server, optionally
+// using recorded fixtures instead of making network requests.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/stashapp/stash/internal/log"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+// maxImageLength is the length that image data URIs are truncated to in the
+// output, unless --full-images is set.
+const maxImageLength = 64
+
+type options struct {
+	scraperPath string
+	contentType string
+	url         string
+	name        string
+	fragment    string
+	fixture     string
+	record      string
+	database    string
+	userAgent   string
+	cdpPath     string
+	fullImages  bool
+}
+
+// globalConfig provides the global scraper options. Responses are never
+// cached, so that fixtures are always used.
+type globalConfig struct {
+	scrapersPath string
+	userAgent    string
+	cdpPath      string
+}
+
+func (c globalConfig) GetScraperUserAgent() string { return c.userAgent }
+func (c globalConfig) GetScrapersPath() string     { return c.scrapersPath }
+func (c globalConfig) GetScraperCDPPath() string   { return c.cdpPath }
+func (c globalConfig) GetScraperCertCheck() bool   { return true }
+func (c globalConfig) GetPythonPath() string       { return "" }
+func (c globalConfig) GetProxy() string            { return "" }
+func (c globalConfig) GetCachePath() string        { return "" }
+
+func customUsage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	fmt.Fprintf(os.Stderr, "%s [OPTIONS] SCRAPER.yml\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Runs the scraper and prints the scraped content as JSON.\n")
+	fmt.Fprintf(os.Stderr, "Exactly one of --url, --name or --fragment must be set.\n\nOptions:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	var o options
+	flag.Usage = customUsage
+	flag.StringVarP(&o.contentType, "type", "t", "scene", "content type to scrape: scene, performer, gallery, image or group")
+	flag.StringVarP(&o.url, "url", "u", "", "scrape by URL")
+	flag.StringVarP(&o.name, "name", "n", "", "scrape by name")
+	flag.StringVar(&o.fragment, "fragment", "", "scrape by fragment, given as a JSON object of the input fields")
+	flag.StringVarP(&o.fixture, "fixture", "f", "", "respond to requests with the contents of a file, or with the responses recorded in a directory")
+	flag.StringVarP(&o.record, "record", "r", "", "record the responses to requests in a directory")
+	flag.StringVar(&o.database, "database", "", "stash database used to match scraped objects. Uses an empty database if not set")
+	flag.StringVar(&o.userAgent, "user-agent", "", "scraper user agent")
+	flag.StringVar(&o.cdpPath, "cdp-path", "", "path to chrome executable or remote address for CDP scrapers")
+	flag.BoolVar(&o.fullImages, "full-images", false, "print full image data instead of truncating it")
+	verbose := flag.BoolP("verbose", "v", false, "print debug log messages")
+	help := flag.BoolP("help", "h", false, "print this help output")
+	flag.Parse()
+
+	if *help {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Missing SCRAPER.yml argument.\n")
+		flag.Usage()
+		os.Exit(2)
+	}
+	o.scraperPath = args[0]
+
+	logLevel := "Warning"
+	if *verbose {
+		logLevel = "Debug"
+	}
+	l := log.NewLogger()
+	l.Init("", true, logLevel)
+	logger.Logger = l
+
+	if err := run(context.Background(), o); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, o options) error {
+	ty, err := parseContentType(o.contentType)
+	if err != nil {
+		return err
+	}
+
+	set := 0
+	for _, v := range []string{o.url, o.name, o.fragment} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of --url, --name or --fragment must be set")
+	}
+
+	if o.fixture != "" && o.record != "" {
+		return errors.New("--fixture and --record cannot be used together")
+	}
+
+	db, cleanup, err := openDatabase(o.database)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cache := scraper.NewCache(globalConfig{
+		scrapersPath: filepath.Dir(o.scraperPath),
+		userAgent:    o.userAgent,
+		cdpPath:      o.cdpPath,
+	}, scraper.NewRepository(db.Repository()))
+
+	switch {
+	case o.fixture != "":
+		transport, err := scraper.NewFixtureTransport(o.fixture)
+		if err != nil {
+			return fmt.Errorf("loading fixture: %w", err)
+		}
+		cache.WrapTransport(func(http.RoundTripper) http.RoundTripper {
+			return transport
+		})
+	case o.record != "":
+		cache.WrapTransport(func(base http.RoundTripper) http.RoundTripper {
+			return scraper.NewRecordingTransport(base, o.record)
+		})
+	}
+
+	s, err := cache.LoadScraperFile(o.scraperPath)
+	if err != nil {
+		return fmt.Errorf("loading scraper: %w", err)
+	}
+
+	var result interface{}
+	switch {
+	case o.url != "":
+		content, err := cache.ScrapeIDURL(ctx, s.ID, o.url, ty)
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			logger.Warnf("scraper %s returned no %s for url %s", s.ID, ty, o.url)
+		}
+		result = content
+	case o.name != "":
+		content, err := cache.ScrapeName(ctx, s.ID, o.name, ty)
+		if err != nil {
+			return err
+		}
+		result = content
+	default:
+		input, err := parseFragment(ty, o.fragment)
+		if err != nil {
+			return err
+		}
+		content, err := cache.ScrapeFragment(ctx, s.ID, *input)
+		if err != nil {
+			return err
+		}
+		result = content
+	}
+
+	return printResult(result, o.fullImages)
+}
+
+func parseContentType(v string) (scraper.ScrapeContentType, error) {
+	ret := scraper.ScrapeContentType(strings.ToUpper(v))
+	if !ret.IsValid() {
+		return "", fmt.Errorf("invalid content type %q", v)
+	}
+
+	return ret, nil
+}
+
+func parseFragment(ty scraper.ScrapeContentType, v string) (*scraper.Input, error) {
+	var ret scraper.Input
+	var dest interface{}
+
+	switch ty {
+	case scraper.ScrapeContentTypeScene:
+		ret.Scene = &scraper.ScrapedSceneInput{}
+		dest = ret.Scene
+	case scraper.ScrapeContentTypePerformer:
+		ret.Performer = &scraper.ScrapedPerformerInput{}
+		dest = ret.Performer
+	case scraper.ScrapeContentTypeGallery:
+		ret.Gallery = &scraper.ScrapedGalleryInput{}
+		dest = ret.Gallery
+	case scraper.ScrapeContentTypeImage:
+		ret.Image = &scraper.ScrapedImageInput{}
+		dest = ret.Image
+	default:
+		return nil, fmt.Errorf("cannot scrape %s by fragment", ty)
+	}
+
+	d := json.NewDecoder(strings.NewReader(v))
+	d.DisallowUnknownFields()
+	if err := d.Decode(dest); err != nil {
+		return nil, fmt.Errorf("invalid fragment: %w", err)
+	}
+
+	return &ret, nil
+}
+
+// openDatabase opens the database at path. If path is empty, then a
+// temporary database is created and removed by the returned cleanup function.
+func openDatabase(path string) (*sqlite.Database, func(), error) {
+	temporary := path == ""
+	if temporary {
+		f, err := os.CreateTemp("", "scrapertest-*.sqlite")
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating temporary database: %w", err)
+		}
+		f.Close()
+		path = f.Name()
+	}
+
+	db := sqlite.NewDatabase()
+	if err := db.Open(path); err != nil {
+		if temporary {
+			os.Remove(path)
+		}
+		return nil, nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			logger.Warnf("error closing database: %v", err)
+		}
+		if temporary {
+			os.Remove(path)
+		}
+	}
+
+	return db, cleanup, nil
+}
+
+func printResult(result interface{}, fullImages bool) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if !fullImages {
+		v = truncateImages(v)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// truncateImages truncates image data URIs in v, which are typically too long
+// to be usefully printed.
+func truncateImages(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "data:") && len(v) > maxImageLength {
+			return fmt.Sprintf("%s... (%d bytes)", v[:maxImageLength], len(v))
+		}
+	case map[string]interface{}:
+		for k, vv := range v {
+			v[k] = truncateImages(vv)
+		}
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = truncateImages(vv)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/audit"
+)
+
+// auditRootField is a root field middleware that records the changes made by
+// mutations in the audit log, with the name of the mutation as the source.
+func auditRootField(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	fc := graphql.GetRootFieldContext(ctx)
+	if fc == nil || fc.Object != "Mutation" {
+		return next(ctx)
+	}
+
+	return next(audit.WithSource(ctx, fc.Field.Name))
+}
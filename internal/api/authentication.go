@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/session"
 )
 
@@ -29,6 +30,22 @@ func allowUnauthenticated(r *http.Request) bool {
 	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets")
 }
 
+// apiKeyAllowsRequest returns false if the request writes data and the API key
+// is read-only. Only safe methods are allowed with read-only keys, except for
+// the graphql endpoint, where mutations are rejected by authorizeRootField.
+func apiKeyAllowsRequest(key *models.APIKey, r *http.Request) bool {
+	if key.Scope != models.APIKeyScopeReadOnly || r.URL.Path == gqlEndpoint {
+		return true
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
 func authenticateHandler() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +57,24 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			userID, err := manager.GetInstance().SessionStore.Authenticate(w, r)
+			mgr := manager.GetInstance()
+			ctx := r.Context()
+
+			var userID string
+			var apiKey *models.APIKey
+			var err error
+			if key := session.APIKeyFromRequest(r); key != "" && key != c.GetAPIKey() {
+				// named api keys are stored in the database. The key in the
+				// configuration is handled by the session store.
+				apiKey, userID, err = mgr.AuthenticateAPIKey(ctx, key)
+				if errors.Is(err, manager.ErrInvalidToken) {
+					logger.Warnf("rejected API key for %s %s: %v", r.Method, r.URL.Path, err)
+					err = session.ErrUnauthorized
+				}
+			} else {
+				userID, err = mgr.SessionStore.Authenticate(w, r)
+			}
+
 			if err != nil {
 				if !errors.Is(err, session.ErrUnauthorized) {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -71,14 +105,40 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			ctx := r.Context()
+			if apiKey != nil {
+				if !apiKeyAllowsRequest(apiKey, r) {
+					logger.Warnf("rejected read-only API key %q (id %d) for %s %s", apiKey.Name, apiKey.ID, r.Method, r.URL.Path)
+					http.Error(w, "read-only API keys cannot perform write requests", http.StatusForbidden)
+					return
+				}
+
+				logger.Tracef("%s %s authenticated with API key %q (id %d)", r.Method, r.URL.Path, apiKey.Name, apiKey.ID)
+				ctx = models.WithAPIKey(ctx, apiKey)
+			}
+
+			if userID != "" && userID != c.GetUsername() {
+				// users other than the configured user are stored in the database
+				u, err := mgr.FindUser(ctx, userID)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				if u == nil {
+					// user has been deleted, treat as logged out
+					userID = ""
+				} else {
+					ctx = models.WithUserID(ctx, u.ID)
+					ctx = models.WithRole(ctx, u.Role, c.GetRoleRestrictions(u.Role))
+				}
+			}
 
 			if c.HasCredentials() {
 				// authentication is required
 				if userID == "" && !allowUnauthenticated(r) {
 					// if graphql or a non-webpage was requested, we just return a forbidden error
 					ext := path.Ext(r.URL.Path)
-					if r.URL.Path == gqlEndpoint || (ext != "" && ext != ".html") {
+					if r.URL.Path == gqlEndpoint || r.URL.Path == metricsEndpoint || (ext != "" && ext != ".html") {
 						w.Header().Add("WWW-Authenticate", "FormBased")
 						w.WriteHeader(http.StatusUnauthorized)
 						return
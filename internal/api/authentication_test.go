@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestAPIKeyAllowsRequest(t *testing.T) {
+	readOnly := &models.APIKey{Scope: models.APIKeyScopeReadOnly}
+	readWrite := &models.APIKey{Scope: models.APIKeyScopeReadWrite}
+
+	tests := []struct {
+		name   string
+		key    *models.APIKey
+		method string
+		path   string
+		want   bool
+	}{
+		{"read-only get", readOnly, http.MethodGet, "/scene/1/stream", true},
+		{"read-only head", readOnly, http.MethodHead, "/scene/1/stream", true},
+		{"read-only post", readOnly, http.MethodPost, "/custom/file", false},
+		{"read-only put", readOnly, http.MethodPut, "/plugin/test/assets/file", false},
+		{"read-only delete", readOnly, http.MethodDelete, "/scene/1/stream", false},
+		{"read-only graphql post", readOnly, http.MethodPost, gqlEndpoint, true},
+		{"read-write post", readWrite, http.MethodPost, "/custom/file", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.want, apiKeyAllowsRequest(tt.key, r))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const hasRoleDirective = "hasRole"
+
+// requiredRole returns the role required to resolve the root field of the
+// provided object type. The role is set with the hasRole directive. Fields
+// without the directive require the editor role for mutations, and the
+// viewer role otherwise.
+func requiredRole(object string, field *ast.FieldDefinition) models.UserRole {
+	if d := field.Directives.ForName(hasRoleDirective); d != nil {
+		if arg := d.Arguments.ForName("role"); arg != nil && arg.Value != nil {
+			return models.UserRole(arg.Value.Raw)
+		}
+	}
+
+	if object == "Mutation" {
+		return models.UserRoleEditor
+	}
+
+	return models.UserRoleViewer
+}
+
+// authorizeRootField is a root field middleware that rejects fields requiring
+// a role which the current user does not have, and mutations made with
+// read-only API keys.
+func authorizeRootField(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	fc := graphql.GetRootFieldContext(ctx)
+	if fc == nil || fc.Field.Field == nil || fc.Field.Definition == nil {
+		return next(ctx)
+	}
+
+	if key := models.APIKeyFromContext(ctx); key != nil && key.Scope == models.APIKeyScopeReadOnly && fc.Object == "Mutation" {
+		graphql.AddError(ctx, fmt.Errorf("%s cannot be performed with a read-only API key", fc.Field.Name))
+		return graphql.Null
+	}
+
+	required := requiredRole(fc.Object, fc.Field.Definition)
+	if role := models.RoleFromContext(ctx); !role.Includes(required) {
+		graphql.AddError(ctx, fmt.Errorf("%s requires the %s role", fc.Field.Name, required))
+		return graphql.Null
+	}
+
+	return next(ctx)
+}
+
+// findManyVisible returns the objects with the provided ids. If content is
+// restricted for the current user, objects hidden by the restrictions are
+// omitted, since find applies the restrictions and findMany does not.
+func findManyVisible[T any](ctx context.Context, ids []int, findMany func(context.Context, []int) ([]*T, error), find func(context.Context, int) (*T, error)) ([]*T, error) {
+	if models.RestrictionsFromContext(ctx) == nil {
+		return findMany(ctx, ids)
+	}
+
+	var ret []*T
+	for _, id := range ids {
+		o, err := find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if o != nil {
+			ret = append(ret, o)
+		}
+	}
+
+	return ret, nil
+}
+
+// omitHidden returns objs without the nil entries left by the dataloaders for
+// objects hidden by the content restrictions.
+func omitHidden[T any](objs []*T) []*T {
+	ret := make([]*T, 0, len(objs))
+	for _, o := range objs {
+		if o != nil {
+			ret = append(ret, o)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestRequiredRole(t *testing.T) {
+	schema := NewExecutableSchema(Config{}).Schema()
+
+	tests := []struct {
+		object string
+		field  string
+		want   models.UserRole
+	}{
+		{"Query", "findScenes", models.UserRoleViewer},
+		{"Query", "scrapeSingleScene", models.UserRoleEditor},
+		{"Query", "configuration", models.UserRoleAdmin},
+		{"Mutation", "sceneUpdate", models.UserRoleEditor},
+		{"Mutation", "sceneAddPlay", models.UserRoleViewer},
+		{"Mutation", "userUpdate", models.UserRoleViewer},
+		{"Mutation", "execSQL", models.UserRoleAdmin},
+		{"Mutation", "metadataScan", models.UserRoleAdmin},
+		{"Subscription", "jobsSubscribe", models.UserRoleViewer},
+		{"Subscription", "loggingSubscribe", models.UserRoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.object+"."+tt.field, func(t *testing.T) {
+			def := schema.Types[tt.object].Fields.ForName(tt.field)
+			if def == nil {
+				t.Fatalf("field %s.%s not found", tt.object, tt.field)
+			}
+
+			assert.Equal(t, tt.want, requiredRole(tt.object, def))
+		})
+	}
+}
+
+func TestUserRole_Includes(t *testing.T) {
+	assert.True(t, models.UserRoleAdmin.Includes(models.UserRoleEditor))
+	assert.True(t, models.UserRoleEditor.Includes(models.UserRoleEditor))
+	assert.True(t, models.UserRoleEditor.Includes(models.UserRoleViewer))
+	assert.False(t, models.UserRoleViewer.Includes(models.UserRoleEditor))
+	assert.False(t, models.UserRoleEditor.Includes(models.UserRoleAdmin))
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"encoding/json"
 	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 // JSONNumberToNumber converts a JSON number to either a float64 or int64.
@@ -34,3 +36,16 @@ func convertMapJSONNumbers(m map[string]interface{}) (ret map[string]interface{}
 
 	return ret
 }
+
+// convertCustomFieldsInput converts all JSON numbers in the custom fields input
+// to either float64 or int64. Returns an empty input if the input is nil.
+func convertCustomFieldsInput(input *models.CustomFieldsInput) models.CustomFieldsInput {
+	if input == nil {
+		return models.CustomFieldsInput{}
+	}
+
+	return models.CustomFieldsInput{
+		Full:    convertMapJSONNumbers(input.Full),
+		Partial: convertMapJSONNumbers(input.Partial),
+	}
+}
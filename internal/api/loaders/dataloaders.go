@@ -19,6 +19,16 @@
 //go:generate go run github.com/vektah/dataloaden SceneOHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden ScenePlayHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden SceneLastPlayedLoader int *time.Time
+//go:generate go run github.com/vektah/dataloaden ImageOCountLoader int int
+//go:generate go run github.com/vektah/dataloaden ImageViewCountLoader int int
+//go:generate go run github.com/vektah/dataloaden ImageOHistoryLoader int []time.Time
+//go:generate go run github.com/vektah/dataloaden ImageViewHistoryLoader int []time.Time
+//go:generate go run github.com/vektah/dataloaden ImageLastViewedLoader int *time.Time
+//go:generate go run github.com/vektah/dataloaden GalleryOCountLoader int int
+//go:generate go run github.com/vektah/dataloaden GalleryViewCountLoader int int
+//go:generate go run github.com/vektah/dataloaden GalleryOHistoryLoader int []time.Time
+//go:generate go run github.com/vektah/dataloaden GalleryViewHistoryLoader int []time.Time
+//go:generate go run github.com/vektah/dataloaden GalleryLastViewedLoader int *time.Time
 package loaders
 
 import (
@@ -41,20 +51,35 @@ const (
 )
 
 type Loaders struct {
-	SceneByID        *SceneLoader
-	SceneFiles       *SceneFileIDsLoader
-	ScenePlayCount   *ScenePlayCountLoader
-	SceneOCount      *SceneOCountLoader
-	ScenePlayHistory *ScenePlayHistoryLoader
-	SceneOHistory    *SceneOHistoryLoader
-	SceneLastPlayed  *SceneLastPlayedLoader
-
-	ImageFiles   *ImageFileIDsLoader
-	GalleryFiles *GalleryFileIDsLoader
+	SceneByID         *SceneLoader
+	SceneFiles        *SceneFileIDsLoader
+	ScenePlayCount    *ScenePlayCountLoader
+	SceneOCount       *SceneOCountLoader
+	ScenePlayHistory  *ScenePlayHistoryLoader
+	SceneOHistory     *SceneOHistoryLoader
+	SceneLastPlayed   *SceneLastPlayedLoader
+	SceneCustomFields *CustomFieldsLoader
+
+	ImageFiles       *ImageFileIDsLoader
+	ImageViewCount   *ImageViewCountLoader
+	ImageOCount      *ImageOCountLoader
+	ImageViewHistory *ImageViewHistoryLoader
+	ImageOHistory    *ImageOHistoryLoader
+	ImageLastViewed  *ImageLastViewedLoader
+
+	GalleryFiles       *GalleryFileIDsLoader
+	GalleryViewCount   *GalleryViewCountLoader
+	GalleryOCount      *GalleryOCountLoader
+	GalleryViewHistory *GalleryViewHistoryLoader
+	GalleryOHistory    *GalleryOHistoryLoader
+	GalleryLastViewed  *GalleryLastViewedLoader
 
 	GalleryByID *GalleryLoader
 	ImageByID   *ImageLoader
 
+	GalleryCustomFields *CustomFieldsLoader
+	ImageCustomFields   *CustomFieldsLoader
+
 	PerformerByID         *PerformerLoader
 	PerformerCustomFields *CustomFieldsLoader
 
@@ -62,6 +87,10 @@ type Loaders struct {
 	TagByID    *TagLoader
 	GroupByID  *GroupLoader
 	FileByID   *FileLoader
+
+	StudioCustomFields *CustomFieldsLoader
+	TagCustomFields    *CustomFieldsLoader
+	GroupCustomFields  *CustomFieldsLoader
 }
 
 type Middleware struct {
@@ -95,7 +124,37 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 			PerformerCustomFields: &CustomFieldsLoader{
 				wait:     wait,
 				maxBatch: maxBatch,
-				fetch:    m.fetchPerformerCustomFields(ctx),
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Performer),
+			},
+			SceneCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Scene),
+			},
+			GalleryCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Gallery),
+			},
+			ImageCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Image),
+			},
+			StudioCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Studio),
+			},
+			TagCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Tag),
+			},
+			GroupCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Group),
 			},
 			StudioByID: &StudioLoader{
 				wait:     wait,
@@ -157,6 +216,56 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 				maxBatch: maxBatch,
 				fetch:    m.fetchScenesOHistory(ctx),
 			},
+			ImageViewCount: &ImageViewCountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchImagesViewCount(ctx),
+			},
+			ImageOCount: &ImageOCountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchImagesOCount(ctx),
+			},
+			ImageViewHistory: &ImageViewHistoryLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchImagesViewHistory(ctx),
+			},
+			ImageOHistory: &ImageOHistoryLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchImagesOHistory(ctx),
+			},
+			ImageLastViewed: &ImageLastViewedLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchImagesLastViewed(ctx),
+			},
+			GalleryViewCount: &GalleryViewCountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesViewCount(ctx),
+			},
+			GalleryOCount: &GalleryOCountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesOCount(ctx),
+			},
+			GalleryViewHistory: &GalleryViewHistoryLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesViewHistory(ctx),
+			},
+			GalleryOHistory: &GalleryOHistoryLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesOHistory(ctx),
+			},
+			GalleryLastViewed: &GalleryLastViewedLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesLastViewed(ctx),
+			},
 		}
 
 		newCtx := context.WithValue(r.Context(), loadersCtxKey, ldrs)
@@ -223,11 +332,11 @@ func (m Middleware) fetchPerformers(ctx context.Context) func(keys []int) ([]*mo
 	}
 }
 
-func (m Middleware) fetchPerformerCustomFields(ctx context.Context) func(keys []int) ([]models.CustomFieldMap, []error) {
+func (m Middleware) fetchCustomFields(ctx context.Context, r models.CustomFieldsReader) func(keys []int) ([]models.CustomFieldMap, []error) {
 	return func(keys []int) (ret []models.CustomFieldMap, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Performer.GetCustomFieldsBulk(ctx, keys)
+			ret, err = r.GetCustomFieldsBulk(ctx, keys)
 			return err
 		})
 
@@ -366,3 +475,113 @@ func (m Middleware) fetchScenesLastPlayed(ctx context.Context) func(keys []int)
 		return ret, toErrorSlice(err)
 	}
 }
+
+func (m Middleware) fetchImagesViewCount(ctx context.Context) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.GetManyViewCount(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchImagesOCount(ctx context.Context) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.GetManyOCount(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchImagesViewHistory(ctx context.Context) func(keys []int) ([][]time.Time, []error) {
+	return func(keys []int) (ret [][]time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.GetManyViewDates(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchImagesOHistory(ctx context.Context) func(keys []int) ([][]time.Time, []error) {
+	return func(keys []int) (ret [][]time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.GetManyODates(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchImagesLastViewed(ctx context.Context) func(keys []int) ([]*time.Time, []error) {
+	return func(keys []int) (ret []*time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.GetManyLastViewed(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesViewCount(ctx context.Context) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Gallery.GetManyViewCount(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesOCount(ctx context.Context) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Gallery.GetManyOCount(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesViewHistory(ctx context.Context) func(keys []int) ([][]time.Time, []error) {
+	return func(keys []int) (ret [][]time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Gallery.GetManyViewDates(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesOHistory(ctx context.Context) func(keys []int) ([][]time.Time, []error) {
+	return func(keys []int) (ret [][]time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Gallery.GetManyODates(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesLastViewed(ctx context.Context) func(keys []int) ([]*time.Time, []error) {
+	return func(keys []int) (ret []*time.Time, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Gallery.GetManyLastViewed(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
@@ -0,0 +1,313 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const (
+	metricsEndpoint  = "/metrics"
+	metricsNamespace = "stash"
+)
+
+// metrics collects the server metrics exposed on the metrics endpoint.
+type metrics struct {
+	manager  *manager.Manager
+	registry *prometheus.Registry
+
+	graphqlDuration *prometheus.HistogramVec
+	txnDuration     *prometheus.HistogramVec
+
+	jobsQueued    *prometheus.Desc
+	jobsRunning   *prometheus.Desc
+	jobsRunTime   *prometheus.Desc
+	streams       *prometheus.Desc
+	transcodes    *prometheus.Desc
+	segmentsWait  *prometheus.Desc
+	segmentWaits  *prometheus.Desc
+	library       map[string]*prometheus.Desc
+	libraryErrors prometheus.Counter
+}
+
+func newMetrics(mgr *manager.Manager) *metrics {
+	m := &metrics{
+		manager:  mgr,
+		registry: prometheus.NewRegistry(),
+
+		graphqlDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "graphql",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of GraphQL queries and mutations, by root field.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"field"}),
+		txnDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "database",
+			Name:      "transaction_duration_seconds",
+			Help:      "Duration of database transactions.",
+			Buckets:   []float64{.0005, .001, .005, .01, .05, .1, .5, 1, 5, 10, 30},
+		}, []string{"mode", "result"}),
+
+		jobsQueued: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "jobs", "queued"),
+			"Number of jobs in the job queue, by status.",
+			[]string{"status"}, nil,
+		),
+		jobsRunning: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "jobs", "running_seconds"),
+			"Time spent so far by the currently running jobs.",
+			nil, nil,
+		),
+		jobsRunTime: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "jobs", "run_seconds"),
+			"Run time of finished jobs, by final status.",
+			[]string{"status"}, nil,
+		),
+		streams: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "streams", "active"),
+			"Number of active transcoded streams, by type.",
+			[]string{"type"}, nil,
+		),
+		transcodes: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "streams", "transcode_processes"),
+			"Number of running transcode processes.",
+			nil, nil,
+		),
+		segmentsWait: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "streams", "waiting_segments"),
+			"Number of segment requests waiting for the segment to be generated.",
+			nil, nil,
+		),
+		segmentWaits: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "streams", "segment_wait_seconds"),
+			"Time spent waiting for served segments to be generated.",
+			nil, nil,
+		),
+		libraryErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "library",
+			Name:      "collection_errors_total",
+			Help:      "Number of errors encountered collecting the library totals.",
+		}),
+	}
+
+	m.library = make(map[string]*prometheus.Desc)
+	for name, help := range map[string]string{
+		"scenes":                  "Number of scenes.",
+		"scenes_size_bytes":       "Total size of scene files.",
+		"scenes_duration_seconds": "Total duration of scenes.",
+		"images":                  "Number of images.",
+		"images_size_bytes":       "Total size of image files.",
+		"galleries":               "Number of galleries.",
+		"performers":              "Number of performers.",
+		"studios":                 "Number of studios.",
+		"groups":                  "Number of groups.",
+		"tags":                    "Number of tags.",
+		"o_count":                 "Total O-count of scenes and images.",
+		"play_duration_seconds":   "Total play duration of scenes.",
+		"play_count":              "Total play count of scenes.",
+		"scenes_played":           "Number of scenes played at least once.",
+	} {
+		m.library[name] = prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "library", name),
+			help, nil, nil,
+		)
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.graphqlDuration,
+		m.txnDuration,
+		m.libraryErrors,
+		m,
+	)
+
+	txn.SetObserver(m.observeTxn)
+
+	return m
+}
+
+func (m *metrics) handler() http.Handler {
+	h := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:          metricsErrorLogger{},
+		EnableOpenMetrics: true,
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// metrics expose server-wide information
+		if !models.RoleFromContext(r.Context()).Includes(models.UserRoleAdmin) {
+			http.Error(w, "metrics require the ADMIN role", http.StatusForbidden)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (m *metrics) observeTxn(o txn.Observation) {
+	mode := "read"
+	if o.Writable {
+		mode = "write"
+	}
+	result := "rollback"
+	if o.Committed {
+		result = "commit"
+	}
+
+	m.txnDuration.WithLabelValues(mode, result).Observe(o.Duration.Seconds())
+}
+
+// aroundOperations observes the duration of GraphQL queries and mutations.
+// Subscriptions are long-lived and are not observed.
+func (m *metrics) aroundOperations(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	start := oc.Stats.OperationStart
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	name := operationField(oc)
+
+	h := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		ret := h(ctx)
+		m.graphqlDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		return ret
+	}
+}
+
+// operationField returns the name of the root field selected by the
+// operation, which is used to label its metrics. Operation names are chosen
+// by the client, so they are not used. Returns "other" if the operation
+// selects more than one root field.
+func operationField(oc *graphql.OperationContext) string {
+	typeName := "Query"
+	if oc.Operation.Operation == ast.Mutation {
+		typeName = "Mutation"
+	}
+
+	var ret string
+	for _, f := range graphql.CollectFields(oc, oc.Operation.SelectionSet, []string{typeName}) {
+		if ret != "" && ret != f.Name {
+			return "other"
+		}
+		ret = f.Name
+	}
+
+	if ret == "" {
+		return "other"
+	}
+
+	return ret
+}
+
+// Describe implements prometheus.Collector.
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.jobsQueued
+	ch <- m.jobsRunning
+	ch <- m.jobsRunTime
+	ch <- m.streams
+	ch <- m.transcodes
+	ch <- m.segmentsWait
+	ch <- m.segmentWaits
+	for _, d := range m.library {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	m.collectJobs(ch)
+	m.collectStreams(ch)
+	m.collectLibrary(ch)
+}
+
+func (m *metrics) collectJobs(ch chan<- prometheus.Metric) {
+	if m.manager.JobManager == nil {
+		return
+	}
+
+	stats := m.manager.JobManager.Stats()
+
+	for status, count := range stats.Queued {
+		ch <- prometheus.MustNewConstMetric(m.jobsQueued, prometheus.GaugeValue, float64(count), string(status))
+	}
+
+	ch <- prometheus.MustNewConstMetric(m.jobsRunning, prometheus.GaugeValue, stats.Running.Seconds())
+
+	for status, count := range stats.Finished {
+		ch <- prometheus.MustNewConstSummary(m.jobsRunTime, uint64(count), stats.RunTime[status].Seconds(), nil, string(status))
+	}
+}
+
+func (m *metrics) collectStreams(ch chan<- prometheus.Metric) {
+	sm := m.manager.StreamManager
+	if sm == nil {
+		return
+	}
+
+	stats := sm.Stats()
+
+	ch <- prometheus.MustNewConstMetric(m.streams, prometheus.GaugeValue, float64(stats.SegmentedStreams), "segmented")
+	ch <- prometheus.MustNewConstMetric(m.streams, prometheus.GaugeValue, float64(stats.DirectTranscodes), "direct")
+	ch <- prometheus.MustNewConstMetric(m.transcodes, prometheus.GaugeValue, float64(stats.TranscodeProcesses))
+	ch <- prometheus.MustNewConstMetric(m.segmentsWait, prometheus.GaugeValue, float64(stats.WaitingSegments))
+	ch <- prometheus.MustNewConstSummary(m.segmentWaits, uint64(stats.SegmentWaits), stats.SegmentWaitTime.Seconds(), nil)
+}
+
+func (m *metrics) collectLibrary(ch chan<- prometheus.Metric) {
+	if m.manager.Repository.TxnManager == nil {
+		return
+	}
+
+	// library totals are not restricted for metrics
+	stats, err := getStats(context.Background(), m.manager.Repository)
+	if err != nil {
+		logger.Warnf("error collecting library metrics: %v", err)
+		m.libraryErrors.Inc()
+		return
+	}
+
+	for name, v := range map[string]float64{
+		"scenes":                  float64(stats.SceneCount),
+		"scenes_size_bytes":       stats.ScenesSize,
+		"scenes_duration_seconds": stats.ScenesDuration,
+		"images":                  float64(stats.ImageCount),
+		"images_size_bytes":       stats.ImagesSize,
+		"galleries":               float64(stats.GalleryCount),
+		"performers":              float64(stats.PerformerCount),
+		"studios":                 float64(stats.StudioCount),
+		"groups":                  float64(stats.GroupCount),
+		"tags":                    float64(stats.TagCount),
+		"o_count":                 float64(stats.TotalOCount),
+		"play_duration_seconds":   stats.TotalPlayDuration,
+		"play_count":              float64(stats.TotalPlayCount),
+		"scenes_played":           float64(stats.ScenesPlayed),
+	} {
+		ch <- prometheus.MustNewConstMetric(m.library[name], prometheus.GaugeValue, v)
+	}
+}
+
+type metricsErrorLogger struct{}
+
+func (metricsErrorLogger) Println(v ...interface{}) {
+	logger.Warn(v...)
+}
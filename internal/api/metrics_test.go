@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestOperationField(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`query FindScenes { findScenes { count } }`, "findScenes"},
+		{`query ClientChosenName { a: findScene(id: 1) { id } b: findScene(id: 2) { id } }`, "findScene"},
+		{`query { ...F } fragment F on Query { stats { scene_count } }`, "stats"},
+		{`mutation M { sceneUpdate(input: {id: 1}) { id } }`, "sceneUpdate"},
+		{`query Multiple { stats { scene_count } version { version } }`, "other"},
+	}
+	for _, tt := range tests {
+		doc, err := parser.ParseQuery(&ast.Source{Input: tt.query})
+		if err != nil {
+			t.Fatalf("parsing %q: %v", tt.query, err)
+		}
+
+		oc := &graphql.OperationContext{
+			Doc:       doc,
+			Operation: doc.Operations[0],
+		}
+		if got := operationField(oc); got != tt.want {
+			t.Errorf("operationField(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -105,6 +105,15 @@ func (r *Resolver) Plugin() PluginResolver {
 func (r *Resolver) ConfigResult() ConfigResultResolver {
 	return &configResultResolver{r}
 }
+func (r *Resolver) ScheduledTask() ScheduledTaskResolver {
+	return &scheduledTaskResolver{r}
+}
+func (r *Resolver) APIKey() APIKeyResolver {
+	return &apiKeyResolver{r}
+}
+func (r *Resolver) AuditLogEntry() AuditLogEntryResolver {
+	return &auditLogEntryResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -129,6 +138,9 @@ type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
+type scheduledTaskResolver struct{ *Resolver }
+type apiKeyResolver struct{ *Resolver }
+type auditLogEntryResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
 	return r.repository.WithTxn(ctx, fn)
@@ -175,9 +187,14 @@ func (r *queryResolver) MarkerStrings(ctx context.Context, q *string, sort *stri
 }
 
 func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
+	return getStats(ctx, r.repository)
+}
+
+// getStats returns the library totals, applying any content restrictions in
+// the context.
+func getStats(ctx context.Context, repo models.Repository) (*StatsResultType, error) {
 	var ret StatsResultType
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		repo := r.repository
+	if err := repo.WithReadTxn(ctx, func(ctx context.Context) error {
 		sceneQB := repo.Scene
 		imageQB := repo.Image
 		galleryQB := repo.Gallery
@@ -242,11 +259,15 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 		if err != nil {
 			return err
 		}
-		imagesTotalOCount, err := imageQB.OCount(ctx)
+		imagesTotalOCount, err := imageQB.GetAllOCount(ctx)
+		if err != nil {
+			return err
+		}
+		galleriesTotalOCount, err := galleryQB.GetAllOCount(ctx)
 		if err != nil {
 			return err
 		}
-		totalOCount := scenesTotalOCount + imagesTotalOCount
+		totalOCount := scenesTotalOCount + imagesTotalOCount + galleriesTotalOCount
 
 		totalPlayDuration, err := sceneQB.PlayDuration(ctx)
 		if err != nil {
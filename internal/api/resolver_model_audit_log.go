@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *auditLogEntryResolver) User(ctx context.Context, obj *models.AuditLogEntry) (ret *models.User, err error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.User.Find(ctx, *obj.UserID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
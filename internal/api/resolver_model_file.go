@@ -1,6 +1,10 @@
 package api
 
-import "context"
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
 
 func (r *galleryFileResolver) Fingerprint(ctx context.Context, obj *GalleryFile, type_ string) (*string, error) {
 	fp := obj.BaseFile.Fingerprints.For(type_)
@@ -28,3 +32,19 @@ func (r *videoFileResolver) Fingerprint(ctx context.Context, obj *VideoFile, typ
 	}
 	return nil, nil
 }
+
+func (r *videoFileResolver) AudioStreams(ctx context.Context, obj *VideoFile) ([]*models.VideoFileStream, error) {
+	return videoFileStreams(obj.AudioStreams), nil
+}
+
+func (r *videoFileResolver) SubtitleStreams(ctx context.Context, obj *VideoFile) ([]*models.VideoFileStream, error) {
+	return videoFileStreams(obj.SubtitleStreams), nil
+}
+
+func videoFileStreams(streams []models.VideoFileStream) []*models.VideoFileStream {
+	ret := make([]*models.VideoFileStream, len(streams))
+	for i := range streams {
+		ret[i] = &streams[i]
+	}
+	return ret
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *galleryResolver) getFiles(ctx context.Context, obj *models.Gallery) ([]models.File, error) {
@@ -101,7 +103,7 @@ func (r *galleryResolver) Scenes(ctx context.Context, obj *models.Gallery) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).SceneByID.LoadAll(obj.SceneIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *galleryResolver) Studio(ctx context.Context, obj *models.Gallery) (ret *models.Studio, err error) {
@@ -123,7 +125,7 @@ func (r *galleryResolver) Tags(ctx context.Context, obj *models.Gallery) (ret []
 
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *galleryResolver) Performers(ctx context.Context, obj *models.Gallery) (ret []*models.Performer, err error) {
@@ -137,7 +139,7 @@ func (r *galleryResolver) Performers(ctx context.Context, obj *models.Gallery) (
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *galleryResolver) ImageCount(ctx context.Context, obj *models.Gallery) (ret int, err error) {
@@ -216,3 +218,71 @@ func (r *galleryResolver) Image(ctx context.Context, obj *models.Gallery, index
 
 	return
 }
+
+func (r *galleryResolver) OCounter(ctx context.Context, obj *models.Gallery) (*int, error) {
+	ret, err := loaders.From(ctx).GalleryOCount.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *galleryResolver) LastViewedAt(ctx context.Context, obj *models.Gallery) (*time.Time, error) {
+	ret, err := loaders.From(ctx).GalleryLastViewed.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *galleryResolver) ViewCount(ctx context.Context, obj *models.Gallery) (*int, error) {
+	ret, err := loaders.From(ctx).GalleryViewCount.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *galleryResolver) ViewHistory(ctx context.Context, obj *models.Gallery) ([]*time.Time, error) {
+	ret, err := loaders.From(ctx).GalleryViewHistory.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(ret), nil
+}
+
+func (r *galleryResolver) OHistory(ctx context.Context, obj *models.Gallery) ([]*time.Time, error) {
+	ret, err := loaders.From(ctx).GalleryOHistory.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(ret), nil
+}
+func (r *galleryResolver) LastImageIndex(ctx context.Context, obj *models.Gallery) (ret *int, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.GetLastImageIndex(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) (map[string]interface{}, error) {
+	m, err := loaders.From(ctx).GalleryCustomFields.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return make(map[string]interface{}), nil
+	}
+
+	return m, nil
+}
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *imageResolver) getFiles(ctx context.Context, obj *models.Image) ([]models.File, error) {
@@ -95,7 +97,7 @@ func (r *imageResolver) Galleries(ctx context.Context, obj *models.Image) (ret [
 
 	var errs []error
 	ret, errs = loaders.From(ctx).GalleryByID.LoadAll(obj.GalleryIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *imageResolver) Rating100(ctx context.Context, obj *models.Image) (*int, error) {
@@ -121,7 +123,7 @@ func (r *imageResolver) Tags(ctx context.Context, obj *models.Image) (ret []*mod
 
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret []*models.Performer, err error) {
@@ -135,7 +137,7 @@ func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *imageResolver) URL(ctx context.Context, obj *models.Image) (*string, error) {
@@ -166,3 +168,61 @@ func (r *imageResolver) Urls(ctx context.Context, obj *models.Image) ([]string,
 
 	return obj.URLs.List(), nil
 }
+
+func (r *imageResolver) OCounter(ctx context.Context, obj *models.Image) (*int, error) {
+	ret, err := loaders.From(ctx).ImageOCount.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *imageResolver) LastViewedAt(ctx context.Context, obj *models.Image) (*time.Time, error) {
+	ret, err := loaders.From(ctx).ImageLastViewed.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *imageResolver) ViewCount(ctx context.Context, obj *models.Image) (*int, error) {
+	ret, err := loaders.From(ctx).ImageViewCount.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *imageResolver) ViewHistory(ctx context.Context, obj *models.Image) ([]*time.Time, error) {
+	ret, err := loaders.From(ctx).ImageViewHistory.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(ret), nil
+}
+
+func (r *imageResolver) OHistory(ctx context.Context, obj *models.Image) ([]*time.Time, error) {
+	ret, err := loaders.From(ctx).ImageOHistory.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(ret), nil
+}
+
+func (r *imageResolver) CustomFields(ctx context.Context, obj *models.Image) (map[string]interface{}, error) {
+	m, err := loaders.From(ctx).ImageCustomFields.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return make(map[string]interface{}), nil
+	}
+
+	return m, nil
+}
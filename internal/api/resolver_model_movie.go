@@ -70,7 +70,7 @@ func (r groupResolver) Tags(ctx context.Context, obj *models.Group) (ret []*mode
 
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r groupResolver) relatedGroups(ctx context.Context, rgd models.RelatedGroupDescriptions) (ret []*GroupDescription, err error) {
@@ -88,13 +88,19 @@ func (r groupResolver) relatedGroups(ctx context.Context, rgd models.RelatedGrou
 		return
 	}
 
-	ret = make([]*GroupDescription, len(groups))
+	ret = make([]*GroupDescription, 0, len(groups))
 	for i, group := range groups {
-		ret[i] = &GroupDescription{Group: group}
+		// omit groups hidden by content restrictions
+		if group == nil {
+			continue
+		}
+
+		gd := &GroupDescription{Group: group}
 		d := gds[i].Description
 		if d != "" {
-			ret[i].Description = &d
+			gd.Description = &d
 		}
+		ret = append(ret, gd)
 	}
 
 	return ret, firstError(errs)
@@ -192,3 +198,16 @@ func (r *groupResolver) Scenes(ctx context.Context, obj *models.Group) (ret []*m
 
 	return ret, nil
 }
+
+func (r *groupResolver) CustomFields(ctx context.Context, obj *models.Group) (map[string]interface{}, error) {
+	m, err := loaders.From(ctx).GroupCustomFields.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return make(map[string]interface{}), nil
+	}
+
+	return m, nil
+}
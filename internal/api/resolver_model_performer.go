@@ -143,7 +143,7 @@ func (r *performerResolver) Tags(ctx context.Context, obj *models.Performer) (re
 
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *performerResolver) SceneCount(ctx context.Context, obj *models.Performer) (ret int, err error) {
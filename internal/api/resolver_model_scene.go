@@ -111,7 +111,7 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	previewPath := builder.GetStreamPreviewURL()
 	streamPath := builder.GetStreamURL(config.GetAPIKey()).String()
 	webpPath := builder.GetStreamPreviewImageURL()
-	objHash := obj.GetHash(config.GetVideoFileNamingAlgorithm())
+	objHash := obj.GetGeneratedHash(config.GetVideoFileNamingAlgorithm())
 	vttPath := builder.GetSpriteVTTURL(objHash)
 	spritePath := builder.GetSpriteURL(objHash)
 	funscriptPath := builder.GetFunscriptURL()
@@ -172,7 +172,7 @@ func (r *sceneResolver) Galleries(ctx context.Context, obj *models.Scene) (ret [
 
 	var errs []error
 	ret, errs = loaders.From(ctx).GalleryByID.LoadAll(obj.GalleryIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *sceneResolver) Studio(ctx context.Context, obj *models.Scene) (ret *models.Studio, err error) {
@@ -202,6 +202,11 @@ func (r *sceneResolver) Movies(ctx context.Context, obj *models.Scene) (ret []*S
 			return nil, err
 		}
 
+		// omit groups hidden by content restrictions
+		if movie == nil {
+			continue
+		}
+
 		sceneIdx := sm.SceneIndex
 		sceneMovie := &SceneMovie{
 			Movie:      movie,
@@ -233,6 +238,11 @@ func (r *sceneResolver) Groups(ctx context.Context, obj *models.Scene) (ret []*S
 			return nil, err
 		}
 
+		// omit groups hidden by content restrictions
+		if group == nil {
+			continue
+		}
+
 		sceneIdx := sm.SceneIndex
 		sceneGroup := &SceneGroup{
 			Group:      group,
@@ -256,7 +266,7 @@ func (r *sceneResolver) Tags(ctx context.Context, obj *models.Scene) (ret []*mod
 
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *sceneResolver) Performers(ctx context.Context, obj *models.Scene) (ret []*models.Performer, err error) {
@@ -270,7 +280,7 @@ func (r *sceneResolver) Performers(ctx context.Context, obj *models.Scene) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return omitHidden(ret), firstError(errs)
 }
 
 func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []*models.StashID, err error) {
@@ -410,3 +420,16 @@ func (r *sceneResolver) OHistory(ctx context.Context, obj *models.Scene) ([]*tim
 
 	return ptrRet, nil
 }
+
+func (r *sceneResolver) CustomFields(ctx context.Context, obj *models.Scene) (map[string]interface{}, error) {
+	m, err := loaders.From(ctx).SceneCustomFields.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return make(map[string]interface{}), nil
+	}
+
+	return m, nil
+}
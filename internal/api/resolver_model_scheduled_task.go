@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func scheduledTaskStatus(id string) manager.ScheduledTaskStatus {
+	scheduler := manager.GetInstance().Scheduler
+	if scheduler == nil {
+		return manager.ScheduledTaskStatus{}
+	}
+
+	return scheduler.Status(id)
+}
+
+func (r *scheduledTaskResolver) NextRun(ctx context.Context, obj *config.ScheduledTask) (*time.Time, error) {
+	return scheduledTaskStatus(obj.ID).NextRun, nil
+}
+
+func (r *scheduledTaskResolver) LastRun(ctx context.Context, obj *config.ScheduledTask) (*time.Time, error) {
+	return scheduledTaskStatus(obj.ID).LastRun, nil
+}
+
+func (r *scheduledTaskResolver) LastJobID(ctx context.Context, obj *config.ScheduledTask) (*string, error) {
+	jobID := scheduledTaskStatus(obj.ID).LastJobID
+	if jobID == nil {
+		return nil, nil
+	}
+
+	ret := strconv.Itoa(*jobID)
+	return &ret, nil
+}
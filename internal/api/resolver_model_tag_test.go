@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+// withLoaders returns ctx with the dataloaders for the repository of db.
+func withLoaders(ctx context.Context, db *mocks.Database) context.Context {
+	var ret context.Context
+	h := loaders.Middleware{Repository: db.Repository()}.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ret = r.Context()
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil).WithContext(ctx)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	return ret
+}
+
+func TestTagParentsOmitsHidden(t *testing.T) {
+	db := mocks.NewDatabase()
+	r := newResolver(db)
+
+	const (
+		hiddenID  = 1
+		visibleID = 2
+	)
+
+	visible := &models.Tag{ID: visibleID, Name: "visible"}
+
+	// hidden tags are returned as nil under content restrictions
+	db.Tag.On("FindMany", mock.Anything, []int{hiddenID, visibleID}).Return([]*models.Tag{nil, visible}, nil).Once()
+
+	ctx := withLoaders(testCtx, db)
+	obj := &models.Tag{
+		ID:        3,
+		ParentIDs: models.NewRelatedIDs([]int{hiddenID, visibleID}),
+	}
+
+	got, err := r.Tag().Parents(ctx, obj)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Tag{visible}, got)
+
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	errAPIKeyNoCredentials = errors.New("a username and password must be configured before adding API keys")
+	errAPIKeyNotOwned      = errors.New("only administrators can revoke API keys of other users")
+)
+
+func (r *mutationResolver) APIKeyCreate(ctx context.Context, input APIKeyCreateInput) (*APIKeyCreateResult, error) {
+	if !config.GetInstance().HasCredentials() {
+		return nil, errAPIKeyNoCredentials
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("name must not be empty")
+	}
+
+	newKey := models.NewAPIKey()
+	newKey.Name = name
+	newKey.UserID = models.UserIDFromContext(ctx)
+
+	if input.Scope != nil {
+		newKey.Scope = *input.Scope
+	}
+
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return nil, errors.New("expiry time must be in the future")
+		}
+		newKey.ExpiresAt = input.ExpiresAt
+	}
+
+	key, err := manager.GetInstance().CreateAPIKey(ctx, &newKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIKeyCreateResult{
+		APIKey: &newKey,
+		Key:    key,
+	}, nil
+}
+
+func (r *mutationResolver) APIKeyRevoke(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.APIKey
+
+		key, err := qb.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+
+		if key == nil {
+			return fmt.Errorf("API key with id %d not found", idInt)
+		}
+
+		if models.RoleFromContext(ctx) != models.UserRoleAdmin && !sameUser(key.UserID, models.UserIDFromContext(ctx)) {
+			return errAPIKeyNotOwned
+		}
+
+		return qb.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func sameUser(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/audit"
+)
+
+func (r *mutationResolver) AuditLogRevert(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		entry, err := r.repository.AuditLog.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+
+		if entry == nil {
+			return fmt.Errorf("audit log entry with id %d not found", idInt)
+		}
+
+		return audit.Revert(ctx, audit.NewRepository(r.repository), entry)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) AuditLogRevertJob(ctx context.Context, jobID string) (ret int, err error) {
+	jobIDInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return 0, fmt.Errorf("converting job id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = audit.RevertJob(ctx, audit.NewRepository(r.repository), jobIDInt)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
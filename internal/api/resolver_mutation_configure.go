@@ -85,6 +85,7 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	refreshWatcher := false
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
@@ -104,6 +105,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 			}
 		}
 		c.SetInterface(config.Stash, input.Stashes)
+		refreshWatcher = true
 	}
 
 	checkConfigOverride := func(key string) error {
@@ -287,6 +289,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if input.MaxStreamingTranscodeSize != nil {
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
+	r.setConfigInt(config.TranscodeCacheMaxSize, input.TranscodeCacheMaxSize)
 	r.setConfigBool(config.WriteImageThumbnails, input.WriteImageThumbnails)
 	r.setConfigBool(config.CreateImageClipsFromVideos, input.CreateImageClipsFromVideos)
 
@@ -368,6 +371,11 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 
+	if input.WatchLibrary != nil && *input.WatchLibrary != c.GetWatchLibrary() {
+		c.SetBool(config.WatchLibrary, *input.WatchLibrary)
+		refreshWatcher = true
+	}
+
 	if input.CustomPerformerImageLocation != nil {
 		c.SetString(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
 		initCustomPerformerImages(*input.CustomPerformerImageLocation)
@@ -438,6 +446,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshWatcher {
+		manager.GetInstance().RefreshWatcher()
+	}
 
 	return makeConfigGeneralResult(), nil
 }
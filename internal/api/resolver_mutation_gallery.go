@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
@@ -14,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -86,7 +88,12 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
-		return nil
+		// convert json.Numbers to int/float
+		customFields := models.CustomFieldsInput{
+			Full: convertMapJSONNumbers(input.CustomFields),
+		}
+
+		return qb.SetCustomFields(ctx, newGallery.ID, customFields)
 	}); err != nil {
 		return nil, err
 	}
@@ -239,6 +246,9 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, fmt.Errorf("converting scene ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedGallery.CustomFields = convertCustomFieldsInput(&input.CustomFields)
+
 	// gallery scene is set from the scene only
 
 	gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
@@ -291,6 +301,9 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		return nil, fmt.Errorf("converting scene ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedGallery.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	ret := []*models.Gallery{}
 
 	// Start the transaction and save the galleries
@@ -672,3 +685,175 @@ func (r *mutationResolver) GalleryChapterDestroy(ctx context.Context, id string)
 
 	return true, nil
 }
+
+func (r *mutationResolver) GalleryResetO(ctx context.Context, id string) (ret int, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		ret, err = qb.ResetO(ctx, galleryID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) GalleryAddO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.AddO(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryDeleteO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.DeleteO(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryAddView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.AddViews(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryDeleteView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.DeleteViews(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryResetViewCount(ctx context.Context, id string) (ret int, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		ret, err = qb.DeleteAllViews(ctx, galleryID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) GallerySaveActivity(ctx context.Context, id string, lastImageIndex *int) (ret bool, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		ret, err = qb.SaveActivity(ctx, galleryID, lastImageIndex)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
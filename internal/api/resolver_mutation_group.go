@@ -97,7 +97,12 @@ func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInp
 			return err
 		}
 
-		return nil
+		// convert json.Numbers to int/float
+		customFields := models.CustomFieldsInput{
+			Full: convertMapJSONNumbers(input.CustomFields),
+		}
+
+		return r.repository.Group.SetCustomFields(ctx, newGroup.ID, customFields)
 	}); err != nil {
 		return nil, err
 	}
@@ -150,6 +155,9 @@ func groupPartialFromGroupUpdateInput(translator changesetTranslator, input Grou
 
 	updatedGroup.URLs = translator.updateStrings(input.Urls, "urls")
 
+	// convert json.Numbers to int/float
+	updatedGroup.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	return updatedGroup, nil
 }
 
@@ -245,6 +253,9 @@ func groupPartialFromBulkGroupUpdateInput(translator changesetTranslator, input
 
 	updatedGroup.URLs = translator.optionalURLsBulk(input.Urls, nil)
 
+	// convert json.Numbers to int/float
+	updatedGroup.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	return updatedGroup, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
@@ -177,6 +178,9 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedImage.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	qb := r.repository.Image
 	image, err := qb.UpdatePartial(ctx, imageID, updatedImage)
 	if err != nil {
@@ -237,6 +241,9 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedImage.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	// Start the transaction and save the images
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		var updatedGalleryIDs []int
@@ -394,40 +401,46 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 	return true, nil
 }
 
+// deprecated
 func (r *mutationResolver) ImageIncrementO(ctx context.Context, id string) (ret int, err error) {
 	imageID, err := strconv.Atoi(id)
 	if err != nil {
 		return 0, fmt.Errorf("converting id: %w", err)
 	}
 
+	var updatedTimes []time.Time
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Image
 
-		ret, err = qb.IncrementOCounter(ctx, imageID)
+		updatedTimes, err = qb.AddO(ctx, imageID, nil)
 		return err
 	}); err != nil {
 		return 0, err
 	}
 
-	return ret, nil
+	return len(updatedTimes), nil
 }
 
+// deprecated
 func (r *mutationResolver) ImageDecrementO(ctx context.Context, id string) (ret int, err error) {
 	imageID, err := strconv.Atoi(id)
 	if err != nil {
 		return 0, fmt.Errorf("converting id: %w", err)
 	}
 
+	var updatedTimes []time.Time
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Image
 
-		ret, err = qb.DecrementOCounter(ctx, imageID)
+		updatedTimes, err = qb.DeleteO(ctx, imageID, nil)
 		return err
 	}); err != nil {
 		return 0, err
 	}
 
-	return ret, nil
+	return len(updatedTimes), nil
 }
 
 func (r *mutationResolver) ImageResetO(ctx context.Context, id string) (ret int, err error) {
@@ -439,7 +452,143 @@ func (r *mutationResolver) ImageResetO(ctx context.Context, id string) (ret int,
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Image
 
-		ret, err = qb.ResetOCounter(ctx, imageID)
+		ret, err = qb.ResetO(ctx, imageID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ImageAddO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.AddO(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageDeleteO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.DeleteO(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageAddView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.AddViews(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageDeleteView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.DeleteViews(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageResetViewCount(ctx context.Context, id string) (ret int, err error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		ret, err = qb.DeleteAllViews(ctx, imageID)
 		return err
 	}); err != nil {
 		return 0, err
@@ -22,3 +22,16 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.ResumeJob(ctx, id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
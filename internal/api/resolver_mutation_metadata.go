@@ -88,8 +88,7 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
-	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
+	jobID := manager.GetInstance().Identify(ctx, input)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -225,16 +225,7 @@ func (r *mutationResolver) handleLegacyURLs(ctx context.Context, performerID int
 	return nil
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
-	performerID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
+func (r *mutationResolver) performerPartialFromInput(input models.PerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
 	// Populate performer from the input
 	updatedPerformer := models.NewPerformerPartial()
 
@@ -268,10 +259,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		updatedPerformer.URLs = translator.updateStrings(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
-
+	var err error
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
 		return nil, fmt.Errorf("converting birthdate: %w", err)
@@ -296,10 +284,30 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
-	updatedPerformer.CustomFields = input.CustomFields
 	// convert json.Numbers to int/float
-	updatedPerformer.CustomFields.Full = convertMapJSONNumbers(updatedPerformer.CustomFields.Full)
-	updatedPerformer.CustomFields.Partial = convertMapJSONNumbers(updatedPerformer.CustomFields.Partial)
+	updatedPerformer.CustomFields = convertCustomFieldsInput(&input.CustomFields)
+
+	return &updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedPerformer, err := r.performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyURL := translator.optionalString(input.URL, "url")
+	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
+	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
 
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
@@ -315,16 +323,16 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		qb := r.repository.Performer
 
 		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
-			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
+			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
 				return err
 			}
 		}
 
-		if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
+		if err := performer.ValidateUpdate(ctx, performerID, *updatedPerformer, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		_, err = qb.UpdatePartial(ctx, performerID, *updatedPerformer)
 		if err != nil {
 			return err
 		}
@@ -416,6 +424,8 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	updatedPerformer.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	ret := []*models.Performer{}
 
 	// Start the transaction and save the performers
@@ -504,3 +514,65 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, input PerformersMergeInput) (*models.Performer, error) {
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destID, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	values := models.NewPerformerPartial()
+	var imageData []byte
+	imageIncluded := false
+
+	if input.Values != nil {
+		translator := changesetTranslator{
+			inputMap: getNamedUpdateInputMap(ctx, "input.values"),
+		}
+
+		// the legacy url fields are not supported when merging
+		if translator.hasField("url") || translator.hasField("twitter") || translator.hasField("instagram") {
+			return nil, fmt.Errorf("url, twitter and instagram fields are not supported, use urls")
+		}
+
+		v, err := r.performerPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+		values = *v
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		if err := performer.Merge(ctx, srcIDs, destID, values, qb); err != nil {
+			return err
+		}
+
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.PerformerMergePost, input, nil)
+	return r.getPerformer(ctx, destID)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -51,6 +52,12 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 	newScene.Rating = input.Rating100
 	newScene.Organized = translator.bool(input.Organized)
 	newScene.StashIDs = models.NewRelatedStashIDs(models.StashIDInputs(input.StashIds).ToStashIDs())
+	newScene.StartPoint = input.StartPoint
+	newScene.EndPoint = input.EndPoint
+
+	if err := scene.ValidateTrim(newScene.StartPoint, newScene.EndPoint); err != nil {
+		return nil, err
+	}
 
 	newScene.Date, err = translator.datePtr(input.Date)
 	if err != nil {
@@ -104,7 +111,16 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		// convert json.Numbers to int/float
+		customFields := models.CustomFieldsInput{
+			Full: convertMapJSONNumbers(input.CustomFields),
+		}
+
+		return r.repository.Scene.SetCustomFields(ctx, ret.ID, customFields)
 	}); err != nil {
 		return nil, err
 	}
@@ -117,14 +133,19 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	trimChanges := newSceneTrimChanges()
+
 	// Start the transaction and save the scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		ret, err = r.sceneUpdate(ctx, input, translator)
+		ret, err = r.sceneUpdate(ctx, input, translator, trimChanges)
 		return err
 	}); err != nil {
+		trimChanges.rollback()
 		return nil, err
 	}
 
+	trimChanges.commit(ctx)
+
 	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, hook.SceneUpdatePost, input, translator.getFields())
 	return r.getScene(ctx, ret.ID)
 }
@@ -132,6 +153,8 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.SceneUpdateInput) (ret []*models.Scene, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	trimChanges := newSceneTrimChanges()
+
 	// Start the transaction and save the scenes
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, scene := range input {
@@ -139,7 +162,7 @@ func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.Sce
 				inputMap: inputMaps[i],
 			}
 
-			thisScene, err := r.sceneUpdate(ctx, *scene, translator)
+			thisScene, err := r.sceneUpdate(ctx, *scene, translator, trimChanges)
 			if err != nil {
 				return err
 			}
@@ -149,9 +172,12 @@ func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.Sce
 
 		return nil
 	}); err != nil {
+		trimChanges.rollback()
 		return nil, err
 	}
 
+	trimChanges.commit(ctx)
+
 	// execute post hooks outside of txn
 	var newRet []*models.Scene
 	for i, scene := range ret {
@@ -190,6 +216,8 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	}
 
 	updatedScene.PlayDuration = translator.optionalFloat64(input.PlayDuration, "play_duration")
+	updatedScene.StartPoint = translator.optionalFloat64(input.StartPoint, "start_point")
+	updatedScene.EndPoint = translator.optionalFloat64(input.EndPoint, "end_point")
 	updatedScene.Organized = translator.optionalBool(input.Organized, "organized")
 	updatedScene.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
@@ -236,10 +264,13 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 		}
 	}
 
+	// convert json.Numbers to int/float
+	updatedScene.CustomFields = convertCustomFieldsInput(&input.CustomFields)
+
 	return &updatedScene, nil
 }
 
-func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUpdateInput, translator changesetTranslator) (*models.Scene, error) {
+func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUpdateInput, translator changesetTranslator, trimChanges *sceneTrimChanges) (*models.Scene, error) {
 	sceneID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("converting id: %w", err)
@@ -273,6 +304,24 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		}
 	}
 
+	if err := scene.ValidateUpdateTrim(*originalScene, *updatedScene); err != nil {
+		return nil, err
+	}
+
+	if scene.TrimChanged(*originalScene, *updatedScene) {
+		// the trimmed phash and the previews and sprites of the previous
+		// range no longer apply
+		updatedScene.TrimmedPhash = models.OptionalInt64{Set: true, Null: true}
+
+		if err := originalScene.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+			return nil, err
+		}
+
+		if err := trimChanges.add(originalScene); err != nil {
+			return nil, err
+		}
+	}
+
 	if updatedScene.PrimaryFileID != nil {
 		newPrimaryFileID := *updatedScene.PrimaryFileID
 
@@ -316,6 +365,80 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 	return scene, nil
 }
 
+// sceneTrimChanges deletes the previews and sprites generated for the
+// previous range of scenes with changed start or end points, and regenerates
+// them for the new range after the transaction is committed.
+type sceneTrimChanges struct {
+	fileDeleter *scene.FileDeleter
+	regenerate  []manager.GenerateMetadataInput
+}
+
+func newSceneTrimChanges() *sceneTrimChanges {
+	mgr := manager.GetInstance()
+	return &sceneTrimChanges{
+		fileDeleter: &scene.FileDeleter{
+			Deleter:        file.NewDeleter(),
+			FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+			Paths:          mgr.Paths,
+		},
+	}
+}
+
+// add records the generated files of the scene before its start or end
+// points are changed. The primary file of the scene must be loaded.
+func (c *sceneTrimChanges) add(s *models.Scene) error {
+	generatedHash := s.GetGeneratedHash(c.fileDeleter.FileNamingAlgo)
+	if generatedHash == "" {
+		return nil
+	}
+
+	exists := func(path string) bool {
+		ret, _ := fsutil.FileExists(path)
+		return ret
+	}
+
+	p := c.fileDeleter.Paths.Scene
+	hasPhash := s.TrimmedPhash != nil
+	if f := s.Files.Primary(); f != nil && !s.IsTrimmed() {
+		hasPhash = f.Fingerprints.Get(models.FingerprintTypePhash) != nil
+	}
+
+	// only regenerate the files that were generated for the previous range.
+	// The image preview is generated from the video preview.
+	imagePreview := exists(p.GetWebpPreviewPath(generatedHash))
+	input := manager.GenerateMetadataInput{
+		SceneIDs:      []string{strconv.Itoa(s.ID)},
+		Previews:      imagePreview || exists(p.GetVideoPreviewPath(generatedHash)),
+		ImagePreviews: imagePreview,
+		Sprites:       exists(p.GetSpriteImageFilePath(generatedHash)),
+		Phashes:       hasPhash,
+	}
+
+	if err := c.fileDeleter.MarkRangeFiles(s); err != nil {
+		return fmt.Errorf("deleting generated files: %w", err)
+	}
+
+	if input.Previews || input.ImagePreviews || input.Sprites || input.Phashes {
+		c.regenerate = append(c.regenerate, input)
+	}
+
+	return nil
+}
+
+func (c *sceneTrimChanges) rollback() {
+	c.fileDeleter.Rollback()
+}
+
+func (c *sceneTrimChanges) commit(ctx context.Context) {
+	c.fileDeleter.Commit()
+
+	for _, input := range c.regenerate {
+		if _, err := manager.GetInstance().Generate(ctx, input); err != nil {
+			logger.Errorf("error regenerating files for scene %s: %v", input.SceneIDs[0], err)
+		}
+	}
+}
+
 func (r *mutationResolver) sceneUpdateCoverImage(ctx context.Context, s *models.Scene, coverImageData []byte) error {
 	if len(coverImageData) > 0 {
 		qb := r.repository.Scene
@@ -373,6 +496,8 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		return nil, fmt.Errorf("converting gallery ids: %w", err)
 	}
 
+	updatedScene.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	if translator.hasField("group_ids") {
 		updatedScene.GroupIDs, err = translator.updateGroupIDsBulk(input.GroupIds, "group_ids")
 		if err != nil {
@@ -625,6 +750,148 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 	return ret, nil
 }
 
+func (r *mutationResolver) SceneSplit(ctx context.Context, input SceneSplitInput) (*SceneSplitResult, error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var ranges []scene.SplitRange
+	for _, rng := range input.Ranges {
+		newRange := scene.SplitRange{
+			Start: rng.Start,
+			End:   rng.End,
+		}
+		if rng.Title != nil {
+			newRange.Title = *rng.Title
+		}
+		ranges = append(ranges, newRange)
+	}
+
+	if len(ranges) == 0 && len(input.MarkerIds) > 0 {
+		ranges, err = r.sceneSplitMarkerRanges(ctx, sceneID, input.MarkerIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options := scene.SplitOptions{
+		CopyFields:    input.CopyFields,
+		DestroySource: utils.IsTrue(input.DestroySource),
+	}
+	if options.CopyFields == nil {
+		options.CopyFields = models.AllSceneSplitField
+	}
+
+	mgr := manager.GetInstance()
+
+	if utils.IsTrue(input.CutFiles) {
+		jobID, err := mgr.SplitScene(ctx, sceneID, ranges, options)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strconv.Itoa(jobID)
+		return &SceneSplitResult{
+			Scenes: []*models.Scene{},
+			JobID:  &id,
+		}, nil
+	}
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        file.NewDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	var ret []*models.Scene
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.Resolver.sceneService.Split(ctx, sceneID, ranges, fileDeleter, options)
+		return err
+	}); err != nil {
+		fileDeleter.Rollback()
+		return nil, err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	return &SceneSplitResult{
+		Scenes: ret,
+	}, nil
+}
+
+func (r *mutationResolver) SceneExportTrimmed(ctx context.Context, id string) (string, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().ExportTrimmedScene(ctx, sceneID)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+// sceneSplitMarkerRanges returns the split ranges defined by markers of the
+// scene. Markers without a title use the name of their primary tag.
+func (r *mutationResolver) sceneSplitMarkerRanges(ctx context.Context, sceneID int, markerIDs []string) ([]scene.SplitRange, error) {
+	ids, err := stringslice.StringSliceToIntSlice(markerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting marker ids: %w", err)
+	}
+
+	var ret []scene.SplitRange
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+			return err
+		}
+
+		var duration float64
+		if f := s.Files.Primary(); f != nil {
+			duration = f.Duration
+		}
+
+		markers, err := r.repository.SceneMarker.FindMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range markers {
+			if m.SceneID != sceneID {
+				return fmt.Errorf("marker %d does not belong to scene %d", m.ID, sceneID)
+			}
+
+			if m.Title == "" {
+				t, err := r.repository.Tag.Find(ctx, m.PrimaryTagID)
+				if err != nil {
+					return err
+				}
+				if t != nil {
+					m.Title = t.Name
+				}
+			}
+		}
+
+		ret = scene.MarkerSplitRanges(markers, duration)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *models.SceneMarker, err error) {
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.SceneMarker.Find(ctx, id)
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func validateScheduledTask(t config.ScheduledTask) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name must not be blank")
+	}
+
+	if !t.Type.IsValid() {
+		return fmt.Errorf("invalid task type %q", t.Type)
+	}
+
+	if _, err := manager.ParseCron(t.Cron); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scheduledTaskIdentifyOptions(input *identify.Options) *identify.Options {
+	if input == nil {
+		return nil
+	}
+
+	// scheduled tasks use the task paths and never target specific objects
+	ret := *input
+	ret.SceneIDs = nil
+	ret.GalleryIDs = nil
+	ret.GroupIDs = nil
+	ret.Paths = nil
+	return &ret
+}
+
+func scheduledTaskAutoTagOptions(input *manager.AutoTagMetadataInput) *config.AutoTagMetadataOptions {
+	if input == nil {
+		return nil
+	}
+
+	return &config.AutoTagMetadataOptions{
+		Performers: input.Performers,
+		Studios:    input.Studios,
+		Tags:       input.Tags,
+	}
+}
+
+func (r *mutationResolver) saveScheduledTasks(tasks []config.ScheduledTask) error {
+	c := config.GetInstance()
+	c.SetScheduledTasks(tasks)
+
+	if err := c.Write(); err != nil {
+		return err
+	}
+
+	manager.GetInstance().RefreshScheduler()
+	return nil
+}
+
+func (r *mutationResolver) ScheduledTaskCreate(ctx context.Context, input ScheduledTaskCreateInput) (*config.ScheduledTask, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	newTask := config.ScheduledTask{
+		ID:       id.String(),
+		Name:     strings.TrimSpace(input.Name),
+		Type:     input.Type,
+		Cron:     strings.TrimSpace(input.Cron),
+		Enabled:  input.Enabled == nil || *input.Enabled,
+		Paths:    input.Paths,
+		AutoTag:  scheduledTaskAutoTagOptions(input.AutoTag),
+		Identify: scheduledTaskIdentifyOptions(input.Identify),
+		DryRun:   input.DryRun != nil && *input.DryRun,
+	}
+
+	if input.Scan != nil {
+		// only store the options, not the paths and filter
+		newTask.Scan = &input.Scan.ScanMetadataOptions
+	}
+
+	if input.Generate != nil {
+		opts := manager.GenerateOptionsFromInput(*input.Generate)
+		newTask.Generate = &opts
+	}
+
+	if err := validateScheduledTask(newTask); err != nil {
+		return nil, err
+	}
+
+	tasks := config.GetInstance().GetScheduledTasks()
+	tasks = append(tasks, newTask)
+
+	if err := r.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return &newTask, nil
+}
+
+func (r *mutationResolver) ScheduledTaskUpdate(ctx context.Context, input ScheduledTaskUpdateInput) (*config.ScheduledTask, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	var t *config.ScheduledTask
+	for i := range tasks {
+		if tasks[i].ID == input.ID {
+			t = &tasks[i]
+			break
+		}
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("scheduled task with id %s not found", input.ID)
+	}
+
+	if input.Name != nil {
+		t.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Type != nil {
+		t.Type = *input.Type
+	}
+	if input.Cron != nil {
+		t.Cron = strings.TrimSpace(*input.Cron)
+	}
+	if input.Enabled != nil {
+		t.Enabled = *input.Enabled
+	}
+	if translator.hasField("paths") {
+		t.Paths = input.Paths
+	}
+	if translator.hasField("scan") {
+		t.Scan = nil
+		if input.Scan != nil {
+			t.Scan = &input.Scan.ScanMetadataOptions
+		}
+	}
+	if translator.hasField("generate") {
+		t.Generate = nil
+		if input.Generate != nil {
+			opts := manager.GenerateOptionsFromInput(*input.Generate)
+			t.Generate = &opts
+		}
+	}
+	if translator.hasField("autoTag") {
+		t.AutoTag = scheduledTaskAutoTagOptions(input.AutoTag)
+	}
+	if translator.hasField("identify") {
+		t.Identify = scheduledTaskIdentifyOptions(input.Identify)
+	}
+	if input.DryRun != nil {
+		t.DryRun = *input.DryRun
+	}
+
+	if err := validateScheduledTask(*t); err != nil {
+		return nil, err
+	}
+
+	ret := *t
+	if err := r.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *mutationResolver) ScheduledTaskDestroy(ctx context.Context, id string) (bool, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	var newTasks []config.ScheduledTask
+	for _, t := range tasks {
+		if t.ID != id {
+			newTasks = append(newTasks, t)
+		}
+	}
+
+	if len(newTasks) == len(tasks) {
+		return false, fmt.Errorf("scheduled task with id %s not found", id)
+	}
+
+	if err := r.saveScheduledTasks(newTasks); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -82,7 +82,12 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 			}
 		}
 
-		return nil
+		// convert json.Numbers to int/float
+		customFields := models.CustomFieldsInput{
+			Full: convertMapJSONNumbers(input.CustomFields),
+		}
+
+		return qb.SetCustomFields(ctx, newStudio.ID, customFields)
 	}); err != nil {
 		return nil, err
 	}
@@ -124,6 +129,9 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedStudio.CustomFields = convertCustomFieldsInput(&input.CustomFields)
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
@@ -205,3 +213,29 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudiosMerge(ctx context.Context, input StudiosMergeInput) (*models.Studio, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return studio.Merge(ctx, source, destination, r.repository.Studio)
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destination, hook.StudioMergePost, input, nil)
+
+	return r.getStudio(ctx, destination)
+}
@@ -79,7 +79,12 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			}
 		}
 
-		return nil
+		// convert json.Numbers to int/float
+		customFields := models.CustomFieldsInput{
+			Full: convertMapJSONNumbers(input.CustomFields),
+		}
+
+		return qb.SetCustomFields(ctx, newTag.ID, customFields)
 	}); err != nil {
 		return nil, err
 	}
@@ -118,6 +123,9 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 		return nil, fmt.Errorf("converting child tag ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedTag.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
 	if input.Image != nil {
@@ -186,6 +194,9 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 		return nil, fmt.Errorf("converting child tag ids: %w", err)
 	}
 
+	// convert json.Numbers to int/float
+	updatedTag.CustomFields = convertCustomFieldsInput(input.CustomFields)
+
 	ret := []*models.Tag{}
 
 	// Start the transaction and save the scenes
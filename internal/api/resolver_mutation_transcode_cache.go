@@ -0,0 +1,14 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) ClearTranscodeCache(ctx context.Context) (bool, error) {
+	if sm := manager.GetInstance().StreamManager; sm != nil {
+		sm.ClearTranscodeCache()
+	}
+	return true, nil
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+var (
+	errAdminOnly     = errors.New("only administrators can manage other users")
+	errNoCredentials = errors.New("a username and password must be configured before adding users")
+)
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input UserCreateInput) (*models.User, error) {
+	c := config.GetInstance()
+	if !c.HasCredentials() {
+		return nil, errNoCredentials
+	}
+
+	username := strings.TrimSpace(input.Username)
+
+	hash, err := user.HashPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	newUser := models.NewUser()
+	newUser.Username = username
+	newUser.Password = hash
+	if input.Role != nil {
+		newUser.Role = *input.Role
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		if err := user.ValidateUsername(ctx, qb, username, c.GetUsername(), 0); err != nil {
+			return err
+		}
+
+		return qb.Create(ctx, &newUser)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input UserUpdateInput) (*models.User, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	isAdmin := models.RoleFromContext(ctx) == models.UserRoleAdmin
+	if currentID := models.UserIDFromContext(ctx); !isAdmin && (currentID == nil || *currentID != id) {
+		return nil, errAdminOnly
+	}
+
+	if input.Role != nil && !isAdmin {
+		return nil, errAdminOnly
+	}
+
+	var ret *models.User
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ret == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if input.Username != nil {
+			username := strings.TrimSpace(*input.Username)
+			if err := user.ValidateUsername(ctx, qb, username, config.GetInstance().GetUsername(), id); err != nil {
+				return err
+			}
+			ret.Username = username
+		}
+
+		if input.Password != nil {
+			ret.Password, err = user.HashPassword(*input.Password)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Role != nil {
+			ret.Role = *input.Role
+		}
+
+		ret.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.User.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ConfigureRoleRestrictions(ctx context.Context, input []*RoleRestrictionsInput) ([]*models.RoleRestrictions, error) {
+	var restrictions []models.RoleRestrictions
+	for _, in := range input {
+		for _, existing := range restrictions {
+			if existing.Role == in.Role {
+				return nil, fmt.Errorf("restrictions for role %s provided more than once", in.Role)
+			}
+		}
+
+		tagIDs, err := stringslice.StringSliceToIntSlice(in.ExcludedTagIds)
+		if err != nil {
+			return nil, fmt.Errorf("converting excluded tag ids: %w", err)
+		}
+
+		studioIDs, err := stringslice.StringSliceToIntSlice(in.ExcludedStudioIds)
+		if err != nil {
+			return nil, fmt.Errorf("converting excluded studio ids: %w", err)
+		}
+
+		restrictions = append(restrictions, models.RoleRestrictions{
+			Role:              in.Role,
+			ExcludedTagIDs:    tagIDs,
+			ExcludedStudioIDs: studioIDs,
+		})
+	}
+
+	c := config.GetInstance()
+	c.SetRoleRestrictions(restrictions)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return roleRestrictions(c.GetAllRoleRestrictions()), nil
+}
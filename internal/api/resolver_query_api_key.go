@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) APIKeys(ctx context.Context) (ret []*models.APIKey, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.APIKey
+		if models.RoleFromContext(ctx) == models.UserRoleAdmin {
+			ret, err = qb.All(ctx)
+		} else {
+			ret, err = qb.FindByUserID(ctx, models.UserIDFromContext(ctx))
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindAuditLog(ctx context.Context, auditFilter *AuditLogFilterType, filter *models.FindFilterType) (ret *FindAuditLogResultType, err error) {
+	f, err := auditLogFilterFromInput(auditFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		entries, count, err := r.repository.AuditLog.Query(ctx, f, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindAuditLogResultType{
+			Count:   count,
+			Entries: entries,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func auditLogFilterFromInput(input *AuditLogFilterType) (models.AuditLogFilter, error) {
+	var ret models.AuditLogFilter
+	if input == nil {
+		return ret, nil
+	}
+
+	ret.ObjectType = input.ObjectType
+
+	for _, v := range []struct {
+		value *string
+		out   **int
+	}{
+		{input.ObjectID, &ret.ObjectID},
+		{input.JobID, &ret.JobID},
+		{input.UserID, &ret.UserID},
+	} {
+		if v.value == nil {
+			continue
+		}
+
+		id, err := strconv.Atoi(*v.value)
+		if err != nil {
+			return ret, fmt.Errorf("converting id %q: %w", *v.value, err)
+		}
+		*v.out = &id
+	}
+
+	return ret, nil
+}
@@ -105,6 +105,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
 		MaxTranscodeSize:              &maxTranscodeSize,
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
+		TranscodeCacheMaxSize:         int(config.GetTranscodeCacheMaxSize() / (1024 * 1024)),
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
 		GalleryCoverRegex:             config.GetGalleryCoverRegex(),
@@ -120,6 +121,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:    config.GetCreateGalleriesFromFolders(),
+		WatchLibrary:                  config.GetWatchLibrary(),
 		Excludes:                      config.GetExcludes(),
 		ImageExcludes:                 config.GetImageExcludes(),
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
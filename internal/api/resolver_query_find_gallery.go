@@ -36,7 +36,7 @@ func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models
 		var total int
 
 		if len(idInts) > 0 {
-			galleries, err = r.repository.Gallery.FindMany(ctx, idInts)
+			galleries, err = findManyVisible(ctx, idInts, r.repository.Gallery.FindMany, r.repository.Gallery.Find)
 			total = len(galleries)
 		} else {
 			galleries, total, err = r.repository.Gallery.Query(ctx, galleryFilter, filter)
@@ -36,7 +36,7 @@ func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.Grou
 		var total int
 
 		if len(idInts) > 0 {
-			groups, err = r.repository.Group.FindMany(ctx, idInts)
+			groups, err = findManyVisible(ctx, idInts, r.repository.Group.FindMany, r.repository.Group.Find)
 			total = len(groups)
 		} else {
 			groups, total, err = r.repository.Group.Query(ctx, groupFilter, filter)
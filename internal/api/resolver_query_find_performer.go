@@ -38,7 +38,7 @@ func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *mod
 		var total int
 
 		if len(performerIDs) > 0 {
-			performers, err = r.repository.Performer.FindMany(ctx, performerIDs)
+			performers, err = findManyVisible(ctx, performerIDs, r.repository.Performer.FindMany, r.repository.Performer.Find)
 			total = len(performers)
 		} else {
 			performers, total, err = r.repository.Performer.Query(ctx, performerFilter, filter)
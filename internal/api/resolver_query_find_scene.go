@@ -97,7 +97,7 @@ func (r *queryResolver) FindScenes(
 		result := &models.SceneQueryResult{}
 
 		if len(sceneIDs) > 0 {
-			scenes, err = r.repository.Scene.FindMany(ctx, sceneIDs)
+			scenes, err = findManyVisible(ctx, sceneIDs, r.repository.Scene.FindMany, r.repository.Scene.Find)
 			if err == nil {
 				result.Count = len(scenes)
 				for _, s := range scenes {
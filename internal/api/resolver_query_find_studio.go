@@ -37,7 +37,7 @@ func (r *queryResolver) FindStudios(ctx context.Context, studioFilter *models.St
 		var total int
 
 		if len(idInts) > 0 {
-			studios, err = r.repository.Studio.FindMany(ctx, idInts)
+			studios, err = findManyVisible(ctx, idInts, r.repository.Studio.FindMany, r.repository.Studio.Find)
 			total = len(studios)
 		} else {
 			studios, total, err = r.repository.Studio.Query(ctx, studioFilter, filter)
@@ -36,7 +36,7 @@ func (r *queryResolver) FindTags(ctx context.Context, tagFilter *models.TagFilte
 		var total int
 
 		if len(idInts) > 0 {
-			tags, err = r.repository.Tag.FindMany(ctx, idInts)
+			tags, err = findManyVisible(ctx, idInts, r.repository.Tag.FindMany, r.repository.Tag.Find)
 			total = len(tags)
 		} else {
 			tags, total, err = r.repository.Tag.Query(ctx, tagFilter, filter)
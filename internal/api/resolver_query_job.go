@@ -8,14 +8,33 @@ import (
 	"github.com/stashapp/stash/pkg/job"
 )
 
-func (r *queryResolver) JobQueue(ctx context.Context) ([]*Job, error) {
-	queue := manager.GetInstance().JobManager.GetQueue()
+const defaultJobHistoryLimit = 100
+
+func (r *queryResolver) JobQueue(ctx context.Context, includeHistory *bool, historyLimit *int) ([]*Job, error) {
+	jobManager := manager.GetInstance().JobManager
+	queue := jobManager.GetQueue()
 
 	var ret []*Job
 	for _, j := range queue {
 		ret = append(ret, jobToJobModel(j))
 	}
 
+	if includeHistory != nil && *includeHistory {
+		limit := defaultJobHistoryLimit
+		if historyLimit != nil {
+			limit = *historyLimit
+		}
+
+		history, err := jobManager.GetHistory(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, j := range history {
+			ret = append(ret, jobToJobModel(j))
+		}
+	}
+
 	return ret, nil
 }
 
@@ -42,6 +61,7 @@ func jobToJobModel(j job.Job) *Job {
 		EndTime:     j.EndTime,
 		AddTime:     j.AddTime,
 		Error:       j.Error,
+		Resumable:   manager.GetInstance().JobManager.IsResumable(j),
 	}
 
 	if j.Progress != -1 {
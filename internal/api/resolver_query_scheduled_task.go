@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *queryResolver) ScheduledTasks(ctx context.Context) ([]*config.ScheduledTask, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	ret := make([]*config.ScheduledTask, len(tasks))
+	for i := range tasks {
+		ret[i] = &tasks[i]
+	}
+
+	return ret, nil
+}
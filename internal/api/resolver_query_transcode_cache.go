@@ -0,0 +1,19 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+)
+
+func (r *queryResolver) TranscodeCache(ctx context.Context) (*ffmpeg.TranscodeCacheStatus, error) {
+	sm := manager.GetInstance().StreamManager
+	if sm == nil {
+		return &ffmpeg.TranscodeCacheStatus{
+			Entries: []*ffmpeg.TranscodeCacheEntry{},
+		}, nil
+	}
+
+	return sm.TranscodeCacheStatus(), nil
+}
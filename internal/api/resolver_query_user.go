@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) Users(ctx context.Context) (ret []*models.User, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.User.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) CurrentUser(ctx context.Context) (ret *models.User, err error) {
+	userID := models.UserIDFromContext(ctx)
+	if userID == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.User.Find(ctx, *userID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) RoleRestrictions(ctx context.Context) ([]*models.RoleRestrictions, error) {
+	return roleRestrictions(config.GetInstance().GetAllRoleRestrictions()), nil
+}
+
+func roleRestrictions(v []models.RoleRestrictions) []*models.RoleRestrictions {
+	ret := make([]*models.RoleRestrictions, len(v))
+	for i := range v {
+		ret[i] = &v[i]
+	}
+
+	return ret
+}
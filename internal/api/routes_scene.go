@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -64,6 +65,9 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream.mkv", rs.StreamMKV)
 		r.Get("/stream.m3u8", rs.StreamHLS)
 		r.Get("/stream.m3u8/{segment}.ts", rs.StreamHLSSegment)
+		r.Get("/stream_copy.m3u8", rs.StreamHLSCopy)
+		r.Get("/stream_copy.m3u8/{segment:init}.mp4", rs.StreamHLSCopySegment)
+		r.Get("/stream_copy.m3u8/{segment}.m4s", rs.StreamHLSCopySegment)
 		r.Get("/stream.mpd", rs.StreamDASH)
 		r.Get("/stream.mpd/{segment}_v.webm", rs.StreamDASHVideoSegment)
 		r.Get("/stream.mpd/{segment}_a.webm", rs.StreamDASHAudioSegment)
@@ -153,11 +157,20 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	ss, _ := strconv.ParseFloat(startTime, 64)
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.TranscodeOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		StartTime:  ss,
+		StreamType:  streamType,
+		VideoFile:   f,
+		Resolution:  resolution,
+		StartTime:   ss,
+		Trim:        sceneTrimRange(scene, f),
+		AudioStream: audioStream,
+		Hash:        scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()),
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
@@ -168,6 +181,32 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, "HLS")
 }
 
+func (rs sceneRoutes) StreamHLSCopy(w http.ResponseWriter, r *http.Request) {
+	if !rs.checkHLSCopy(w, r) {
+		return
+	}
+
+	rs.streamManifest(w, r, ffmpeg.StreamTypeHLSCopy, "HLS copy")
+}
+
+// checkHLSCopy returns true if the video stream of the scene can be copied to
+// a HLS stream. Otherwise it writes an error response and returns false.
+func (rs sceneRoutes) checkHLSCopy(w http.ResponseWriter, r *http.Request) bool {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	f := scene.Files.Primary()
+	if f == nil {
+		return false
+	}
+
+	if err := manager.CanCopyToHLS(f); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
 func (rs sceneRoutes) StreamDASH(w http.ResponseWriter, r *http.Request) {
 	rs.streamManifest(w, r, ffmpeg.StreamTypeDASHVideo, "DASH")
 }
@@ -192,14 +231,28 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
-	streamManager.ServeManifest(w, r, streamType, f, resolution)
+	streamManager.ServeManifest(w, r, streamType, f, resolution, audioStream, sceneTrimRange(scene, f))
 }
 
 func (rs sceneRoutes) StreamHLSSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeHLS)
 }
 
+func (rs sceneRoutes) StreamHLSCopySegment(w http.ResponseWriter, r *http.Request) {
+	if !rs.checkHLSCopy(w, r) {
+		return
+	}
+
+	rs.streamSegment(w, r, ffmpeg.StreamTypeHLSCopy)
+}
+
 func (rs sceneRoutes) StreamDASHVideoSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHVideo)
 }
@@ -231,17 +284,63 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	segment := chi.URLParam(r, "segment")
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.StreamOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		Hash:       sceneHash,
-		Segment:    segment,
+		StreamType:  streamType,
+		VideoFile:   f,
+		Resolution:  resolution,
+		AudioStream: audioStream,
+		Trim:        sceneTrimRange(scene, f),
+		Hash:        sceneHash,
+		Segment:     segment,
 	}
 
 	streamManager.ServeSegment(w, r, options)
 }
 
+// sceneTrimRange returns the range of the primary file of the scene to
+// stream, applying the start and end points of the scene.
+func sceneTrimRange(scene *models.Scene, f *models.VideoFile) ffmpeg.TrimRange {
+	if !scene.IsTrimmed() {
+		return ffmpeg.TrimRange{}
+	}
+
+	start, end := scene.TrimmedRange(f.Duration)
+	if end == f.Duration {
+		// stream to the end of the file
+		end = 0
+	}
+
+	return ffmpeg.TrimRange{Start: start, End: end}
+}
+
+// getAudioStream returns the index of the audio stream requested by the audio
+// query parameter. The default audio stream of the file is returned if the
+// parameter is not set. The form must be parsed before calling this function.
+func getAudioStream(r *http.Request, f *models.VideoFile) (int, error) {
+	v := r.Form.Get("audio")
+	if v == "" {
+		return f.DefaultAudioStream(), nil
+	}
+
+	ret, err := strconv.Atoi(v)
+	if err != nil || ret < 0 {
+		return 0, fmt.Errorf("invalid audio stream %q", v)
+	}
+
+	// streams are unknown if the file has not been scanned for them
+	if f.AudioStreams != nil && f.GetAudioStream(ret) == nil {
+		return 0, fmt.Errorf("audio stream %d not found", ret)
+	}
+
+	return ret, nil
+}
+
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -254,7 +353,7 @@ func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 
 func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
-	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	sceneHash := scene.GetGeneratedHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 	filepath := manager.GetInstance().Paths.Scene.GetVideoPreviewPath(sceneHash)
 
 	utils.ServeStaticFile(w, r, filepath)
@@ -262,7 +361,7 @@ func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 
 func (rs sceneRoutes) Webp(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
-	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	sceneHash := scene.GetGeneratedHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 	filepath := manager.GetInstance().Paths.Scene.GetWebpPreviewPath(sceneHash)
 
 	utils.ServeStaticFile(w, r, filepath)
@@ -346,7 +445,7 @@ func (rs sceneRoutes) VttThumbs(w http.ResponseWriter, r *http.Request) {
 	scene, ok := r.Context().Value(sceneKey).(*models.Scene)
 	var sceneHash string
 	if ok && scene != nil {
-		sceneHash = scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+		sceneHash = scene.GetGeneratedHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 	} else {
 		sceneHash = chi.URLParam(r, "sceneHash")
 	}
@@ -360,7 +459,7 @@ func (rs sceneRoutes) VttSprite(w http.ResponseWriter, r *http.Request) {
 	scene, ok := r.Context().Value(sceneKey).(*models.Scene)
 	var sceneHash string
 	if ok && scene != nil {
-		sceneHash = scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+		sceneHash = scene.GetGeneratedHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 	} else {
 		sceneHash = chi.URLParam(r, "sceneHash")
 	}
@@ -454,11 +553,65 @@ func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 		logger.Warnf("[caption] error parsing query form: %v", err)
 	}
 
+	// serve an embedded subtitle stream if the stream query param is provided
+	if stream := r.Form.Get("stream"); stream != "" {
+		rs.captionStream(w, r, stream)
+		return
+	}
+
 	l := r.Form.Get("lang")
 	ext := r.Form.Get("type")
 	rs.Caption(w, r, l, ext)
 }
 
+// captionStream serves the embedded text subtitle stream with the given index
+// as WebVTT.
+func (rs sceneRoutes) captionStream(w http.ResponseWriter, r *http.Request, stream string) {
+	s := r.Context().Value(sceneKey).(*models.Scene)
+
+	f := s.Files.Primary()
+	if f == nil {
+		http.Error(w, "scene has no files", http.StatusNotFound)
+		return
+	}
+
+	index, err := strconv.Atoi(stream)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid subtitle stream %q", stream), http.StatusBadRequest)
+		return
+	}
+
+	subtitleStream := f.GetSubtitleStream(index)
+	if subtitleStream == nil {
+		http.Error(w, fmt.Sprintf("subtitle stream %d not found", index), http.StatusNotFound)
+		return
+	}
+
+	if !ffmpeg.IsTextSubtitleCodec(subtitleStream.Codec) {
+		http.Error(w, fmt.Sprintf("subtitle codec %s cannot be converted to WebVTT", subtitleStream.Codec), http.StatusBadRequest)
+		return
+	}
+
+	encoder := manager.GetInstance().FFMpeg
+	if encoder == nil {
+		http.Error(w, "ffmpeg not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	vtt, err := encoder.ExtractSubtitleVTT(r.Context(), f.Path, index)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		logger.Warnf("[caption] error extracting subtitle stream %d from %s: %v", index, f.Path, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt")
+	utils.ServeStaticContent(w, r, vtt)
+}
+
 func (rs sceneRoutes) SceneMarkerStream(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
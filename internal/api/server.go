@@ -169,8 +169,13 @@ func Initialize() (*Server, error) {
 		hookExecutor:   pluginCache,
 	}
 
+	serverMetrics := newMetrics(mgr)
+
 	gqlSrv := gqlHandler.New(NewExecutableSchema(Config{Resolvers: resolver}))
 	gqlSrv.SetRecoverFunc(recoverFunc)
+	gqlSrv.AroundOperations(serverMetrics.aroundOperations)
+	gqlSrv.AroundRootFields(authorizeRootField)
+	gqlSrv.AroundRootFields(auditRootField)
 	gqlSrv.AddTransport(gqlTransport.Websocket{
 		Upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
@@ -209,6 +214,8 @@ func Initialize() (*Server, error) {
 		gqlPlayground.Handler("GraphQL playground", endpoint)(w, r)
 	})
 
+	r.Handle(metricsEndpoint, serverMetrics.handler())
+
 	r.Mount("/performer", server.getPerformerRoutes())
 	r.Mount("/scene", server.getSceneRoutes())
 	r.Mount("/gallery", server.getGalleryRoutes())
@@ -116,7 +116,9 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 	if f != nil {
 		size = int(f.Size)
 		bitrate = uint(f.BitRate)
-		duration = int64(f.Duration)
+		// report the duration of the trimmed range of the scene
+		start, end := scene.TrimmedRange(f.Duration)
+		duration = int64(end - start)
 	}
 
 	item.Res = append(item.Res, upnpav.Resource{
@@ -23,6 +23,9 @@ type Repository struct {
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
 	GroupFinder     GroupFinder
+
+	// returns the content restrictions applied to DLNA clients
+	restrictions func() *models.RoleRestrictions
 }
 
 func NewRepository(repo models.Repository) Repository {
@@ -38,6 +41,12 @@ func NewRepository(repo models.Repository) Repository {
 }
 
 func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
+	if r.restrictions != nil {
+		// DLNA clients are not authenticated, and are shown the content
+		// visible to viewers
+		ctx = models.WithRole(ctx, models.UserRoleViewer, r.restrictions())
+	}
+
 	return txn.WithReadTxn(ctx, r.TxnManager, fn)
 }
 
@@ -77,6 +86,7 @@ type Config interface {
 	GetDLNADefaultIPWhitelist() []string
 	GetVideoSortOrder() string
 	GetDLNAPortAsString() string
+	GetRoleRestrictions(role models.UserRole) *models.RoleRestrictions
 }
 
 type Service struct {
@@ -216,6 +226,10 @@ func (s *Service) init() error {
 
 // NewService initialises and returns a new DLNA service.
 func NewService(repo Repository, cfg Config, sceneServer sceneServer) *Service {
+	repo.restrictions = func() *models.RoleRestrictions {
+		return cfg.GetRoleRestrictions(models.UserRoleViewer)
+	}
+
 	ret := &Service{
 		repository:  repo,
 		sceneServer: sceneServer,
@@ -0,0 +1,314 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// GalleryScraper is implemented by sources which can scrape galleries.
+// The gallery urls are loaded before scraping.
+type GalleryScraper interface {
+	ScrapeGalleries(ctx context.Context, g *models.Gallery) ([]*scraper.ScrapedGallery, error)
+}
+
+type GalleryReaderUpdater interface {
+	models.GalleryUpdater
+	models.PerformerIDLoader
+	models.TagIDLoader
+	models.URLLoader
+}
+
+type PostHookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+}
+
+type GalleryIdentifier struct {
+	TxnManager           txn.Manager
+	GalleryReaderUpdater GalleryReaderUpdater
+	StudioReaderWriter   models.StudioReaderWriter
+	PerformerCreator     PerformerCreator
+	TagFinderCreator     models.TagFinderCreator
+
+	DefaultOptions   *MetadataOptions
+	Sources          []ScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type galleryScrapeResult struct {
+	result *scraper.ScrapedGallery
+	source ScraperSource
+}
+
+func (t *GalleryIdentifier) Identify(ctx context.Context, g *models.Gallery) error {
+	if err := txn.WithReadTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		return g.LoadURLs(ctx, t.GalleryReaderUpdater)
+	}); err != nil {
+		return err
+	}
+
+	result, err := t.scrapeGallery(ctx, g)
+	var multipleMatchErr *MultipleMatchesFoundError
+	if err != nil {
+		if !errors.As(err, &multipleMatchErr) {
+			return err
+		}
+	}
+
+	if result == nil {
+		if multipleMatchErr != nil {
+			logger.Debugf("Identify skipped because multiple results returned for %s", g.DisplayName())
+
+			// find if the gallery should be tagged for multiple results
+			options := getOptions(t.DefaultOptions, multipleMatchErr.Source)
+			if options.SkipMultipleMatchTag != nil && len(*options.SkipMultipleMatchTag) > 0 {
+				return t.addTagToGallery(ctx, g, *options.SkipMultipleMatchTag)
+			}
+		} else {
+			logger.Debugf("Unable to identify %s", g.DisplayName())
+		}
+		return nil
+	}
+
+	// results were found, modify the gallery
+	if err := t.modifyGallery(ctx, g, result); err != nil {
+		return fmt.Errorf("error modifying gallery: %v", err)
+	}
+
+	return nil
+}
+
+func (t *GalleryIdentifier) scrapeGallery(ctx context.Context, g *models.Gallery) (*galleryScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		gs, ok := source.Scraper.(GalleryScraper)
+		if !ok {
+			// source does not support galleries
+			continue
+		}
+
+		results, err := gs.ScrapeGalleries(ctx, g)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		if len(results) > 0 {
+			options := getOptions(t.DefaultOptions, source)
+			if len(results) > 1 && utils.IsTrue(options.SkipMultipleMatches) {
+				return nil, &MultipleMatchesFoundError{
+					Source: source,
+				}
+			}
+
+			// if results were found then return
+			return &galleryScrapeResult{
+				result: results[0],
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *GalleryIdentifier) getGalleryPartial(ctx context.Context, g *models.Gallery, result *galleryScrapeResult) (*models.GalleryPartial, error) {
+	fieldOptions := getSourceFieldOptions(t.DefaultOptions, result.source)
+	options := getOptions(t.DefaultOptions, result.source)
+
+	scraped := result.result
+
+	rel := relationships{
+		studioReaderWriter:       t.StudioReaderWriter,
+		performerCreator:         t.PerformerCreator,
+		tagCreator:               t.TagFinderCreator,
+		fieldOptions:             fieldOptions,
+		endpoint:                 result.source.RemoteSite,
+		skipSingleNamePerformers: utils.IsTrue(options.SkipSingleNamePerformers),
+	}
+
+	setOrganized := utils.IsTrue(options.SetOrganized)
+	ret := getGalleryPartial(g, scraped, fieldOptions, setOrganized)
+
+	studioID, err := rel.studio(ctx, g.StudioID, scraped.Studio)
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio: %w", err)
+	}
+
+	if studioID != nil {
+		ret.StudioID = models.NewOptionalInt(*studioID)
+	}
+
+	includeMalePerformers := true
+	if options.IncludeMalePerformers != nil {
+		includeMalePerformers = *options.IncludeMalePerformers
+	}
+
+	skippedSingleNamePerformer := false
+	performerIDs, err := rel.performers(ctx, g.PerformerIDs.List(), scraped.Performers, !includeMalePerformers)
+	if err != nil {
+		if errors.Is(err, ErrSkipSingleNamePerformer) {
+			skippedSingleNamePerformer = true
+		} else {
+			return nil, err
+		}
+	}
+	if performerIDs != nil {
+		ret.PerformerIDs = &models.UpdateIDs{
+			IDs:  performerIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	tagIDs, err := rel.tags(ctx, g.TagIDs.List(), scraped.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if skippedSingleNamePerformer {
+		tagIDs, err = addSkipSingleNamePerformerTag(tagIDs, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tagIDs != nil {
+		ret.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	return &ret, nil
+}
+
+func (t *GalleryIdentifier) modifyGallery(ctx context.Context, g *models.Gallery, result *galleryScrapeResult) error {
+	var partial *models.GalleryPartial
+	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		// load gallery relationships
+		if err := g.LoadPerformerIDs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+		if err := g.LoadTagIDs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+
+		var err error
+		partial, err = t.getGalleryPartial(ctx, g, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if isEmptyGalleryPartial(*partial) {
+			logger.Debugf("Nothing to set for %s", g.DisplayName())
+			return nil
+		}
+
+		partial.UpdatedAt = models.NewOptionalTime(time.Now())
+		if _, err := t.GalleryReaderUpdater.UpdatePartial(ctx, g.ID, *partial); err != nil {
+			return fmt.Errorf("error updating gallery: %w", err)
+		}
+
+		as := ""
+		if partial.Title.Ptr() != nil {
+			as = fmt.Sprintf(" as %s", partial.Title.Value)
+		}
+		logger.Infof("Successfully identified %s%s using %s", g.DisplayName(), as, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// fire post-update hooks
+	if !isEmptyGalleryPartial(*partial) && t.PostHookExecutor != nil {
+		updateInput := partial.UpdateInput(g.ID)
+		fields := utils.NotNilFields(updateInput, "json")
+		t.PostHookExecutor.ExecutePostHooks(ctx, g.ID, hook.GalleryUpdatePost, updateInput, fields)
+	}
+
+	return nil
+}
+
+func (t *GalleryIdentifier) addTagToGallery(ctx context.Context, g *models.Gallery, tagToAdd string) error {
+	return txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		tagID, err := strconv.Atoi(tagToAdd)
+		if err != nil {
+			return fmt.Errorf("error converting tag ID %s: %w", tagToAdd, err)
+		}
+
+		if err := g.LoadTagIDs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+
+		if slices.Contains(g.TagIDs.List(), tagID) {
+			// skip if the gallery was already tagged
+			return nil
+		}
+
+		if err := gallery.AddTag(ctx, t.GalleryReaderUpdater, g, tagID); err != nil {
+			return err
+		}
+
+		logger.Infof("Added tag id %s to skipped gallery %s", tagToAdd, g.DisplayName())
+		return nil
+	})
+}
+
+// isEmptyGalleryPartial returns true if no fields other than the updated at
+// time are set.
+func isEmptyGalleryPartial(p models.GalleryPartial) bool {
+	p.UpdatedAt = models.OptionalTime{}
+	return reflect.DeepEqual(p, models.GalleryPartial{})
+}
+
+func getGalleryPartial(g *models.Gallery, scraped *scraper.ScrapedGallery, fieldOptions map[string]*FieldOptions, setOrganized bool) models.GalleryPartial {
+	partial := models.GalleryPartial{}
+
+	if scraped.Title != nil && (g.Title != *scraped.Title) {
+		if shouldSetSingleValueField(fieldOptions["title"], g.Title != "") {
+			partial.Title = models.NewOptionalString(*scraped.Title)
+		}
+	}
+	if scraped.Date != nil && (g.Date == nil || g.Date.String() != *scraped.Date) {
+		if shouldSetSingleValueField(fieldOptions["date"], g.Date != nil) {
+			d, err := models.ParseDate(*scraped.Date)
+			if err == nil {
+				partial.Date = models.NewOptionalDate(d)
+			}
+		}
+	}
+	if scraped.Details != nil && (g.Details != *scraped.Details) {
+		if shouldSetSingleValueField(fieldOptions["details"], g.Details != "") {
+			partial.Details = models.NewOptionalString(*scraped.Details)
+		}
+	}
+	partial.URLs = getURLsUpdate(fieldOptions, g.URLs.List(), scraped.URLs)
+	if scraped.Photographer != nil && (g.Photographer != *scraped.Photographer) {
+		if shouldSetSingleValueField(fieldOptions["photographer"], g.Photographer != "") {
+			partial.Photographer = models.NewOptionalString(*scraped.Photographer)
+		}
+	}
+	if scraped.Code != nil && (g.Code != *scraped.Code) {
+		if shouldSetSingleValueField(fieldOptions["code"], g.Code != "") {
+			partial.Code = models.NewOptionalString(*scraped.Code)
+		}
+	}
+
+	if setOrganized && !g.Organized {
+		partial.Organized = models.NewOptionalBool(true)
+	}
+
+	return partial
+}
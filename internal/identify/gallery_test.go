@@ -0,0 +1,369 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockGalleryScraper struct {
+	mockSceneScraper
+	errIDs  []int
+	results map[int][]*scraper.ScrapedGallery
+}
+
+func (s mockGalleryScraper) ScrapeGalleries(ctx context.Context, g *models.Gallery) ([]*scraper.ScrapedGallery, error) {
+	if slices.Contains(s.errIDs, g.ID) {
+		return nil, errors.New("scrape gallery error")
+	}
+	return s.results[g.ID], nil
+}
+
+func TestGalleryIdentifier_Identify(t *testing.T) {
+	const (
+		errID1 = iota
+		missingID
+		found1ID
+		found2ID
+		multiFoundID
+		multiFound2ID
+		errUpdateID
+	)
+
+	var (
+		skipMultipleTagID    = 1
+		skipMultipleTagIDStr = strconv.Itoa(skipMultipleTagID)
+	)
+
+	var (
+		scrapedTitle  = "scrapedTitle"
+		scrapedTitle2 = "scrapedTitle2"
+
+		boolFalse = false
+		boolTrue  = true
+	)
+
+	defaultOptions := &MetadataOptions{
+		SetOrganized:             &boolFalse,
+		IncludeMalePerformers:    &boolFalse,
+		SkipSingleNamePerformers: &boolFalse,
+	}
+	sources := []ScraperSource{
+		{
+			// scene-only scrapers are skipped
+			Scraper: mockSceneScraper{},
+		},
+		{
+			Scraper: mockGalleryScraper{
+				errIDs: []int{errID1},
+				results: map[int][]*scraper.ScrapedGallery{
+					found1ID: {{
+						Title: &scrapedTitle,
+					}},
+				},
+			},
+		},
+		{
+			Scraper: mockGalleryScraper{
+				results: map[int][]*scraper.ScrapedGallery{
+					found2ID: {{
+						Title: &scrapedTitle,
+					}},
+					errUpdateID: {{
+						Title: &scrapedTitle,
+					}},
+					multiFoundID: {
+						{
+							Title: &scrapedTitle,
+						},
+						{
+							Title: &scrapedTitle2,
+						},
+					},
+					multiFound2ID: {
+						{
+							Title: &scrapedTitle,
+						},
+						{
+							Title: &scrapedTitle2,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	db := mocks.NewDatabase()
+
+	db.Gallery.On("GetURLs", mock.Anything, mock.Anything).Return(nil, nil)
+	db.Gallery.On("UpdatePartial", mock.Anything, mock.MatchedBy(func(id int) bool {
+		return id == errUpdateID
+	}), mock.Anything).Return(nil, errors.New("update error"))
+	db.Gallery.On("UpdatePartial", mock.Anything, mock.MatchedBy(func(id int) bool {
+		return id != errUpdateID
+	}), mock.Anything).Return(nil, nil)
+
+	tests := []struct {
+		name      string
+		galleryID int
+		options   *MetadataOptions
+		wantErr   bool
+	}{
+		{
+			"error scraping",
+			errID1,
+			nil,
+			false,
+		},
+		{
+			"found in first scraper",
+			found1ID,
+			nil,
+			false,
+		},
+		{
+			"found in second scraper",
+			found2ID,
+			nil,
+			false,
+		},
+		{
+			"not found",
+			missingID,
+			nil,
+			false,
+		},
+		{
+			"error modifying",
+			errUpdateID,
+			nil,
+			true,
+		},
+		{
+			"multiple found",
+			multiFoundID,
+			nil,
+			false,
+		},
+		{
+			"multiple found - set tag",
+			multiFound2ID,
+			&MetadataOptions{
+				SkipMultipleMatches:  &boolTrue,
+				SkipMultipleMatchTag: &skipMultipleTagIDStr,
+			},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identifier := GalleryIdentifier{
+				TxnManager:           db,
+				GalleryReaderUpdater: db.Gallery,
+				StudioReaderWriter:   db.Studio,
+				PerformerCreator:     db.Performer,
+				TagFinderCreator:     db.Tag,
+				DefaultOptions:       defaultOptions,
+				Sources:              sources,
+				PostHookExecutor:     mockHookExecutor{},
+			}
+
+			if tt.options != nil {
+				identifier.DefaultOptions = tt.options
+			}
+
+			gallery := &models.Gallery{
+				ID:           tt.galleryID,
+				PerformerIDs: models.NewRelatedIDs([]int{}),
+				TagIDs:       models.NewRelatedIDs([]int{}),
+			}
+			if err := identifier.Identify(testCtx, gallery); (err != nil) != tt.wantErr {
+				t.Errorf("GalleryIdentifier.Identify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getGalleryPartial(t *testing.T) {
+	var (
+		originalTitle        = "originalTitle"
+		originalDate         = "2001-01-01"
+		originalPhotographer = "originalPhotographer"
+		originalURL          = "originalURL"
+	)
+
+	var (
+		scrapedTitle        = "scrapedTitle"
+		scrapedDate         = "2002-02-02"
+		scrapedPhotographer = "scrapedPhotographer"
+		scrapedURL          = "scrapedURL"
+	)
+
+	originalDateObj, _ := models.ParseDate(originalDate)
+	scrapedDateObj, _ := models.ParseDate(scrapedDate)
+
+	originalGallery := &models.Gallery{
+		Title:        originalTitle,
+		Date:         &originalDateObj,
+		Photographer: originalPhotographer,
+		URLs:         models.NewRelatedStrings([]string{originalURL}),
+	}
+
+	organisedGallery := *originalGallery
+	organisedGallery.Organized = true
+
+	emptyGallery := &models.Gallery{
+		URLs: models.NewRelatedStrings([]string{}),
+	}
+
+	postPartial := models.GalleryPartial{
+		Title:        models.NewOptionalString(scrapedTitle),
+		Date:         models.NewOptionalDate(scrapedDateObj),
+		Photographer: models.NewOptionalString(scrapedPhotographer),
+		URLs: &models.UpdateStrings{
+			Values: []string{scrapedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		},
+	}
+
+	scrapedGallery := &scraper.ScrapedGallery{
+		Title:        &scrapedTitle,
+		Date:         &scrapedDate,
+		Photographer: &scrapedPhotographer,
+		URLs:         []string{scrapedURL},
+	}
+
+	scrapedUnchangedGallery := &scraper.ScrapedGallery{
+		Title:        &originalTitle,
+		Date:         &originalDate,
+		Photographer: &originalPhotographer,
+		URLs:         []string{originalURL},
+	}
+
+	makeFieldOptions := func(input *FieldOptions) map[string]*FieldOptions {
+		return map[string]*FieldOptions{
+			"title":        input,
+			"date":         input,
+			"photographer": input,
+			"url":          input,
+		}
+	}
+
+	overwriteAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyOverwrite,
+	})
+	ignoreAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyIgnore,
+	})
+	mergeAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyMerge,
+	})
+
+	setOrganised := true
+
+	type args struct {
+		gallery      *models.Gallery
+		scraped      *scraper.ScrapedGallery
+		fieldOptions map[string]*FieldOptions
+		setOrganized bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want models.GalleryPartial
+	}{
+		{
+			"set all",
+			args{
+				emptyGallery,
+				scrapedGallery,
+				nil,
+				false,
+			},
+			postPartial,
+		},
+		{
+			"overwrite all",
+			args{
+				originalGallery,
+				scrapedGallery,
+				overwriteAll,
+				false,
+			},
+			postPartial,
+		},
+		{
+			"ignore all",
+			args{
+				originalGallery,
+				scrapedGallery,
+				ignoreAll,
+				false,
+			},
+			models.GalleryPartial{},
+		},
+		{
+			"merge (existing values)",
+			args{
+				originalGallery,
+				scrapedGallery,
+				mergeAll,
+				false,
+			},
+			models.GalleryPartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"unchanged",
+			args{
+				originalGallery,
+				scrapedUnchangedGallery,
+				overwriteAll,
+				false,
+			},
+			models.GalleryPartial{},
+		},
+		{
+			"set organized",
+			args{
+				originalGallery,
+				scrapedUnchangedGallery,
+				overwriteAll,
+				setOrganised,
+			},
+			models.GalleryPartial{
+				Organized: models.NewOptionalBool(true),
+			},
+		},
+		{
+			"set organized unchanged",
+			args{
+				&organisedGallery,
+				scrapedUnchangedGallery,
+				overwriteAll,
+				setOrganised,
+			},
+			models.GalleryPartial{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getGalleryPartial(tt.args.gallery, tt.args.scraped, tt.args.fieldOptions, tt.args.setOrganized); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getGalleryPartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
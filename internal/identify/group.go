@@ -0,0 +1,353 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// GroupScraper is implemented by sources which can scrape groups.
+// The group urls are loaded before scraping.
+type GroupScraper interface {
+	ScrapeGroups(ctx context.Context, g *models.Group) ([]*models.ScrapedGroup, error)
+}
+
+type GroupReaderUpdater interface {
+	models.GroupUpdater
+	models.TagIDLoader
+	models.URLLoader
+}
+
+type GroupIdentifier struct {
+	TxnManager         txn.Manager
+	GroupReaderUpdater GroupReaderUpdater
+	StudioReaderWriter models.StudioReaderWriter
+	TagFinderCreator   models.TagFinderCreator
+
+	DefaultOptions   *MetadataOptions
+	Sources          []ScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type groupScrapeResult struct {
+	result *models.ScrapedGroup
+	source ScraperSource
+}
+
+// groupUpdate contains the changes to apply to a group.
+type groupUpdate struct {
+	partial    models.GroupPartial
+	frontImage []byte
+	backImage  []byte
+}
+
+func (u groupUpdate) isEmpty() bool {
+	p := u.partial
+	p.UpdatedAt = models.OptionalTime{}
+	return reflect.DeepEqual(p, models.GroupPartial{}) && len(u.frontImage) == 0 && len(u.backImage) == 0
+}
+
+// hookInput returns the input and input fields to pass to the post-update hooks.
+func (u groupUpdate) hookInput(id int) (map[string]interface{}, []string) {
+	p := u.partial
+	input := map[string]interface{}{
+		"id": strconv.Itoa(id),
+	}
+
+	setIfNotNil := func(key string, v interface{}) {
+		if !reflect.ValueOf(v).IsNil() {
+			input[key] = v
+		}
+	}
+
+	setIfNotNil("name", p.Name.Ptr())
+	setIfNotNil("aliases", p.Aliases.Ptr())
+	setIfNotNil("director", p.Director.Ptr())
+	setIfNotNil("synopsis", p.Synopsis.Ptr())
+	setIfNotNil("studio_id", p.StudioID.StringPtr())
+	if p.Date.Set {
+		input["date"] = p.Date.Value.String()
+	}
+	if p.URLs != nil {
+		input["urls"] = p.URLs.Strings()
+	}
+	if p.TagIDs != nil {
+		input["tag_ids"] = p.TagIDs.IDStrings()
+	}
+
+	var fields []string
+	for k := range input {
+		fields = append(fields, k)
+	}
+	slices.Sort(fields)
+
+	return input, fields
+}
+
+func (t *GroupIdentifier) Identify(ctx context.Context, g *models.Group) error {
+	if err := txn.WithReadTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		return g.LoadURLs(ctx, t.GroupReaderUpdater)
+	}); err != nil {
+		return err
+	}
+
+	result, err := t.scrapeGroup(ctx, g)
+	var multipleMatchErr *MultipleMatchesFoundError
+	if err != nil {
+		if !errors.As(err, &multipleMatchErr) {
+			return err
+		}
+	}
+
+	if result == nil {
+		if multipleMatchErr != nil {
+			logger.Debugf("Identify skipped because multiple results returned for group %s", g.Name)
+
+			// find if the group should be tagged for multiple results
+			options := getOptions(t.DefaultOptions, multipleMatchErr.Source)
+			if options.SkipMultipleMatchTag != nil && len(*options.SkipMultipleMatchTag) > 0 {
+				return t.addTagToGroup(ctx, g, *options.SkipMultipleMatchTag)
+			}
+		} else {
+			logger.Debugf("Unable to identify group %s", g.Name)
+		}
+		return nil
+	}
+
+	// results were found, modify the group
+	if err := t.modifyGroup(ctx, g, result); err != nil {
+		return fmt.Errorf("error modifying group: %v", err)
+	}
+
+	return nil
+}
+
+func (t *GroupIdentifier) scrapeGroup(ctx context.Context, g *models.Group) (*groupScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		gs, ok := source.Scraper.(GroupScraper)
+		if !ok {
+			// source does not support groups
+			continue
+		}
+
+		results, err := gs.ScrapeGroups(ctx, g)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		if len(results) > 0 {
+			options := getOptions(t.DefaultOptions, source)
+			if len(results) > 1 && utils.IsTrue(options.SkipMultipleMatches) {
+				return nil, &MultipleMatchesFoundError{
+					Source: source,
+				}
+			}
+
+			// if results were found then return
+			return &groupScrapeResult{
+				result: results[0],
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *GroupIdentifier) getGroupUpdate(ctx context.Context, g *models.Group, result *groupScrapeResult) (*groupUpdate, error) {
+	fieldOptions := getSourceFieldOptions(t.DefaultOptions, result.source)
+	options := getOptions(t.DefaultOptions, result.source)
+
+	scraped := result.result
+
+	rel := relationships{
+		studioReaderWriter: t.StudioReaderWriter,
+		tagCreator:         t.TagFinderCreator,
+		fieldOptions:       fieldOptions,
+		endpoint:           result.source.RemoteSite,
+	}
+
+	ret := &groupUpdate{
+		partial: getGroupPartial(g, scraped, fieldOptions),
+	}
+
+	studioID, err := rel.studio(ctx, g.StudioID, scraped.Studio)
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio: %w", err)
+	}
+
+	if studioID != nil {
+		ret.partial.StudioID = models.NewOptionalInt(*studioID)
+	}
+
+	tagIDs, err := rel.tags(ctx, g.TagIDs.List(), scraped.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if tagIDs != nil {
+		ret.partial.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	// SetCoverImage defaults to true if unset
+	if options.SetCoverImage == nil || *options.SetCoverImage {
+		ret.frontImage, err = processScrapedImage(ctx, scraped.FrontImage)
+		if err != nil {
+			return nil, fmt.Errorf("error processing front image: %w", err)
+		}
+		ret.backImage, err = processScrapedImage(ctx, scraped.BackImage)
+		if err != nil {
+			return nil, fmt.Errorf("error processing back image: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+func processScrapedImage(ctx context.Context, scraped *string) ([]byte, error) {
+	if scraped == nil || *scraped == "" {
+		return nil, nil
+	}
+
+	return utils.ProcessImageInput(ctx, *scraped)
+}
+
+func (t *GroupIdentifier) modifyGroup(ctx context.Context, g *models.Group, result *groupScrapeResult) error {
+	var update *groupUpdate
+	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		// load group relationships
+		if err := g.LoadTagIDs(ctx, t.GroupReaderUpdater); err != nil {
+			return err
+		}
+
+		var err error
+		update, err = t.getGroupUpdate(ctx, g, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if update.isEmpty() {
+			logger.Debugf("Nothing to set for group %s", g.Name)
+			return nil
+		}
+
+		r := t.GroupReaderUpdater
+
+		update.partial.UpdatedAt = models.NewOptionalTime(time.Now())
+		if _, err := r.UpdatePartial(ctx, g.ID, update.partial); err != nil {
+			return fmt.Errorf("error updating group: %w", err)
+		}
+
+		if len(update.frontImage) > 0 {
+			if err := r.UpdateFrontImage(ctx, g.ID, update.frontImage); err != nil {
+				return fmt.Errorf("error updating group front image: %w", err)
+			}
+		}
+
+		if len(update.backImage) > 0 {
+			if err := r.UpdateBackImage(ctx, g.ID, update.backImage); err != nil {
+				return fmt.Errorf("error updating group back image: %w", err)
+			}
+		}
+
+		as := ""
+		if update.partial.Name.Ptr() != nil {
+			as = fmt.Sprintf(" as %s", update.partial.Name.Value)
+		}
+		logger.Infof("Successfully identified group %s%s using %s", g.Name, as, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// fire post-update hooks
+	if !update.isEmpty() && t.PostHookExecutor != nil {
+		input, fields := update.hookInput(g.ID)
+		t.PostHookExecutor.ExecutePostHooks(ctx, g.ID, hook.GroupUpdatePost, input, fields)
+	}
+
+	return nil
+}
+
+func (t *GroupIdentifier) addTagToGroup(ctx context.Context, g *models.Group, tagToAdd string) error {
+	return txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		tagID, err := strconv.Atoi(tagToAdd)
+		if err != nil {
+			return fmt.Errorf("error converting tag ID %s: %w", tagToAdd, err)
+		}
+
+		if err := g.LoadTagIDs(ctx, t.GroupReaderUpdater); err != nil {
+			return err
+		}
+
+		if slices.Contains(g.TagIDs.List(), tagID) {
+			// skip if the group was already tagged
+			return nil
+		}
+
+		partial := models.NewGroupPartial()
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  []int{tagID},
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+
+		if _, err := t.GroupReaderUpdater.UpdatePartial(ctx, g.ID, partial); err != nil {
+			return err
+		}
+
+		logger.Infof("Added tag id %s to skipped group %s", tagToAdd, g.Name)
+		return nil
+	})
+}
+
+func getGroupPartial(g *models.Group, scraped *models.ScrapedGroup, fieldOptions map[string]*FieldOptions) models.GroupPartial {
+	partial := models.GroupPartial{}
+
+	if scraped.Name != nil && (g.Name != *scraped.Name) {
+		if shouldSetSingleValueField(fieldOptions["name"], g.Name != "") {
+			partial.Name = models.NewOptionalString(*scraped.Name)
+		}
+	}
+	if scraped.Aliases != nil && (g.Aliases != *scraped.Aliases) {
+		if shouldSetSingleValueField(fieldOptions["aliases"], g.Aliases != "") {
+			partial.Aliases = models.NewOptionalString(*scraped.Aliases)
+		}
+	}
+	if scraped.Date != nil && (g.Date == nil || g.Date.String() != *scraped.Date) {
+		if shouldSetSingleValueField(fieldOptions["date"], g.Date != nil) {
+			d, err := models.ParseDate(*scraped.Date)
+			if err == nil {
+				partial.Date = models.NewOptionalDate(d)
+			}
+		}
+	}
+	if scraped.Director != nil && (g.Director != *scraped.Director) {
+		if shouldSetSingleValueField(fieldOptions["director"], g.Director != "") {
+			partial.Director = models.NewOptionalString(*scraped.Director)
+		}
+	}
+	if scraped.Synopsis != nil && (g.Synopsis != *scraped.Synopsis) {
+		if shouldSetSingleValueField(fieldOptions["synopsis"], g.Synopsis != "") {
+			partial.Synopsis = models.NewOptionalString(*scraped.Synopsis)
+		}
+	}
+	partial.URLs = getURLsUpdate(fieldOptions, g.URLs.List(), scraped.URLs)
+
+	return partial
+}
@@ -0,0 +1,110 @@
+package identify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func Test_getGroupPartial(t *testing.T) {
+	var (
+		originalName     = "originalName"
+		originalDirector = "originalDirector"
+		originalURL      = "originalURL"
+	)
+
+	var (
+		scrapedName     = "scrapedName"
+		scrapedDirector = "scrapedDirector"
+		scrapedSynopsis = "scrapedSynopsis"
+		scrapedURL      = "scrapedURL"
+	)
+
+	originalGroup := &models.Group{
+		Name:     originalName,
+		Director: originalDirector,
+		URLs:     models.NewRelatedStrings([]string{originalURL}),
+	}
+
+	emptyGroup := &models.Group{
+		URLs: models.NewRelatedStrings([]string{}),
+	}
+
+	scrapedGroup := &models.ScrapedGroup{
+		Name:     &scrapedName,
+		Director: &scrapedDirector,
+		Synopsis: &scrapedSynopsis,
+		URLs:     []string{scrapedURL},
+	}
+
+	postPartial := models.GroupPartial{
+		Name:     models.NewOptionalString(scrapedName),
+		Director: models.NewOptionalString(scrapedDirector),
+		Synopsis: models.NewOptionalString(scrapedSynopsis),
+		URLs: &models.UpdateStrings{
+			Values: []string{scrapedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		},
+	}
+
+	makeFieldOptions := func(input *FieldOptions) map[string]*FieldOptions {
+		return map[string]*FieldOptions{
+			"name":     input,
+			"director": input,
+			"synopsis": input,
+			"url":      input,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		group        *models.Group
+		fieldOptions map[string]*FieldOptions
+		want         models.GroupPartial
+	}{
+		{
+			"set all",
+			emptyGroup,
+			nil,
+			postPartial,
+		},
+		{
+			"overwrite all",
+			originalGroup,
+			makeFieldOptions(&FieldOptions{
+				Strategy: FieldStrategyOverwrite,
+			}),
+			postPartial,
+		},
+		{
+			"ignore all",
+			originalGroup,
+			makeFieldOptions(&FieldOptions{
+				Strategy: FieldStrategyIgnore,
+			}),
+			models.GroupPartial{},
+		},
+		{
+			"merge (existing values)",
+			originalGroup,
+			makeFieldOptions(&FieldOptions{
+				Strategy: FieldStrategyMerge,
+			}),
+			models.GroupPartial{
+				Synopsis: models.NewOptionalString(scrapedSynopsis),
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getGroupPartial(tt.group, scrapedGroup, tt.fieldOptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getGroupPartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
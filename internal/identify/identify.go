@@ -14,7 +14,6 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
-	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/txn"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -131,9 +130,13 @@ func (t *SceneIdentifier) scrapeScene(ctx context.Context, scene *models.Scene)
 
 // Returns a MetadataOptions object with any default options overwritten by source specific options
 func (t *SceneIdentifier) getOptions(source ScraperSource) MetadataOptions {
+	return getOptions(t.DefaultOptions, source)
+}
+
+func getOptions(defaultOptions *MetadataOptions, source ScraperSource) MetadataOptions {
 	var options MetadataOptions
-	if t.DefaultOptions != nil {
-		options = *t.DefaultOptions
+	if defaultOptions != nil {
+		options = *defaultOptions
 	}
 	if source.Options == nil {
 		return options
@@ -169,15 +172,7 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 		ID: s.ID,
 	}
 
-	allOptions := []MetadataOptions{}
-	if result.source.Options != nil {
-		allOptions = append(allOptions, *result.source.Options)
-	}
-	if t.DefaultOptions != nil {
-		allOptions = append(allOptions, *t.DefaultOptions)
-	}
-
-	fieldOptions := getFieldOptions(allOptions)
+	fieldOptions := getSourceFieldOptions(t.DefaultOptions, result.source)
 	options := t.getOptions(result.source)
 
 	scraped := result.result
@@ -210,11 +205,11 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 		includeMalePerformers = *options.IncludeMalePerformers
 	}
 
-	addSkipSingleNamePerformerTag := false
+	skippedSingleNamePerformer := false
 	performerIDs, err := rel.performers(ctx, !includeMalePerformers)
 	if err != nil {
 		if errors.Is(err, ErrSkipSingleNamePerformer) {
-			addSkipSingleNamePerformerTag = true
+			skippedSingleNamePerformer = true
 		} else {
 			return nil, err
 		}
@@ -230,13 +225,11 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 	if err != nil {
 		return nil, err
 	}
-	if addSkipSingleNamePerformerTag && options.SkipSingleNamePerformerTag != nil {
-		tagID, err := strconv.ParseInt(*options.SkipSingleNamePerformerTag, 10, 64)
+	if skippedSingleNamePerformer {
+		tagIDs, err = addSkipSingleNamePerformerTag(tagIDs, options)
 		if err != nil {
-			return nil, fmt.Errorf("error converting tag ID %s: %w", *options.SkipSingleNamePerformerTag, err)
+			return nil, err
 		}
-
-		tagIDs = sliceutil.AppendUnique(tagIDs, int(tagID))
 	}
 	if tagIDs != nil {
 		ret.Partial.TagIDs = &models.UpdateIDs{
@@ -360,6 +353,20 @@ func (t *SceneIdentifier) addTagToScene(ctx context.Context, s *models.Scene, ta
 	return nil
 }
 
+// getSourceFieldOptions returns the field options of the source, falling back
+// to the default field options.
+func getSourceFieldOptions(defaultOptions *MetadataOptions, source ScraperSource) map[string]*FieldOptions {
+	allOptions := []MetadataOptions{}
+	if source.Options != nil {
+		allOptions = append(allOptions, *source.Options)
+	}
+	if defaultOptions != nil {
+		allOptions = append(allOptions, *defaultOptions)
+	}
+
+	return getFieldOptions(allOptions)
+}
+
 func getFieldOptions(options []MetadataOptions) map[string]*FieldOptions {
 	// prefer source-specific field strategies, then the defaults
 	ret := make(map[string]*FieldOptions)
@@ -395,29 +402,7 @@ func getScenePartial(scene *models.Scene, scraped *scraper.ScrapedScene, fieldOp
 			partial.Details = models.NewOptionalString(*scraped.Details)
 		}
 	}
-	if len(scraped.URLs) > 0 && shouldSetSingleValueField(fieldOptions["url"], false) {
-		// if overwrite, then set over the top
-		switch getFieldStrategy(fieldOptions["url"]) {
-		case FieldStrategyOverwrite:
-			// only overwrite if not equal
-			if len(sliceutil.Exclude(scraped.URLs, scene.URLs.List())) != 0 {
-				partial.URLs = &models.UpdateStrings{
-					Values: scraped.URLs,
-					Mode:   models.RelationshipUpdateModeSet,
-				}
-			}
-		case FieldStrategyMerge:
-			// if merge, add if not already present
-			urls := sliceutil.AppendUniques(scene.URLs.List(), scraped.URLs)
-
-			if len(urls) != len(scene.URLs.List()) {
-				partial.URLs = &models.UpdateStrings{
-					Values: urls,
-					Mode:   models.RelationshipUpdateModeSet,
-				}
-			}
-		}
-	}
+	partial.URLs = getURLsUpdate(fieldOptions, scene.URLs.List(), scraped.URLs)
 	if scraped.Director != nil && (scene.Director != *scraped.Director) {
 		if shouldSetSingleValueField(fieldOptions["director"], scene.Director != "") {
 			partial.Director = models.NewOptionalString(*scraped.Director)
@@ -21,8 +21,19 @@ type Options struct {
 	Options *MetadataOptions `json:"options"`
 	// scene ids to identify
 	SceneIDs []string `json:"sceneIDs"`
-	// paths of scenes to identify - ignored if scene ids are set
+	// gallery ids to identify
+	GalleryIDs []string `json:"galleryIDs"`
+	// group ids to identify
+	GroupIDs []string `json:"groupIDs"`
+	// paths of scenes and galleries to identify - ignored if ids are set
 	Paths []string `json:"paths"`
+	// types of objects to identify if no ids are set - defaults to scenes
+	ObjectTypes []ObjectType `json:"objectTypes"`
+}
+
+// HasIDs returns true if any scene, gallery or group ids are set.
+func (o Options) HasIDs() bool {
+	return len(o.SceneIDs) > 0 || len(o.GalleryIDs) > 0 || len(o.GroupIDs) > 0
 }
 
 type MetadataOptions struct {
@@ -98,3 +109,46 @@ func (e *FieldStrategy) UnmarshalGQL(v interface{}) error {
 func (e FieldStrategy) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ObjectType string
+
+const (
+	ObjectTypeScene   ObjectType = "SCENE"
+	ObjectTypeGallery ObjectType = "GALLERY"
+	ObjectTypeGroup   ObjectType = "GROUP"
+)
+
+var AllObjectType = []ObjectType{
+	ObjectTypeScene,
+	ObjectTypeGallery,
+	ObjectTypeGroup,
+}
+
+func (e ObjectType) IsValid() bool {
+	switch e {
+	case ObjectTypeScene, ObjectTypeGallery, ObjectTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (e ObjectType) String() string {
+	return string(e)
+}
+
+func (e *ObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid IdentifyObjectType", str)
+	}
+	return nil
+}
+
+func (e ObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,208 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// relationships contains the shared logic for resolving the studio,
+// performers and tags of a scraped object against the existing values.
+type relationships struct {
+	studioReaderWriter       models.StudioReaderWriter
+	performerCreator         PerformerCreator
+	tagCreator               models.TagCreator
+	fieldOptions             map[string]*FieldOptions
+	endpoint                 string
+	skipSingleNamePerformers bool
+}
+
+// studio returns the id of the scraped studio, creating it if needed.
+// Returns nil if the studio should not be set.
+func (r relationships) studio(ctx context.Context, existingID *int, scraped *models.ScrapedStudio) (*int, error) {
+	fieldStrategy := r.fieldOptions["studio"]
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+
+	if scraped == nil || !shouldSetSingleValueField(fieldStrategy, existingID != nil) {
+		return nil, nil
+	}
+
+	if scraped.StoredID != nil {
+		// existing studio, just set it
+		studioID, err := strconv.Atoi(*scraped.StoredID)
+		if err != nil {
+			return nil, fmt.Errorf("error converting studio ID %s: %w", *scraped.StoredID, err)
+		}
+
+		// only return value if different to current
+		if existingID == nil || *existingID != studioID {
+			return &studioID, nil
+		}
+	} else if createMissing {
+		return createMissingStudio(ctx, r.endpoint, r.studioReaderWriter, scraped)
+	}
+
+	return nil, nil
+}
+
+// performers returns the updated performer ids, creating performers if
+// needed. Returns nil if nothing was changed.
+func (r relationships) performers(ctx context.Context, originalPerformerIDs []int, scraped []*models.ScrapedPerformer, ignoreMale bool) ([]int, error) {
+	fieldStrategy := r.fieldOptions["performers"]
+
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var performerIDs []int
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		performerIDs = originalPerformerIDs
+	}
+
+	singleNamePerformerSkipped := false
+
+	for _, p := range scraped {
+		if ignoreMale && p.Gender != nil && strings.EqualFold(*p.Gender, models.GenderEnumMale.String()) {
+			continue
+		}
+
+		performerID, err := getPerformerID(ctx, r.endpoint, r.performerCreator, p, createMissing, r.skipSingleNamePerformers)
+		if err != nil {
+			if errors.Is(err, ErrSkipSingleNamePerformer) {
+				singleNamePerformerSkipped = true
+				continue
+			}
+			return nil, err
+		}
+
+		if performerID != nil {
+			performerIDs = sliceutil.AppendUnique(performerIDs, *performerID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalPerformerIDs, performerIDs) {
+		if singleNamePerformerSkipped {
+			return nil, ErrSkipSingleNamePerformer
+		}
+		return nil, nil
+	}
+
+	if singleNamePerformerSkipped {
+		return performerIDs, ErrSkipSingleNamePerformer
+	}
+	return performerIDs, nil
+}
+
+// tags returns the updated tag ids, creating tags if needed. Returns nil if
+// nothing was changed.
+func (r relationships) tags(ctx context.Context, originalTagIDs []int, scraped []*models.ScrapedTag) ([]int, error) {
+	fieldStrategy := r.fieldOptions["tags"]
+
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var tagIDs []int
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		tagIDs = originalTagIDs
+	}
+
+	for _, t := range scraped {
+		if t.StoredID != nil {
+			// existing tag, just add it
+			tagID, err := strconv.ParseInt(*t.StoredID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error converting tag ID %s: %w", *t.StoredID, err)
+			}
+
+			tagIDs = sliceutil.AppendUnique(tagIDs, int(tagID))
+		} else if createMissing {
+			newTag := models.NewTag()
+			newTag.Name = t.Name
+
+			err := r.tagCreator.Create(ctx, &newTag)
+			if err != nil {
+				return nil, fmt.Errorf("error creating tag: %w", err)
+			}
+
+			tagIDs = append(tagIDs, newTag.ID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalTagIDs, tagIDs) {
+		return nil, nil
+	}
+
+	return tagIDs, nil
+}
+
+// getURLsUpdate returns the updated urls. Returns nil if nothing was changed.
+func getURLsUpdate(fieldOptions map[string]*FieldOptions, existing []string, scraped []string) *models.UpdateStrings {
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldOptions["url"], false) {
+		return nil
+	}
+
+	switch getFieldStrategy(fieldOptions["url"]) {
+	case FieldStrategyOverwrite:
+		// only overwrite if not equal
+		if len(sliceutil.Exclude(scraped, existing)) != 0 {
+			return &models.UpdateStrings{
+				Values: scraped,
+				Mode:   models.RelationshipUpdateModeSet,
+			}
+		}
+	case FieldStrategyMerge:
+		// if merge, add if not already present
+		urls := sliceutil.AppendUniques(existing, scraped)
+
+		if len(urls) != len(existing) {
+			return &models.UpdateStrings{
+				Values: urls,
+				Mode:   models.RelationshipUpdateModeSet,
+			}
+		}
+	}
+
+	return nil
+}
+
+// addSkipSingleNamePerformerTag adds the skip single name performer tag to
+// the tag ids.
+func addSkipSingleNamePerformerTag(tagIDs []int, options MetadataOptions) ([]int, error) {
+	if options.SkipSingleNamePerformerTag == nil {
+		return tagIDs, nil
+	}
+
+	tagID, err := strconv.ParseInt(*options.SkipSingleNamePerformerTag, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error converting tag ID %s: %w", *options.SkipSingleNamePerformerTag, err)
+	}
+
+	return sliceutil.AppendUnique(tagIDs, int(tagID)), nil
+}
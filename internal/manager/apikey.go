@@ -1,22 +1,31 @@
 package manager
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 var ErrInvalidToken = errors.New("invalid apikey")
 
 const APIKeySubject = "APIKey"
 
+// apiKeyLastUsedInterval is the minimum interval between updates of the last
+// used time of an API key, to avoid writing to the database on every request.
+const apiKeyLastUsedInterval = time.Minute
+
 type APIKeyClaims struct {
 	UserID string `json:"uid"`
 	jwt.RegisteredClaims
 }
 
+// GenerateAPIKey generates the API key stored in the configuration.
 func GenerateAPIKey(userID string) (string, error) {
 	claims := &APIKeyClaims{
 		UserID: userID,
@@ -26,6 +35,10 @@ func GenerateAPIKey(userID string) (string, error) {
 		},
 	}
 
+	return signAPIKey(claims)
+}
+
+func signAPIKey(claims *APIKeyClaims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	ss, err := token.SignedString(config.GetInstance().GetJWTSignKey())
@@ -36,20 +49,118 @@ func GenerateAPIKey(userID string) (string, error) {
 	return ss, nil
 }
 
-// GetUserIDFromAPIKey validates the provided api key and returns the user ID
-func GetUserIDFromAPIKey(apiKey string) (string, error) {
+func parseAPIKey(apiKey string) (*APIKeyClaims, error) {
 	claims := &APIKeyClaims{}
 	token, err := jwt.ParseWithClaims(apiKey, claims, func(t *jwt.Token) (interface{}, error) {
 		return config.GetInstance().GetJWTSignKey(), nil
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return "", ErrInvalidToken
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// GetUserIDFromAPIKey validates the provided api key and returns the user ID
+func GetUserIDFromAPIKey(apiKey string) (string, error) {
+	claims, err := parseAPIKey(apiKey)
+	if err != nil {
+		return "", err
 	}
 
 	return claims.UserID, nil
 }
+
+// CreateAPIKey stores a named API key and returns the key. The key is not
+// stored, so it cannot be retrieved again.
+func (s *Manager) CreateAPIKey(ctx context.Context, newKey *models.APIKey) (string, error) {
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.APIKey.Create(ctx, newKey)
+	}); err != nil {
+		return "", err
+	}
+
+	// the key contains the id of the stored key, so that it can be revoked
+	claims := &APIKeyClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       strconv.Itoa(newKey.ID),
+			Subject:  APIKeySubject,
+			IssuedAt: jwt.NewNumericDate(newKey.CreatedAt),
+		},
+	}
+
+	if newKey.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*newKey.ExpiresAt)
+	}
+
+	return signAPIKey(claims)
+}
+
+// AuthenticateAPIKey validates a named API key. Returns the stored key and the
+// username of the user it belongs to. Returns an error wrapping
+// ErrInvalidToken if the key is invalid, expired or revoked.
+func (s *Manager) AuthenticateAPIKey(ctx context.Context, apiKey string) (*models.APIKey, string, error) {
+	claims, err := parseAPIKey(apiKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	id, err := strconv.Atoi(claims.ID)
+	if err != nil || claims.Subject != APIKeySubject {
+		return nil, "", ErrInvalidToken
+	}
+
+	var key *models.APIKey
+	username := s.Config.GetUsername()
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		key, err = r.APIKey.Find(ctx, id)
+		if err != nil || key == nil || key.UserID == nil {
+			return err
+		}
+
+		u, err := r.User.Find(ctx, *key.UserID)
+		if err != nil {
+			return err
+		}
+
+		// key is removed with the user, so this should not happen
+		if u == nil {
+			key = nil
+			return nil
+		}
+
+		username = u.Username
+		return nil
+	}); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+
+	if key == nil {
+		return nil, "", fmt.Errorf("%w: key has been revoked", ErrInvalidToken)
+	}
+
+	if key.IsExpired(now) {
+		return nil, "", fmt.Errorf("%w: key has expired", ErrInvalidToken)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.APIKey.UpdateLastUsed(ctx, key.ID, now)
+		}); err != nil {
+			return nil, "", err
+		}
+
+		key.LastUsedAt = &now
+	}
+
+	return key, username, nil
+}
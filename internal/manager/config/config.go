@@ -60,6 +60,10 @@ const (
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+	// WatchLibrary is the config key used to determine if the library paths
+	// are watched for changes.
+	WatchLibrary = "watch_library"
+
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
 	CalculateMD5 = "calculate_md5"
@@ -71,6 +75,10 @@ const (
 	MaxTranscodeSize          = "max_transcode_size"
 	MaxStreamingTranscodeSize = "max_streaming_transcode_size"
 
+	// TranscodeCacheMaxSize is the maximum size in MiB of the cached live
+	// transcodes. Zero disables the cache.
+	TranscodeCacheMaxSize = "transcode_cache_max_size"
+
 	// ffmpeg extra args options
 	TranscodeInputArgs      = "ffmpeg.transcode.input_args"
 	TranscodeOutputArgs     = "ffmpeg.transcode.output_args"
@@ -263,6 +271,12 @@ const (
 	DefaultAutoTagSettings  = "defaults.auto_tag_task"
 	DefaultGenerateSettings = "defaults.generate_task"
 
+	// Scheduled tasks
+	ScheduledTasks = "scheduled_tasks"
+
+	// Content restrictions of user roles
+	RoleRestrictions = "role_restrictions"
+
 	DeleteFileDefault             = "defaults.delete_file"
 	DeleteGeneratedDefault        = "defaults.delete_generated"
 	deleteGeneratedDefaultDefault = true
@@ -772,6 +786,12 @@ func (i *Config) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// GetWatchLibrary returns true if the library paths should be watched and
+// changed files scanned automatically.
+func (i *Config) GetWatchLibrary() bool {
+	return i.getBool(WatchLibrary)
+}
+
 func (i *Config) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -1023,6 +1043,12 @@ func (i *Config) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum {
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetTranscodeCacheMaxSize returns the maximum size of the transcode cache in bytes.
+// Returns 0 if the transcode cache is disabled.
+func (i *Config) GetTranscodeCacheMaxSize() int64 {
+	return int64(i.getInt(TranscodeCacheMaxSize)) * 1024 * 1024
+}
+
 func (i *Config) GetTranscodeInputArgs() []string {
 	return i.getStringSlice(TranscodeInputArgs)
 }
@@ -1532,6 +1558,49 @@ func (i *Config) GetDefaultGenerateSettings() *models.GenerateMetadataOptions {
 	return nil
 }
 
+// GetScheduledTasks returns the configured scheduled tasks.
+func (i *Config) GetScheduledTasks() []ScheduledTask {
+	var ret []ScheduledTask
+	if err := i.unmarshalKey(ScheduledTasks, &ret); err != nil {
+		logger.Warnf("error reading scheduled tasks: %v", err)
+		return nil
+	}
+
+	return ret
+}
+
+func (i *Config) SetScheduledTasks(v []ScheduledTask) {
+	i.SetInterface(ScheduledTasks, v)
+}
+
+// GetAllRoleRestrictions returns the configured content restrictions of
+// user roles.
+func (i *Config) GetAllRoleRestrictions() []models.RoleRestrictions {
+	var ret []models.RoleRestrictions
+	if err := i.unmarshalKey(RoleRestrictions, &ret); err != nil {
+		logger.Warnf("error reading role restrictions: %v", err)
+		return nil
+	}
+
+	return ret
+}
+
+// GetRoleRestrictions returns the content restrictions of the provided role.
+// Returns nil if content is not restricted for the role.
+func (i *Config) GetRoleRestrictions(role models.UserRole) *models.RoleRestrictions {
+	for _, r := range i.GetAllRoleRestrictions() {
+		if r.Role == role && !r.IsEmpty() {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+func (i *Config) SetRoleRestrictions(v []models.RoleRestrictions) {
+	i.SetInterface(RoleRestrictions, v)
+}
+
 // GetDangerousAllowPublicWithoutAuth determines if the security feature is enabled.
 // See https://docs.stashapp.cc/faq/setup/#protecting-against-accidental-exposure-to-the-internet
 func (i *Config) GetDangerousAllowPublicWithoutAuth() bool {
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func TestConfig_GetAllPluginConfiguration(t *testing.T) {
@@ -32,3 +35,91 @@ func TestConfig_GetAllPluginConfiguration(t *testing.T) {
 		"plugin2": {"key3": "value3"},
 	}, i.GetAllPluginConfiguration())
 }
+
+func TestConfig_ScheduledTasks(t *testing.T) {
+	i := InitializeEmpty()
+	i.filePath = filepath.Join(t.TempDir(), "config.yml")
+
+	assert.Len(t, i.GetScheduledTasks(), 0)
+
+	tasks := []ScheduledTask{
+		{
+			ID:      "1",
+			Name:    "nightly scan",
+			Type:    ScheduledTaskTypeScan,
+			Cron:    "0 3 * * *",
+			Enabled: true,
+			Paths:   []string{"/stash"},
+			Scan: &ScanMetadataOptions{
+				ScanGenerateCovers:  true,
+				ScanGeneratePhashes: true,
+			},
+		},
+		{
+			ID:     "2",
+			Name:   "weekly clean",
+			Type:   ScheduledTaskTypeClean,
+			Cron:   "@weekly",
+			Paths:  []string{"/stash/a", "/stash/b"},
+			DryRun: true,
+		},
+	}
+
+	i.SetScheduledTasks(tasks)
+	assert.Equal(t, tasks, i.GetScheduledTasks())
+
+	// ensure the tasks are read correctly from the config file
+	if err := i.Write(); err != nil {
+		t.Errorf("Write error: %v", err)
+		return
+	}
+
+	loaded := InitializeEmpty()
+	if err := loaded.load(i.filePath); err != nil {
+		t.Errorf("load error: %v", err)
+		return
+	}
+
+	assert.Equal(t, tasks, loaded.GetScheduledTasks())
+}
+
+func TestConfig_RoleRestrictions(t *testing.T) {
+	i := InitializeEmpty()
+	i.filePath = filepath.Join(t.TempDir(), "config.yml")
+
+	assert.Nil(t, i.GetRoleRestrictions(models.UserRoleViewer))
+
+	restrictions := []models.RoleRestrictions{
+		{
+			Role:              models.UserRoleViewer,
+			ExcludedTagIDs:    []int{1, 2},
+			ExcludedStudioIDs: []int{},
+		},
+		{
+			Role:              models.UserRoleEditor,
+			ExcludedTagIDs:    []int{},
+			ExcludedStudioIDs: []int{3},
+		},
+		{
+			Role: models.UserRoleAdmin,
+		},
+	}
+
+	i.SetRoleRestrictions(restrictions)
+
+	if err := i.Write(); err != nil {
+		t.Errorf("Write error: %v", err)
+		return
+	}
+
+	loaded := InitializeEmpty()
+	if err := loaded.load(i.filePath); err != nil {
+		t.Errorf("load error: %v", err)
+		return
+	}
+
+	assert.Equal(t, &restrictions[0], loaded.GetRoleRestrictions(models.UserRoleViewer))
+	assert.Equal(t, &restrictions[1], loaded.GetRoleRestrictions(models.UserRoleEditor))
+	// empty restrictions are ignored
+	assert.Nil(t, loaded.GetRoleRestrictions(models.UserRoleAdmin))
+}
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/models"
+)
+
 type ScanMetadataOptions struct {
 	// Forces a rescan on files even if they have not changed
 	Rescan bool `json:"rescan"`
@@ -17,6 +22,8 @@ type ScanMetadataOptions struct {
 	ScanGenerateThumbnails bool `json:"scanGenerateThumbnails"`
 	// Generate image thumbnails during scan
 	ScanGenerateClipPreviews bool `json:"scanGenerateClipPreviews"`
+	// Generate image phashes during scan
+	ScanGenerateImagePhashes bool `json:"scanGenerateImagePhashes"`
 }
 
 type AutoTagMetadataOptions struct {
@@ -27,3 +34,25 @@ type AutoTagMetadataOptions struct {
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
 }
+
+// ScheduledTask is a task that is run periodically according to a cron
+// expression.
+type ScheduledTask struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Type    ScheduledTaskType `json:"type"`
+	Cron    string            `json:"cron"`
+	Enabled bool              `json:"enabled"`
+
+	// Paths to process. Applies to scan, auto tag, clean and identify tasks.
+	Paths []string `json:"paths"`
+
+	// Task options. If nil, the default task settings are used.
+	Scan     *ScanMetadataOptions            `json:"scan"`
+	Generate *models.GenerateMetadataOptions `json:"generate"`
+	AutoTag  *AutoTagMetadataOptions         `json:"autoTag"`
+	Identify *identify.Options               `json:"identify"`
+
+	// Do a dry run of the clean task
+	DryRun bool `json:"dryRun"`
+}
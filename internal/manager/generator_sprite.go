@@ -25,6 +25,11 @@ type SpriteGenerator struct {
 	Columns         int
 	SlowSeek        bool // use alternate seek function, very slow!
 
+	// Start and End trim the range of the video to generate the sprite
+	// from. A zero End generates to the end of the video.
+	Start float64
+	End   float64
+
 	Overwrite bool
 
 	g *generate.Generator
@@ -103,10 +108,10 @@ func (g *SpriteGenerator) generateSpriteImage() error {
 	if !g.SlowSeek {
 		logger.Infof("[generator] generating sprite image for %s", g.Info.VideoFile.Path)
 		// generate `ChunkCount` thumbnails
-		stepSize := g.Info.VideoFile.VideoStreamDuration / float64(g.Info.ChunkCount)
+		stepSize := g.duration() / float64(g.Info.ChunkCount)
 
 		for i := 0; i < g.Info.ChunkCount; i++ {
-			time := float64(i) * stepSize
+			time := g.Start + float64(i)*stepSize
 
 			img, err := g.g.SpriteScreenshot(context.TODO(), g.Info.VideoFile.Path, time)
 			if err != nil {
@@ -117,11 +122,12 @@ func (g *SpriteGenerator) generateSpriteImage() error {
 	} else {
 		logger.Infof("[generator] generating sprite image for %s (%d frames)", g.Info.VideoFile.Path, g.Info.VideoFile.FrameCount)
 
-		stepFrame := float64(g.Info.VideoFile.FrameCount-1) / float64(g.Info.ChunkCount)
+		startFrame, frameCount := g.frameRange()
+		stepFrame := float64(frameCount-1) / float64(g.Info.ChunkCount)
 
 		for i := 0; i < g.Info.ChunkCount; i++ {
 			// generate exactly `ChunkCount` thumbnails, using duplicate frames if needed
-			frame := math.Round(float64(i) * stepFrame)
+			frame := float64(startFrame) + math.Round(float64(i)*stepFrame)
 			if frame >= math.MaxInt || frame <= math.MinInt {
 				return errors.New("invalid frame number conversion")
 			}
@@ -149,16 +155,45 @@ func (g *SpriteGenerator) generateSpriteVTT() error {
 	logger.Infof("[generator] generating sprite vtt for %s", g.Info.VideoFile.Path)
 
 	var stepSize float64
-	if !g.SlowSeek {
-		stepSize = float64(g.Info.NthFrame) / g.Info.FrameRate
-	} else {
+	switch {
+	case g.SlowSeek:
 		// for files with a low framecount (<ChunkCount) g.Info.NthFrame can be zero
 		// so recalculate from scratch
-		stepSize = float64(g.Info.VideoFile.FrameCount-1) / float64(g.Info.ChunkCount)
+		_, frameCount := g.frameRange()
+		stepSize = float64(frameCount-1) / float64(g.Info.ChunkCount)
 		stepSize /= g.Info.FrameRate
+	case g.isTrimmed():
+		stepSize = g.duration() / float64(g.Info.ChunkCount)
+	default:
+		stepSize = float64(g.Info.NthFrame) / g.Info.FrameRate
+	}
+
+	return g.g.SpriteVTT(context.TODO(), g.VTTOutputPath, g.ImageOutputPath, g.Start, stepSize)
+}
+
+func (g *SpriteGenerator) isTrimmed() bool {
+	return g.Start != 0 || g.End != 0
+}
+
+// duration returns the duration of the trimmed range of the video.
+func (g *SpriteGenerator) duration() float64 {
+	end := g.Info.VideoFile.VideoStreamDuration
+	if g.End > 0 && g.End < end {
+		end = g.End
+	}
+	return max(end-g.Start, 0)
+}
+
+// frameRange returns the first frame and the number of frames
+// of the trimmed range of the video.
+func (g *SpriteGenerator) frameRange() (start int64, count int64) {
+	if !g.isTrimmed() || g.Info.FrameRate <= 0 {
+		return 0, g.Info.VideoFile.FrameCount
 	}
 
-	return g.g.SpriteVTT(context.TODO(), g.VTTOutputPath, g.ImageOutputPath, stepSize)
+	start = int64(math.Round(g.Start * g.Info.FrameRate))
+	count = min(int64(math.Round(g.duration()*g.Info.FrameRate)), g.Info.VideoFile.FrameCount-start)
+	return start, max(count, 1)
 }
 
 func (g *SpriteGenerator) imageExists() bool {
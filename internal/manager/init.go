@@ -109,6 +109,8 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		scanSubs: &subscriptionManager{},
 	}
 
+	mgr.registerJobFactories()
+
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
@@ -130,7 +132,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		// create temporary session store - this will be re-initialised
 		// after config is complete
-		mgr.SessionStore = session.NewStore(cfg)
+		mgr.SessionStore = session.NewStore(cfg, nil)
 
 		logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
 	}
@@ -189,7 +191,7 @@ func initJobManager(cfg *config.Config) *job.Manager {
 func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshConfig()
 
-	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore = session.NewStore(s.Config, s)
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	s.RefreshPluginCache()
@@ -234,6 +236,9 @@ func (s *Manager) postInit(ctx context.Context) error {
 		} else {
 			return err
 		}
+	} else {
+		// otherwise the job store is attached after the migration
+		s.initJobStore(ctx)
 	}
 
 	// Set the proxy if defined in config
@@ -247,6 +252,9 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
 
+	s.initScheduler()
+	s.RefreshWatcher()
+
 	return nil
 }
 
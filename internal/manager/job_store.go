@@ -0,0 +1,218 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// resumable job types
+const (
+	jobTypeScan     = "scan"
+	jobTypeGenerate = "generate"
+	jobTypeAutoTag  = "auto_tag"
+	jobTypeClean    = "clean"
+	jobTypeIdentify = "identify"
+)
+
+// jobStore persists job manager jobs in the database.
+type jobStore struct {
+	TxnManager txn.Manager
+	Repository models.JobReaderWriter
+}
+
+func jobToModel(j job.Job) *models.JobRecord {
+	return &models.JobRecord{
+		ID:          j.ID,
+		Status:      string(j.Status),
+		Type:        j.Type,
+		Description: j.Description,
+		Input:       j.Input,
+		Progress:    j.Progress,
+		Error:       j.Error,
+		AddTime:     j.AddTime,
+		StartTime:   j.StartTime,
+		EndTime:     j.EndTime,
+	}
+}
+
+func jobFromModel(j *models.JobRecord) job.Job {
+	return job.Job{
+		ID:          j.ID,
+		Status:      job.Status(j.Status),
+		Type:        j.Type,
+		Description: j.Description,
+		Input:       j.Input,
+		Progress:    j.Progress,
+		Error:       j.Error,
+		AddTime:     j.AddTime,
+		StartTime:   j.StartTime,
+		EndTime:     j.EndTime,
+	}
+}
+
+func jobsFromModels(jobs []*models.JobRecord) []job.Job {
+	ret := make([]job.Job, len(jobs))
+	for i, j := range jobs {
+		ret[i] = jobFromModel(j)
+	}
+
+	return ret
+}
+
+func (s *jobStore) Save(ctx context.Context, j job.Job) error {
+	return txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		return s.Repository.Save(ctx, jobToModel(j))
+	})
+}
+
+func (s *jobStore) Find(ctx context.Context, id int) (*job.Job, error) {
+	var ret *job.Job
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		j, err := s.Repository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if j != nil {
+			jj := jobFromModel(j)
+			ret = &jj
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *jobStore) FindByStatus(ctx context.Context, statuses []job.Status) ([]job.Job, error) {
+	ss := make([]string, len(statuses))
+	for i, status := range statuses {
+		ss[i] = string(status)
+	}
+
+	var ret []job.Job
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		jobs, err := s.Repository.FindByStatus(ctx, ss)
+		if err != nil {
+			return err
+		}
+
+		ret = jobsFromModels(jobs)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *jobStore) FindRecent(ctx context.Context, limit int) ([]job.Job, error) {
+	var ret []job.Job
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		jobs, err := s.Repository.FindRecent(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		ret = jobsFromModels(jobs)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *jobStore) MaxID(ctx context.Context) (int, error) {
+	var ret int
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		var err error
+		ret, err = s.Repository.MaxID(ctx)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (s *jobStore) Prune(ctx context.Context, keep int) error {
+	return txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		return s.Repository.DestroyFinished(ctx, keep)
+	})
+}
+
+// jsonFactory returns a job.Factory that decodes the stored input into T
+// and passes it to fn.
+func jsonFactory[T any](fn func(input T) (job.JobExec, error)) job.Factory {
+	return func(data []byte) (job.JobExec, error) {
+		var input T
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, err
+		}
+
+		return fn(input)
+	}
+}
+
+// registerJobFactories registers the factories used to resume interrupted
+// jobs.
+func (s *Manager) registerJobFactories() {
+	s.JobManager.RegisterFactory(jobTypeScan, jsonFactory(func(input ScanMetadataInput) (job.JobExec, error) {
+		return s.newScanJob(input)
+	}))
+	s.JobManager.RegisterFactory(jobTypeGenerate, jsonFactory(func(input GenerateMetadataInput) (job.JobExec, error) {
+		return s.newGenerateJob(input)
+	}))
+	s.JobManager.RegisterFactory(jobTypeAutoTag, jsonFactory(func(input AutoTagMetadataInput) (job.JobExec, error) {
+		return s.newAutoTagJob(input), nil
+	}))
+	s.JobManager.RegisterFactory(jobTypeClean, jsonFactory(func(input CleanMetadataInput) (job.JobExec, error) {
+		return s.newCleanJob(input), nil
+	}))
+	s.JobManager.RegisterFactory(jobTypeIdentify, jsonFactory(func(input identify.Options) (job.JobExec, error) {
+		return CreateIdentifyJob(input), nil
+	}))
+}
+
+// initJobStore sets the job manager store, loading the job history and any
+// interrupted jobs from the database.
+func (s *Manager) initJobStore(ctx context.Context) {
+	store := &jobStore{
+		TxnManager: s.Repository.TxnManager,
+		Repository: s.Repository.Job,
+	}
+
+	if err := s.JobManager.SetStore(ctx, store); err != nil {
+		logger.Errorf("error loading stored jobs: %v", err)
+	}
+}
+
+// PostMigrate attaches the job store after the database has been migrated,
+// since it is not attached when the database needs a migration on startup.
+func (s *Manager) PostMigrate(ctx context.Context) {
+	s.initJobStore(ctx)
+}
+
+// jobStoreResetter resets the database and reattaches the job store to the
+// new database.
+type jobStoreResetter struct {
+	manager *Manager
+}
+
+func (r jobStoreResetter) Reset() error {
+	if err := r.manager.Database.Reset(); err != nil {
+		return err
+	}
+
+	r.manager.initJobStore(context.Background())
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -48,6 +49,7 @@ type Manager struct {
 
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	Scheduler       *Scheduler
 
 	DownloadStore *DownloadStore
 	SessionStore  *session.Store
@@ -69,6 +71,9 @@ type Manager struct {
 	GroupService   GroupService
 
 	scanSubs *subscriptionManager
+
+	watcherMutex sync.Mutex
+	stopWatcher  context.CancelFunc
 }
 
 var instance *Manager
@@ -422,11 +427,25 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 func (s *Manager) Shutdown() {
 	// TODO: Each part of the manager needs to gracefully stop at some point
 
+	if s.Scheduler != nil {
+		s.Scheduler.Stop()
+	}
+
+	s.watcherMutex.Lock()
+	if s.stopWatcher != nil {
+		s.stopWatcher()
+		s.stopWatcher = nil
+	}
+	s.watcherMutex.Unlock()
+
 	if s.StreamManager != nil {
 		s.StreamManager.Shutdown()
 		s.StreamManager = nil
 	}
 
+	// write the state of running jobs before the database is closed
+	s.JobManager.Shutdown()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	file_image "github.com/stashapp/stash/pkg/file/image"
@@ -16,6 +17,7 @@ import (
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 )
 
 func useAsVideo(pathname string) bool {
@@ -96,11 +98,30 @@ type ScanMetaDataFilterInput struct {
 }
 
 func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error) {
-	if err := s.validateFFmpeg(); err != nil {
+	scanJob, err := s.newScanJob(input)
+	if err != nil {
 		return 0, err
 	}
 
-	scanner := &file.Scanner{
+	return s.JobManager.AddResumable(ctx, "Scanning...", scanJob, jobTypeScan, input), nil
+}
+
+func (s *Manager) newScanJob(input ScanMetadataInput) (*ScanJob, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return nil, err
+	}
+
+	return &ScanJob{
+		scanner:       s.newScanner(),
+		input:         input,
+		subscriptions: s.scanSubs,
+	}, nil
+}
+
+// newScanner returns a file scanner which creates the file records
+// of scanned files, using the configured fingerprints and decorators.
+func (s *Manager) newScanner() *file.Scanner {
+	return &file.Scanner{
 		Repository: file.NewRepository(s.Repository),
 		FileDecorators: []file.Decorator{
 			&file.FilteredDecorator{
@@ -119,14 +140,6 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		FingerprintCalculator: &fingerprintCalculator{s.Config},
 		FS:                    &file.OsFS{},
 	}
-
-	scanJob := ScanJob{
-		scanner:       scanner,
-		input:         input,
-		subscriptions: s.scanSubs,
-	}
-
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -139,7 +152,7 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
 		task := ImportTask{
 			repository:          s.Repository,
-			resetter:            s.Database,
+			resetter:            jobStoreResetter{manager: s},
 			BaseDir:             metadataPath,
 			Reset:               true,
 			DuplicateBehaviour:  ImportDuplicateEnumFail,
@@ -193,19 +206,26 @@ func (s *Manager) RunSingleTask(ctx context.Context, t Task) int {
 }
 
 func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (int, error) {
-	if err := s.validateFFmpeg(); err != nil {
+	j, err := s.newGenerateJob(input)
+	if err != nil {
 		return 0, err
 	}
+
+	return s.JobManager.AddResumable(ctx, "Generating...", j, jobTypeGenerate, input), nil
+}
+
+func (s *Manager) newGenerateJob(input GenerateMetadataInput) (*GenerateJob, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return nil, err
+	}
 	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
 		logger.Warnf("could not generate temporary directory: %v", err)
 	}
 
-	j := &GenerateJob{
+	return &GenerateJob{
 		repository: s.Repository,
 		input:      input,
-	}
-
-	return s.JobManager.Add(ctx, "Generating...", j), nil
+	}, nil
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -273,21 +293,32 @@ type AutoTagMetadataInput struct {
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
-	j := autoTagJob{
+	j := s.newAutoTagJob(input)
+	return s.JobManager.AddResumable(ctx, "Auto-tagging...", j, jobTypeAutoTag, input)
+}
+
+func (s *Manager) newAutoTagJob(input AutoTagMetadataInput) *autoTagJob {
+	return &autoTagJob{
 		repository: s.Repository,
 		input:      input,
 	}
-
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
 }
 
 type CleanMetadataInput struct {
 	Paths []string `json:"paths"`
 	// Do a dry run. Don't delete any files
 	DryRun bool `json:"dryRun"`
+	// Mark files missing from disk as missing instead of deleting them.
+	// Not exposed to the API.
+	MarkMissing bool `json:"markMissing"`
 }
 
 func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
+	j := s.newCleanJob(input)
+	return s.JobManager.AddResumable(ctx, "Cleaning...", j, jobTypeClean, input)
+}
+
+func (s *Manager) newCleanJob(input CleanMetadataInput) *cleanJob {
 	cleaner := &file.Cleaner{
 		FS:         &file.OsFS{},
 		Repository: file.NewRepository(s.Repository),
@@ -296,7 +327,7 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		},
 	}
 
-	j := cleanJob{
+	return &cleanJob{
 		cleaner:      cleaner,
 		repository:   s.Repository,
 		sceneService: s.SceneService,
@@ -304,8 +335,11 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		input:        input,
 		scanSubs:     s.scanSubs,
 	}
+}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+func (s *Manager) Identify(ctx context.Context, input identify.Options) int {
+	j := CreateIdentifyJob(input)
+	return s.JobManager.AddResumable(ctx, "Identifying...", j, jobTypeIdentify, input)
 }
 
 func (s *Manager) OptimiseDatabase(ctx context.Context) int {
@@ -316,6 +350,36 @@ func (s *Manager) OptimiseDatabase(ctx context.Context) int {
 	return s.JobManager.Add(ctx, "Optimising database...", &j)
 }
 
+// SplitScene starts a job which cuts the ranges of a scene into new files
+// and creates a new scene for each of them.
+func (s *Manager) SplitScene(ctx context.Context, sceneID int, ranges []scene.SplitRange, options scene.SplitOptions) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	j := &SceneSplitJob{
+		SceneID: sceneID,
+		Ranges:  ranges,
+		Options: options,
+	}
+
+	return s.JobManager.Add(ctx, "Splitting scene...", j), nil
+}
+
+// ExportTrimmedScene starts a job that cuts the trimmed range of the scene
+// into a new file, which replaces the primary file of the scene.
+func (s *Manager) ExportTrimmedScene(ctx context.Context, sceneID int) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	j := &SceneExportTrimmedJob{
+		SceneID: sceneID,
+	}
+
+	return s.JobManager.Add(ctx, "Exporting trimmed scene...", j), nil
+}
+
 func (s *Manager) MigrateHash(ctx context.Context) int {
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
 		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
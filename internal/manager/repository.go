@@ -13,6 +13,8 @@ type SceneService interface {
 	Create(ctx context.Context, input *models.Scene, fileIDs []models.FileID, coverImage []byte) (*models.Scene, error)
 	AssignFile(ctx context.Context, sceneID int, fileID models.FileID) error
 	Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *scene.FileDeleter, options scene.MergeOptions) error
+	Split(ctx context.Context, sourceID int, ranges []scene.SplitRange, fileDeleter *scene.FileDeleter, options scene.SplitOptions) ([]*models.Scene, error)
+	ReplaceTrimmedFile(ctx context.Context, sceneID int, fileID models.FileID, fileDeleter *scene.FileDeleter) error
 	Destroy(ctx context.Context, scene *models.Scene, fileDeleter *scene.FileDeleter, deleteGenerated, deleteFile bool) error
 }
 
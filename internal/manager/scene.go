@@ -49,6 +49,16 @@ var (
 		mimeType:  ffmpeg.MimeHLS,
 		extension: ".m3u8",
 	}
+	hlsAdaptiveEndpointType = endpointType{
+		label:     "HLS Adaptive",
+		mimeType:  ffmpeg.MimeHLS,
+		extension: ".m3u8",
+	}
+	hlsCopyEndpointType = endpointType{
+		label:     "HLS Original (no transcode)",
+		mimeType:  ffmpeg.MimeHLS,
+		extension: "_copy.m3u8",
+	}
 	dashEndpointType = endpointType{
 		label:     "DASH",
 		mimeType:  ffmpeg.MimeDASH,
@@ -166,7 +176,12 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 
 	mp4Streams := []*SceneStreamEndpoint{}
 	webmStreams := []*SceneStreamEndpoint{}
-	hlsStreams := []*SceneStreamEndpoint{}
+	// the adaptive HLS stream lists all of the available resolutions
+	hlsStreams := []*SceneStreamEndpoint{makeStreamEndpoint(hlsAdaptiveEndpointType, "")}
+
+	if CanCopyToHLS(pf) == nil {
+		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsCopyEndpointType, ""))
+	}
 	dashStreams := []*SceneStreamEndpoint{}
 
 	if includeSceneStreamPath(models.StreamingResolutionEnumOriginal) {
@@ -219,6 +234,13 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	return endpoints, nil
 }
 
+// CanCopyToHLS returns nil if the video stream of the file can be copied
+// into a fragmented MP4 HLS stream without transcoding. The audio stream is
+// always transcoded to AAC.
+func CanCopyToHLS(file *models.VideoFile) error {
+	return ffmpeg.IsHLSCopyable(file.VideoCodec)
+}
+
 // HasTranscode returns true if a transcoded video exists for the provided
 // scene. It will check using the OSHash of the scene first, then fall back
 // to the checksum.
@@ -0,0 +1,341 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ParseCron parses a cron expression in the standard five-field format.
+// Descriptors such as @daily and @every 1h are also accepted.
+func ParseCron(expr string) (cron.Schedule, error) {
+	ret, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	return ret, nil
+}
+
+// scheduledTaskRunner adds the job for a scheduled task, returning the job id.
+type scheduledTaskRunner func(ctx context.Context, t config.ScheduledTask) (int, error)
+
+type scheduledTaskState struct {
+	entryID   cron.EntryID
+	lastRun   *time.Time
+	lastJobID *int
+}
+
+// ScheduledTaskStatus is the run state of a scheduled task.
+type ScheduledTaskStatus struct {
+	NextRun   *time.Time
+	LastRun   *time.Time
+	LastJobID *int
+}
+
+// Scheduler runs the configured scheduled tasks according to their cron
+// expressions. A task is skipped if the job from its previous run is still
+// queued or running.
+type Scheduler struct {
+	jobManager *job.Manager
+	run        scheduledTaskRunner
+
+	mutex sync.Mutex
+	cron  *cron.Cron
+	state map[string]*scheduledTaskState
+}
+
+func newScheduler(jobManager *job.Manager, run scheduledTaskRunner) *Scheduler {
+	ret := &Scheduler{
+		jobManager: jobManager,
+		run:        run,
+		cron:       cron.New(),
+		state:      make(map[string]*scheduledTaskState),
+	}
+
+	ret.cron.Start()
+	return ret
+}
+
+// Refresh replaces the scheduled entries with the provided tasks. The last
+// run state of existing tasks is retained.
+func (s *Scheduler) Refresh(tasks []config.ScheduledTask) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, st := range s.state {
+		if st.entryID != 0 {
+			s.cron.Remove(st.entryID)
+			st.entryID = 0
+		}
+	}
+
+	newState := make(map[string]*scheduledTaskState)
+	for _, t := range tasks {
+		st := s.state[t.ID]
+		if st == nil {
+			st = &scheduledTaskState{}
+		}
+		newState[t.ID] = st
+
+		if !t.Enabled {
+			continue
+		}
+
+		schedule, err := ParseCron(t.Cron)
+		if err != nil {
+			logger.Errorf("[scheduler] not scheduling task %q: %v", t.Name, err)
+			continue
+		}
+
+		task := t
+		st.entryID = s.cron.Schedule(schedule, cron.FuncJob(func() {
+			s.runTask(context.Background(), task)
+		}))
+	}
+
+	s.state = newState
+}
+
+// Stop stops the scheduler. Running jobs are not affected.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// Status returns the run state of the scheduled task with the provided id.
+func (s *Scheduler) Status(id string) ScheduledTaskStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret ScheduledTaskStatus
+	st := s.state[id]
+	if st == nil {
+		return ret
+	}
+
+	ret.LastRun = st.lastRun
+	ret.LastJobID = st.lastJobID
+
+	if st.entryID != 0 {
+		next := s.cron.Entry(st.entryID).Next
+		if !next.IsZero() {
+			ret.NextRun = &next
+		}
+	}
+
+	return ret
+}
+
+// isRunning returns true if the job from the previous run of the task is
+// still in the queue. Interrupted jobs count as running, since they stay in
+// the queue until they are resumed or cancelled.
+func (s *Scheduler) isRunning(st *scheduledTaskState) bool {
+	if st.lastJobID == nil {
+		return false
+	}
+
+	j := s.jobManager.GetJob(*st.lastJobID)
+	if j == nil {
+		return false
+	}
+
+	switch j.Status {
+	case job.StatusReady, job.StatusRunning, job.StatusStopping, job.StatusInterrupted:
+		return true
+	}
+
+	return false
+}
+
+func (s *Scheduler) runTask(ctx context.Context, t config.ScheduledTask) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	st := s.state[t.ID]
+	if st == nil {
+		// task was removed
+		return
+	}
+
+	if s.isRunning(st) {
+		logger.Infof("[scheduler] skipping task %q: previous run (job %d) has not finished", t.Name, *st.lastJobID)
+		return
+	}
+
+	now := time.Now()
+	st.lastRun = &now
+
+	logger.Infof("[scheduler] running task %q", t.Name)
+	jobID, err := s.run(ctx, t)
+	if err != nil {
+		logger.Errorf("[scheduler] error running task %q: %v", t.Name, err)
+		return
+	}
+
+	st.lastJobID = &jobID
+}
+
+func (s *Manager) initScheduler() {
+	if s.Scheduler == nil {
+		s.Scheduler = newScheduler(s.JobManager, s.runScheduledTask)
+	}
+
+	s.Scheduler.Refresh(s.Config.GetScheduledTasks())
+}
+
+// RefreshScheduler reschedules the scheduled tasks after a configuration
+// change.
+func (s *Manager) RefreshScheduler() {
+	if s.Scheduler != nil {
+		s.Scheduler.Refresh(s.Config.GetScheduledTasks())
+	}
+}
+
+func (s *Manager) runScheduledTask(ctx context.Context, t config.ScheduledTask) (int, error) {
+	switch t.Type {
+	case config.ScheduledTaskTypeScan:
+		input := ScanMetadataInput{
+			Paths: t.Paths,
+		}
+
+		opts := t.Scan
+		if opts == nil {
+			opts = s.Config.GetDefaultScanSettings()
+		}
+		if opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		return s.Scan(ctx, input)
+	case config.ScheduledTaskTypeGenerate:
+		opts := t.Generate
+		if opts == nil {
+			opts = s.Config.GetDefaultGenerateSettings()
+		}
+
+		return s.Generate(ctx, generateInputFromOptions(opts))
+	case config.ScheduledTaskTypeAutoTag:
+		input := AutoTagMetadataInput{
+			Paths: t.Paths,
+		}
+
+		opts := t.AutoTag
+		if opts == nil {
+			opts = s.Config.GetDefaultAutoTagSettings()
+		}
+		if opts != nil {
+			input.Performers = opts.Performers
+			input.Studios = opts.Studios
+			input.Tags = opts.Tags
+		}
+
+		return s.AutoTag(ctx, input), nil
+	case config.ScheduledTaskTypeClean:
+		return s.Clean(ctx, CleanMetadataInput{
+			Paths:  t.Paths,
+			DryRun: t.DryRun,
+		}), nil
+	case config.ScheduledTaskTypeIdentify:
+		opts := t.Identify
+		if opts == nil {
+			opts = s.Config.GetDefaultIdentifySettings()
+		}
+		if opts == nil {
+			return 0, fmt.Errorf("no identify settings configured")
+		}
+
+		input := *opts
+		input.SceneIDs = nil
+		input.Paths = t.Paths
+
+		return s.Identify(ctx, input), nil
+	case config.ScheduledTaskTypeBackupDatabase:
+		j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+			backupPath, _, err := s.BackupDatabase(false)
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully backed up database to: %s", backupPath)
+			return nil
+		})
+
+		return s.JobManager.Add(ctx, "Backing up database...", j), nil
+	}
+
+	return 0, fmt.Errorf("unsupported scheduled task type %q", t.Type)
+}
+
+func generateInputFromOptions(opts *models.GenerateMetadataOptions) GenerateMetadataInput {
+	if opts == nil {
+		return GenerateMetadataInput{}
+	}
+
+	ret := GenerateMetadataInput{
+		Covers:                    opts.Covers,
+		Sprites:                   opts.Sprites,
+		Previews:                  opts.Previews,
+		ImagePreviews:             opts.ImagePreviews,
+		Markers:                   opts.Markers,
+		MarkerImagePreviews:       opts.MarkerImagePreviews,
+		MarkerScreenshots:         opts.MarkerScreenshots,
+		Transcodes:                opts.Transcodes,
+		Phashes:                   opts.Phashes,
+		InteractiveHeatmapsSpeeds: opts.InteractiveHeatmapsSpeeds,
+		ImageThumbnails:           opts.ImageThumbnails,
+		ImagePhashes:              opts.ImagePhashes,
+		ClipPreviews:              opts.ClipPreviews,
+	}
+
+	if opts.PreviewOptions != nil {
+		ret.PreviewOptions = &GeneratePreviewOptionsInput{
+			PreviewSegments:        opts.PreviewOptions.PreviewSegments,
+			PreviewSegmentDuration: opts.PreviewOptions.PreviewSegmentDuration,
+			PreviewExcludeStart:    opts.PreviewOptions.PreviewExcludeStart,
+			PreviewExcludeEnd:      opts.PreviewOptions.PreviewExcludeEnd,
+			PreviewPreset:          opts.PreviewOptions.PreviewPreset,
+		}
+	}
+
+	return ret
+}
+
+// GenerateOptionsFromInput returns the generate options stored in the
+// configuration for the provided input.
+func GenerateOptionsFromInput(input GenerateMetadataInput) models.GenerateMetadataOptions {
+	ret := models.GenerateMetadataOptions{
+		Covers:                    input.Covers,
+		Sprites:                   input.Sprites,
+		Previews:                  input.Previews,
+		ImagePreviews:             input.ImagePreviews,
+		Markers:                   input.Markers,
+		MarkerImagePreviews:       input.MarkerImagePreviews,
+		MarkerScreenshots:         input.MarkerScreenshots,
+		Transcodes:                input.Transcodes,
+		Phashes:                   input.Phashes,
+		InteractiveHeatmapsSpeeds: input.InteractiveHeatmapsSpeeds,
+		ImageThumbnails:           input.ImageThumbnails,
+		ImagePhashes:              input.ImagePhashes,
+		ClipPreviews:              input.ClipPreviews,
+	}
+
+	if input.PreviewOptions != nil {
+		ret.PreviewOptions = &models.GeneratePreviewOptions{
+			PreviewSegments:        input.PreviewOptions.PreviewSegments,
+			PreviewSegmentDuration: input.PreviewOptions.PreviewSegmentDuration,
+			PreviewExcludeStart:    input.PreviewOptions.PreviewExcludeStart,
+			PreviewExcludeEnd:      input.PreviewOptions.PreviewExcludeEnd,
+			PreviewPreset:          input.PreviewOptions.PreviewPreset,
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,182 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"0 3 * * *",
+		"*/15 * * * *",
+		"@daily",
+		"@every 1h",
+	}
+	invalid := []string{
+		"",
+		"* * *",
+		"0 0 3 * * *",
+		"@sometimes",
+	}
+
+	for _, v := range valid {
+		_, err := ParseCron(v)
+		assert.NoError(t, err, v)
+	}
+
+	for _, v := range invalid {
+		_, err := ParseCron(v)
+		assert.Error(t, err, v)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	jobManager := job.NewManager()
+	defer jobManager.Stop()
+
+	finish := make(chan struct{})
+	runs := 0
+
+	s := newScheduler(jobManager, func(ctx context.Context, t config.ScheduledTask) (int, error) {
+		runs++
+		return jobManager.Add(ctx, t.Name, job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+			<-finish
+			return nil
+		})), nil
+	})
+	defer s.Stop()
+
+	task := config.ScheduledTask{
+		ID:      "1",
+		Name:    "task",
+		Type:    config.ScheduledTaskTypeScan,
+		Cron:    "@daily",
+		Enabled: true,
+	}
+	disabled := config.ScheduledTask{
+		ID:   "2",
+		Name: "disabled",
+		Type: config.ScheduledTaskTypeClean,
+		Cron: "@daily",
+	}
+
+	s.Refresh([]config.ScheduledTask{task, disabled})
+
+	assert := assert.New(t)
+
+	status := s.Status(task.ID)
+	assert.NotNil(status.NextRun)
+	assert.Nil(status.LastRun)
+	assert.Nil(status.LastJobID)
+
+	assert.Nil(s.Status(disabled.ID).NextRun)
+
+	ctx := context.Background()
+	s.runTask(ctx, task)
+	assert.Equal(1, runs)
+
+	status = s.Status(task.ID)
+	assert.NotNil(status.LastRun)
+	if !assert.NotNil(status.LastJobID) {
+		return
+	}
+	jobID := *status.LastJobID
+
+	// expect run to be skipped while the previous job is still running
+	s.runTask(ctx, task)
+	assert.Equal(1, runs)
+
+	close(finish)
+
+	// wait for the job to finish
+	for i := 0; i < 100; i++ {
+		j := jobManager.GetJob(jobID)
+		if j == nil || j.Status == job.StatusFinished {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s.runTask(ctx, task)
+	assert.Equal(2, runs)
+
+	// expect removed tasks to not be run
+	s.Refresh(nil)
+	s.runTask(ctx, task)
+	assert.Equal(2, runs)
+	assert.Nil(s.Status(task.ID).NextRun)
+}
+
+// interruptedJobStore is a job store containing a single job that was running
+// when it was stored.
+type interruptedJobStore struct {
+	job job.Job
+}
+
+func (s *interruptedJobStore) Save(ctx context.Context, j job.Job) error {
+	return nil
+}
+
+func (s *interruptedJobStore) Find(ctx context.Context, id int) (*job.Job, error) {
+	return nil, nil
+}
+
+func (s *interruptedJobStore) FindByStatus(ctx context.Context, statuses []job.Status) ([]job.Job, error) {
+	return []job.Job{s.job}, nil
+}
+
+func (s *interruptedJobStore) FindRecent(ctx context.Context, limit int) ([]job.Job, error) {
+	return nil, nil
+}
+
+func (s *interruptedJobStore) MaxID(ctx context.Context) (int, error) {
+	return s.job.ID, nil
+}
+
+func (s *interruptedJobStore) Prune(ctx context.Context, keep int) error {
+	return nil
+}
+
+func TestSchedulerInterruptedJob(t *testing.T) {
+	jobManager := job.NewManager()
+	defer jobManager.Stop()
+
+	ctx := context.Background()
+	store := &interruptedJobStore{job: job.Job{ID: 1, Status: job.StatusRunning, Description: "task"}}
+	if err := jobManager.SetStore(ctx, store); err != nil {
+		t.Fatalf("SetStore() error = %v", err)
+	}
+
+	runs := 0
+	s := newScheduler(jobManager, func(ctx context.Context, t config.ScheduledTask) (int, error) {
+		runs++
+		return 0, nil
+	})
+	defer s.Stop()
+
+	task := config.ScheduledTask{
+		ID:      "1",
+		Name:    "task",
+		Type:    config.ScheduledTaskTypeScan,
+		Cron:    "@daily",
+		Enabled: true,
+	}
+	s.Refresh([]config.ScheduledTask{task})
+
+	jobID := store.job.ID
+	s.state[task.ID].lastJobID = &jobID
+
+	if j := jobManager.GetJob(jobID); j == nil || j.Status != job.StatusInterrupted {
+		t.Fatalf("job %d is not interrupted", jobID)
+	}
+
+	// expect run to be skipped while the previous job is interrupted
+	s.runTask(ctx, task)
+	assert.Equal(t, 0, runs)
+}
@@ -21,6 +21,9 @@ type MigrateJob struct {
 	BackupPath string
 	Config     migrateJobConfig
 	Database   *sqlite.Database
+
+	// PostMigrate is called after a successful migration, if set.
+	PostMigrate func(ctx context.Context)
 }
 
 type databaseSchemaInfo struct {
@@ -89,6 +92,10 @@ func (s *MigrateJob) Execute(ctx context.Context, progress *job.Progress) error
 
 	logger.Infof("Database migration complete")
 
+	if s.PostMigrate != nil {
+		s.PostMigrate(ctx)
+	}
+
 	return nil
 }
 
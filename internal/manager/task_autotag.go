@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/internal/autotag"
+	"github.com/stashapp/stash/pkg/audit"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -28,6 +29,9 @@ type autoTagJob struct {
 func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) error {
 	begin := time.Now()
 
+	// record changes in the audit log, so that they can be reverted
+	ctx = audit.WithSource(ctx, "autoTag")
+
 	input := j.input
 	if j.isFileBasedAutoTag(input) {
 		// doing file-based auto-tag
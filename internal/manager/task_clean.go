@@ -40,9 +40,10 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 	}
 
 	j.cleaner.Clean(ctx, file.CleanOptions{
-		Paths:      j.input.Paths,
-		DryRun:     j.input.DryRun,
-		PathFilter: newCleanFilter(instance.Config),
+		Paths:       j.input.Paths,
+		DryRun:      j.input.DryRun,
+		MarkMissing: j.input.MarkMissing,
+		PathFilter:  newCleanFilter(instance.Config),
 	}, progress)
 
 	if job.IsCancelled(ctx) {
@@ -101,7 +102,7 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 		return
 	}
 
-	if !j.input.DryRun {
+	if !j.input.DryRun && !j.input.MarkMissing {
 		for _, id := range toClean {
 			j.deleteGallery(ctx, id)
 		}
@@ -296,6 +296,8 @@ func (t *ExportTask) populateGroupScenes(ctx context.Context) {
 		groups, err = reader.All(ctx)
 	} else if t.groups != nil && len(t.groups.IDs) > 0 {
 		groups, err = reader.FindMany(ctx, t.groups.IDs)
+		// objects hidden by content restrictions are not exported
+		groups = sliceutil.OmitNil(groups)
 	}
 
 	if err != nil {
@@ -327,6 +329,8 @@ func (t *ExportTask) populateGalleryImages(ctx context.Context) {
 		galleries, err = reader.All(ctx)
 	} else if t.galleries != nil && len(t.galleries.IDs) > 0 {
 		galleries, err = reader.FindMany(ctx, t.galleries.IDs)
+		// objects hidden by content restrictions are not exported
+		galleries = sliceutil.OmitNil(galleries)
 	}
 
 	if err != nil {
@@ -363,6 +367,8 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 		scenes, err = sceneReader.All(ctx)
 	} else if t.scenes != nil && len(t.scenes.IDs) > 0 {
 		scenes, err = sceneReader.FindMany(ctx, t.scenes.IDs)
+		// objects hidden by content restrictions are not exported
+		scenes = sliceutil.OmitNil(scenes)
 	}
 
 	if err != nil {
@@ -612,6 +618,8 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int) {
 		images, err = imageReader.All(ctx)
 	} else if t.images != nil && len(t.images.IDs) > 0 {
 		images, err = imageReader.FindMany(ctx, t.images.IDs)
+		// objects hidden by content restrictions are not exported
+		images = sliceutil.OmitNil(images)
 	}
 
 	if err != nil {
@@ -679,6 +687,18 @@ func (t *ExportTask) exportImage(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		newImageJSON.CustomFields, err = r.Image.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[images] <%s> error getting image custom fields: %v", imageHash, err)
+			continue
+		}
+
+		newImageJSON.ViewHistory, newImageJSON.OHistory, err = image.GetHistory(ctx, r.Image, s)
+		if err != nil {
+			logger.Errorf("[images] <%s> error getting image history: %v", imageHash, err)
+			continue
+		}
+
 		imageGalleries, err := galleryReader.FindByImageID(ctx, s.ID)
 		if err != nil {
 			logger.Errorf("[images] <%s> error getting image galleries: %v", imageHash, err)
@@ -740,6 +760,8 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int) {
 		galleries, err = reader.All(ctx)
 	} else if t.galleries != nil && len(t.galleries.IDs) > 0 {
 		galleries, err = reader.FindMany(ctx, t.galleries.IDs)
+		// objects hidden by content restrictions are not exported
+		galleries = sliceutil.OmitNil(galleries)
 	}
 
 	if err != nil {
@@ -798,6 +820,24 @@ func (t *ExportTask) exportGallery(ctx context.Context, wg *sync.WaitGroup, jobC
 			continue
 		}
 
+		newGalleryJSON.CustomFields, err = r.Gallery.GetCustomFields(ctx, g.ID)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery custom fields: %v", g.DisplayName(), err)
+			continue
+		}
+
+		newGalleryJSON.ViewHistory, newGalleryJSON.OHistory, err = gallery.GetHistory(ctx, r.Gallery, g)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery history: %v", g.DisplayName(), err)
+			continue
+		}
+
+		newGalleryJSON.LastImageIndex, err = r.Gallery.GetLastImageIndex(ctx, g.ID)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery last image index: %v", g.DisplayName(), err)
+			continue
+		}
+
 		// export files
 		for _, f := range g.Files.List() {
 			t.exportFile(f)
@@ -886,6 +926,8 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int) {
 		performers, err = reader.All(ctx)
 	} else if t.performers != nil && len(t.performers.IDs) > 0 {
 		performers, err = reader.FindMany(ctx, t.performers.IDs)
+		// objects hidden by content restrictions are not exported
+		performers = sliceutil.OmitNil(performers)
 	}
 
 	if err != nil {
@@ -959,6 +1001,8 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int) {
 		studios, err = reader.All(ctx)
 	} else if t.studios != nil && len(t.studios.IDs) > 0 {
 		studios, err = reader.FindMany(ctx, t.studios.IDs)
+		// objects hidden by content restrictions are not exported
+		studios = sliceutil.OmitNil(studios)
 	}
 
 	if err != nil {
@@ -1033,6 +1077,8 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int) {
 		tags, err = reader.All(ctx)
 	} else if t.tags != nil && len(t.tags.IDs) > 0 {
 		tags, err = reader.FindMany(ctx, t.tags.IDs)
+		// objects hidden by content restrictions are not exported
+		tags = sliceutil.OmitNil(tags)
 	}
 
 	if err != nil {
@@ -1094,6 +1140,8 @@ func (t *ExportTask) ExportGroups(ctx context.Context, workers int) {
 		groups, err = reader.All(ctx)
 	} else if t.groups != nil && len(t.groups.IDs) > 0 {
 		groups, err = reader.FindMany(ctx, t.groups.IDs)
+		// objects hidden by content restrictions are not exported
+		groups = sliceutil.OmitNil(groups)
 	}
 
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -32,6 +33,7 @@ type GenerateMetadataInput struct {
 	InteractiveHeatmapsSpeeds bool `json:"interactiveHeatmapsSpeeds"`
 	ClipPreviews              bool `json:"clipPreviews"`
 	ImageThumbnails           bool `json:"imageThumbnails"`
+	ImagePhashes              bool `json:"imagePhashes"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
@@ -76,6 +78,7 @@ type totalsGenerate struct {
 	interactiveHeatmapSpeeds int64
 	clipPreviews             int64
 	imageThumbnails          int64
+	imagePhashes             int64
 
 	tasks int
 }
@@ -123,7 +126,8 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 			} else {
 				if len(j.input.SceneIDs) > 0 {
 					scenes, err = qb.FindMany(ctx, sceneIDs)
-					for _, s := range scenes {
+					// scenes hidden by content restrictions are not generated
+					for _, s := range sliceutil.OmitNil(scenes) {
 						if err := s.LoadFiles(ctx, qb); err != nil {
 							return err
 						}
@@ -181,6 +185,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.ImageThumbnails {
 			logMsg += fmt.Sprintf(" %d Image Thumbnails", totals.imageThumbnails)
 		}
+		if j.input.ImagePhashes {
+			logMsg += fmt.Sprintf(" %d Image Phashes", totals.imagePhashes)
+		}
 		if logMsg == "Generating" {
 			logMsg = "Nothing selected to generate"
 		}
@@ -284,7 +291,7 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 
 	r := j.repository
 
-	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
+	for more := j.input.ClipPreviews || j.input.ImageThumbnails || j.input.ImagePhashes; more; {
 		if job.IsCancelled(ctx) {
 			return
 		}
@@ -464,6 +471,23 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 				queue <- task
 			}
 		}
+
+		// the start and end points of the scene apply to its primary file
+		if f := scene.Files.Primary(); f != nil && scene.IsTrimmed() {
+			task := &GeneratePhashTask{
+				repository:          r,
+				File:                f,
+				Scene:               scene,
+				fileNamingAlgorithm: j.fileNamingAlgo,
+				Overwrite:           j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.phashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
 	}
 
 	if j.input.InteractiveHeatmapsSpeeds {
@@ -521,4 +545,26 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 			queue <- task
 		}
 	}
+
+	if j.input.ImagePhashes {
+		// generate for all image files, including those in zip files
+		for _, f := range image.Files.List() {
+			imageFile, ok := f.(*models.ImageFile)
+			if !ok {
+				continue
+			}
+
+			task := &GenerateImagePhashTask{
+				repository: j.repository,
+				File:       imageFile,
+				Overwrite:  j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.imagePhashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
+	}
 }
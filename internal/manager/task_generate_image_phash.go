@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/imagephash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type GenerateImagePhashTask struct {
+	repository models.Repository
+	File       *models.ImageFile
+	Overwrite  bool
+}
+
+func (t *GenerateImagePhashTask) GetDescription() string {
+	return fmt.Sprintf("Generating phash for image %s", t.File.Path)
+}
+
+func (t *GenerateImagePhashTask) Start(ctx context.Context) {
+	if !t.required() {
+		return
+	}
+
+	generated, err := imagephash.Generate(&file.OsFS{}, t.File)
+	if err != nil {
+		logger.Errorf("Error generating phash for image %s: %v", t.File.Path, err)
+		return
+	}
+
+	hash := int64(*generated)
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		t.File.Fingerprints = t.File.Fingerprints.AppendUnique(models.Fingerprint{
+			Type:        models.FingerprintTypePhash,
+			Fingerprint: hash,
+		})
+
+		return r.File.Update(ctx, t.File)
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting phash for image %s: %v", t.File.Path, err)
+	}
+}
+
+func (t *GenerateImagePhashTask) required() bool {
+	if t.Overwrite {
+		return true
+	}
+
+	return t.File.Fingerprints.Get(models.FingerprintTypePhash) == nil
+}
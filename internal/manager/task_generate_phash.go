@@ -14,6 +14,11 @@ type GeneratePhashTask struct {
 	File                *models.VideoFile
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
+
+	// Scene is set to generate the phash of the trimmed range of the scene's
+	// primary file. The result is stored against the scene, leaving the
+	// whole file phash fingerprint of the file unchanged.
+	Scene *models.Scene
 }
 
 func (t *GeneratePhashTask) GetDescription() string {
@@ -25,6 +30,11 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		return
 	}
 
+	if t.Scene != nil {
+		t.generateTrimmed(ctx)
+		return
+	}
+
 	var hash int64
 	set := false
 
@@ -65,6 +75,27 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 	}
 }
 
+func (t *GeneratePhashTask) generateTrimmed(ctx context.Context) {
+	start, end := t.Scene.TrimmedRange(t.File.Duration)
+
+	generated, err := videophash.GenerateRange(instance.FFMpeg, t.File, start, end)
+	if err != nil {
+		logger.Errorf("Error generating trimmed phash: %v", err)
+		logErrorOutput(err)
+		return
+	}
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		partial := models.NewScenePartial()
+		partial.TrimmedPhash = models.NewOptionalInt64(int64(*generated))
+		_, err := r.Scene.UpdatePartial(ctx, t.Scene.ID, partial)
+		return err
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting trimmed phash: %v", err)
+	}
+}
+
 func (t *GeneratePhashTask) findExistingPhash(ctx context.Context) (interface{}, error) {
 	r := t.repository
 	var ret interface{}
@@ -100,5 +131,9 @@ func (t *GeneratePhashTask) required() bool {
 		return true
 	}
 
+	if t.Scene != nil {
+		return t.Scene.TrimmedPhash == nil
+	}
+
 	return t.File.Fingerprints.Get(models.FingerprintTypePhash) == nil
 }
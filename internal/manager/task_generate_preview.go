@@ -30,7 +30,7 @@ func (t *GeneratePreviewTask) GetDescription() string {
 }
 
 func (t *GeneratePreviewTask) Start(ctx context.Context) {
-	videoChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
+	videoChecksum := t.Scene.GetGeneratedHash(t.fileNamingAlgorithm)
 
 	if t.videoPreviewRequired() {
 		ffprobe := instance.FFProbe
@@ -40,7 +40,8 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 			return
 		}
 
-		if err := t.generateVideo(videoChecksum, videoFile.VideoStreamDuration, videoFile.FrameRate); err != nil {
+		start, end := t.Scene.TrimmedRange(videoFile.VideoStreamDuration)
+		if err := t.generateVideo(videoChecksum, start, end-start, videoFile.FrameRate); err != nil {
 			logger.Errorf("error generating preview: %v", err)
 			logErrorOutput(err)
 			return
@@ -55,7 +56,7 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 	}
 }
 
-func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration float64, videoFrameRate float64) error {
+func (t *GeneratePreviewTask) generateVideo(videoChecksum string, start float64, videoDuration float64, videoFrameRate float64) error {
 	videoFilename := t.Scene.Path
 	useVsync2 := false
 
@@ -64,9 +65,9 @@ func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration
 		useVsync2 = true
 	}
 
-	if err := t.generator.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, t.Options, false, useVsync2); err != nil {
+	if err := t.generator.PreviewVideo(context.TODO(), videoFilename, start, videoDuration, videoChecksum, t.Options, false, useVsync2); err != nil {
 		logger.Warnf("[generator] failed generating scene preview, trying fallback")
-		if err := t.generator.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, t.Options, true, useVsync2); err != nil {
+		if err := t.generator.PreviewVideo(context.TODO(), videoFilename, start, videoDuration, videoChecksum, t.Options, true, useVsync2); err != nil {
 			return err
 		}
 	}
@@ -92,7 +93,7 @@ func (t *GeneratePreviewTask) videoPreviewRequired() bool {
 		return true
 	}
 
-	sceneChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
+	sceneChecksum := t.Scene.GetGeneratedHash(t.fileNamingAlgorithm)
 	if sceneChecksum == "" {
 		return false
 	}
@@ -118,7 +119,7 @@ func (t *GeneratePreviewTask) imagePreviewRequired() bool {
 		return true
 	}
 
-	sceneChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
+	sceneChecksum := t.Scene.GetGeneratedHash(t.fileNamingAlgorithm)
 	if sceneChecksum == "" {
 		return false
 	}
@@ -31,7 +31,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 		return
 	}
 
-	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	sceneHash := t.Scene.GetGeneratedHash(t.fileNamingAlgorithm)
 	imagePath := instance.Paths.Scene.GetSpriteImageFilePath(sceneHash)
 	vttPath := instance.Paths.Scene.GetSpriteVttFilePath(sceneHash)
 	generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, 9, 9)
@@ -41,6 +41,9 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 		return
 	}
 	generator.Overwrite = t.Overwrite
+	if t.Scene.IsTrimmed() {
+		generator.Start, generator.End = t.Scene.TrimmedRange(videoFile.VideoStreamDuration)
+	}
 
 	if err := generator.Generate(); err != nil {
 		logger.Errorf("error generating sprite: %s", err.Error())
@@ -59,7 +62,7 @@ func (t GenerateSpriteTask) required() bool {
 		return true
 	}
 
-	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	sceneHash := t.Scene.GetGeneratedHash(t.fileNamingAlgorithm)
 	return !t.doesSpriteExist(sceneHash)
 }
 
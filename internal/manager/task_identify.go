@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/audit"
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/group"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -18,8 +21,13 @@ import (
 
 var ErrInput = errors.New("invalid request input")
 
+type identifyPostHookExecutor interface {
+	identify.SceneUpdatePostHookExecutor
+	identify.PostHookExecutor
+}
+
 type IdentifyJob struct {
-	postHookExecutor identify.SceneUpdatePostHookExecutor
+	postHookExecutor identifyPostHookExecutor
 	input            identify.Options
 
 	stashBoxes []*models.StashBox
@@ -37,6 +45,9 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
 
+	// record changes in the audit log, so that they can be reverted
+	ctx = audit.WithSource(ctx, "identify")
+
 	// if no sources provided - just return
 	if len(j.input.Sources) == 0 {
 		return nil
@@ -47,85 +58,169 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 		return err
 	}
 
-	// if scene ids provided, use those
-	// otherwise, batch query for all scenes - ordering by path
-	// don't use a transaction to query scenes
+	// if ids provided, use those
+	// otherwise, batch query for all objects of the requested types
+	// don't use a transaction to query objects
 	r := instance.Repository
 	if err := r.WithDB(ctx, func(ctx context.Context) error {
-		if len(j.input.SceneIDs) == 0 {
-			return j.identifyAllScenes(ctx, sources)
+		if !j.input.HasIDs() {
+			return j.identifyAll(ctx, sources)
+		}
+
+		return j.identifyIDs(ctx, sources)
+	}); err != nil {
+		return fmt.Errorf("error encountered while identifying: %w", err)
+	}
+
+	return nil
+}
+
+func (j *IdentifyJob) identifyIDs(ctx context.Context, sources []identify.ScraperSource) error {
+	r := instance.Repository
+
+	sceneIDs, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
+	if err != nil {
+		return fmt.Errorf("invalid scene IDs: %w", err)
+	}
+
+	galleryIDs, err := stringslice.StringSliceToIntSlice(j.input.GalleryIDs)
+	if err != nil {
+		return fmt.Errorf("invalid gallery IDs: %w", err)
+	}
+
+	groupIDs, err := stringslice.StringSliceToIntSlice(j.input.GroupIDs)
+	if err != nil {
+		return fmt.Errorf("invalid group IDs: %w", err)
+	}
+
+	j.progress.SetTotal(len(sceneIDs) + len(galleryIDs) + len(groupIDs))
+
+	for _, id := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			return nil
 		}
 
-		sceneIDs, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
+		// find the scene
+		scene, err := r.Scene.Find(ctx, id)
 		if err != nil {
-			return fmt.Errorf("invalid scene IDs: %w", err)
+			return fmt.Errorf("finding scene id %d: %w", id, err)
 		}
 
-		progress.SetTotal(len(sceneIDs))
-		for _, id := range sceneIDs {
-			if job.IsCancelled(ctx) {
-				break
-			}
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", id)
+		}
 
-			// find the scene
-			var err error
-			scene, err := r.Scene.Find(ctx, id)
-			if err != nil {
-				return fmt.Errorf("finding scene id %d: %w", id, err)
-			}
+		j.identifyScene(ctx, scene, sources)
+	}
 
-			if scene == nil {
-				return fmt.Errorf("scene with id %d not found", id)
-			}
+	for _, id := range galleryIDs {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
 
-			j.identifyScene(ctx, scene, sources)
+		gallery, err := r.Gallery.Find(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding gallery id %d: %w", id, err)
 		}
 
-		return nil
-	}); err != nil {
-		return fmt.Errorf("error encountered while identifying scenes: %w", err)
+		if gallery == nil {
+			return fmt.Errorf("gallery with id %d not found", id)
+		}
+
+		j.identifyGallery(ctx, gallery, sources)
+	}
+
+	for _, id := range groupIDs {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		group, err := r.Group.Find(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding group id %d: %w", id, err)
+		}
+
+		if group == nil {
+			return fmt.Errorf("group with id %d not found", id)
+		}
+
+		j.identifyGroup(ctx, group, sources)
 	}
 
 	return nil
 }
 
-func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.ScraperSource) error {
+func (j *IdentifyJob) identifyAll(ctx context.Context, sources []identify.ScraperSource) error {
+	objectTypes := j.input.ObjectTypes
+	if len(objectTypes) == 0 {
+		objectTypes = []identify.ObjectType{identify.ObjectTypeScene}
+	}
+
 	r := instance.Repository
 
 	// exclude organised
 	organised := false
+
 	sceneFilter := scene.FilterFromPaths(j.input.Paths)
 	sceneFilter.Organized = &organised
 
-	sort := "path"
-	findFilter := &models.FindFilterType{
-		Sort: &sort,
+	galleryFilter := gallery.FilterFromPaths(j.input.Paths)
+	galleryFilter.Organized = &organised
+
+	// get the total count
+	total := 0
+	for _, t := range objectTypes {
+		var count int
+		var err error
+		switch t {
+		case identify.ObjectTypeScene:
+			count, err = r.Scene.QueryCount(ctx, sceneFilter, nil)
+		case identify.ObjectTypeGallery:
+			count, err = r.Gallery.QueryCount(ctx, galleryFilter, nil)
+		case identify.ObjectTypeGroup:
+			count, err = r.Group.Count(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("error getting %s count: %w", strings.ToLower(t.String()), err)
+		}
+		total += count
 	}
 
-	// get the count
-	pp := 0
-	findFilter.PerPage = &pp
-	countResult, err := r.Scene.Query(ctx, models.SceneQueryOptions{
-		QueryOptions: models.QueryOptions{
-			FindFilter: findFilter,
-			Count:      true,
-		},
-		SceneFilter: sceneFilter,
-	})
-	if err != nil {
-		return fmt.Errorf("error getting scene count: %w", err)
+	j.progress.SetTotal(total)
+
+	for _, t := range objectTypes {
+		var err error
+		switch t {
+		case identify.ObjectTypeScene:
+			err = scene.BatchProcess(ctx, r.Scene, sceneFilter, pathSortFilter(), func(scene *models.Scene) error {
+				j.identifyScene(ctx, scene, sources)
+				return nil
+			})
+		case identify.ObjectTypeGallery:
+			err = gallery.BatchProcess(ctx, r.Gallery, galleryFilter, pathSortFilter(), func(gallery *models.Gallery) error {
+				j.identifyGallery(ctx, gallery, sources)
+				return nil
+			})
+		case identify.ObjectTypeGroup:
+			sort := "name"
+			err = group.BatchProcess(ctx, r.Group, nil, &models.FindFilterType{Sort: &sort}, func(group *models.Group) error {
+				j.identifyGroup(ctx, group, sources)
+				return nil
+			})
+		}
+		if err != nil {
+			return err
+		}
 	}
 
-	j.progress.SetTotal(countResult.Count)
-
-	return scene.BatchProcess(ctx, r.Scene, sceneFilter, findFilter, func(scene *models.Scene) error {
-		if job.IsCancelled(ctx) {
-			return nil
-		}
+	return nil
+}
 
-		j.identifyScene(ctx, scene, sources)
-		return nil
-	})
+func pathSortFilter() *models.FindFilterType {
+	sort := "path"
+	return &models.FindFilterType{
+		Sort: &sort,
+	}
 }
 
 func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, sources []identify.ScraperSource) {
@@ -158,6 +253,65 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 	j.progress.Increment()
 }
 
+func (j *IdentifyJob) identifyGallery(ctx context.Context, g *models.Gallery, sources []identify.ScraperSource) {
+	if job.IsCancelled(ctx) {
+		return
+	}
+
+	var taskError error
+	j.progress.ExecuteTask("Identifying "+g.DisplayName(), func() {
+		r := instance.Repository
+		task := identify.GalleryIdentifier{
+			TxnManager:           r.TxnManager,
+			GalleryReaderUpdater: r.Gallery,
+			StudioReaderWriter:   r.Studio,
+			PerformerCreator:     r.Performer,
+			TagFinderCreator:     r.Tag,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		taskError = task.Identify(ctx, g)
+	})
+
+	if taskError != nil {
+		logger.Errorf("Error encountered identifying %s: %v", g.DisplayName(), taskError)
+	}
+
+	j.progress.Increment()
+}
+
+func (j *IdentifyJob) identifyGroup(ctx context.Context, g *models.Group, sources []identify.ScraperSource) {
+	if job.IsCancelled(ctx) {
+		return
+	}
+
+	var taskError error
+	j.progress.ExecuteTask("Identifying group "+g.Name, func() {
+		r := instance.Repository
+		task := identify.GroupIdentifier{
+			TxnManager:         r.TxnManager,
+			GroupReaderUpdater: r.Group,
+			StudioReaderWriter: r.Studio,
+			TagFinderCreator:   r.Tag,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		taskError = task.Identify(ctx, g)
+	})
+
+	if taskError != nil {
+		logger.Errorf("Error encountered identifying group %s: %v", g.Name, taskError)
+	}
+
+	j.progress.Increment()
+}
+
 func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	var ret []identify.ScraperSource
 	for _, source := range j.input.Sources {
@@ -292,3 +446,67 @@ func (s scraperSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scrape
 func (s scraperSource) String() string {
 	return fmt.Sprintf("scraper %s", s.scraperID)
 }
+
+func (s scraperSource) ScrapeGalleries(ctx context.Context, g *models.Gallery) ([]*scraper.ScrapedGallery, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, g.ID, scraper.ScrapeContentTypeGallery)
+	if err != nil && !errors.Is(err, scraper.ErrNotSupported) {
+		return nil, err
+	}
+
+	// fall back to scraping the gallery urls
+	for _, url := range g.URLs.List() {
+		if content != nil {
+			break
+		}
+
+		content, err = s.cache.ScrapeIDURL(ctx, s.scraperID, url, scraper.ScrapeContentTypeGallery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	switch v := content.(type) {
+	case scraper.ScrapedGallery:
+		return []*scraper.ScrapedGallery{&v}, nil
+	case *scraper.ScrapedGallery:
+		return []*scraper.ScrapedGallery{v}, nil
+	}
+
+	return nil, errors.New("could not convert content to gallery")
+}
+
+func (s scraperSource) ScrapeGroups(ctx context.Context, g *models.Group) ([]*models.ScrapedGroup, error) {
+	for _, url := range g.URLs.List() {
+		content, err := s.cache.ScrapeIDURL(ctx, s.scraperID, url, scraper.ScrapeContentTypeMovie)
+		if err != nil {
+			return nil, err
+		}
+
+		// don't try to convert nil return value
+		if content == nil {
+			continue
+		}
+
+		switch v := content.(type) {
+		case models.ScrapedMovie:
+			ret := v.ScrapedGroup()
+			return []*models.ScrapedGroup{&ret}, nil
+		case *models.ScrapedMovie:
+			ret := v.ScrapedGroup()
+			return []*models.ScrapedGroup{&ret}, nil
+		case models.ScrapedGroup:
+			return []*models.ScrapedGroup{&v}, nil
+		case *models.ScrapedGroup:
+			return []*models.ScrapedGroup{v}, nil
+		}
+
+		return nil, errors.New("could not convert content to group")
+	}
+
+	return nil, nil
+}
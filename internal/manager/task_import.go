@@ -75,7 +75,7 @@ func CreateImportTask(a models.HashAlgorithm, input ImportObjectsInput) (*Import
 	mgr := GetInstance()
 	return &ImportTask{
 		repository:          mgr.Repository,
-		resetter:            mgr.Database,
+		resetter:            jobStoreResetter{manager: mgr},
 		BaseDir:             baseDir,
 		TmpZip:              tmpZip,
 		Reset:               false,
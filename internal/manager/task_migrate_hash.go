@@ -30,4 +30,14 @@ func (t *MigrateHashTask) Start() {
 	}
 
 	scene.MigrateHash(instance.Paths, oldHash, newHash)
+
+	// previews and sprites of trimmed scenes are named by the trimmed range
+	if t.Scene.IsTrimmed() {
+		oldAlgorithm := models.HashAlgorithmOshash
+		if t.fileNamingAlgorithm == models.HashAlgorithmOshash {
+			oldAlgorithm = models.HashAlgorithmMd5
+		}
+
+		scene.MigrateHash(instance.Paths, t.Scene.GetGeneratedHash(oldAlgorithm), t.Scene.GetGeneratedHash(t.fileNamingAlgorithm))
+	}
 }
@@ -463,6 +463,27 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 		}
 	}
 
+	imageFile, isImage := f.(*models.ImageFile)
+	if isImage && t.ScanGenerateImagePhashes {
+		progress.AddTotal(1)
+		phashFn := func(ctx context.Context) {
+			taskPhash := GenerateImagePhashTask{
+				repository: GetInstance().Repository,
+				File:       imageFile,
+				Overwrite:  overwrite,
+			}
+
+			taskPhash.Start(ctx)
+			progress.Increment()
+		}
+
+		if g.sequentialScanning {
+			phashFn(ctx)
+		} else {
+			g.taskQueue.Add(fmt.Sprintf("Generating phash for %s", path), phashFn)
+		}
+	}
+
 	return nil
 }
 
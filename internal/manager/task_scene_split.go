@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// SceneSplitJob splits a scene into new scenes with their own files. Each
+// range is cut losslessly into a new file next to the source file using
+// stream copy, and a new scene is created for each new file.
+type SceneSplitJob struct {
+	SceneID int
+	Ranges  []scene.SplitRange
+	Options scene.SplitOptions
+}
+
+// splitFilePath returns the path of the file cut from the range at index i
+// of the file at path.
+func splitFilePath(path string, i int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.part%d%s", strings.TrimSuffix(path, ext), i+1, ext)
+}
+
+func (j *SceneSplitJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := GetInstance()
+	r := mgr.Repository
+
+	var vf *models.VideoFile
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, j.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", j.SceneID)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+			return err
+		}
+
+		vf = s.Files.Primary()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if vf == nil {
+		return errors.New("scene has no files")
+	}
+	if vf.ZipFileID != nil {
+		return errors.New("cannot split files in zip files")
+	}
+
+	ranges, err := scene.SortSplitRanges(j.Ranges, vf.Duration)
+	if err != nil {
+		return err
+	}
+
+	// one task for each cut file, and one for scanning and creating the scenes
+	progress.SetTotal(len(ranges) + 1)
+
+	start := time.Now()
+
+	var paths []string
+	for i, rng := range ranges {
+		path := splitFilePath(vf.Path, i)
+
+		progress.ExecuteTask(fmt.Sprintf("Cutting %s", filepath.Base(path)), func() {
+			err = cutVideoFile(ctx, vf.Path, path, rng.Start, rng.End)
+			progress.Increment()
+		})
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cutting %s: %w", path, err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	progress.ExecuteTask("Creating scenes", func() {
+		err = j.createScenes(ctx, ranges, paths)
+		progress.Increment()
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Split scene %d into %d scenes after %s", j.SceneID, len(ranges), time.Since(start))
+	return nil
+}
+
+// cutVideoFile cuts the range between start and end from the video file at
+// input using stream copy, and writes it to output. Existing files are not
+// overwritten.
+func cutVideoFile(ctx context.Context, input string, output string, start float64, end float64) error {
+	if exists, _ := fsutil.FileExists(output); exists {
+		return fmt.Errorf("%s already exists", output)
+	}
+
+	mgr := GetInstance()
+
+	if err := mgr.Paths.Generated.EnsureTmpDir(); err != nil {
+		return err
+	}
+
+	// cut to a temporary file in case the process ends abruptly
+	tmpFn := mgr.Paths.Generated.GetTmpPath("cut_" + filepath.Base(output))
+
+	args := transcoder.Transcode(input, transcoder.TranscodeOptions{
+		OutputPath: tmpFn,
+		VideoCodec: ffmpeg.VideoCodecCopy,
+		AudioCodec: ffmpeg.AudioCodecCopy,
+		StartTime:  start,
+		Duration:   end - start,
+		// start the cut file at zero
+		ExtraOutputArgs: []string{"-avoid_negative_ts", "make_zero"},
+	})
+
+	lockCtx := mgr.ReadLockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	if err := mgr.FFMpeg.Generate(lockCtx, args); err != nil {
+		_ = os.Remove(tmpFn)
+		return err
+	}
+
+	return fsutil.SafeMove(tmpFn, output)
+}
+
+// createScenes scans the cut files and creates the new scenes for them.
+func (j *SceneSplitJob) createScenes(ctx context.Context, ranges []scene.SplitRange, paths []string) error {
+	mgr := GetInstance()
+	r := mgr.Repository
+
+	// scan the new files without handlers, so that no scenes are
+	// created for them by the scan
+	mgr.newScanner().Scan(ctx, nil, file.ScanOptions{
+		Paths:         paths,
+		ParallelTasks: 1,
+	}, nopProgressReporter{})
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        file.NewDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		for i, path := range paths {
+			f, err := r.File.FindByPath(ctx, path)
+			if err != nil {
+				return fmt.Errorf("finding file %s: %w", path, err)
+			}
+			if f == nil {
+				return fmt.Errorf("%s was not added by the scan", path)
+			}
+
+			id := f.Base().ID
+			ranges[i].FileID = &id
+		}
+
+		_, err := mgr.SceneService.Split(ctx, j.SceneID, ranges, fileDeleter, j.Options)
+		return err
+	}); err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	fileDeleter.Commit()
+	return nil
+}
+
+// nopProgressReporter ignores the progress of a scan.
+type nopProgressReporter struct{}
+
+func (nopProgressReporter) AddTotal(total int)                        {}
+func (nopProgressReporter) Increment()                                {}
+func (nopProgressReporter) Definite()                                 {}
+func (nopProgressReporter) ExecuteTask(description string, fn func()) { fn() }
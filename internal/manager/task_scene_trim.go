@@ -0,0 +1,139 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// SceneExportTrimmedJob cuts the trimmed range of the primary file of a
+// scene into a new file next to it using stream copy. The new file replaces
+// the primary file of the scene, and the start and end points of the scene
+// are cleared.
+type SceneExportTrimmedJob struct {
+	SceneID int
+}
+
+// trimmedFilePath returns the path of the file cut from the trimmed range
+// of the file at path.
+func trimmedFilePath(path string) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.trimmed%s", strings.TrimSuffix(path, ext), ext)
+}
+
+func (j *SceneExportTrimmedJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := GetInstance()
+	r := mgr.Repository
+
+	var (
+		vf         *models.VideoFile
+		start, end float64
+	)
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, j.SceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", j.SceneID)
+		}
+		if !s.IsTrimmed() {
+			return errors.New("scene has no start or end point")
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+			return err
+		}
+
+		vf = s.Files.Primary()
+		if vf != nil {
+			start, end = s.TrimmedRange(vf.Duration)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if vf == nil {
+		return errors.New("scene has no files")
+	}
+	if vf.ZipFileID != nil {
+		return errors.New("cannot cut files in zip files")
+	}
+
+	// one task for cutting the file, and one for replacing the scene file
+	progress.SetTotal(2)
+
+	startTime := time.Now()
+	path := trimmedFilePath(vf.Path)
+
+	var err error
+	progress.ExecuteTask(fmt.Sprintf("Cutting %s", filepath.Base(path)), func() {
+		err = cutVideoFile(ctx, vf.Path, path, start, end)
+		progress.Increment()
+	})
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cutting %s: %w", path, err)
+	}
+
+	progress.ExecuteTask("Replacing scene file", func() {
+		err = j.replaceFile(ctx, path)
+		progress.Increment()
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Exported trimmed scene %d to %s after %s", j.SceneID, path, time.Since(startTime))
+	return nil
+}
+
+// replaceFile scans the cut file and makes it the primary file of the scene.
+func (j *SceneExportTrimmedJob) replaceFile(ctx context.Context, path string) error {
+	mgr := GetInstance()
+	r := mgr.Repository
+
+	// scan the new file without handlers, so that no scene is
+	// created for it by the scan
+	mgr.newScanner().Scan(ctx, nil, file.ScanOptions{
+		Paths:         []string{path},
+		ParallelTasks: 1,
+	}, nopProgressReporter{})
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        file.NewDeleter(),
+		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+		Paths:          mgr.Paths,
+	}
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		f, err := r.File.FindByPath(ctx, path)
+		if err != nil {
+			return fmt.Errorf("finding file %s: %w", path, err)
+		}
+		if f == nil {
+			return fmt.Errorf("%s was not added by the scan", path)
+		}
+
+		return mgr.SceneService.ReplaceTrimmedFile(ctx, j.SceneID, f.Base().ID, fileDeleter)
+	}); err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	fileDeleter.Commit()
+	return nil
+}
@@ -0,0 +1,41 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+// AuthenticateUser returns true if the username and password match a user
+// account stored in the database. The configured user is authenticated
+// separately.
+func (s *Manager) AuthenticateUser(ctx context.Context, username string, password string) (bool, error) {
+	var ret bool
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		u, err := user.Authenticate(ctx, r.User, username, password)
+		ret = u != nil
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
+
+// FindUser returns the user account with the provided username. Returns nil
+// if the user does not exist.
+func (s *Manager) FindUser(ctx context.Context, username string) (*models.User, error) {
+	var ret *models.User
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.User.FindByUsername(ctx, username)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
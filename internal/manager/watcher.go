@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// RefreshWatcher starts, stops or restarts the library watcher as needed.
+// Should be called when the watch setting or the stash paths change.
+func (s *Manager) RefreshWatcher() {
+	s.watcherMutex.Lock()
+	defer s.watcherMutex.Unlock()
+
+	if s.stopWatcher != nil {
+		s.stopWatcher()
+		s.stopWatcher = nil
+	}
+
+	if !s.Config.GetWatchLibrary() {
+		return
+	}
+
+	var paths []string
+	for _, p := range s.Config.GetStashPaths() {
+		paths = append(paths, p.Path)
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWatcher = cancel
+
+	w := &file.Watcher{
+		Filter:   s.watchFilter(),
+		OnChange: s.onWatchChanges,
+	}
+
+	go func() {
+		logger.Infof("Watching %d library paths for changes", len(paths))
+		if err := w.Watch(ctx, paths); err != nil {
+			logger.Errorf("error watching library paths: %v", err)
+		}
+	}()
+}
+
+// watchFilter excludes directories written to by stash from being watched,
+// so that generating content does not trigger scans.
+func (s *Manager) watchFilter() func(path string, info fs.FileInfo) bool {
+	var excluded []string
+	for _, p := range []string{
+		s.Config.GetGeneratedPath(),
+		s.Config.GetCachePath(),
+		s.Config.GetBlobsPath(),
+		s.Config.GetMetadataPath(),
+	} {
+		if p != "" {
+			excluded = append(excluded, p)
+		}
+	}
+
+	return func(path string, info fs.FileInfo) bool {
+		for _, p := range excluded {
+			if fsutil.IsPathInDir(p, path) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func (s *Manager) onWatchChanges(ctx context.Context, changes file.WatchChanges) {
+	if len(changes.Changed) > 0 {
+		logger.Infof("[watcher] %d paths changed, scanning", len(changes.Changed))
+
+		input := ScanMetadataInput{
+			Paths: changes.Changed,
+		}
+		if opts := s.Config.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		if _, err := s.Scan(ctx, input); err != nil {
+			logger.Errorf("[watcher] error starting scan: %v", err)
+		}
+	}
+
+	if len(changes.Removed) > 0 {
+		// Removed files are not deleted by the watcher, since an unmounted
+		// share or a burst of renames would otherwise remove the metadata of
+		// every file under it. Their folders are checked by a clean that
+		// marks the files that are still missing, queued after the scan so
+		// that moved files are matched by fingerprint and renamed first.
+		// Deleting the missing files is left to an explicit clean, and
+		// files that are scanned again are no longer marked as missing.
+		var folders []string
+		for _, p := range changes.Removed {
+			folders = sliceutil.AppendUnique(folders, filepath.Dir(p))
+		}
+
+		logger.Infof("[watcher] %d paths removed, checking %d folders for missing files", len(changes.Removed), len(folders))
+
+		s.Clean(ctx, CleanMetadataInput{
+			Paths:       folders,
+			MarkMissing: true,
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/job"
+)
+
+type sourceContextKey struct{}
+
+type sourceContextValue struct {
+	source string
+	jobID  *int
+}
+
+// WithSource returns a context in which changes are recorded in the audit
+// log, with the provided source as the name of the mutation or task making
+// the changes.
+//
+// The source only applies to the job executing with the context, so that
+// jobs started by a mutation do not record changes with the source of the
+// mutation. Tasks must call WithSource to record their changes.
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceContextKey{}, sourceContextValue{
+		source: source,
+		jobID:  job.IDFromContext(ctx),
+	})
+}
+
+// SourceFromContext returns the source set with WithSource and the id of the
+// job making the changes. Returns false if changes made with the context
+// should not be recorded.
+func SourceFromContext(ctx context.Context) (source string, jobID *int, ok bool) {
+	v, ok := ctx.Value(sourceContextKey{}).(sourceContextValue)
+	if !ok {
+		return "", nil, false
+	}
+
+	jobID = job.IDFromContext(ctx)
+	if !sameID(v.jobID, jobID) {
+		return "", nil, false
+	}
+
+	return v.source, jobID, true
+}
+
+func sameID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,3 @@
+// Package audit provides the recording and reverting of changes made to
+// objects by mutations and tasks.
+package audit
@@ -0,0 +1,242 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrAlreadyReverted = errors.New("audit log entry has already been reverted")
+	ErrNotRevertable   = errors.New("audit log entry cannot be reverted")
+	ErrConflict        = errors.New("audited fields have been changed since the audit log entry")
+)
+
+type PerformerUpdaterDestroyer interface {
+	models.PerformerUpdater
+	models.PerformerDestroyer
+}
+
+type StudioUpdaterDestroyer interface {
+	models.StudioUpdater
+	models.StudioDestroyer
+}
+
+type TagUpdaterDestroyer interface {
+	models.TagUpdater
+	models.TagDestroyer
+}
+
+type GroupUpdaterDestroyer interface {
+	models.GroupUpdater
+	models.GroupDestroyer
+}
+
+type Repository struct {
+	AuditLog  models.AuditLogReaderWriter
+	Scene     models.SceneUpdater
+	Image     models.ImageUpdater
+	Gallery   models.GalleryUpdater
+	Performer PerformerUpdaterDestroyer
+	Studio    StudioUpdaterDestroyer
+	Tag       TagUpdaterDestroyer
+	Group     GroupUpdaterDestroyer
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		AuditLog:  repo.AuditLog,
+		Scene:     repo.Scene,
+		Image:     repo.Image,
+		Gallery:   repo.Gallery,
+		Performer: repo.Performer,
+		Studio:    repo.Studio,
+		Tag:       repo.Tag,
+		Group:     repo.Group,
+	}
+}
+
+// Revert reverts the change recorded in the audit log entry, and marks the
+// entry as reverted.
+//
+// Updates are reverted by restoring the old values of the changed fields.
+// Fields which have been changed again since the entry are not restored, and
+// are logged. Returns ErrConflict if all of the fields have been changed
+// again. Created performers, studios, tags and groups are reverted by destroying
+// them. Returns ErrNotRevertable for other created objects and for destroyed
+// objects.
+func Revert(ctx context.Context, r Repository, entry *models.AuditLogEntry) error {
+	if entry.RevertedAt != nil {
+		return ErrAlreadyReverted
+	}
+
+	var err error
+	switch entry.Action {
+	case models.AuditActionUpdate:
+		err = revertUpdate(ctx, r, entry)
+	case models.AuditActionCreate:
+		err = revertCreate(ctx, r, entry)
+	default:
+		err = ErrNotRevertable
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := r.AuditLog.SetReverted(ctx, entry.ID, time.Now()); err != nil {
+		return fmt.Errorf("setting audit log entry %d as reverted: %w", entry.ID, err)
+	}
+
+	return nil
+}
+
+// RevertJob reverts the changes made by the job with the provided id, most
+// recent first. Entries which have already been reverted, which cannot be
+// reverted, or whose fields have all been changed since, are skipped. Returns the number of reverted entries.
+func RevertJob(ctx context.Context, r Repository, jobID int) (int, error) {
+	perPage := models.PerPageAll
+	entries, _, err := r.AuditLog.Query(ctx, models.AuditLogFilter{
+		JobID: &jobID,
+	}, &models.FindFilterType{
+		PerPage: &perPage,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("querying audit log: %w", err)
+	}
+
+	ret := 0
+	for _, entry := range entries {
+		if entry.RevertedAt != nil {
+			continue
+		}
+
+		err := Revert(ctx, r, entry)
+		if errors.Is(err, ErrNotRevertable) || errors.Is(err, ErrConflict) {
+			logger.Warnf("Skipping audit log entry %d: %v", entry.ID, err)
+			continue
+		}
+		if err != nil {
+			return ret, fmt.Errorf("reverting audit log entry %d: %w", entry.ID, err)
+		}
+
+		ret++
+	}
+
+	return ret, nil
+}
+
+// revertValues returns the old values of the fields of the entry which still
+// have the new values of the entry, and the keys of the fields which have
+// been changed since.
+func revertValues(entry *models.AuditLogEntry, current map[string]interface{}) (values map[string]interface{}, conflicts []string) {
+	values = make(map[string]interface{})
+	for k, v := range entry.OldValues {
+		if !jsonEquivalent(entry.NewValues[k], current[k]) {
+			conflicts = append(conflicts, k)
+			continue
+		}
+
+		values[k] = v
+	}
+
+	sort.Strings(conflicts)
+	return values, conflicts
+}
+
+func revertUpdate(ctx context.Context, r Repository, entry *models.AuditLogEntry) error {
+	id := entry.ObjectID
+
+	current, err := r.AuditLog.CurrentValues(ctx, entry.ObjectType, id)
+	if err != nil {
+		return fmt.Errorf("getting current values of %s %d: %w", entry.ObjectType, id, err)
+	}
+
+	values, conflicts := revertValues(entry, current)
+	if len(conflicts) > 0 {
+		if len(values) == 0 {
+			return fmt.Errorf("%w: %s", ErrConflict, strings.Join(conflicts, ", "))
+		}
+
+		logger.Warnf("Not reverting fields of %s %d changed since audit log entry %d: %s", entry.ObjectType, id, entry.ID, strings.Join(conflicts, ", "))
+	}
+
+	switch entry.ObjectType {
+	case models.AuditObjectTypeScene:
+		partial := models.NewScenePartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Scene.UpdatePartial(ctx, id, partial)
+		}
+	case models.AuditObjectTypeImage:
+		partial := models.NewImagePartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Image.UpdatePartial(ctx, id, partial)
+		}
+	case models.AuditObjectTypeGallery:
+		partial := models.NewGalleryPartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Gallery.UpdatePartial(ctx, id, partial)
+		}
+	case models.AuditObjectTypePerformer:
+		partial := models.NewPerformerPartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Performer.UpdatePartial(ctx, id, partial)
+		}
+	case models.AuditObjectTypeStudio:
+		partial := models.NewStudioPartial()
+		if err = Apply(values, &partial); err == nil {
+			partial.ID = id
+			_, err = r.Studio.UpdatePartial(ctx, partial)
+		}
+	case models.AuditObjectTypeTag:
+		partial := models.NewTagPartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Tag.UpdatePartial(ctx, id, partial)
+		}
+	case models.AuditObjectTypeGroup:
+		partial := models.NewGroupPartial()
+		if err = Apply(values, &partial); err == nil {
+			_, err = r.Group.UpdatePartial(ctx, id, partial)
+		}
+	default:
+		return ErrNotRevertable
+	}
+
+	if err != nil {
+		return fmt.Errorf("reverting %s %d: %w", entry.ObjectType, id, err)
+	}
+
+	return nil
+}
+
+func revertCreate(ctx context.Context, r Repository, entry *models.AuditLogEntry) error {
+	id := entry.ObjectID
+
+	var err error
+	switch entry.ObjectType {
+	case models.AuditObjectTypePerformer:
+		err = r.Performer.Destroy(ctx, id)
+	case models.AuditObjectTypeStudio:
+		err = r.Studio.Destroy(ctx, id)
+	case models.AuditObjectTypeTag:
+		err = r.Tag.Destroy(ctx, id)
+	case models.AuditObjectTypeGroup:
+		err = r.Group.Destroy(ctx, id)
+	default:
+		// scenes, images and galleries are associated with files, which
+		// must be deleted separately
+		return ErrNotRevertable
+	}
+
+	if err != nil {
+		return fmt.Errorf("destroying %s %d: %w", entry.ObjectType, id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,354 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Diff returns the values of the fields which differ between old and new.
+// old and new must be pointers to partial structs of the same type, such as
+// *models.ScenePartial, or nil. Only fields which are set are considered.
+//
+// If old is nil, then all set fields of new are returned, and vice versa.
+func Diff(old, new interface{}) (oldValues, newValues map[string]interface{}, err error) {
+	o, err := encodePartial(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := encodePartial(new)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldValues = make(map[string]interface{})
+	newValues = make(map[string]interface{})
+
+	for k, nv := range n {
+		ov, found := o[k]
+		if found && jsonEqual(ov, nv) {
+			continue
+		}
+
+		if found {
+			oldValues[k] = ov
+		}
+		newValues[k] = nv
+	}
+
+	for k, ov := range o {
+		if _, found := n[k]; !found {
+			oldValues[k] = ov
+		}
+	}
+
+	return oldValues, newValues, nil
+}
+
+// Apply sets the fields of partial from values, as returned by Diff. partial
+// must be a pointer to a partial struct, such as *models.ScenePartial.
+// Relationships are set, replacing the existing values.
+func Apply(values map[string]interface{}, partial interface{}) error {
+	v := reflect.ValueOf(partial)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("invalid partial type %T", partial)
+	}
+
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		key := fieldKey(t.Field(i).Name)
+		value, found := values[key]
+		if !found {
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", key, err)
+		}
+
+		decoded, err := decodeField(t.Field(i).Type, data)
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", key, err)
+		}
+
+		if decoded.IsValid() {
+			v.Field(i).Set(decoded)
+		}
+	}
+
+	return nil
+}
+
+func encodePartial(partial interface{}) (map[string]interface{}, error) {
+	ret := make(map[string]interface{})
+	if partial == nil {
+		return ret, nil
+	}
+
+	v := reflect.ValueOf(partial)
+	if v.Kind() != reflect.Pointer || v.Type().Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid partial type %T", partial)
+	}
+	if v.IsNil() {
+		return ret, nil
+	}
+
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		if value, set := encodeField(v.Field(i).Interface()); set {
+			ret[fieldKey(t.Field(i).Name)] = value
+		}
+	}
+
+	return ret, nil
+}
+
+// encodeField returns the value of a partial field. Returns false if the
+// field is not set, or is not a supported type.
+func encodeField(f interface{}) (interface{}, bool) {
+	switch f := f.(type) {
+	case models.OptionalString:
+		return optionalValue(f.Value, f.Null, f.Set)
+	case models.OptionalInt:
+		return optionalValue(f.Value, f.Null, f.Set)
+	case models.OptionalInt64:
+		return optionalValue(f.Value, f.Null, f.Set)
+	case models.OptionalBool:
+		return optionalValue(f.Value, f.Null, f.Set)
+	case models.OptionalFloat64:
+		return optionalValue(f.Value, f.Null, f.Set)
+	case models.OptionalDate:
+		return optionalValue(f.Value.String(), f.Null, f.Set)
+	case models.OptionalTime:
+		return optionalValue(f.Value.Format(time.RFC3339), f.Null, f.Set)
+	case *models.UpdateStrings:
+		if f == nil {
+			return nil, false
+		}
+		return nonNil(f.Values), true
+	case *models.UpdateIDs:
+		if f == nil {
+			return nil, false
+		}
+		ids := append([]int{}, f.IDs...)
+		sort.Ints(ids)
+		return ids, true
+	case *models.UpdateStashIDs:
+		if f == nil {
+			return nil, false
+		}
+		return nonNil(f.StashIDs), true
+	case *models.UpdateGroupIDs:
+		if f == nil {
+			return nil, false
+		}
+		groups := append([]models.GroupsScenes{}, f.Groups...)
+		sort.SliceStable(groups, func(i, j int) bool {
+			return groups[i].GroupID < groups[j].GroupID
+		})
+		return groups, true
+	case *models.UpdateGroupDescriptions:
+		if f == nil {
+			return nil, false
+		}
+		groups := append([]models.GroupIDDescription{}, f.Groups...)
+		sort.SliceStable(groups, func(i, j int) bool {
+			return groups[i].GroupID < groups[j].GroupID
+		})
+		return groups, true
+	case models.CustomFieldsInput:
+		if f.Full == nil {
+			return nil, false
+		}
+		return f.Full, true
+	}
+
+	return nil, false
+}
+
+func optionalValue(v interface{}, null bool, set bool) (interface{}, bool) {
+	if !set {
+		return nil, false
+	}
+	if null {
+		return nil, true
+	}
+	return v, true
+}
+
+func nonNil[T any](v []T) []T {
+	if v == nil {
+		return []T{}
+	}
+	return v
+}
+
+// decodeField decodes data into a value of the provided partial field type.
+// Returns an invalid value if the type is not supported.
+func decodeField(t reflect.Type, data []byte) (reflect.Value, error) {
+	null := bytes.Equal(data, []byte("null"))
+
+	var ret interface{}
+	var err error
+
+	switch t {
+	case reflect.TypeOf(models.OptionalString{}):
+		var v *string
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalStringPtr(v)
+	case reflect.TypeOf(models.OptionalInt{}):
+		var v *int
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalIntPtr(v)
+	case reflect.TypeOf(models.OptionalInt64{}):
+		var v *int64
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalInt64Ptr(v)
+	case reflect.TypeOf(models.OptionalBool{}):
+		var v *bool
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalBoolPtr(v)
+	case reflect.TypeOf(models.OptionalFloat64{}):
+		var v *float64
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalFloat64Ptr(v)
+	case reflect.TypeOf(models.OptionalDate{}):
+		var v *string
+		var d *models.Date
+		err = json.Unmarshal(data, &v)
+		if err == nil && v != nil {
+			var parsed models.Date
+			parsed, err = models.ParseDate(*v)
+			d = &parsed
+		}
+		ret = models.NewOptionalDatePtr(d)
+	case reflect.TypeOf(models.OptionalTime{}):
+		var v *time.Time
+		err = json.Unmarshal(data, &v)
+		ret = models.NewOptionalTimePtr(v)
+	case reflect.TypeOf(&models.UpdateStrings{}):
+		var v []string
+		err = json.Unmarshal(data, &v)
+		ret = &models.UpdateStrings{Values: v, Mode: models.RelationshipUpdateModeSet}
+	case reflect.TypeOf(&models.UpdateIDs{}):
+		var v []int
+		err = json.Unmarshal(data, &v)
+		ret = &models.UpdateIDs{IDs: v, Mode: models.RelationshipUpdateModeSet}
+	case reflect.TypeOf(&models.UpdateStashIDs{}):
+		var v []models.StashID
+		err = json.Unmarshal(data, &v)
+		ret = &models.UpdateStashIDs{StashIDs: v, Mode: models.RelationshipUpdateModeSet}
+	case reflect.TypeOf(&models.UpdateGroupIDs{}):
+		var v []models.GroupsScenes
+		err = json.Unmarshal(data, &v)
+		ret = &models.UpdateGroupIDs{Groups: v, Mode: models.RelationshipUpdateModeSet}
+	case reflect.TypeOf(&models.UpdateGroupDescriptions{}):
+		var v []models.GroupIDDescription
+		err = json.Unmarshal(data, &v)
+		ret = &models.UpdateGroupDescriptions{Groups: v, Mode: models.RelationshipUpdateModeSet}
+	case reflect.TypeOf(models.CustomFieldsInput{}):
+		var v map[string]interface{}
+		if !null {
+			d := json.NewDecoder(bytes.NewReader(data))
+			d.UseNumber()
+			err = d.Decode(&v)
+		}
+		// an empty map clears the custom fields
+		ret = models.CustomFieldsInput{Full: convertJSONNumbers(v)}
+	default:
+		return reflect.Value{}, nil
+	}
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return reflect.ValueOf(ret), nil
+}
+
+// convertJSONNumbers converts all JSON numbers in a map to either float64 or
+// int64. Returns an empty map if m is nil.
+func convertJSONNumbers(m map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for k, v := range m {
+		switch v := v.(type) {
+		case json.Number:
+			if strings.Contains(string(v), ".") {
+				ret[k], _ = v.Float64()
+			} else {
+				ret[k], _ = v.Int64()
+			}
+		case map[string]interface{}:
+			ret[k] = convertJSONNumbers(v)
+		default:
+			ret[k] = v
+		}
+	}
+
+	return ret
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aa, aErr := json.Marshal(a)
+	bb, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aa, bb)
+}
+
+// jsonEquivalent returns true if a and b are equal once decoded from JSON.
+// Unlike jsonEqual, values decoded from JSON compare equal to the values
+// they were encoded from.
+func jsonEquivalent(a, b interface{}) bool {
+	return jsonEqual(jsonNormalise(a), jsonNormalise(b))
+}
+
+func jsonNormalise(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var ret interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return v
+	}
+
+	return ret
+}
+
+var fieldKeyReplacer = strings.NewReplacer(
+	"IDs", "Ids",
+	"URLs", "Urls",
+	"ID", "Id",
+	"URL", "Url",
+)
+
+// fieldKey returns the snake case key for a partial field name. For example,
+// TagIDs is returned as tag_ids.
+func fieldKey(name string) string {
+	name = fieldKeyReplacer.Replace(name)
+
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
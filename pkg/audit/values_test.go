@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Title", "title"},
+		{"StudioID", "studio_id"},
+		{"TagIDs", "tag_ids"},
+		{"URLs", "urls"},
+		{"URL", "url"},
+		{"IgnoreAutoTag", "ignore_auto_tag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldKey(tt.name))
+		})
+	}
+}
+
+func TestDiffApply(t *testing.T) {
+	date, _ := models.ParseDate("2024-01-02")
+
+	old := &models.ScenePartial{
+		Title:    models.NewOptionalString("old title"),
+		Details:  models.NewOptionalString("details"),
+		Date:     models.NewOptionalDate(date),
+		StudioID: models.NewOptionalInt(1),
+		TagIDs: &models.UpdateIDs{
+			IDs:  []int{2, 1},
+			Mode: models.RelationshipUpdateModeSet,
+		},
+		CustomFields: models.CustomFieldsInput{
+			Full: map[string]interface{}{"count": int64(3)},
+		},
+	}
+	updated := &models.ScenePartial{
+		Title:    models.NewOptionalString("new title"),
+		Details:  models.NewOptionalString("details"),
+		Date:     models.NewOptionalDatePtr(nil),
+		StudioID: models.NewOptionalInt(1),
+		TagIDs: &models.UpdateIDs{
+			IDs:  []int{1, 2},
+			Mode: models.RelationshipUpdateModeSet,
+		},
+		CustomFields: models.CustomFieldsInput{
+			Full: map[string]interface{}{},
+		},
+	}
+
+	oldValues, newValues, err := Diff(old, updated)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	// unchanged fields are omitted, and ids are compared regardless of order
+	assert.Equal(t, map[string]interface{}{
+		"title":         "old title",
+		"date":          "2024-01-02",
+		"custom_fields": map[string]interface{}{"count": int64(3)},
+	}, oldValues)
+	assert.Equal(t, map[string]interface{}{
+		"title":         "new title",
+		"date":          nil,
+		"custom_fields": map[string]interface{}{},
+	}, newValues)
+
+	// apply the values as stored in the database
+	data, err := json.Marshal(oldValues)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var stored map[string]interface{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	var got models.ScenePartial
+	if err := Apply(stored, &got); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	assert.Equal(t, models.NewOptionalString("old title"), got.Title)
+	assert.Equal(t, models.NewOptionalDate(date), got.Date)
+	assert.False(t, got.Details.Set)
+	assert.Nil(t, got.TagIDs)
+	assert.Equal(t, map[string]interface{}{"count": int64(3)}, got.CustomFields.Full)
+}
+
+func TestDiffCreate(t *testing.T) {
+	created := &models.TagPartial{
+		Name:      models.NewOptionalString("tag"),
+		ParentIDs: &models.UpdateIDs{Mode: models.RelationshipUpdateModeSet},
+	}
+
+	oldValues, newValues, err := Diff(nil, created)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	assert.Empty(t, oldValues)
+	assert.Equal(t, map[string]interface{}{
+		"name":       "tag",
+		"parent_ids": []int{},
+	}, newValues)
+}
@@ -8,6 +8,9 @@ import (
 // only support H264 by default, since Safari does not support VP8/VP9
 var defaultSupportedCodecs = []string{H264, H265}
 
+// video codecs which can be copied into a fragmented MP4 HLS stream
+var hlsCopySupportedCodecs = []string{H264, H265, Hevc, Av1}
+
 var validForH264Mkv = []Container{Mp4, Matroska}
 var validForH264 = []Container{Mp4}
 var validForH265Mkv = []Container{Mp4, Matroska}
@@ -17,6 +20,8 @@ var validForVp9Mkv = []Container{Webm, Matroska}
 var validForVp9 = []Container{Webm}
 var validForHevcMkv = []Container{Mp4, Matroska}
 var validForHevc = []Container{Mp4}
+var validForAv1Mkv = []Container{Mp4, Webm, Matroska}
+var validForAv1 = []Container{Mp4, Webm}
 
 var validAudioForMkv = []ProbeAudioCodec{Aac, Mp3, Vorbis, Opus}
 var validAudioForWebm = []ProbeAudioCodec{Vorbis, Opus}
@@ -35,8 +40,16 @@ var (
 
 // IsStreamable returns nil if the file is streamable, or an error if it is not.
 func IsStreamable(videoCodec string, audioCodec ProbeAudioCodec, container Container) error {
-	supportedVideoCodecs := defaultSupportedCodecs
+	return isStreamable(videoCodec, audioCodec, container, defaultSupportedCodecs)
+}
+
+// IsHLSCopyable returns nil if the video stream can be copied into a
+// fragmented MP4 HLS stream with AAC audio, or an error if it cannot.
+func IsHLSCopyable(videoCodec string) error {
+	return isStreamable(videoCodec, Aac, Mp4, hlsCopySupportedCodecs)
+}
 
+func isStreamable(videoCodec string, audioCodec ProbeAudioCodec, container Container, supportedVideoCodecs []string) error {
 	// check if the video codec matches the supported codecs
 	if !isValidCodec(videoCodec, supportedVideoCodecs) {
 		return fmt.Errorf("%w: %s", ErrUnsupportedVideoCodecForBrowser, videoCodec)
@@ -122,6 +135,11 @@ func isValidCombo(codecName string, format Container, supportedVideoCodecs []str
 			}
 			return isValidForContainer(format, validForHevc)
 		}
+	case Av1:
+		if supportMKV {
+			return isValidForContainer(format, validForAv1Mkv)
+		}
+		return isValidForContainer(format, validForAv1)
 	}
 	return false
 }
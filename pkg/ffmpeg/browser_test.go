@@ -0,0 +1,39 @@
+package ffmpeg
+
+import "testing"
+
+func TestIsStreamable(t *testing.T) {
+	tests := []struct {
+		videoCodec string
+		container  Container
+		want       bool
+	}{
+		{H264, Mp4, true},
+		{H264, Matroska, false},
+		{Vp9, Webm, false},
+		{Hevc, Mp4, false},
+		{Av1, Mp4, false},
+	}
+	for _, tt := range tests {
+		if got := IsStreamable(tt.videoCodec, Aac, tt.container) == nil; got != tt.want {
+			t.Errorf("IsStreamable(%s, %s) = %v, want %v", tt.videoCodec, tt.container, got, tt.want)
+		}
+	}
+}
+
+func TestIsHLSCopyable(t *testing.T) {
+	tests := []struct {
+		videoCodec string
+		want       bool
+	}{
+		{H264, true},
+		{Hevc, true},
+		{Av1, true},
+		{Vp9, false},
+	}
+	for _, tt := range tests {
+		if got := IsHLSCopyable(tt.videoCodec) == nil; got != tt.want {
+			t.Errorf("IsHLSCopyable(%s) = %v, want %v", tt.videoCodec, got, tt.want)
+		}
+	}
+}
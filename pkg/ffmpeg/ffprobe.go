@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -89,6 +90,11 @@ type VideoFile struct {
 	AudioStream *FFProbeStream
 	VideoStream *FFProbeStream
 
+	// AudioStreams and SubtitleStreams contain all of the audio and
+	// subtitle streams, in the order they appear in the file.
+	AudioStreams    []*FFProbeStream
+	SubtitleStreams []*FFProbeStream
+
 	Path      string
 	Title     string
 	Comment   string
@@ -264,6 +270,48 @@ func (f *FFProbe) GetReadFrameCount(path string) (int64, error) {
 	return fc.FrameCount, err
 }
 
+// GetKeyframes returns the presentation times in seconds of the keyframes of
+// the first video stream, in ascending order. The packets are read without
+// being decoded.
+func (f *FFProbe) GetKeyframes(path string) ([]float64, error) {
+	args := []string{
+		"-v", "error",
+		"-select_streams", "V:0",
+		"-show_entries", "packet=pts_time,flags",
+		"-of", "csv=print_section=0",
+		path,
+	}
+	out, err := stashExec.Command(f.path, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("FFProbe encountered an error reading the keyframes of <%s>: %w", path, err)
+	}
+
+	return parseKeyframes(out), nil
+}
+
+// parseKeyframes parses the pts_time,flags csv output of ffprobe.
+func parseKeyframes(out []byte) []float64 {
+	var ret []float64
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < 2 || !strings.Contains(fields[1], "K") {
+			continue
+		}
+
+		// pts_time is N/A if the packet has no pts
+		t, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+
+		ret = append(ret, t)
+	}
+
+	// packets are in decoding order
+	slices.Sort(ret)
+	return ret
+}
+
 func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	if probeJSON == nil {
 		return nil, fmt.Errorf("failed to get ffprobe json for <%s>", filePath)
@@ -301,6 +349,9 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 		result.AudioStream = audioStream
 	}
 
+	result.AudioStreams = result.getStreams("audio")
+	result.SubtitleStreams = result.getStreams("subtitle")
+
 	videoStream := result.getVideoStream()
 	if videoStream != nil {
 		result.VideoStream = videoStream
@@ -373,6 +424,17 @@ func (v *VideoFile) getAudioStream() *FFProbeStream {
 	return nil
 }
 
+// getStreams returns all streams of the given type, excluding cover art.
+func (v *VideoFile) getStreams(codecType string) []*FFProbeStream {
+	var ret []*FFProbeStream
+	for i, stream := range v.JSON.Streams {
+		if stream.CodecType == codecType && stream.Disposition.AttachedPic == 0 {
+			ret = append(ret, &v.JSON.Streams[i])
+		}
+	}
+	return ret
+}
+
 func (v *VideoFile) getVideoStream() *FFProbeStream {
 	index := v.getStreamIndex("video", v.JSON)
 	if index != -1 {
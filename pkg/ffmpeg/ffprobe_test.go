@@ -0,0 +1,71 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testProbeJSON = `{
+	"format": {
+		"format_name": "matroska,webm",
+		"duration": "10.0"
+	},
+	"streams": [
+		{"index": 0, "codec_type": "video", "codec_name": "h264", "width": 1920, "height": 1080},
+		{"index": 1, "codec_type": "audio", "codec_name": "aac", "tags": {"language": "eng"}, "disposition": {"default": 1}},
+		{"index": 2, "codec_type": "audio", "codec_name": "opus", "tags": {"language": "jpn", "title": "Commentary"}},
+		{"index": 3, "codec_type": "subtitle", "codec_name": "subrip", "tags": {"language": "eng"}, "disposition": {"forced": 1}},
+		{"index": 4, "codec_type": "video", "codec_name": "mjpeg", "disposition": {"attached_pic": 1}}
+	]
+}`
+
+func TestParseStreams(t *testing.T) {
+	var probeJSON FFProbeJSON
+	if err := json.Unmarshal([]byte(testProbeJSON), &probeJSON); err != nil {
+		t.Fatalf("error unmarshalling probe json: %v", err)
+	}
+
+	// parse stats the file
+	path := filepath.Join(t.TempDir(), "test.mkv")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("error writing test file: %v", err)
+	}
+
+	v, err := parse(path, &probeJSON)
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+
+	if v.AudioCodec != "aac" {
+		t.Errorf("AudioCodec = %q, want %q", v.AudioCodec, "aac")
+	}
+
+	if len(v.AudioStreams) != 2 {
+		t.Fatalf("len(AudioStreams) = %d, want 2", len(v.AudioStreams))
+	}
+	if got := v.AudioStreams[1]; got.CodecName != "opus" || got.Tags.Language != "jpn" || got.Tags.Title != "Commentary" {
+		t.Errorf("AudioStreams[1] = %+v, want opus jpn Commentary", got)
+	}
+	if v.AudioStreams[0].Disposition.Default != 1 {
+		t.Errorf("AudioStreams[0] is not the default stream")
+	}
+
+	if len(v.SubtitleStreams) != 1 {
+		t.Fatalf("len(SubtitleStreams) = %d, want 1", len(v.SubtitleStreams))
+	}
+	if got := v.SubtitleStreams[0]; got.CodecName != "subrip" || got.Disposition.Forced != 1 {
+		t.Errorf("SubtitleStreams[0] = %+v, want forced subrip", got)
+	}
+}
+
+func TestParseKeyframes(t *testing.T) {
+	out := "0.000000,K__\n0.041708,___\n2.002000,K__\nN/A,K__\n1.001000,K_\n4.004000,__\n"
+
+	want := []float64{0, 1.001, 2.002}
+	if got := parseKeyframes([]byte(out)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeyframes() = %v, want %v", got, want)
+	}
+}
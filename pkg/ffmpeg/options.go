@@ -96,6 +96,18 @@ func (a Args) MaxMuxingQueueSize(s int) Args {
 	return append(a, "-max_muxing_queue_size", fmt.Sprint(s))
 }
 
+// Map adds the map flag with the given stream specifier and returns the result.
+func (a Args) Map(stream string) Args {
+	return append(a, "-map", stream)
+}
+
+// MapVideoAudio maps the first video stream (excluding attached pictures) and
+// the audio stream with the given index among the input audio streams, and
+// returns the result. The audio stream is ignored if it does not exist.
+func (a Args) MapVideoAudio(audioStream int) Args {
+	return a.Map("0:V:0").Map(fmt.Sprintf("0:a:%d?", audioStream))
+}
+
 // SkipAudio adds the skip audio flag (-an) and returns the result.
 func (a Args) SkipAudio() Args {
 	return append(a, "-an")
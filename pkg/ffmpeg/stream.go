@@ -2,7 +2,9 @@ package ffmpeg
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -28,11 +30,18 @@ type StreamManager struct {
 	config      StreamManagerConfig
 	lockManager *fsutil.ReadLockManager
 
+	// cache is nil if the cache directory is not set
+	cache *transcodeCache
+
+	keyframes *keyframeCache
+
 	context    context.Context
 	cancelFunc context.CancelFunc
 
 	runningStreams map[string]*runningStream
 	streamsMutex   sync.Mutex
+
+	counters streamCounters
 }
 
 type StreamManagerConfig interface {
@@ -40,6 +49,7 @@ type StreamManagerConfig interface {
 	GetLiveTranscodeInputArgs() []string
 	GetLiveTranscodeOutputArgs() []string
 	GetTranscodeHardwareAcceleration() bool
+	GetTranscodeCacheMaxSize() int64
 }
 
 func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config StreamManagerConfig, lockManager *fsutil.ReadLockManager) *StreamManager {
@@ -60,6 +70,12 @@ func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config
 		runningStreams: make(map[string]*runningStream),
 	}
 
+	ret.keyframes = newKeyframeCache(ffprobe.GetKeyframes)
+
+	if cacheDir != "" {
+		ret.cache = newTranscodeCache(filepath.Join(cacheDir, transcodeCacheDir), config.GetTranscodeCacheMaxSize)
+	}
+
 	go func() {
 		for {
 			select {
@@ -74,10 +90,60 @@ func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config
 	return ret
 }
 
-// Shutdown shuts down the stream manager, killing any running transcoding processes and removing all cached files.
+// Shutdown shuts down the stream manager, killing any running transcoding processes.
+// The transcoded files are removed unless the transcode cache is enabled.
 func (sm *StreamManager) Shutdown() {
 	sm.cancelFunc()
-	sm.stopAndRemoveAll()
+	sm.stopAll()
+}
+
+// TranscodeCacheStatus returns the current contents of the transcode cache.
+func (sm *StreamManager) TranscodeCacheStatus() *TranscodeCacheStatus {
+	if sm.cache == nil {
+		return &TranscodeCacheStatus{
+			Entries: []*TranscodeCacheEntry{},
+		}
+	}
+
+	return sm.cache.status()
+}
+
+// ClearTranscodeCache removes all cached transcodes which are not currently in use.
+func (sm *StreamManager) ClearTranscodeCache() {
+	if sm.cache != nil {
+		sm.cache.clear()
+	}
+}
+
+// TrimRange is the range of a video file that is streamed, in seconds.
+// Streams start at Start, and end at End, or at the end of the file if End
+// is zero. Times in the stream are relative to Start.
+type TrimRange struct {
+	Start float64
+	End   float64
+}
+
+// IsZero returns true if the whole file is streamed.
+func (t TrimRange) IsZero() bool {
+	return t.Start == 0 && t.End == 0
+}
+
+// end returns the end of the range for a file with the given duration.
+func (t TrimRange) end(duration float64) float64 {
+	if t.End > 0 && t.End < duration {
+		return t.End
+	}
+	return duration
+}
+
+// duration returns the duration of the range for a file with the given duration.
+func (t TrimRange) duration(duration float64) float64 {
+	return max(t.end(duration)-t.Start, 0)
+}
+
+// key returns the part of a cache key identifying the range.
+func (t TrimRange) key() string {
+	return fmt.Sprintf("t%v-%v", t.Start, t.End)
 }
 
 type StreamRequestContext struct {
@@ -0,0 +1,96 @@
+package ffmpeg
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// keyframeCacheSize is the maximum number of files with cached keyframes.
+const keyframeCacheSize = 100
+
+type keyframeCacheEntry struct {
+	modTime  time.Time
+	accessed time.Time
+
+	// done is closed once keyframes and err are set
+	done      chan struct{}
+	keyframes []float64
+	err       error
+}
+
+// keyframeCache caches the keyframes of recently streamed files, so that the
+// keyframes are not read for every manifest and segment request. Entries are
+// keyed by path and modification time, so that changed files are read again.
+type keyframeCache struct {
+	read func(path string) ([]float64, error)
+
+	mutex   sync.Mutex
+	entries map[string]*keyframeCacheEntry
+}
+
+func newKeyframeCache(read func(path string) ([]float64, error)) *keyframeCache {
+	return &keyframeCache{
+		read:    read,
+		entries: make(map[string]*keyframeCacheEntry),
+	}
+}
+
+// get returns the keyframes of the video file, reading them if they are not
+// cached. Concurrent requests for the same file wait for a single read.
+func (c *keyframeCache) get(vf *models.VideoFile) ([]float64, error) {
+	c.mutex.Lock()
+	e := c.entries[vf.Path]
+	if e != nil && e.modTime.Equal(vf.ModTime) {
+		e.accessed = time.Now()
+		c.mutex.Unlock()
+
+		<-e.done
+		return e.keyframes, e.err
+	}
+
+	if e == nil {
+		c.evict()
+	}
+
+	e = &keyframeCacheEntry{
+		modTime:  vf.ModTime,
+		accessed: time.Now(),
+		done:     make(chan struct{}),
+	}
+	c.entries[vf.Path] = e
+	c.mutex.Unlock()
+
+	e.keyframes, e.err = c.read(vf.Path)
+	close(e.done)
+
+	// errors are not cached
+	if e.err != nil {
+		c.mutex.Lock()
+		if c.entries[vf.Path] == e {
+			delete(c.entries, vf.Path)
+		}
+		c.mutex.Unlock()
+	}
+
+	return e.keyframes, e.err
+}
+
+// evict removes the least recently accessed entry if the cache is full.
+// Assumes the lock is held.
+func (c *keyframeCache) evict() {
+	if len(c.entries) < keyframeCacheSize {
+		return
+	}
+
+	var oldest string
+	var oldestAccessed time.Time
+	for path, e := range c.entries {
+		if oldest == "" || e.accessed.Before(oldestAccessed) {
+			oldest = path
+			oldestAccessed = e.accessed
+		}
+	}
+	delete(c.entries, oldest)
+}
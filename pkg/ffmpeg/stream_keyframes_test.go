@@ -0,0 +1,81 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestKeyframeCache(t *testing.T) {
+	newVideoFile := func(path string, modTime time.Time) *models.VideoFile {
+		return &models.VideoFile{BaseFile: &models.BaseFile{
+			Path:     path,
+			DirEntry: models.DirEntry{ModTime: modTime},
+		}}
+	}
+
+	reads := 0
+	fail := false
+	c := newKeyframeCache(func(path string) ([]float64, error) {
+		reads++
+		if fail {
+			return nil, errors.New("read failed")
+		}
+		return []float64{0, float64(reads)}, nil
+	})
+
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	vf := newVideoFile("a.mp4", modTime)
+
+	get := func(vf *models.VideoFile, want []float64) {
+		t.Helper()
+		got, err := c.get(vf)
+		if err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("get() = %v, want %v", got, want)
+		}
+	}
+
+	get(vf, []float64{0, 1})
+	get(vf, []float64{0, 1})
+	if reads != 1 {
+		t.Errorf("reads = %d, want 1", reads)
+	}
+
+	// a modified file is read again
+	modified := newVideoFile("a.mp4", modTime.Add(time.Second))
+	get(modified, []float64{0, 2})
+	get(modified, []float64{0, 2})
+	if reads != 2 {
+		t.Errorf("reads = %d, want 2", reads)
+	}
+
+	// errors are not cached
+	fail = true
+	other := newVideoFile("b.mp4", modTime)
+	if _, err := c.get(other); err == nil {
+		t.Error("get() expected error")
+	}
+	fail = false
+	get(other, []float64{0, 4})
+
+	// the least recently accessed file is evicted when the cache is full
+	for i := len(c.entries); i < keyframeCacheSize; i++ {
+		get(newVideoFile(fmt.Sprintf("%d.mp4", i), modTime), []float64{0, float64(reads + 1)})
+	}
+	get(modified, []float64{0, 2})
+	reads = 0
+	get(newVideoFile("new.mp4", modTime), []float64{0, 1})
+	if len(c.entries) != keyframeCacheSize {
+		t.Errorf("len(entries) = %d, want %d", len(c.entries), keyframeCacheSize)
+	}
+	if _, found := c.entries[other.Path]; found {
+		t.Errorf("%s was not evicted", other.Path)
+	}
+}
@@ -44,10 +44,19 @@ const (
 	maxSegmentBuffer = 15
 
 	// maximum idle time between segment requests before
-	// stopping transcode and deleting cache folder
+	// stopping transcode and releasing the stream files to the cache
 	maxIdleTime = 30 * time.Second
 
-	resolutionParamKey = "resolution"
+	// hls_time used when splitting at every keyframe. It must be smaller
+	// than the shortest possible frame interval.
+	keyframeSegmentTime = "0.001"
+
+	// seek offset before a segment keyframe, to account for rounding of
+	// the keyframe times
+	keyframeSeekOffset = 0.001
+
+	resolutionParamKey  = "resolution"
+	audioStreamParamKey = "audio"
 	// TODO - setting the apikey in here isn't ideal
 	apiKeyParamKey = "apikey"
 )
@@ -55,8 +64,13 @@ const (
 type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
-	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string)
-	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
+	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, audioStream int, trim TrimRange)
+	// Args returns the output arguments of the transcode. tsOffset is the
+	// time in the video file that the stream starts at.
+	Args func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream int, tsOffset float64, outputDir string) Args
+	// KeyframeSegments is true if the video stream is copied, so that the
+	// segments start at the keyframes of the video instead of at fixed intervals.
+	KeyframeSegments bool
 }
 
 var (
@@ -64,25 +78,25 @@ var (
 		Name:          "hls",
 		SegmentType:   SegmentTypeTS,
 		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream int, tsOffset float64, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			args = append(args,
 				"-flags", "+cgop",
-				"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength),
+				"-force_key_frames", forceSegmentKeyframes(tsOffset),
 			)
 			args = args.VideoFilter(videoFilter)
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				args = args.MapVideoAudio(audioStream)
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
 				)
 			}
+			args = append(args, "-sn")
+			args = copyTimestamps(args, tsOffset)
 			args = append(args,
-				"-sn",
-				"-copyts",
-				"-avoid_negative_ts", "disabled",
 				"-f", "hls",
 				"-start_number", fmt.Sprint(segment),
 				"-hls_time", fmt.Sprint(segmentLength),
@@ -96,14 +110,23 @@ var (
 		},
 	}
 	StreamTypeHLSCopy = &StreamType{
-		Name:          "hls-copy",
-		SegmentType:   SegmentTypeTS,
-		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Name:             "hls-copy",
+		SegmentType:      SegmentTypeFMP4,
+		ServeManifest:    serveHLSCopyManifest,
+		KeyframeSegments: true,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream int, tsOffset float64, outputDir string) (args Args) {
+			// only generate the actual init segment (init.mp4)
+			// when generating the first segment
+			init := ".init"
+			if segment == 0 {
+				init = "init"
+			}
+
 			args = CodecInit(codec)
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				args = args.MapVideoAudio(audioStream)
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
@@ -111,15 +134,22 @@ var (
 			}
 			args = append(args,
 				"-sn",
-				"-copyts",
-				"-avoid_negative_ts", "disabled",
+				// discard the packets before the seek position, so that
+				// the first segment starts at the requested keyframe
+				"-copypriorss", "0",
+			)
+			args = copyTimestamps(args, tsOffset)
+			args = append(args,
 				"-f", "hls",
 				"-start_number", fmt.Sprint(segment),
-				"-hls_time", fmt.Sprint(segmentLength),
-				"-hls_flags", "split_by_time",
-				"-hls_segment_type", "mpegts",
+				// the video is copied, so segments can only be split at
+				// keyframes. Split at every keyframe so that the segments
+				// are the same regardless of where the transcode started.
+				"-hls_time", keyframeSegmentTime,
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", init+".mp4",
 				"-hls_playlist_type", "vod",
-				"-hls_segment_filename", filepath.Join(outputDir, ".%d.ts"),
+				"-hls_segment_filename", filepath.Join(outputDir, ".%d.m4s"),
 				filepath.Join(outputDir, "manifest.m3u8"),
 			)
 			return
@@ -129,7 +159,7 @@ var (
 		Name:          "dash-v",
 		SegmentType:   SegmentTypeWEBMVideo,
 		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream int, tsOffset float64, outputDir string) (args Args) {
 			// only generate the actual init segment (init_v.webm)
 			// when generating the first segment
 			init := ".init"
@@ -139,13 +169,12 @@ var (
 
 			args = CodecInit(codec)
 			args = append(args,
-				"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength),
+				"-force_key_frames", forceSegmentKeyframes(tsOffset),
 			)
 
 			args = args.VideoFilter(videoFilter)
+			args = copyTimestamps(args, tsOffset)
 			args = append(args,
-				"-copyts",
-				"-avoid_negative_ts", "disabled",
 				"-map", "0:v:0",
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
@@ -159,7 +188,7 @@ var (
 		Name:          "dash-a",
 		SegmentType:   SegmentTypeWEBMAudio,
 		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream int, tsOffset float64, outputDir string) (args Args) {
 			// only generate the actual init segment (init_a.webm)
 			// when generating the first segment
 			init := ".init"
@@ -170,9 +199,10 @@ var (
 				"-c:a", "libopus",
 				"-b:a", "96000",
 				"-ar", "48000",
-				"-copyts",
-				"-avoid_negative_ts", "disabled",
-				"-map", "0:a:0",
+			)
+			args = copyTimestamps(args, tsOffset)
+			args = append(args,
+				"-map", fmt.Sprintf("0:a:%d", audioStream),
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
 				"-audio_chunk_duration", fmt.Sprint(segmentLength*1000),
@@ -184,6 +214,29 @@ var (
 	}
 )
 
+// forceSegmentKeyframes returns the expression forcing a keyframe at the
+// start of each segment of a stream starting at tsOffset.
+func forceSegmentKeyframes(tsOffset float64) string {
+	if tsOffset == 0 {
+		return fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength)
+	}
+	return fmt.Sprintf("expr:gte(t,n_forced*%d+%v)", segmentLength, tsOffset)
+}
+
+// copyTimestamps keeps the timestamps of the input, so that the segments
+// line up regardless of where the transcode started. The timestamps are
+// shifted by tsOffset so that the stream starts at zero.
+func copyTimestamps(args Args, tsOffset float64) Args {
+	args = append(args,
+		"-copyts",
+		"-avoid_negative_ts", "disabled",
+	)
+	if tsOffset != 0 {
+		args = append(args, "-output_ts_offset", fmt.Sprint(-tsOffset))
+	}
+	return args
+}
+
 type SegmentType struct {
 	Format       string
 	MimeType     string
@@ -252,14 +305,41 @@ var (
 	}
 )
 
+var SegmentTypeFMP4 = &SegmentType{
+	Format:   "%d.m4s",
+	MimeType: MimeMp4Video,
+	MakeFilename: func(segment int) string {
+		if segment == -1 {
+			return "init.mp4"
+		} else {
+			return fmt.Sprintf("%d.m4s", segment)
+		}
+	},
+	ParseSegment: func(str string) (int, error) {
+		if str == "init" {
+			return -1, nil
+		} else {
+			segment, err := strconv.Atoi(str)
+			if err != nil || segment < 0 {
+				err = ErrInvalidSegment
+			}
+			return segment, err
+		}
+	},
+}
+
 var ErrInvalidSegment = errors.New("invalid segment")
 
 type StreamOptions struct {
 	StreamType *StreamType
 	VideoFile  *models.VideoFile
 	Resolution string
-	Hash       string
-	Segment    string
+	// AudioStream is the index of the audio stream to stream
+	AudioStream int
+	// Trim is the range of the video file to stream
+	Trim    TrimRange
+	Hash    string
+	Segment string
 }
 
 type transcodeProcess struct {
@@ -286,8 +366,14 @@ type runningStream struct {
 	dir              string
 	streamType       *StreamType
 	vf               *models.VideoFile
+	codec            VideoCodec
 	maxTranscodeSize int
+	audioStream      int
+	trim             TrimRange
 	outputDir        string
+	// start times of the segments in the video file,
+	// if the segments start at keyframes
+	segmentStarts []float64
 
 	waitingSegments []*waitingSegment
 	tp              *transcodeProcess
@@ -299,12 +385,25 @@ func (t StreamType) String() string {
 	return t.Name
 }
 
-func (t StreamType) FileDir(hash string, maxTranscodeSize int) string {
+// FileDir returns the name of the directory of the stream segments.
+// It is also used as the transcode cache key of the stream.
+func (t StreamType) FileDir(hash string, codec VideoCodec, maxTranscodeSize int, audioStream int, trim TrimRange) string {
+	var ret string
 	if maxTranscodeSize == 0 {
-		return fmt.Sprintf("%s_%s", hash, t)
+		ret = fmt.Sprintf("%s_%s_%s", hash, t, codec.CodeName)
 	} else {
-		return fmt.Sprintf("%s_%s_%d", hash, t, maxTranscodeSize)
+		ret = fmt.Sprintf("%s_%s_%s_%d", hash, t, codec.CodeName, maxTranscodeSize)
+	}
+
+	// the first audio stream keeps the original directory name
+	if audioStream != 0 {
+		ret = fmt.Sprintf("%s_a%d", ret, audioStream)
+	}
+	if !trim.IsZero() {
+		ret = fmt.Sprintf("%s_%s", ret, trim.key())
 	}
+
+	return ret
 }
 
 func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
@@ -333,14 +432,18 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 	args := Args{"-hide_banner"}
 	args = args.LogLevel(LogLevelError)
 
-	codec := HLSGetCodec(sm, s.streamType.Name)
+	codec := s.codec
 
 	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
-	if segment > 0 {
-		args = args.Seek(float64(segment * segmentLength))
+	start := s.segmentStart(segment)
+	if start > 0 {
+		args = args.Seek(start)
+	}
+	if s.trim.End > 0 {
+		args = args.Duration(max(s.trim.End-start, 0))
 	}
 
 	args = args.Input(s.vf.Path)
@@ -349,13 +452,35 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw)
 
-	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
+	// Safari only plays hevc in mp4 with the hvc1 tag
+	if codec == VideoCodecCopy && s.streamType.SegmentType == SegmentTypeFMP4 && s.vf.VideoCodec == Hevc {
+		args = append(args, "-tag:v", "hvc1")
+	}
+
+	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.audioStream, s.tsOffset(), s.outputDir)...)
 
 	args = append(args, extraOutputArgs...)
 
 	return args
 }
 
+// segmentStart returns the time in the video file to start transcoding
+// the segment at.
+func (s *runningStream) segmentStart(segment int) float64 {
+	if s.segmentStarts != nil {
+		return max(s.segmentStarts[segment]-keyframeSeekOffset, 0)
+	}
+	return s.trim.Start + float64(segment*segmentLength)
+}
+
+// tsOffset returns the time in the video file that the stream starts at.
+func (s *runningStream) tsOffset() float64 {
+	if s.segmentStarts != nil {
+		return s.segmentStarts[0]
+	}
+	return s.trim.Start
+}
+
 // checkSegments renames temp segments that have been completely generated.
 // existing segments are not replaced - if a segment is generated
 // multiple times, then only the first one is kept.
@@ -401,8 +526,65 @@ func (tp *transcodeProcess) checkSegments() {
 	}
 }
 
-func lastSegment(vf *models.VideoFile) int {
-	return int(math.Ceil(vf.Duration/segmentLength)) - 1
+func lastSegment(duration float64, segmentStarts []float64) int {
+	if segmentStarts != nil {
+		return len(segmentStarts) - 1
+	}
+	return int(math.Ceil(duration/segmentLength)) - 1
+}
+
+// keyframeSegmentStarts returns the start times of the segments of a stream
+// split at each keyframe. The first segment starts at the last keyframe at or
+// before the start of the trimmed range, or at the first keyframe. Negative
+// keyframe times are treated as zero. Keyframes at or after the end of the
+// trimmed range are ignored. A stream without keyframes in the trimmed range
+// is a single segment from the start of the range.
+func keyframeSegmentStarts(keyframes []float64, trim TrimRange) []float64 {
+	var ret []float64
+	for _, k := range keyframes {
+		k = max(k, 0)
+		if trim.End > 0 && k >= trim.End {
+			break
+		}
+
+		switch {
+		case k <= trim.Start:
+			ret = []float64{k}
+		case len(ret) > 0 && k <= ret[len(ret)-1]:
+			// ignore duplicate keyframe times
+		default:
+			ret = append(ret, k)
+		}
+	}
+
+	if len(ret) == 0 {
+		return []float64{trim.Start}
+	}
+	return ret
+}
+
+// segmentDurations returns the duration of each segment, given the segment
+// start times and the end time of the last segment.
+func segmentDurations(segmentStarts []float64, end float64) []float64 {
+	ret := make([]float64, len(segmentStarts))
+	for i, start := range segmentStarts {
+		segmentEnd := end
+		if i+1 < len(segmentStarts) {
+			segmentEnd = segmentStarts[i+1]
+		}
+		ret[i] = segmentEnd - start
+	}
+	return ret
+}
+
+// fixedSegmentDurations returns the duration of each segment of a stream
+// split at fixed intervals.
+func fixedSegmentDurations(duration float64) []float64 {
+	var ret []float64
+	for leftover := duration; leftover > 0; leftover -= segmentLength {
+		ret = append(ret, min(leftover, segmentLength))
+	}
+	return ret
 }
 
 func segmentExists(path string) bool {
@@ -410,15 +592,151 @@ func segmentExists(path string) bool {
 	return exists
 }
 
+// hlsRenditionBandwidth is the estimated peak bandwidth in bits per second
+// of each HLS rendition, including the audio stream.
+var hlsRenditionBandwidth = map[models.StreamingResolutionEnum]int{
+	models.StreamingResolutionEnumLow:        500000,
+	models.StreamingResolutionEnumStandard:   1400000,
+	models.StreamingResolutionEnumStandardHd: 3000000,
+	models.StreamingResolutionEnumFullHd:     6000000,
+	models.StreamingResolutionEnumFourK:      18000000,
+}
+
+// hlsRendition is a variant stream listed in the HLS master playlist.
+type hlsRendition struct {
+	resolution models.StreamingResolutionEnum
+	width      int
+	height     int
+	bandwidth  int
+}
+
+// hlsRenditions returns the renditions of the video file to list in the HLS
+// master playlist, from lowest to highest resolution. Renditions larger than
+// the video file or larger than maxTranscodeSize are not included.
+// The original resolution is included if it is not larger than maxTranscodeSize.
+func hlsRenditions(vf *models.VideoFile, maxTranscodeSize int) []hlsRendition {
+	videoSize := min(vf.Width, vf.Height)
+
+	var ret []hlsRendition
+	for _, res := range []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumLow,
+		models.StreamingResolutionEnumStandard,
+		models.StreamingResolutionEnumStandardHd,
+		models.StreamingResolutionEnumFullHd,
+		models.StreamingResolutionEnumFourK,
+	} {
+		size := res.GetMaxResolution()
+		if size >= videoSize || (maxTranscodeSize != 0 && size > maxTranscodeSize) {
+			break
+		}
+
+		width, height := scaledDimensions(vf.Width, vf.Height, size)
+		ret = append(ret, hlsRendition{
+			resolution: res,
+			width:      width,
+			height:     height,
+			bandwidth:  hlsRenditionBandwidth[res],
+		})
+	}
+
+	if maxTranscodeSize == 0 || videoSize <= maxTranscodeSize {
+		// estimate the bandwidth using the smallest rendition at least
+		// as large as the original
+		bandwidth := hlsRenditionBandwidth[models.StreamingResolutionEnumFourK]
+		for _, res := range []models.StreamingResolutionEnum{
+			models.StreamingResolutionEnumLow,
+			models.StreamingResolutionEnumStandard,
+			models.StreamingResolutionEnumStandardHd,
+			models.StreamingResolutionEnumFullHd,
+		} {
+			if videoSize <= res.GetMaxResolution() {
+				bandwidth = hlsRenditionBandwidth[res]
+				break
+			}
+		}
+
+		ret = append(ret, hlsRendition{
+			resolution: models.StreamingResolutionEnumOriginal,
+			width:      vf.Width,
+			height:     vf.Height,
+			bandwidth:  bandwidth,
+		})
+	}
+
+	return ret
+}
+
+// scaledDimensions returns the dimensions of a video scaled so that its
+// smaller dimension is maxSize, keeping the dimensions even.
+// The dimensions are returned unchanged if they are not larger than maxSize.
+func scaledDimensions(width, height, maxSize int) (int, int) {
+	videoSize := min(width, height)
+	if maxSize == 0 || maxSize >= videoSize {
+		return width, height
+	}
+
+	scale := func(v int) int {
+		return int(math.Round(float64(v)*float64(maxSize)/float64(videoSize)/2)) * 2
+	}
+
+	if width > height {
+		return scale(width), maxSize
+	}
+	return maxSize, scale(height)
+}
+
+// serveHLSMasterPlaylist serves a master playlist listing a rendition for each
+// available resolution, so that clients can switch between renditions
+// depending on their bandwidth. The URLs for the renditions are of the
+// form {r.URL}?resolution={resolution}. Renditions are only transcoded when
+// their segments are requested. All renditions share the same segment
+// boundaries, so clients can switch renditions at any segment.
+func serveHLSMasterPlaylist(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, audioStream int) {
+	baseUrl := *r.URL
+	baseUrl.RawQuery = ""
+	baseURL := baseUrl.String()
+
+	urlQuery := hlsSegmentQuery(r, "", audioStream)
+
+	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+
+	var buf bytes.Buffer
+
+	fmt.Fprint(&buf, "#EXTM3U\n")
+	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+	fmt.Fprint(&buf, "#EXT-X-INDEPENDENT-SEGMENTS\n")
+
+	for _, rendition := range hlsRenditions(vf, maxTranscodeSize) {
+		urlQuery.Set(resolutionParamKey, rendition.resolution.String())
+
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d", rendition.bandwidth)
+		if rendition.width > 0 && rendition.height > 0 {
+			fmt.Fprintf(&buf, ",RESOLUTION=%dx%d", rendition.width, rendition.height)
+		}
+		fmt.Fprint(&buf, "\n")
+		fmt.Fprintf(&buf, "%s?%s\n", baseURL, urlQuery.Encode())
+	}
+
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
 // serveHLSManifest serves a generated HLS playlist. The URLs for the segments
 // are of the form {r.URL}/%d.ts{?urlQuery} where %d is the segment index.
-func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+// If resolution is empty, then a master playlist listing the available
+// renditions is served instead.
+func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, audioStream int, trim TrimRange) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
 		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
 		return
 	}
 
+	if resolution == "" {
+		serveHLSMasterPlaylist(sm, w, r, vf, audioStream)
+		return
+	}
+
 	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
 	if err != nil {
 		logger.Warnf("[transcode] error generating HLS manifest: %v", err)
@@ -426,50 +744,98 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	baseUrl := *r.URL
-	baseUrl.RawQuery = ""
-	baseURL := baseUrl.String()
+	urlQuery := hlsSegmentQuery(r, resolution, audioStream)
+	durations := fixedSegmentDurations(trim.duration(probeResult.FileDuration))
+	serveHLSMediaPlaylist(w, r, SegmentTypeTS, urlQuery, durations)
+}
+
+// serveHLSCopyManifest serves a generated HLS playlist for a stream where the
+// video is copied. The segments start at the keyframes of the video. The URLs
+// for the segments are of the form {r.URL}/%d.m4s{?urlQuery}.
+func serveHLSCopyManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, audioStream int, trim TrimRange) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
+		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
+	if err != nil {
+		logger.Warnf("[transcode] error generating HLS manifest: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
+	keyframes, err := sm.keyframes.get(vf)
+	if err != nil {
+		logger.Warnf("[transcode] error generating HLS manifest: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the video is not scaled, so the resolution is ignored
+	urlQuery := hlsSegmentQuery(r, "", audioStream)
+	durations := segmentDurations(keyframeSegmentStarts(keyframes, trim), trim.end(probeResult.FileDuration))
+	serveHLSMediaPlaylist(w, r, SegmentTypeFMP4, urlQuery, durations)
+}
+
+// hlsSegmentQuery returns the query parameters to add to the segment URLs.
+func hlsSegmentQuery(r *http.Request, resolution string, audioStream int) url.Values {
 	urlQuery := url.Values{}
-	apikey := r.URL.Query().Get(apiKeyParamKey)
 
 	if resolution != "" {
 		urlQuery.Set(resolutionParamKey, resolution)
 	}
+	if r.URL.Query().Has(audioStreamParamKey) {
+		urlQuery.Set(audioStreamParamKey, strconv.Itoa(audioStream))
+	}
 
 	// TODO - this needs to be handled outside of this package
-	if apikey != "" {
+	if apikey := r.URL.Query().Get(apiKeyParamKey); apikey != "" {
 		urlQuery.Set(apiKeyParamKey, apikey)
 	}
 
+	return urlQuery
+}
+
+// serveHLSMediaPlaylist serves a media playlist with a segment for each of
+// the given durations. Fragmented MP4 segments are preceded by an init segment.
+func serveHLSMediaPlaylist(w http.ResponseWriter, r *http.Request, segmentType *SegmentType, urlQuery url.Values, durations []float64) {
+	baseUrl := *r.URL
+	baseUrl.RawQuery = ""
+	baseURL := baseUrl.String()
+
 	urlQueryString := ""
 	if len(urlQuery) > 0 {
 		urlQueryString = "?" + urlQuery.Encode()
 	}
 
+	targetDuration := segmentLength
+	for _, d := range durations {
+		targetDuration = max(targetDuration, int(math.Ceil(d)))
+	}
+
+	version := 3
+	if segmentType == SegmentTypeFMP4 {
+		version = 7
+	}
+
 	var buf bytes.Buffer
 
 	fmt.Fprint(&buf, "#EXTM3U\n")
 
-	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+	fmt.Fprintf(&buf, "#EXT-X-VERSION:%d\n", version)
 	fmt.Fprint(&buf, "#EXT-X-MEDIA-SEQUENCE:0\n")
-	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", segmentLength)
+	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", targetDuration)
 	fmt.Fprint(&buf, "#EXT-X-PLAYLIST-TYPE:VOD\n")
 
-	leftover := probeResult.FileDuration
-	segment := 0
-
-	for leftover > 0 {
-		thisLength := float64(segmentLength)
-		if leftover < thisLength {
-			thisLength = leftover
-		}
-
-		fmt.Fprintf(&buf, "#EXTINF:%f,\n", thisLength)
-		fmt.Fprintf(&buf, "%s/%d.ts%s\n", baseURL, segment, urlQueryString)
+	if segmentType == SegmentTypeFMP4 {
+		fmt.Fprintf(&buf, "#EXT-X-MAP:URI=\"%s/%s%s\"\n", baseURL, segmentType.MakeFilename(-1), urlQueryString)
+	}
 
-		leftover -= thisLength
-		segment++
+	for segment, d := range durations {
+		fmt.Fprintf(&buf, "#EXTINF:%f,\n", d)
+		fmt.Fprintf(&buf, "%s/%s%s\n", baseURL, segmentType.MakeFilename(segment), urlQueryString)
 	}
 
 	fmt.Fprint(&buf, "#EXT-X-ENDLIST\n")
@@ -479,7 +845,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 }
 
 // serveDASHManifest serves a generated DASH manifest.
-func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, audioStream int, trim TrimRange) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
 		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
@@ -556,7 +922,7 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		urlQueryString = "?" + urlQuery.Encode()
 	}
 
-	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
+	mediaDuration := mpd.Duration(time.Duration(trim.duration(probeResult.FileDuration) * float64(time.Second)))
 	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
 
 	baseUrl := r.URL.JoinPath("/")
@@ -569,9 +935,7 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
 
 	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
-		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
-		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
-		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
+		addDASHAudioAdaptationSets(m, vf, urlQuery, audioStream)
 	}
 
 	var buf bytes.Buffer
@@ -581,8 +945,47 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string) {
-	streamType.ServeManifest(sm, w, r, vf, resolution)
+// addDASHAudioAdaptationSets adds an audio adaptation set for each audio
+// stream of the file. The requested audio stream is listed first, so that
+// players select it by default.
+func addDASHAudioAdaptationSets(m *mpd.MPD, vf *models.VideoFile, urlQuery url.Values, audioStream int) {
+	streams := []models.VideoFileStream{{Index: audioStream}}
+	if s := vf.GetAudioStream(audioStream); s != nil {
+		streams = []models.VideoFileStream{*s}
+		for _, s := range vf.AudioStreams {
+			if s.Index != audioStream {
+				streams = append(streams, s)
+			}
+		}
+	}
+
+	for i, s := range streams {
+		lang := s.Language
+		if lang == "" {
+			lang = "und"
+		}
+
+		query := url.Values{}
+		for k, v := range urlQuery {
+			query[k] = v
+		}
+		if len(streams) > 1 || s.Index != 0 {
+			query.Set(audioStreamParamKey, strconv.Itoa(s.Index))
+		}
+
+		urlQueryString := ""
+		if len(query) > 0 {
+			urlQueryString = "?" + query.Encode()
+		}
+
+		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, lang)
+		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
+		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", strconv.Itoa(i+1))
+	}
+}
+
+func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string, audioStream int, trim TrimRange) {
+	streamType.ServeManifest(sm, w, r, vf, resolution, audioStream, trim)
 }
 
 func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Request, segment *waitingSegment) {
@@ -591,6 +994,7 @@ func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Requ
 		break
 	case err := <-segment.available:
 		if err == nil {
+			sm.counters.addSegmentWait(time.Since(segment.accessed))
 			logger.Tracef("[transcode] streaming segment file %s", segment.file)
 			w.Header().Set("Content-Type", segment.segmentType.MimeType)
 			utils.ServeStaticFile(w, r, segment.path)
@@ -615,20 +1019,36 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 
 	streamType := options.StreamType
 
-	segment, err := streamType.SegmentType.ParseSegment(options.Segment)
-	// error if segment is past the end of the video
-	if err != nil || segment > lastSegment(options.VideoFile) {
-		http.Error(w, "invalid segment", http.StatusBadRequest)
-		return
-	}
-
 	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 	if options.Resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(options.Resolution).GetMaxResolution()
 	}
+	if streamType.KeyframeSegments {
+		// the video is copied, so it is never scaled
+		maxTranscodeSize = 0
+	}
+
+	codec := HLSGetCodec(sm, streamType.Name)
+	dir := options.StreamType.FileDir(options.Hash, codec, maxTranscodeSize, options.AudioStream, options.Trim)
+	outputDir := sm.cache.path(dir)
+
+	var segmentStarts []float64
+	if streamType.KeyframeSegments {
+		var err error
+		segmentStarts, err = sm.getSegmentStarts(dir, options.VideoFile, options.Trim)
+		if err != nil {
+			logger.Errorf("[transcode] error getting keyframes: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
-	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize)
-	outputDir := filepath.Join(sm.cacheDir, dir)
+	segment, err := streamType.SegmentType.ParseSegment(options.Segment)
+	// error if segment is past the end of the video
+	if err != nil || segment > lastSegment(options.Trim.duration(options.VideoFile.Duration), segmentStarts) {
+		http.Error(w, "invalid segment", http.StatusBadRequest)
+		return
+	}
 
 	name := streamType.SegmentType.MakeFilename(segment)
 	file := filepath.Join(dir, name)
@@ -637,12 +1057,19 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 
 	stream := sm.runningStreams[dir]
 	if stream == nil {
+		// keep the segments in the cache while the stream is running
+		sm.cache.acquire(dir)
+
 		stream = &runningStream{
 			dir:              dir,
 			streamType:       options.StreamType,
 			vf:               options.VideoFile,
+			codec:            codec,
 			maxTranscodeSize: maxTranscodeSize,
+			audioStream:      options.AudioStream,
+			trim:             options.Trim,
 			outputDir:        outputDir,
+			segmentStarts:    segmentStarts,
 
 			// initialize to cap 10 to avoid reallocations
 			waitingSegments: make([]*waitingSegment, 0, 10),
@@ -660,7 +1087,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		segmentType: streamType.SegmentType,
 		idx:         segment,
 		file:        file,
-		path:        filepath.Join(sm.cacheDir, file),
+		path:        sm.cache.path(file),
 		accessed:    now,
 		available:   make(chan error, 1),
 	}
@@ -671,6 +1098,26 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 	sm.serveWaitingSegment(w, r, waitingSegment)
 }
 
+// getSegmentStarts returns the segment start times of a stream split at
+// keyframes. The start times of a running stream are reused, otherwise the
+// cached keyframes of the video file are used.
+func (sm *StreamManager) getSegmentStarts(dir string, vf *models.VideoFile, trim TrimRange) ([]float64, error) {
+	sm.streamsMutex.Lock()
+	stream := sm.runningStreams[dir]
+	sm.streamsMutex.Unlock()
+
+	if stream != nil {
+		return stream.segmentStarts, nil
+	}
+
+	keyframes, err := sm.keyframes.get(vf)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyframeSegmentStarts(keyframes, trim), nil
+}
+
 // assume lock is held
 func (sm *StreamManager) startTranscode(stream *runningStream, segment int, done chan<- error) {
 	// generate segment 0 if init segment requested
@@ -781,11 +1228,12 @@ func (sm *StreamManager) stopTranscode(stream *runningStream) {
 
 func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
 	if len(stream.waitingSegments) == 0 && stream.lastAccessed.Add(maxIdleTime).Before(now) {
-		// Stream expired. Cancel the transcode process and delete the files
-		logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and removing files", stream.dir)
+		// Stream expired. Cancel the transcode process and return the files
+		// to the cache, which removes them if the cache is disabled
+		logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode", stream.dir)
 
 		sm.stopTranscode(stream)
-		sm.removeTranscodeFiles(stream)
+		sm.cache.release(stream.dir)
 
 		delete(sm.runningStreams, stream.dir)
 		return
@@ -869,16 +1317,9 @@ func (sm *StreamManager) monitorStreams() {
 	}
 }
 
-// assume lock is held
-func (sm *StreamManager) removeTranscodeFiles(stream *runningStream) {
-	path := stream.outputDir
-	if err := os.RemoveAll(path); err != nil {
-		logger.Warnf("[transcode] error removing segment directory %s: %v", path, err)
-	}
-}
-
-// stopAndRemoveAll stops all current streams and removes all cache files
-func (sm *StreamManager) stopAndRemoveAll() {
+// stopAll stops all current streams. The stream files are kept if the
+// transcode cache is enabled, otherwise they are removed.
+func (sm *StreamManager) stopAll() {
 	sm.streamsMutex.Lock()
 	defer sm.streamsMutex.Unlock()
 
@@ -889,7 +1330,7 @@ func (sm *StreamManager) stopAndRemoveAll() {
 			}
 		}
 		sm.stopTranscode(stream)
-		sm.removeTranscodeFiles(stream)
+		sm.cache.release(stream.dir)
 	}
 
 	// ensure nothing else can use the map
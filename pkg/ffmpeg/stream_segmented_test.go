@@ -0,0 +1,171 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestHLSRenditions(t *testing.T) {
+	const (
+		low        = models.StreamingResolutionEnumLow
+		standard   = models.StreamingResolutionEnumStandard
+		standardHD = models.StreamingResolutionEnumStandardHd
+		fullHD     = models.StreamingResolutionEnumFullHd
+		original   = models.StreamingResolutionEnumOriginal
+	)
+
+	tests := []struct {
+		name             string
+		width            int
+		height           int
+		maxTranscodeSize int
+		want             []hlsRendition
+	}{
+		{
+			"1080p uncapped",
+			1920,
+			1080,
+			0,
+			[]hlsRendition{
+				{low, 426, 240, hlsRenditionBandwidth[low]},
+				{standard, 854, 480, hlsRenditionBandwidth[standard]},
+				{standardHD, 1280, 720, hlsRenditionBandwidth[standardHD]},
+				{original, 1920, 1080, hlsRenditionBandwidth[fullHD]},
+			},
+		},
+		{
+			"1080p capped at 720p",
+			1920,
+			1080,
+			720,
+			[]hlsRendition{
+				{low, 426, 240, hlsRenditionBandwidth[low]},
+				{standard, 854, 480, hlsRenditionBandwidth[standard]},
+				{standardHD, 1280, 720, hlsRenditionBandwidth[standardHD]},
+			},
+		},
+		{
+			"portrait 720p",
+			720,
+			1280,
+			0,
+			[]hlsRendition{
+				{low, 240, 426, hlsRenditionBandwidth[low]},
+				{standard, 480, 854, hlsRenditionBandwidth[standard]},
+				{original, 720, 1280, hlsRenditionBandwidth[standardHD]},
+			},
+		},
+		{
+			"smaller than lowest rendition",
+			320,
+			180,
+			0,
+			[]hlsRendition{
+				{original, 320, 180, hlsRenditionBandwidth[low]},
+			},
+		},
+		{
+			"unknown dimensions",
+			0,
+			0,
+			0,
+			[]hlsRendition{
+				{original, 0, 0, hlsRenditionBandwidth[low]},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vf := &models.VideoFile{
+				Width:  tt.width,
+				Height: tt.height,
+			}
+			if got := hlsRenditions(vf, tt.maxTranscodeSize); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hlsRenditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyframeSegmentDurations(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyframes     []float64
+		trim          TrimRange
+		duration      float64
+		wantStarts    []float64
+		wantDurations []float64
+	}{
+		{
+			"keyframe at start",
+			[]float64{0, 2.5, 6},
+			TrimRange{},
+			10,
+			[]float64{0, 2.5, 6},
+			[]float64{2.5, 3.5, 4},
+		},
+		{
+			"negative first keyframe",
+			[]float64{-0.5, 4},
+			TrimRange{},
+			5,
+			[]float64{0, 4},
+			[]float64{4, 1},
+		},
+		{
+			"first keyframe after start",
+			[]float64{1.5, 4},
+			TrimRange{},
+			6,
+			[]float64{1.5, 4},
+			[]float64{2.5, 2},
+		},
+		{
+			"no keyframes",
+			nil,
+			TrimRange{},
+			3,
+			[]float64{0},
+			[]float64{3},
+		},
+		{
+			"trimmed",
+			[]float64{0, 2.5, 6, 8},
+			TrimRange{Start: 3, End: 7},
+			10,
+			[]float64{2.5, 6},
+			[]float64{3.5, 1},
+		},
+		{
+			"trimmed at keyframe",
+			[]float64{0, 2.5, 6},
+			TrimRange{Start: 2.5},
+			10,
+			[]float64{2.5, 6},
+			[]float64{3.5, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			starts := keyframeSegmentStarts(tt.keyframes, tt.trim)
+			if !reflect.DeepEqual(starts, tt.wantStarts) {
+				t.Errorf("keyframeSegmentStarts() = %v, want %v", starts, tt.wantStarts)
+			}
+
+			if got := segmentDurations(starts, tt.trim.end(tt.duration)); !reflect.DeepEqual(got, tt.wantDurations) {
+				t.Errorf("segmentDurations() = %v, want %v", got, tt.wantDurations)
+			}
+		})
+	}
+}
+
+func TestFixedSegmentDurations(t *testing.T) {
+	want := []float64{2, 2, 1}
+	if got := fixedSegmentDurations(5); !reflect.DeepEqual(got, want) {
+		t.Errorf("fixedSegmentDurations() = %v, want %v", got, want)
+	}
+}
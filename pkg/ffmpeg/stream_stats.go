@@ -0,0 +1,63 @@
+package ffmpeg
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// StreamStats contains statistics about the streams served by a StreamManager.
+type StreamStats struct {
+	// SegmentedStreams is the number of running HLS/DASH streams.
+	SegmentedStreams int
+	// TranscodeProcesses is the number of running ffmpeg processes, including
+	// those used for HLS/DASH streams and direct transcodes.
+	TranscodeProcesses int
+	// WaitingSegments is the number of segment requests waiting for the
+	// segment to be generated.
+	WaitingSegments int
+	// DirectTranscodes is the number of running direct transcode streams.
+	DirectTranscodes int
+
+	// SegmentWaits is the number of segment requests served since the
+	// manager was started.
+	SegmentWaits int64
+	// SegmentWaitTime is the total time spent waiting for the served
+	// segments to be generated.
+	SegmentWaitTime time.Duration
+}
+
+type streamCounters struct {
+	directTranscodes atomic.Int64
+	segmentWaits     atomic.Int64
+	segmentWaitTime  atomic.Int64
+}
+
+func (c *streamCounters) addSegmentWait(d time.Duration) {
+	c.segmentWaits.Add(1)
+	c.segmentWaitTime.Add(int64(d))
+}
+
+// Stats returns statistics about the currently running streams.
+func (sm *StreamManager) Stats() StreamStats {
+	sm.streamsMutex.Lock()
+	defer sm.streamsMutex.Unlock()
+
+	directTranscodes := int(sm.counters.directTranscodes.Load())
+
+	ret := StreamStats{
+		SegmentedStreams:   len(sm.runningStreams),
+		TranscodeProcesses: directTranscodes,
+		DirectTranscodes:   directTranscodes,
+		SegmentWaits:       sm.counters.segmentWaits.Load(),
+		SegmentWaitTime:    time.Duration(sm.counters.segmentWaitTime.Load()),
+	}
+
+	for _, stream := range sm.runningStreams {
+		if stream.tp != nil {
+			ret.TranscodeProcesses++
+		}
+		ret.WaitingSegments += len(stream.waitingSegments)
+	}
+
+	return ret
+}
@@ -3,8 +3,10 @@ package ffmpeg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
 	"strings"
 	"syscall"
@@ -12,11 +14,14 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type StreamFormat struct {
 	MimeType string
-	Args     func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) Args
+	// Extension is the file extension used for cached transcodes
+	Extension string
+	Args      func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) Args
 }
 
 func CodecInit(codec VideoCodec) (args Args) {
@@ -94,7 +99,8 @@ func CodecInit(codec VideoCodec) (args Args) {
 
 var (
 	StreamTypeMP4 = StreamFormat{
-		MimeType: MimeMp4Video,
+		MimeType:  MimeMp4Video,
+		Extension: "mp4",
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			args = append(args, "-movflags", "frag_keyframe+empty_moov")
@@ -109,7 +115,8 @@ var (
 		},
 	}
 	StreamTypeWEBM = StreamFormat{
-		MimeType: MimeWebmVideo,
+		MimeType:  MimeWebmVideo,
+		Extension: "webm",
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			args = args.VideoFilter(videoFilter)
@@ -123,7 +130,8 @@ var (
 		},
 	}
 	StreamTypeMKV = StreamFormat{
-		MimeType: MimeMkvVideo,
+		MimeType:  MimeMkvVideo,
+		Extension: "mkv",
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			if videoOnly {
@@ -146,7 +154,40 @@ type TranscodeOptions struct {
 	StreamType StreamFormat
 	VideoFile  *models.VideoFile
 	Resolution string
-	StartTime  float64
+	// StartTime is relative to the start of Trim
+	StartTime float64
+	// Trim is the range of the video file to transcode
+	Trim TrimRange
+	// AudioStream is the index of the audio stream to transcode
+	AudioStream int
+	// Hash is used to cache the transcode. The transcode is not cached if empty.
+	Hash string
+}
+
+func (o TranscodeOptions) maxTranscodeSize(sm *StreamManager) int {
+	if o.Resolution != "" {
+		return models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
+	}
+	return sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+}
+
+// cacheKey returns the name of the transcoded file in the transcode cache.
+func (o TranscodeOptions) cacheKey(sm *StreamManager) string {
+	maxTranscodeSize := o.maxTranscodeSize(sm)
+	codec := o.FileGetCodec(sm, maxTranscodeSize)
+
+	ret := fmt.Sprintf("%s_%s_%s", o.Hash, o.StreamType.Extension, codec.CodeName)
+	if maxTranscodeSize != 0 {
+		ret = fmt.Sprintf("%s_%d", ret, maxTranscodeSize)
+	}
+	if o.AudioStream != 0 {
+		ret = fmt.Sprintf("%s_a%d", ret, o.AudioStream)
+	}
+	if !o.Trim.IsZero() {
+		ret = fmt.Sprintf("%s_%s", ret, o.Trim.key())
+	}
+
+	return ret + "." + o.StreamType.Extension
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -185,10 +226,7 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 }
 
 func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
-	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
-	if o.Resolution != "" {
-		maxTranscodeSize = models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
-	}
+	maxTranscodeSize := o.maxTranscodeSize(sm)
 	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
 	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
 
@@ -201,8 +239,12 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
-	if o.StartTime != 0 {
-		args = args.Seek(o.StartTime)
+	start := o.Trim.Start + o.StartTime
+	if start != 0 {
+		args = args.Seek(start)
+	}
+	if o.Trim.End > 0 {
+		args = args.Duration(max(o.Trim.End-start, 0))
 	}
 
 	args = args.Input(o.VideoFile.Path)
@@ -211,6 +253,10 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw)
 
+	if !videoOnly {
+		args = args.MapVideoAudio(o.AudioStream)
+	}
+
 	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly)...)
 
 	args = append(args, extraOutputArgs...)
@@ -221,6 +267,22 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 }
 
 func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request, options TranscodeOptions) {
+	// only complete transcodes are cached
+	cacheKey := ""
+	if sm.cache != nil && sm.cache.enabled() && options.Hash != "" && options.StartTime == 0 {
+		cacheKey = options.cacheKey(sm)
+		sm.cache.acquire(cacheKey)
+		defer sm.cache.release(cacheKey)
+
+		path := sm.cache.path(cacheKey)
+		if _, err := os.Stat(path); err == nil {
+			logger.Tracef("[transcode] serving cached transcode %s", cacheKey)
+			w.Header().Set("Content-Type", options.StreamType.MimeType)
+			utils.ServeStaticFile(w, r, path)
+			return
+		}
+	}
+
 	streamRequestCtx := NewStreamRequestContext(w, r)
 	lockCtx := sm.lockManager.ReadLock(streamRequestCtx, options.VideoFile.Path)
 
@@ -228,7 +290,7 @@ func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request,
 	// due to ERR_INCOMPLETE_CHUNKED_ENCODING
 	// We trust that the request context will be closed, so we don't need to call Cancel on the returned context here.
 
-	handler, err := sm.getTranscodeStream(lockCtx, options)
+	handler, err := sm.getTranscodeStream(lockCtx, options, cacheKey)
 
 	if err != nil {
 		// don't log context canceled errors
@@ -242,10 +304,16 @@ func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	sm.counters.directTranscodes.Add(1)
+	defer sm.counters.directTranscodes.Add(-1)
+
 	handler(w, r)
 }
 
-func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options TranscodeOptions) (http.HandlerFunc, error) {
+// getTranscodeStream starts the transcode process and returns a handler
+// which streams the output. If cacheKey is set, the output is also written
+// to the transcode cache, and kept if the transcode completes successfully.
+func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options TranscodeOptions, cacheKey string) (http.HandlerFunc, error) {
 	args := options.makeStreamArgs(sm)
 	cmd := sm.encoder.Command(ctx, args)
 
@@ -266,6 +334,8 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 	}
 	ctx.AttachCommand(cmd)
 
+	cmdDone := make(chan error, 1)
+
 	// stderr must be consumed or the process deadlocks
 	go func() {
 		errStr, _ := io.ReadAll(stderr)
@@ -286,6 +356,8 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 		if err != nil && !errors.As(err, &exitError) {
 			logger.Errorf("[transcode] ffmpeg error when running command <%s>: %v", strings.Join(cmd.Args, " "), err)
 		}
+
+		cmdDone <- err
 	}()
 
 	mimeType := options.StreamType.MimeType
@@ -296,12 +368,68 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 
 		// process killing should be handled by command context
 
-		_, err := io.Copy(w, stdout)
+		var cacheFile *os.File
+		var out io.Writer = w
+		if cacheKey != "" {
+			cacheFile = sm.createCacheFile(cacheKey)
+			if cacheFile != nil {
+				out = io.MultiWriter(w, cacheFile)
+			}
+		}
+
+		_, err := io.Copy(out, stdout)
 		if err != nil && !errors.Is(err, syscall.EPIPE) && !errors.Is(err, syscall.ECONNRESET) {
 			logger.Errorf("[transcode] error serving transcoded video file: %v", err)
 		}
 
 		w.(http.Flusher).Flush()
+
+		if cacheFile != nil {
+			// only keep the file if the whole output was written
+			complete := err == nil && <-cmdDone == nil
+			sm.commitCacheFile(cacheFile, cacheKey, complete)
+		}
 	}
 	return handler, nil
 }
+
+// createCacheFile creates the temporary file that a transcode is written to
+// before it is added to the transcode cache. Returns nil on error.
+func (sm *StreamManager) createCacheFile(cacheKey string) *os.File {
+	if err := os.MkdirAll(sm.cache.dir, os.ModePerm); err != nil {
+		logger.Warnf("[transcode] error creating transcode cache directory: %v", err)
+		return nil
+	}
+
+	// temporary files are hidden so that they are ignored when loading the cache
+	f, err := os.CreateTemp(sm.cache.dir, "."+cacheKey+".*")
+	if err != nil {
+		logger.Warnf("[transcode] error creating transcode cache file: %v", err)
+		return nil
+	}
+
+	return f
+}
+
+// commitCacheFile moves a completed temporary transcode file into the
+// transcode cache. Incomplete files are removed.
+func (sm *StreamManager) commitCacheFile(f *os.File, cacheKey string, complete bool) {
+	tmpPath := f.Name()
+	if err := f.Close(); err != nil {
+		logger.Warnf("[transcode] error closing transcode cache file: %v", err)
+		complete = false
+	}
+
+	if complete {
+		if err := os.Rename(tmpPath, sm.cache.path(cacheKey)); err != nil {
+			logger.Warnf("[transcode] error adding %s to transcode cache: %v", cacheKey, err)
+		} else {
+			logger.Debugf("[transcode] added %s to transcode cache", cacheKey)
+			return
+		}
+	}
+
+	if err := os.Remove(tmpPath); err != nil {
+		logger.Warnf("[transcode] error removing %s: %v", tmpPath, err)
+	}
+}
@@ -0,0 +1,39 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// textSubtitleCodecs are the subtitle codecs that can be converted to WebVTT.
+// Image based subtitles such as dvd_subtitle and hdmv_pgs_subtitle cannot be converted.
+var textSubtitleCodecs = []string{
+	"subrip",
+	"srt",
+	"ass",
+	"ssa",
+	"webvtt",
+	"mov_text",
+	"text",
+}
+
+// IsTextSubtitleCodec returns true if the subtitle codec is text based,
+// and can be converted to WebVTT.
+func IsTextSubtitleCodec(codec string) bool {
+	return slices.Contains(textSubtitleCodecs, codec)
+}
+
+// ExtractSubtitleVTT extracts the subtitle stream with the given index among
+// the subtitle streams of the input file, and returns it in WebVTT format.
+func (f *FFMpeg) ExtractSubtitleVTT(ctx context.Context, input string, subtitleStream int) ([]byte, error) {
+	var args Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(LogLevelError)
+	args = args.Input(input)
+	args = args.Map(fmt.Sprintf("0:s:%d", subtitleStream))
+	args = args.Format(FormatWebVTT)
+	args = args.Output("pipe:")
+
+	return f.GenerateOutput(ctx, args, nil)
+}
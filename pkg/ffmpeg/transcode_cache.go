@@ -0,0 +1,274 @@
+package ffmpeg
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// transcodeCacheDir is the directory within the cache directory
+// where the live transcodes are stored.
+const transcodeCacheDir = "transcodes"
+
+// TranscodeCacheEntry is a cached live transcode. An entry is either a
+// directory of stream segments or a full transcoded file.
+type TranscodeCacheEntry struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastAccessed time.Time `json:"last_accessed"`
+	// InUse is true if the entry is currently being generated or served
+	InUse bool `json:"in_use"`
+}
+
+// TranscodeCacheStatus describes the contents of the transcode cache.
+type TranscodeCacheStatus struct {
+	Enabled bool                   `json:"enabled"`
+	Size    int64                  `json:"size"`
+	MaxSize int64                  `json:"max_size"`
+	Entries []*TranscodeCacheEntry `json:"entries"`
+}
+
+type transcodeCacheEntry struct {
+	size     int64
+	accessed time.Time
+	users    int
+}
+
+// transcodeCache stores live transcodes on disk, keyed by the file hash,
+// resolution and codec of the transcode. When the total size of the cache
+// exceeds the configured maximum, the least recently used entries which are
+// not in use are removed.
+type transcodeCache struct {
+	dir     string
+	maxSize func() int64
+
+	mutex   sync.Mutex
+	entries map[string]*transcodeCacheEntry
+}
+
+// newTranscodeCache returns a transcode cache stored in dir, populated
+// with the entries already in the directory. maxSize returns the current
+// maximum size in bytes - a maximum of zero disables the cache.
+func newTranscodeCache(dir string, maxSize func() int64) *transcodeCache {
+	ret := &transcodeCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*transcodeCacheEntry),
+	}
+
+	ret.load()
+
+	return ret
+}
+
+func (c *transcodeCache) enabled() bool {
+	return c.maxSize() > 0
+}
+
+func (c *transcodeCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// load adds the existing entries in the cache directory, using the
+// modification time as the last accessed time. Incomplete temporary files
+// are removed.
+func (c *transcodeCache) load() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warnf("[transcode] error reading transcode cache directory: %v", err)
+		}
+		return
+	}
+
+	for _, e := range dirEntries {
+		key := e.Name()
+		if strings.HasPrefix(key, ".") {
+			c.remove(key)
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			logger.Warnf("[transcode] error reading transcode cache entry %s: %v", key, err)
+			continue
+		}
+
+		c.entries[key] = &transcodeCacheEntry{
+			size:     pathSize(c.path(key)),
+			accessed: info.ModTime(),
+		}
+	}
+
+	c.evict()
+}
+
+// pathSize returns the total size of the files in path.
+func pathSize(path string) int64 {
+	var ret int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				ret += info.Size()
+			}
+		}
+		return nil
+	})
+	return ret
+}
+
+// acquire marks the entry with the given key as in use, creating it if
+// needed, so that it is not evicted. Returns true if the entry already
+// existed.
+func (c *transcodeCache) acquire(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e := c.entries[key]
+	exists := e != nil
+	if !exists {
+		e = &transcodeCacheEntry{}
+		c.entries[key] = e
+	}
+
+	e.users++
+	e.accessed = time.Now()
+
+	return exists
+}
+
+// release marks the entry with the given key as no longer in use by the
+// caller and updates its size. If the cache is disabled, the entry is
+// removed once it is no longer in use. Otherwise, least recently used
+// entries are evicted if the cache is too large.
+func (c *transcodeCache) release(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e := c.entries[key]
+	if e == nil {
+		return
+	}
+
+	if e.users > 0 {
+		e.users--
+	}
+	e.accessed = time.Now()
+
+	path := c.path(key)
+	if _, err := os.Stat(path); err != nil {
+		// nothing was stored
+		if e.users == 0 {
+			delete(c.entries, key)
+		}
+		return
+	}
+
+	e.size = pathSize(path)
+
+	// persist the access time for the next startup
+	if err := os.Chtimes(path, e.accessed, e.accessed); err != nil {
+		logger.Warnf("[transcode] error updating access time of %s: %v", path, err)
+	}
+
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache is within
+// the maximum size. All unused entries are removed if the cache is disabled.
+// assume lock is held
+func (c *transcodeCache) evict() {
+	maxSize := c.maxSize()
+
+	var size int64
+	for _, e := range c.entries {
+		size += e.size
+	}
+
+	if maxSize > 0 && size <= maxSize {
+		return
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for key, e := range c.entries {
+		if e.users == 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		return c.entries[a].accessed.Compare(c.entries[b].accessed)
+	})
+
+	for _, key := range keys {
+		if maxSize > 0 && size <= maxSize {
+			break
+		}
+
+		logger.Debugf("[transcode] removing %s from transcode cache", key)
+		size -= c.entries[key].size
+		c.remove(key)
+	}
+}
+
+// assume lock is held
+func (c *transcodeCache) remove(key string) {
+	path := c.path(key)
+	if err := os.RemoveAll(path); err != nil {
+		logger.Warnf("[transcode] error removing %s: %v", path, err)
+	}
+	delete(c.entries, key)
+}
+
+// clear removes all entries which are not in use.
+func (c *transcodeCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, e := range c.entries {
+		if e.users == 0 {
+			c.remove(key)
+		}
+	}
+}
+
+func (c *transcodeCache) status() *TranscodeCacheStatus {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	maxSize := c.maxSize()
+	ret := &TranscodeCacheStatus{
+		Enabled: maxSize > 0,
+		MaxSize: maxSize,
+		Entries: []*TranscodeCacheEntry{},
+	}
+
+	for key, e := range c.entries {
+		ret.Size += e.size
+		ret.Entries = append(ret.Entries, &TranscodeCacheEntry{
+			Key:          key,
+			Size:         e.size,
+			LastAccessed: e.accessed,
+			InUse:        e.users > 0,
+		})
+	}
+
+	// most recently used first
+	slices.SortFunc(ret.Entries, func(a, b *TranscodeCacheEntry) int {
+		return b.LastAccessed.Compare(a.LastAccessed)
+	})
+
+	return ret
+}
@@ -0,0 +1,92 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCacheFile(t *testing.T, path string, size int, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func cacheKeys(c *transcodeCache) map[string]bool {
+	ret := make(map[string]bool)
+	for _, e := range c.status().Entries {
+		ret[e.Key] = true
+	}
+	return ret
+}
+
+func TestTranscodeCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	writeCacheFile(t, filepath.Join(dir, "oldest.mp4"), 100, now.Add(-3*time.Hour))
+	writeCacheFile(t, filepath.Join(dir, "older_hls_libx264", "0.ts"), 100, now.Add(-2*time.Hour))
+	if err := os.Chtimes(filepath.Join(dir, "older_hls_libx264"), now.Add(-2*time.Hour), now.Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	writeCacheFile(t, filepath.Join(dir, "newest.webm"), 100, now.Add(-time.Hour))
+	writeCacheFile(t, filepath.Join(dir, ".incomplete.mp4.123"), 100, now)
+
+	maxSize := int64(250)
+	c := newTranscodeCache(dir, func() int64 { return maxSize })
+
+	// the oldest entry is evicted on load, and the temporary file is removed
+	got := cacheKeys(c)
+	want := map[string]bool{"older_hls_libx264": true, "newest.webm": true}
+	if len(got) != len(want) || !got["older_hls_libx264"] || !got["newest.webm"] {
+		t.Errorf("entries after load = %v, want %v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "oldest.mp4")); !os.IsNotExist(err) {
+		t.Errorf("oldest.mp4 was not removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".incomplete.mp4.123")); !os.IsNotExist(err) {
+		t.Errorf("temporary file was not removed")
+	}
+
+	// accessing the older entry makes newest.webm the least recently used
+	c.acquire("older_hls_libx264")
+	c.release("older_hls_libx264")
+
+	// entries in use are not evicted
+	c.acquire("new.mp4")
+	writeCacheFile(t, filepath.Join(dir, "new.mp4"), 100, now)
+	c.acquire("older_hls_libx264")
+	c.release("new.mp4")
+
+	got = cacheKeys(c)
+	if len(got) != 2 || !got["older_hls_libx264"] || !got["new.mp4"] {
+		t.Errorf("entries after release = %v, want older_hls_libx264 and new.mp4", got)
+	}
+
+	status := c.status()
+	if status.Size != 200 {
+		t.Errorf("size = %d, want 200", status.Size)
+	}
+
+	// clear removes everything not in use
+	c.clear()
+	got = cacheKeys(c)
+	if len(got) != 1 || !got["older_hls_libx264"] {
+		t.Errorf("entries after clear = %v, want older_hls_libx264", got)
+	}
+
+	// all unused entries are removed when the cache is disabled
+	maxSize = 0
+	c.release("older_hls_libx264")
+	if got := cacheKeys(c); len(got) != 0 {
+		t.Errorf("entries after disabling = %v, want none", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -35,6 +36,11 @@ type CleanOptions struct {
 	// Do a dry run. Don't delete any files
 	DryRun bool
 
+	// MarkMissing marks the files that are missing from disk as missing,
+	// instead of deleting them. Files excluded by the PathFilter are
+	// left unchanged.
+	MarkMissing bool
+
 	// PathFilter are used to determine if a file should be included.
 	// Excluded files are marked for cleaning.
 	PathFilter PathFilter
@@ -146,6 +152,11 @@ func (j *cleanJob) execute(ctx context.Context) error {
 		return nil
 	}
 
+	if j.options.MarkMissing {
+		progress.AddProcessed(toDelete.len())
+		return j.markMissing(ctx, &toDelete)
+	}
+
 	progress.ExecuteTask(fmt.Sprintf("Cleaning %d files and folders", toDelete.len()), func() {
 		for _, ff := range toDelete.orderedList {
 			if job.IsCancelled(ctx) {
@@ -166,6 +177,49 @@ func (j *cleanJob) execute(ctx context.Context) error {
 	return nil
 }
 
+// markMissing sets the missing time of the files flagged for deletion that
+// are missing from disk, if not already set.
+func (j *cleanJob) markMissing(ctx context.Context, toDelete *deleteSet) error {
+	var ids []models.FileID
+	for _, ff := range toDelete.orderedList {
+		if ff.fileID == 0 {
+			continue
+		}
+
+		if _, err := j.FS.Lstat(toDelete.fileIDSet[ff.fileID]); isNotFound(err) {
+			ids = append(ids, ff.fileID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	r := j.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		files, err := r.File.Find(ctx, ids...)
+		if err != nil {
+			return fmt.Errorf("finding missing files: %w", err)
+		}
+
+		for _, f := range files {
+			base := f.Base()
+			if base.MissingSince != nil {
+				continue
+			}
+
+			logger.Infof("Marking file as missing: %q", base.Path)
+			base.MissingSince = &now
+			if err := r.File.Update(ctx, f); err != nil {
+				return fmt.Errorf("marking file %q as missing: %w", base.Path, err)
+			}
+		}
+
+		return nil
+	})
+}
+
 func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
 	const batchSize = 1000
 	offset := 0
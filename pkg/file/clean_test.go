@@ -0,0 +1,63 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanMarkMissing(t *testing.T) {
+	dir := t.TempDir()
+	existingPath := filepath.Join(dir, "existing.mp4")
+	if err := os.WriteFile(existingPath, []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		existingID models.FileID = iota + 1
+		missingID
+	)
+
+	missing := &models.BaseFile{ID: missingID, Path: filepath.Join(dir, "missing.mp4")}
+
+	db := mocks.NewDatabase()
+	db.File.On("Find", mock.Anything, missingID).Return([]models.File{missing}, nil).Once()
+	db.File.On("Update", mock.Anything, mock.MatchedBy(func(f models.File) bool {
+		return f.Base().ID == missingID
+	})).Return(nil).Once()
+
+	j := &cleanJob{
+		Cleaner: &Cleaner{
+			FS:         &OsFS{},
+			Repository: NewRepository(db.Repository()),
+		},
+		options: CleanOptions{MarkMissing: true},
+	}
+
+	// files excluded by the path filter are flagged but not missing
+	toDelete := newDeleteSet()
+	toDelete.add(existingID, existingPath)
+	toDelete.add(missingID, missing.Path)
+
+	if err := j.markMissing(context.Background(), &toDelete); err != nil {
+		t.Fatalf("markMissing() error = %v", err)
+	}
+
+	assert.NotNil(t, missing.MissingSince)
+	markedAt := *missing.MissingSince
+
+	// files already marked keep the time they were first found missing
+	db.File.On("Find", mock.Anything, missingID).Return([]models.File{missing}, nil).Once()
+	if err := j.markMissing(context.Background(), &toDelete); err != nil {
+		t.Fatalf("markMissing() error = %v", err)
+	}
+
+	assert.Equal(t, markedAt, *missing.MissingSince)
+	db.AssertExpectations(t)
+}
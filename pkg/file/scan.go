@@ -959,6 +959,7 @@ func (s *scanJob) isHandlerRequired(ctx context.Context, f models.File) bool {
 // - file size
 // - image format, width or height
 // - video codec, audio codec, format, width, height, framerate or bitrate
+// - video audio and subtitle streams
 func (s *scanJob) isMissingMetadata(ctx context.Context, f scanFile, existing models.File) bool {
 	for _, h := range s.FileDecorators {
 		if h.IsMissingMetadata(ctx, f.fs, existing) {
@@ -1019,6 +1020,26 @@ func (s *scanJob) setMissingFingerprints(ctx context.Context, f scanFile, existi
 	return existing, nil
 }
 
+// setFound clears the missing time of a file that was marked as missing.
+func (s *scanJob) setFound(ctx context.Context, existing models.File) (models.File, error) {
+	path := existing.Base().Path
+	logger.Infof("Missing file %s has been found", path)
+
+	existing.Base().MissingSince = nil
+
+	if err := s.withTxn(ctx, func(ctx context.Context) error {
+		if err := s.Repository.File.Update(ctx, existing); err != nil {
+			return fmt.Errorf("updating file %q: %w", path, err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
 // returns a file only if it was updated
 func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing models.File) (models.File, error) {
 	base := existing.Base()
@@ -1042,6 +1063,7 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 
 	base.ModTime = fileModTime
 	base.Size = f.Size
+	base.MissingSince = nil
 	base.UpdatedAt = time.Now()
 
 	// calculate and update fingerprints for the file
@@ -1123,6 +1145,13 @@ func (s *scanJob) onUnchangedFile(ctx context.Context, f scanFile, existing mode
 		return nil, err
 	}
 
+	if existing.Base().MissingSince != nil {
+		existing, err = s.setFound(ctx, existing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	handlerRequired := false
 	if err := s.withDB(ctx, func(ctx context.Context) error {
 		// check if the handler needs to be run
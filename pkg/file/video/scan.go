@@ -54,9 +54,28 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 		FrameRate:   videoFile.FrameRate,
 		BitRate:     videoFile.Bitrate,
 		Interactive: interactive,
+
+		AudioStreams:    toVideoFileStreams(videoFile.AudioStreams),
+		SubtitleStreams: toVideoFileStreams(videoFile.SubtitleStreams),
 	}, nil
 }
 
+func toVideoFileStreams(streams []*ffmpeg.FFProbeStream) []models.VideoFileStream {
+	// return a non-nil slice to indicate that the streams have been probed
+	ret := make([]models.VideoFileStream, len(streams))
+	for i, s := range streams {
+		ret[i] = models.VideoFileStream{
+			Index:    i,
+			Codec:    s.CodecName,
+			Language: s.Tags.Language,
+			Title:    s.Tags.Title,
+			Default:  s.Disposition.Default == 1,
+			Forced:   s.Disposition.Forced == 1,
+		}
+	}
+	return ret
+}
+
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f models.File) bool {
 	const (
 		unsetString = "unset"
@@ -77,5 +96,6 @@ func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f model
 		vf.Format == unsetString || vf.Width == unsetNumber ||
 		vf.Height == unsetNumber || vf.FrameRate == unsetNumber ||
 		vf.Duration == unsetNumber ||
-		vf.BitRate == unsetNumber || interactive != vf.Interactive
+		vf.BitRate == unsetNumber || interactive != vf.Interactive ||
+		vf.AudioStreams == nil || vf.SubtitleStreams == nil
 }
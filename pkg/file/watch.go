@@ -0,0 +1,233 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// DefaultWatchDebounce is the default time to wait after the last filesystem
+// event before changes are reported.
+const DefaultWatchDebounce = 10 * time.Second
+
+// WatchChanges is a set of changes collected by the Watcher.
+type WatchChanges struct {
+	// Changed are the paths of created, modified or moved files and folders
+	// which exist on disk. Paths contained in other changed folders are
+	// omitted.
+	Changed []string
+
+	// Removed are the paths of files and folders which were removed or moved
+	// away, and no longer exist on disk.
+	Removed []string
+}
+
+// Watcher watches directory trees for changes, and reports the changed paths
+// after events have stopped for the debounce period. It uses inotify on Linux,
+// and the equivalent platform facility elsewhere.
+//
+// Watches are not recursive, so every directory in the tree is watched.
+// Directories created while watching are added as they are seen.
+type Watcher struct {
+	// Debounce is the time to wait after the last event before OnChange is
+	// called. Defaults to DefaultWatchDebounce if zero.
+	Debounce time.Duration
+
+	// Filter is used to determine whether a directory should be watched.
+	// All directories are watched if nil.
+	Filter func(path string, info fs.FileInfo) bool
+
+	// OnChange is called with the collected changes.
+	OnChange func(ctx context.Context, changes WatchChanges)
+}
+
+type watchState struct {
+	*Watcher
+	fsWatcher *fsnotify.Watcher
+
+	changed map[string]struct{}
+	removed map[string]struct{}
+}
+
+// Watch watches the provided paths until the context is cancelled.
+func (w *Watcher) Watch(ctx context.Context, paths []string) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating filesystem watcher: %w", err)
+	}
+	defer fsWatcher.Close()
+
+	s := &watchState{
+		Watcher:   w,
+		fsWatcher: fsWatcher,
+		changed:   make(map[string]struct{}),
+		removed:   make(map[string]struct{}),
+	}
+
+	for _, p := range paths {
+		s.addTree(p)
+	}
+
+	debounce := w.Debounce
+	if debounce == 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if s.handleEvent(event) {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			// overflow errors mean that events were dropped
+			logger.Warnf("[watcher] %v", err)
+		case <-timer.C:
+			changes := s.flush()
+			if len(changes.Changed) > 0 || len(changes.Removed) > 0 {
+				w.OnChange(ctx, changes)
+			}
+		}
+	}
+}
+
+// addTree adds watches for the directory at p and all of its subdirectories.
+func (s *watchState) addTree(p string) {
+	if err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			logger.Warnf("[watcher] error walking %s: %v", path, err)
+			return nil
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if s.Filter != nil {
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+
+			if !s.Filter(path, info) {
+				return fs.SkipDir
+			}
+		}
+
+		if err := s.fsWatcher.Add(path); err != nil {
+			logger.Warnf("[watcher] could not watch %s: %v", path, err)
+		}
+
+		return nil
+	}); err != nil {
+		logger.Warnf("[watcher] error walking %s: %v", p, err)
+	}
+}
+
+// handleEvent records the event. Returns true if the event is relevant.
+func (s *watchState) handleEvent(event fsnotify.Event) bool {
+	path := event.Name
+
+	switch {
+	case event.Has(fsnotify.Create):
+		info, err := os.Stat(path)
+		if err != nil {
+			// removed before it could be handled
+			return false
+		}
+
+		if info.IsDir() {
+			s.addTree(path)
+		}
+
+		s.changed[path] = struct{}{}
+	case event.Has(fsnotify.Write):
+		s.changed[path] = struct{}{}
+	case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+		// the watch is removed automatically for removed directories
+		s.removed[path] = struct{}{}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// flush returns the collected changes and resets the state.
+func (s *watchState) flush() WatchChanges {
+	var ret WatchChanges
+
+	var changed []string
+	for p := range s.changed {
+		if _, err := os.Lstat(p); err == nil {
+			changed = append(changed, p)
+		} else if errors.Is(err, fs.ErrNotExist) {
+			s.removed[p] = struct{}{}
+		}
+	}
+
+	for p := range s.removed {
+		if _, err := os.Lstat(p); err == nil {
+			// replaced by a new file
+			changed = append(changed, p)
+		} else {
+			ret.Removed = append(ret.Removed, p)
+		}
+	}
+
+	ret.Changed = topLevelPaths(changed)
+	sort.Strings(ret.Removed)
+
+	s.changed = make(map[string]struct{})
+	s.removed = make(map[string]struct{})
+
+	return ret
+}
+
+// topLevelPaths returns the sorted unique paths, omitting any paths contained
+// in other paths.
+func topLevelPaths(paths []string) []string {
+	set := make(map[string]struct{})
+	for _, p := range paths {
+		set[p] = struct{}{}
+	}
+
+	var ret []string
+	for p := range set {
+		contained := false
+		for dir := filepath.Dir(p); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if _, found := set[dir]; found {
+				contained = true
+				break
+			}
+		}
+
+		if !contained {
+			ret = append(ret, p)
+		}
+	}
+
+	sort.Strings(ret)
+	return ret
+}
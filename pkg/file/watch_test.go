@@ -0,0 +1,92 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopLevelPaths(t *testing.T) {
+	sep := string(filepath.Separator)
+	a := sep + filepath.Join("stash", "a")
+	aFile := filepath.Join(a, "file.mp4")
+	aSub := filepath.Join(a, "sub", "file.mp4")
+	aSpace := sep + filepath.Join("stash", "a b")
+	aSpaceFile := filepath.Join(aSpace, "file.mp4")
+
+	assert.Equal(t, []string{a, aSpace}, topLevelPaths([]string{aSub, aSpaceFile, a, aFile, aSpace, a}))
+	assert.Equal(t, []string{aSpaceFile, aFile}, topLevelPaths([]string{aSpaceFile, aFile}))
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.mp4")
+	if err := os.WriteFile(existing, []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changesCh := make(chan WatchChanges, 1)
+	w := &Watcher{
+		Debounce: 200 * time.Millisecond,
+		OnChange: func(ctx context.Context, changes WatchChanges) {
+			changesCh <- changes
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := w.Watch(ctx, []string{dir}); err != nil {
+			t.Errorf("Watch error: %v", err)
+		}
+	}()
+
+	// allow the watches to be added
+	time.Sleep(100 * time.Millisecond)
+
+	subDir := filepath.Join(dir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	moved := filepath.Join(subDir, "moved.mp4")
+	if err := os.Rename(existing, moved); err != nil {
+		t.Fatal(err)
+	}
+	created := filepath.Join(dir, "created.mp4")
+	if err := os.WriteFile(created, []byte("created"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changes := <-changesCh:
+		// moved file is contained in the new folder
+		assert.Equal(t, []string{created, subDir}, changes.Changed)
+		assert.Equal(t, []string{existing}, changes.Removed)
+	case <-time.After(5 * time.Second):
+		t.Error("no changes reported")
+	}
+
+	// expect new folders to be watched
+	newFile := filepath.Join(subDir, "new.mp4")
+	if err := os.WriteFile(newFile, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changes := <-changesCh:
+		assert.Equal(t, []string{newFile}, changes.Changed)
+		assert.Len(t, changes.Removed, 0)
+	case <-time.After(5 * time.Second):
+		t.Error("no changes reported")
+	}
+
+	cancel()
+	<-done
+}
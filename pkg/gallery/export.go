@@ -43,6 +43,34 @@ func ToBasicJSON(gallery *models.Gallery) (*jsonschema.Gallery, error) {
 	return &newGalleryJSON, nil
 }
 
+type HistoryReader interface {
+	models.ViewDateReader
+	models.ODateReader
+}
+
+// GetHistory returns the view and o history of the provided gallery.
+func GetHistory(ctx context.Context, reader HistoryReader, gallery *models.Gallery) (viewHistory []json.JSONTime, oHistory []json.JSONTime, err error) {
+	viewDates, err := reader.GetViewDates(ctx, gallery.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting view dates: %w", err)
+	}
+
+	for _, date := range viewDates {
+		viewHistory = append(viewHistory, json.JSONTime{Time: date})
+	}
+
+	oDates, err := reader.GetODates(ctx, gallery.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting o dates: %w", err)
+	}
+
+	for _, date := range oDates {
+		oHistory = append(oHistory, json.JSONTime{Time: date})
+	}
+
+	return viewHistory, oHistory, nil
+}
+
 // GetStudioName returns the name of the provided gallery's studio. It returns an
 // empty string if there is no studio assigned to the gallery.
 func GetStudioName(ctx context.Context, reader models.StudioGetter, gallery *models.Gallery) (string, error) {
@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/json"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 type ImporterReaderWriter interface {
 	models.GalleryCreatorUpdater
+	models.ViewHistoryWriter
+	models.OHistoryWriter
+	SaveActivity(ctx context.Context, id int, lastImageIndex *int) (bool, error)
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Gallery, error)
 	FindByFolderID(ctx context.Context, folderID models.FolderID) ([]*models.Gallery, error)
 	FindUserGalleryByTitle(ctx context.Context, title string) ([]*models.Gallery, error)
@@ -296,7 +301,33 @@ func (i *Importer) populateFilesFolder(ctx context.Context) error {
 	return nil
 }
 
+func jsonTimes(times []json.JSONTime) []time.Time {
+	var ret []time.Time
+	for _, t := range times {
+		ret = append(ret, t.GetTime())
+	}
+	return ret
+}
+
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.ViewHistory) > 0 {
+		if _, err := i.ReaderWriter.AddViews(ctx, id, jsonTimes(i.Input.ViewHistory)); err != nil {
+			return fmt.Errorf("error adding view date: %v", err)
+		}
+	}
+
+	if len(i.Input.OHistory) > 0 {
+		if _, err := i.ReaderWriter.AddO(ctx, id, jsonTimes(i.Input.OHistory)); err != nil {
+			return fmt.Errorf("error adding o date: %v", err)
+		}
+	}
+
+	if i.Input.LastImageIndex != nil {
+		if _, err := i.ReaderWriter.SaveActivity(ctx, id, i.Input.LastImageIndex); err != nil {
+			return fmt.Errorf("error setting last image index: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -359,6 +390,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating gallery: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.gallery.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.gallery.ID
 	return &id, nil
 }
@@ -371,5 +406,23 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing gallery: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting gallery custom fields: %v", err)
+	}
+
 	return nil
 }
@@ -50,7 +50,12 @@ func (s *Service) ValidateImageGalleryChange(ctx context.Context, i *models.Imag
 		return err
 	}
 
-	for _, g := range galleries {
+	for idx, g := range galleries {
+		// galleries hidden by content restrictions are not found
+		if g == nil {
+			return fmt.Errorf("gallery with id %d not found", changedIDs[idx])
+		}
+
 		if err := validateContentChange(g); err != nil {
 			return fmt.Errorf("changing galleries of image %q: %w", i.GetTitle(), err)
 		}
@@ -14,6 +14,7 @@ import (
 type ImageGetter interface {
 	GetFrontImage(ctx context.Context, movieID int) ([]byte, error)
 	GetBackImage(ctx context.Context, movieID int) ([]byte, error)
+	models.CustomFieldsReader
 }
 
 // ToJSON converts a Movie into its JSON equivalent.
@@ -67,5 +68,10 @@ func ToJSON(ctx context.Context, reader ImageGetter, studioReader models.StudioG
 		newMovieJSON.BackImage = utils.GetBase64StringFromData(backImage)
 	}
 
+	newMovieJSON.CustomFields, err = reader.GetCustomFields(ctx, movie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting movie custom fields: %v", err)
+	}
+
 	return &newMovieJSON, nil
 }
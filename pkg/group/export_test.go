@@ -8,6 +8,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"testing"
 	"time"
@@ -187,6 +188,8 @@ func TestToJSON(t *testing.T) {
 	db.Group.On("GetBackImage", testCtx, errFrontImageID).Return(backImageBytes, nil).Maybe()
 	db.Group.On("GetBackImage", testCtx, errStudioMovieID).Return(backImageBytes, nil).Maybe()
 
+	db.Group.On("GetCustomFields", testCtx, mock.Anything).Return(nil, nil).Maybe()
+
 	studioErr := errors.New("error getting studio")
 
 	db.Studio.On("Find", testCtx, studioID).Return(&movieStudio, nil)
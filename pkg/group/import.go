@@ -271,6 +271,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating group: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.group.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.group.ID
 	return &id, nil
 }
@@ -283,6 +287,24 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing group: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting group custom fields: %v", err)
+	}
+
 	return nil
 }
 
@@ -2,11 +2,51 @@ package group
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 )
 
+func BatchProcess(ctx context.Context, reader models.GroupQueryer, groupFilter *models.GroupFilterType, findFilter *models.FindFilterType, fn func(group *models.Group) error) error {
+	const batchSize = 1000
+
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	page := 1
+	perPage := batchSize
+	findFilter.Page = &page
+	findFilter.PerPage = &perPage
+
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		groups, _, err := reader.Query(ctx, groupFilter, findFilter)
+		if err != nil {
+			return fmt.Errorf("error querying for groups: %w", err)
+		}
+
+		for _, group := range groups {
+			if err := fn(group); err != nil {
+				return err
+			}
+		}
+
+		if len(groups) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
+
 func CountByStudioID(ctx context.Context, r models.GroupQueryer, id int, depth *int) (int, error) {
 	filter := &models.GroupFilterType{
 		Studios: &models.HierarchicalMultiCriterionInput{
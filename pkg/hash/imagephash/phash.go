@@ -0,0 +1,43 @@
+// Package imagephash computes perceptual hashes of image files.
+package imagephash
+
+import (
+	"fmt"
+	"image"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/corona10/goimagehash"
+	_ "golang.org/x/image/webp"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Generate returns the perceptual hash of the image file. Images inside zip
+// files are read from the zip file.
+func Generate(fs models.FS, f *models.ImageFile) (*uint64, error) {
+	reader, err := f.Open(fs)
+	if err != nil {
+		return nil, fmt.Errorf("opening image file: %w", err)
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image file: %w", err)
+	}
+
+	return generate(img)
+}
+
+func generate(img image.Image) (*uint64, error) {
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("computing phash from image: %w", err)
+	}
+
+	hashValue := hash.GetHash()
+	return &hashValue, nil
+}
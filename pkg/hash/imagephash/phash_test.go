@@ -0,0 +1,61 @@
+package imagephash
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/disintegration/imaging"
+)
+
+// blocksImage returns an image made of blocks of pseudo-random shades.
+func blocksImage(w, h int) image.Image {
+	const blocks = 8
+
+	rnd := rand.New(rand.NewSource(1))
+	shades := make([]uint8, blocks*blocks)
+	for i := range shades {
+		shades[i] = uint8(rnd.Intn(256))
+	}
+
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.Gray{Y: shades[(y*blocks/h)*blocks+x*blocks/w]})
+		}
+	}
+	return img
+}
+
+func TestGenerateResized(t *testing.T) {
+	original := blocksImage(400, 300)
+	resized := imaging.Resize(original, 200, 150, imaging.Lanczos)
+	flipped := imaging.FlipH(original)
+
+	originalHash, err := generate(original)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	resizedHash, err := generate(resized)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	flippedHash, err := generate(flipped)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	const maxDistance = 4
+
+	if d := bits.OnesCount64(*originalHash ^ *resizedHash); d > maxDistance {
+		t.Errorf("distance between original and resized = %d, want <= %d", d, maxDistance)
+	}
+
+	if d := bits.OnesCount64(*originalHash ^ *flippedHash); d <= maxDistance {
+		t.Errorf("distance between original and flipped = %d, want > %d", d, maxDistance)
+	}
+}
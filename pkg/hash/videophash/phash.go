@@ -24,7 +24,13 @@ const (
 )
 
 func Generate(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (*uint64, error) {
-	sprite, err := generateSprite(encoder, videoFile)
+	return GenerateRange(encoder, videoFile, 0, videoFile.Duration)
+}
+
+// GenerateRange generates the phash of the range of the video file between
+// start and end, in seconds.
+func GenerateRange(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile, start float64, end float64) (*uint64, error) {
+	sprite, err := generateSprite(encoder, videoFile, start, end)
 	if err != nil {
 		return nil, err
 	}
@@ -76,13 +82,14 @@ func combineImages(images []image.Image) image.Image {
 	return montage
 }
 
-func generateSprite(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (image.Image, error) {
+func generateSprite(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile, start float64, end float64) (image.Image, error) {
 	logger.Infof("[generator] generating phash sprite for %s", videoFile.Path)
 
 	// Generate sprite image offset by 5% on each end to avoid intro/outros
 	chunkCount := columns * rows
-	offset := 0.05 * videoFile.Duration
-	stepSize := (0.9 * videoFile.Duration) / float64(chunkCount)
+	duration := end - start
+	offset := start + 0.05*duration
+	stepSize := (0.9 * duration) / float64(chunkCount)
 	var images []image.Image
 	for i := 0; i < chunkCount; i++ {
 		time := offset + (float64(i) * stepSize)
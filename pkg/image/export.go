@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
@@ -31,7 +32,6 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 	}
 
 	newImageJSON.Organized = image.Organized
-	newImageJSON.OCounter = image.OCounter
 
 	for _, f := range image.Files.List() {
 		newImageJSON.Files = append(newImageJSON.Files, f.Base().Path)
@@ -40,6 +40,34 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 	return &newImageJSON
 }
 
+type HistoryReader interface {
+	models.ViewDateReader
+	models.ODateReader
+}
+
+// GetHistory returns the view and o history of the provided image.
+func GetHistory(ctx context.Context, reader HistoryReader, image *models.Image) (viewHistory []json.JSONTime, oHistory []json.JSONTime, err error) {
+	viewDates, err := reader.GetViewDates(ctx, image.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting view dates: %w", err)
+	}
+
+	for _, date := range viewDates {
+		viewHistory = append(viewHistory, json.JSONTime{Time: date})
+	}
+
+	oDates, err := reader.GetODates(ctx, image.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting o dates: %w", err)
+	}
+
+	for _, date := range oDates {
+		oHistory = append(oHistory, json.JSONTime{Time: date})
+	}
+
+	return viewHistory, oHistory, nil
+}
+
 // GetStudioName returns the name of the provided image's studio. It returns an
 // empty string if there is no studio assigned to the image.
 func GetStudioName(ctx context.Context, reader models.StudioGetter, image *models.Image) (string, error) {
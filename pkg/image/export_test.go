@@ -28,7 +28,6 @@ var (
 	date       = "2001-01-01"
 	dateObj, _ = models.ParseDate(date)
 	organized  = true
-	ocounter   = 2
 )
 
 const (
@@ -50,7 +49,6 @@ func createFullImage(id int) models.Image {
 			},
 		}),
 		Title:     title,
-		OCounter:  ocounter,
 		Rating:    &rating,
 		Date:      &dateObj,
 		URLs:      models.NewRelatedStrings([]string{url}),
@@ -63,7 +61,6 @@ func createFullImage(id int) models.Image {
 func createFullJSONImage() *jsonschema.Image {
 	return &jsonschema.Image{
 		Title:     title,
-		OCounter:  ocounter,
 		Rating:    rating,
 		Date:      date,
 		URLs:      []string{url},
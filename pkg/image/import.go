@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
@@ -18,6 +19,8 @@ type GalleryFinder interface {
 
 type ImporterReaderWriter interface {
 	models.ImageCreatorUpdater
+	models.ViewHistoryWriter
+	models.OHistoryWriter
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Image, error)
 }
 
@@ -69,7 +72,6 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 
 		Title:     imageJSON.Title,
 		Organized: imageJSON.Organized,
-		OCounter:  imageJSON.OCounter,
 		CreatedAt: imageJSON.CreatedAt.GetTime(),
 		UpdatedAt: imageJSON.UpdatedAt.GetTime(),
 	}
@@ -303,7 +305,42 @@ func (i *Importer) populateTags(ctx context.Context) error {
 	return nil
 }
 
+// oHistory returns the o history to import. Images exported before the o
+// history was recorded have an o-counter, which is imported as o dates at
+// the creation time of the image.
+func (i *Importer) oHistory() []time.Time {
+	var ret []time.Time
+	for _, d := range i.Input.OHistory {
+		ret = append(ret, d.GetTime())
+	}
+
+	if len(ret) == 0 {
+		for j := 0; j < i.Input.OCounter; j++ {
+			ret = append(ret, i.Input.CreatedAt.GetTime())
+		}
+	}
+
+	return ret
+}
+
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.ViewHistory) > 0 {
+		var viewHistory []time.Time
+		for _, d := range i.Input.ViewHistory {
+			viewHistory = append(viewHistory, d.GetTime())
+		}
+
+		if _, err := i.ReaderWriter.AddViews(ctx, id, viewHistory); err != nil {
+			return fmt.Errorf("error adding view date: %v", err)
+		}
+	}
+
+	if oHistory := i.oHistory(); len(oHistory) > 0 {
+		if _, err := i.ReaderWriter.AddO(ctx, id, oHistory); err != nil {
+			return fmt.Errorf("error adding o date: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -349,6 +386,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating image: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.image.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.image.ID
 	i.ID = id
 	return &id, nil
@@ -363,6 +404,24 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing image: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting image custom fields: %v", err)
+	}
+
 	return nil
 }
 
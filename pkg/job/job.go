@@ -29,6 +29,23 @@ func MakeJobExec(fn JobExecFn) JobExec {
 	}
 }
 
+type idContextKey struct{}
+
+// withID returns a context for the job with the provided id.
+func withID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, idContextKey{}, id)
+}
+
+// IDFromContext returns the id of the job executing with the context.
+// Returns nil if the context is not for a job.
+func IDFromContext(ctx context.Context) *int {
+	if v, ok := ctx.Value(idContextKey{}).(int); ok {
+		return &v
+	}
+
+	return nil
+}
+
 // Status is the status of a Job
 type Status string
 
@@ -45,6 +62,9 @@ const (
 	StatusCancelled Status = "CANCELLED"
 	// StatusFailed means that the job failed.
 	StatusFailed Status = "FAILED"
+	// StatusInterrupted means that the job was queued or running when the
+	// application was stopped. Interrupted jobs may be resumed.
+	StatusInterrupted Status = "INTERRUPTED"
 )
 
 // Job represents the status of a queued or running job.
@@ -61,6 +81,12 @@ type Job struct {
 	AddTime   time.Time
 	Error     *string
 
+	// Type identifies the Factory used to recreate a resumable job.
+	// Empty if the job is not resumable.
+	Type string
+	// Input is the serialised input used to recreate a resumable job.
+	Input []byte
+
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
@@ -69,18 +95,20 @@ type Job struct {
 // TimeElapsed returns the total time elapsed for the job.
 // If the EndTime is set, then it uses this to calculate the elapsed time, otherwise it uses time.Now.
 func (j *Job) TimeElapsed() time.Duration {
-	var end time.Time
+	end := time.Now()
 	if j.EndTime != nil {
-		end = time.Now()
-	} else {
 		end = *j.EndTime
 	}
 
 	return end.Sub(*j.StartTime)
 }
 
+func (j *Job) isFinished() bool {
+	return j.Status == StatusFinished || j.Status == StatusCancelled || j.Status == StatusFailed
+}
+
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	if j.Status == StatusReady || j.Status == StatusInterrupted {
 		j.Status = StatusCancelled
 	} else if j.Status == StatusRunning {
 		j.Status = StatusStopping
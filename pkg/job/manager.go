@@ -2,7 +2,10 @@ package job
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,6 +29,12 @@ type Manager struct {
 
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
+
+	factories map[string]Factory
+	store     Store
+	persister *persister
+
+	finished finishedStats
 }
 
 // NewManager initialises and returns a new Manager.
@@ -33,6 +42,7 @@ func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
 		updateThrottleLimit: defaultThrottleLimit,
+		factories:           make(map[string]Factory),
 	}
 
 	ret.notEmpty = sync.NewCond(&ret.mutex)
@@ -47,6 +57,98 @@ func NewManager() *Manager {
 func (m *Manager) Stop() {
 	m.CancelAll()
 	close(m.stop)
+
+	m.mutex.Lock()
+	p := m.persister
+	m.mutex.Unlock()
+
+	if p != nil {
+		p.close()
+	}
+}
+
+// Shutdown stops the dispatcher thread and writes any pending job updates to
+// the store. Unlike Stop, running jobs are not cancelled, so that they remain
+// stored as running and are resumed as interrupted on the next startup. The
+// store is not written to after Shutdown returns.
+func (m *Manager) Shutdown() {
+	close(m.stop)
+
+	m.mutex.Lock()
+	p := m.persister
+	m.persister = nil
+	m.mutex.Unlock()
+
+	if p != nil {
+		p.close()
+	}
+}
+
+// RegisterFactory registers the Factory used to recreate resumable jobs of
+// the provided type.
+func (m *Manager) RegisterFactory(jobType string, f Factory) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.factories[jobType] = f
+}
+
+// SetStore sets the store used to persist jobs and loads the existing jobs
+// from it. Jobs that were queued or running when they were last stored are
+// added to the queue as interrupted, and may be resumed using ResumeJob.
+func (m *Manager) SetStore(ctx context.Context, s Store) error {
+	maxID, err := s.MaxID(ctx)
+	if err != nil {
+		return fmt.Errorf("getting max job id: %w", err)
+	}
+
+	unfinished, err := s.FindByStatus(ctx, []Status{StatusReady, StatusRunning, StatusStopping, StatusInterrupted})
+	if err != nil {
+		return fmt.Errorf("finding unfinished jobs: %w", err)
+	}
+
+	if err := s.Prune(ctx, maxStoredJobs); err != nil {
+		return fmt.Errorf("pruning stored jobs: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.persister != nil {
+		m.persister.close()
+	}
+
+	m.store = s
+	m.persister = newPersister(s)
+
+	if maxID > m.lastID {
+		m.lastID = maxID
+	}
+
+	for i := range unfinished {
+		j := unfinished[i]
+
+		// ignore jobs that are already known
+		if _, existing := m.getJob(append(m.queue, m.graveyard...), j.ID); existing != nil {
+			continue
+		}
+
+		if j.Status == StatusStopping {
+			// job was being cancelled, so don't resume it
+			j.Status = StatusCancelled
+			m.persist(&j)
+			continue
+		}
+
+		j.Status = StatusInterrupted
+		j.Progress = ProgressIndefinite
+		j.Details = nil
+		m.queue = append(m.queue, &j)
+		m.persist(&j)
+		m.notifyNewJob(&j)
+	}
+
+	return nil
 }
 
 // Add queues a job.
@@ -54,6 +156,27 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	return m.add(ctx, description, e, "", nil)
+}
+
+// AddResumable queues a job that may be resumed if it is interrupted by a
+// restart. The input is serialised and passed to the Factory registered for
+// jobType when the job is resumed.
+func (m *Manager) AddResumable(ctx context.Context, description string, e JobExec, jobType string, input interface{}) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		logger.Warnf("error serialising input for job %q, job will not be resumable: %v", description, err)
+		return m.add(ctx, description, e, "", nil)
+	}
+
+	return m.add(ctx, description, e, jobType, data)
+}
+
+func (m *Manager) add(ctx context.Context, description string, e JobExec, jobType string, input []byte) int {
+	// assumes lock held
 	t := time.Now()
 
 	j := Job{
@@ -61,22 +184,84 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 		Status:      StatusReady,
 		Description: description,
 		AddTime:     t,
+		Type:        jobType,
+		Input:       input,
 		exec:        e,
 		outerCtx:    ctx,
 	}
 
 	m.queue = append(m.queue, &j)
 
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
-	}
+	// notify that there is now a job in the queue
+	m.notEmpty.Broadcast()
 
 	m.notifyNewJob(&j)
+	m.persist(&j)
 
 	return j.ID
 }
 
+// IsResumable returns true if the job can be resumed using ResumeJob.
+func (m *Manager) IsResumable(j Job) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.isResumable(&j)
+}
+
+func (m *Manager) isResumable(j *Job) bool {
+	// assumes lock held
+	if j.Status != StatusInterrupted || j.Type == "" {
+		return false
+	}
+
+	_, found := m.factories[j.Type]
+	return found
+}
+
+// ResumeJob requeues an interrupted job, recreating it from its stored
+// input. Returns an error if the job does not exist or is not resumable.
+func (m *Manager) ResumeJob(ctx context.Context, id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return fmt.Errorf("job %d not found in queue", id)
+	}
+
+	if !m.isResumable(j) {
+		return fmt.Errorf("job %d is not resumable", id)
+	}
+
+	e, err := m.factories[j.Type](j.Input)
+	if err != nil {
+		return fmt.Errorf("recreating job %d: %w", id, err)
+	}
+
+	j.Status = StatusReady
+	j.Progress = 0
+	j.StartTime = nil
+	j.EndTime = nil
+	j.Error = nil
+	j.exec = e
+	j.outerCtx = ctx
+
+	m.notEmpty.Broadcast()
+
+	m.notifyJobUpdate(j)
+	m.persist(j)
+
+	return nil
+}
+
+func (m *Manager) persist(j *Job) {
+	// assumes lock held
+	if m.persister != nil {
+		m.persister.add(*j)
+	}
+}
+
 // Start adds a job and starts it immediately, concurrently with any other
 // jobs.
 func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
@@ -95,6 +280,7 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	}
 
 	m.queue = append(m.queue, &j)
+	m.persist(&j)
 
 	m.dispatch(ctx, &j)
 
@@ -179,13 +365,14 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	j.StartTime = &t
 	j.Status = StatusRunning
 
-	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
+	ctx, cancelFunc := context.WithCancel(withID(utils.ValueOnlyContext{Context: ctx}, j.ID))
 	j.cancelFunc = cancelFunc
 
 	done = make(chan struct{})
 	go m.executeJob(ctx, j, done)
 
 	m.notifyJobUpdate(j)
+	m.persist(j)
 
 	return
 }
@@ -223,6 +410,9 @@ func (m *Manager) onJobFinish(job *Job) {
 	}
 	t := time.Now()
 	job.EndTime = &t
+
+	m.finished.add(job)
+	m.persist(job)
 }
 
 func (m *Manager) removeJob(job *Job) {
@@ -236,6 +426,7 @@ func (m *Manager) removeJob(job *Job) {
 	job.Details = nil
 
 	m.queue = append(m.queue[:index], m.queue[index+1:]...)
+	m.persist(job)
 
 	m.graveyard = append(m.graveyard, job)
 	if len(m.graveyard) > maxGraveyardSize {
@@ -301,21 +492,88 @@ func (m *Manager) CancelAll() {
 	}
 }
 
-// GetJob returns a copy of the Job for the provided id. Returns nil if the job
-// does not exist.
+// GetJob returns a copy of the Job for the provided id. If the job is not in
+// the queue or recent history, then it is read from the store, if set.
+// Returns nil if the job does not exist.
 func (m *Manager) GetJob(id int) *Job {
 	m.mutex.Lock()
-	defer m.mutex.Unlock()
 
 	// get from the queue or graveyard
 	_, j := m.getJob(append(m.queue, m.graveyard...), id)
 	if j != nil {
 		// make a copy of the job and return the pointer
 		jCopy := *j
+		m.mutex.Unlock()
 		return &jCopy
 	}
 
-	return nil
+	store := m.store
+	m.mutex.Unlock()
+
+	if store == nil {
+		return nil
+	}
+
+	ret, err := store.Find(context.Background(), id)
+	if err != nil {
+		logger.Errorf("error finding job %d: %v", id, err)
+		return nil
+	}
+
+	return ret
+}
+
+// GetHistory returns up to limit finished jobs, most recently added first.
+// Jobs older than the in-memory history are read from the store, if set.
+func (m *Manager) GetHistory(ctx context.Context, limit int) ([]Job, error) {
+	m.mutex.Lock()
+
+	// copy the in-memory history, most recent first
+	var ret []Job
+	for i := len(m.graveyard) - 1; i >= 0; i-- {
+		ret = append(ret, *m.graveyard[i])
+	}
+
+	store := m.store
+	queueLen := len(m.queue)
+	m.mutex.Unlock()
+
+	if store != nil {
+		// stored jobs include those still in the queue
+		stored, err := store.FindRecent(ctx, limit+queueLen)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, j := range stored {
+			if !j.isFinished() {
+				continue
+			}
+
+			// in-memory jobs may not yet have been written
+			found := false
+			for _, jj := range ret {
+				if jj.ID == j.ID {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				ret = append(ret, j)
+			}
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID > ret[j].ID
+	})
+
+	if limit >= 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+
+	return ret, nil
 }
 
 // GetQueue returns a copy of the current job queue.
@@ -388,6 +646,7 @@ type updater struct {
 func (u *updater) notifyUpdate() {
 	// assumes lock held
 	u.m.notifyJobUpdate(u.job)
+	u.m.persist(u.job)
 	u.lastUpdate = time.Now()
 	u.updateTimer = nil
 }
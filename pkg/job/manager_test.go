@@ -343,3 +343,34 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func TestStats(t *testing.T) {
+	m := NewManager()
+
+	finish := make(chan struct{})
+	exec1 := newTestExec(finish)
+	exec2 := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "test job 1", exec1)
+	m.Add(context.Background(), "test job 2", exec2)
+
+	// wait for the first job to start
+	<-exec1.started
+
+	assert := assert.New(t)
+
+	stats := m.Stats()
+	assert.Equal(1, stats.Queued[StatusRunning])
+	assert.Equal(1, stats.Queued[StatusReady])
+	assert.Empty(stats.Finished)
+
+	// finish the first job
+	close(finish)
+	<-exec2.started
+
+	stats = m.Stats()
+	assert.Equal(1, stats.Queued[StatusRunning])
+	assert.Equal(0, stats.Queued[StatusReady])
+	assert.Equal(1, stats.Finished[StatusFinished])
+	assert.Greater(stats.RunTime[StatusFinished], time.Duration(0))
+}
@@ -0,0 +1,63 @@
+package job
+
+import "time"
+
+// ManagerStats contains statistics about the jobs run by a Manager.
+type ManagerStats struct {
+	// Queued contains the number of jobs in the queue, by status.
+	Queued map[Status]int
+	// Running is the total time spent by the currently running jobs.
+	Running time.Duration
+	// Finished contains the number of jobs that have finished since the
+	// manager was started, by final status.
+	Finished map[Status]int
+	// RunTime contains the total run time of the jobs that have finished
+	// since the manager was started, by final status.
+	RunTime map[Status]time.Duration
+}
+
+type finishedStats struct {
+	count   map[Status]int
+	runTime map[Status]time.Duration
+}
+
+// assumes lock held
+func (s *finishedStats) add(j *Job) {
+	if s.count == nil {
+		s.count = make(map[Status]int)
+		s.runTime = make(map[Status]time.Duration)
+	}
+
+	s.count[j.Status]++
+	if j.StartTime != nil {
+		s.runTime[j.Status] += j.TimeElapsed()
+	}
+}
+
+// Stats returns statistics about the queued and finished jobs.
+func (m *Manager) Stats() ManagerStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ret := ManagerStats{
+		Queued:   make(map[Status]int),
+		Finished: make(map[Status]int),
+		RunTime:  make(map[Status]time.Duration),
+	}
+
+	for _, j := range m.queue {
+		ret.Queued[j.Status]++
+		if j.Status == StatusRunning || j.Status == StatusStopping {
+			ret.Running += j.TimeElapsed()
+		}
+	}
+
+	for k, v := range m.finished.count {
+		ret.Finished[k] = v
+	}
+	for k, v := range m.finished.runTime {
+		ret.RunTime[k] = v
+	}
+
+	return ret
+}
@@ -0,0 +1,138 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// persistInterval is the minimum time between writes to the job store.
+	persistInterval = time.Second
+
+	// maxStoredJobs is the maximum number of finished jobs to keep in the
+	// job store.
+	maxStoredJobs = 1000
+)
+
+// Store persists jobs so that the job history and any interrupted jobs
+// survive a restart.
+type Store interface {
+	// Save creates or replaces the stored job.
+	Save(ctx context.Context, j Job) error
+	// Find returns the stored job with the provided id. Returns nil if the
+	// job does not exist.
+	Find(ctx context.Context, id int) (*Job, error)
+	// FindByStatus returns the stored jobs with any of the provided statuses,
+	// in the order they were added.
+	FindByStatus(ctx context.Context, statuses []Status) ([]Job, error)
+	// FindRecent returns up to limit stored jobs, most recently added first.
+	FindRecent(ctx context.Context, limit int) ([]Job, error)
+	// MaxID returns the highest job id used by the store, including ids of
+	// pruned jobs that are still referenced, or 0 if no ids have been used.
+	// New jobs are given ids above it.
+	MaxID(ctx context.Context) (int, error)
+	// Prune removes all but the most recent keep finished jobs.
+	Prune(ctx context.Context, keep int) error
+}
+
+// Factory recreates the JobExec of a resumable job from its serialised input.
+type Factory func(input []byte) (JobExec, error)
+
+// persister writes job updates to a Store in the background.
+// Multiple updates to the same job between writes are coalesced.
+type persister struct {
+	store Store
+
+	mutex   sync.Mutex
+	pending map[int]Job
+	order   []int
+	signal  chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newPersister(store Store) *persister {
+	ret := &persister{
+		store:   store,
+		pending: make(map[int]Job),
+		signal:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go ret.run()
+
+	return ret
+}
+
+func (p *persister) add(j Job) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, found := p.pending[j.ID]; !found {
+		p.order = append(p.order, j.ID)
+	}
+	p.pending[j.ID] = j
+
+	// don't block if a write is already pending
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (p *persister) take() []Job {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	ret := make([]Job, len(p.order))
+	for i, id := range p.order {
+		ret[i] = p.pending[id]
+	}
+
+	p.pending = make(map[int]Job)
+	p.order = nil
+
+	return ret
+}
+
+func (p *persister) flush() {
+	ctx := context.Background()
+	for _, j := range p.take() {
+		if err := p.store.Save(ctx, j); err != nil {
+			logger.Errorf("error saving job %d: %v", j.ID, err)
+		}
+	}
+}
+
+func (p *persister) run() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.stop:
+			p.flush()
+			return
+		case <-p.signal:
+		}
+
+		p.flush()
+
+		// throttle writes to the store
+		select {
+		case <-p.stop:
+			p.flush()
+			return
+		case <-time.After(persistInterval):
+		}
+	}
+}
+
+// close writes any pending updates and stops the persister.
+func (p *persister) close() {
+	close(p.stop)
+	<-p.done
+}
@@ -0,0 +1,285 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memStore struct {
+	mutex sync.Mutex
+	jobs  map[int]Job
+}
+
+func newMemStore(jobs ...Job) *memStore {
+	ret := &memStore{
+		jobs: make(map[int]Job),
+	}
+
+	for _, j := range jobs {
+		ret.jobs[j.ID] = j
+	}
+
+	return ret
+}
+
+func (s *memStore) Save(ctx context.Context, j Job) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jobs[j.ID] = j
+	return nil
+}
+
+func (s *memStore) Find(ctx context.Context, id int) (*Job, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	j, found := s.jobs[id]
+	if !found {
+		return nil, nil
+	}
+
+	return &j, nil
+}
+
+func (s *memStore) sorted() []Job {
+	var ret []Job
+	for _, j := range s.jobs {
+		ret = append(ret, j)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+
+	return ret
+}
+
+func (s *memStore) FindByStatus(ctx context.Context, statuses []Status) ([]Job, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret []Job
+	for _, j := range s.sorted() {
+		for _, status := range statuses {
+			if j.Status == status {
+				ret = append(ret, j)
+				break
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *memStore) FindRecent(ctx context.Context, limit int) ([]Job, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret []Job
+	sorted := s.sorted()
+	for i := len(sorted) - 1; i >= 0 && len(ret) < limit; i-- {
+		ret = append(ret, sorted[i])
+	}
+
+	return ret, nil
+}
+
+func (s *memStore) MaxID(ctx context.Context) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := 0
+	for id := range s.jobs {
+		if id > ret {
+			ret = id
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *memStore) Prune(ctx context.Context, keep int) error {
+	return nil
+}
+
+func (s *memStore) get(id int) Job {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs[id]
+}
+
+const testJobType = "test"
+
+func TestSetStore(t *testing.T) {
+	addTime := time.Now().Add(-time.Hour)
+	input, _ := json.Marshal("input")
+
+	store := newMemStore(
+		Job{ID: 3, Status: StatusFinished, Description: "finished", AddTime: addTime},
+		Job{ID: 5, Status: StatusRunning, Description: "running", AddTime: addTime, Type: testJobType, Input: input},
+		Job{ID: 6, Status: StatusReady, Description: "ready", AddTime: addTime},
+		Job{ID: 7, Status: StatusStopping, Description: "stopping", AddTime: addTime},
+	)
+
+	m := NewManager()
+	m.RegisterFactory(testJobType, func(input []byte) (JobExec, error) {
+		return newTestExec(nil), nil
+	})
+
+	if err := m.SetStore(context.Background(), store); err != nil {
+		t.Errorf("SetStore error: %v", err)
+		return
+	}
+
+	assert := assert.New(t)
+
+	// expect queued and running jobs to be interrupted
+	queue := m.GetQueue()
+	if assert.Len(queue, 2) {
+		assert.Equal(5, queue[0].ID)
+		assert.Equal(StatusInterrupted, queue[0].Status)
+		assert.True(m.IsResumable(queue[0]))
+
+		assert.Equal(6, queue[1].ID)
+		assert.Equal(StatusInterrupted, queue[1].Status)
+
+		// no type, so not resumable
+		assert.False(m.IsResumable(queue[1]))
+	}
+
+	// expect new job ids to follow the stored ids
+	exec := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "new job", exec)
+	assert.Equal(8, jobID)
+
+	// expect interrupted jobs to not block the queue
+	select {
+	case <-exec.started:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("exec was not started")
+	}
+
+	close(exec.finish)
+	time.Sleep(sleepTime)
+
+	// expect finished jobs to be found from the store
+	j := m.GetJob(3)
+	if assert.NotNil(j) {
+		assert.Equal(StatusFinished, j.Status)
+	}
+
+	m.Stop()
+
+	// expect stopping job to be cancelled
+	assert.Equal(StatusCancelled, store.get(7).Status)
+
+	// expect new job to be written to the store
+	assert.Equal(StatusFinished, store.get(jobID).Status)
+}
+
+func TestResumeJob(t *testing.T) {
+	input, _ := json.Marshal("input")
+
+	store := newMemStore(
+		Job{ID: 1, Status: StatusRunning, Description: "running", Type: testJobType, Input: input},
+		Job{ID: 2, Status: StatusRunning, Description: "not resumable"},
+	)
+
+	var resumedInput string
+	exec := newTestExec(make(chan struct{}))
+
+	m := NewManager()
+	m.RegisterFactory(testJobType, func(input []byte) (JobExec, error) {
+		if err := json.Unmarshal(input, &resumedInput); err != nil {
+			return nil, err
+		}
+		return exec, nil
+	})
+
+	if err := m.SetStore(context.Background(), store); err != nil {
+		t.Errorf("SetStore error: %v", err)
+		return
+	}
+
+	assert := assert.New(t)
+
+	assert.Error(m.ResumeJob(context.Background(), 2))
+	assert.Error(m.ResumeJob(context.Background(), 3))
+
+	if err := m.ResumeJob(context.Background(), 1); err != nil {
+		t.Errorf("ResumeJob error: %v", err)
+		return
+	}
+
+	select {
+	case <-exec.started:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("exec was not started")
+	}
+
+	assert.Equal("input", resumedInput)
+
+	j := m.GetJob(1)
+	assert.Equal(StatusRunning, j.Status)
+	assert.False(m.IsResumable(*j))
+
+	close(exec.finish)
+	time.Sleep(sleepTime)
+
+	// cancel the unresumable job
+	m.CancelJob(2)
+
+	history, err := m.GetHistory(context.Background(), 10)
+	if assert.NoError(err) && assert.Len(history, 2) {
+		assert.Equal(2, history[0].ID)
+		assert.Equal(StatusCancelled, history[0].Status)
+		assert.Equal(1, history[1].ID)
+		assert.Equal(StatusFinished, history[1].Status)
+	}
+
+	m.Stop()
+
+	assert.Equal(StatusFinished, store.get(1).Status)
+	assert.Equal(StatusCancelled, store.get(2).Status)
+}
+
+func TestShutdown(t *testing.T) {
+	store := newMemStore()
+
+	m := NewManager()
+	if err := m.SetStore(context.Background(), store); err != nil {
+		t.Errorf("SetStore error: %v", err)
+		return
+	}
+
+	exec := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "running job", exec)
+
+	select {
+	case <-exec.started:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("exec was not started")
+	}
+
+	m.Shutdown()
+
+	// expect running job to be stored as running, without being cancelled
+	assert.Equal(t, StatusRunning, store.get(jobID).Status)
+
+	close(exec.finish)
+	time.Sleep(sleepTime)
+
+	// expect no writes after shutdown
+	assert.Equal(t, StatusRunning, store.get(jobID).Status)
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type APIKeyScope string
+
+const (
+	// APIKeyScopeReadOnly keys can only perform queries.
+	APIKeyScopeReadOnly APIKeyScope = "READ_ONLY"
+	// APIKeyScopeReadWrite keys can perform queries and mutations.
+	APIKeyScopeReadWrite APIKeyScope = "READ_WRITE"
+)
+
+var AllAPIKeyScope = []APIKeyScope{
+	APIKeyScopeReadOnly,
+	APIKeyScopeReadWrite,
+}
+
+func (e APIKeyScope) IsValid() bool {
+	switch e {
+	case APIKeyScopeReadOnly, APIKeyScopeReadWrite:
+		return true
+	}
+	return false
+}
+
+func (e APIKeyScope) String() string {
+	return string(e)
+}
+
+func (e *APIKeyScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeyScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APIKeyScope", str)
+	}
+	return nil
+}
+
+func (e APIKeyScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type APIKeyReader interface {
+	All(ctx context.Context) ([]*APIKey, error)
+	Find(ctx context.Context, id int) (*APIKey, error)
+	// FindByUserID returns the keys of the user with the provided id, or
+	// the keys of the configured user if userID is nil.
+	FindByUserID(ctx context.Context, userID *int) ([]*APIKey, error)
+}
+
+type APIKeyWriter interface {
+	Create(ctx context.Context, obj *APIKey) error
+	Destroy(ctx context.Context, id int) error
+	UpdateLastUsed(ctx context.Context, id int, lastUsedAt time.Time) error
+}
+
+type APIKeyReaderWriter interface {
+	APIKeyReader
+	APIKeyWriter
+}
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns a context for a request authenticated with the provided
+// API key.
+func WithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext returns the API key set with WithAPIKey. Returns nil if
+// the request was not authenticated with a named API key.
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	if v, ok := ctx.Value(apiKeyContextKey{}).(*APIKey); ok {
+		return v
+	}
+
+	return nil
+}
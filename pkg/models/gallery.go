@@ -19,6 +19,12 @@ type GalleryFilterType struct {
 	IsZip *bool `json:"is_zip"`
 	// Filter by rating expressed as 1-100
 	Rating100 *IntCriterionInput `json:"rating100"`
+	// Filter by o-counter
+	OCounter *IntCriterionInput `json:"o_counter"`
+	// Filter by view count
+	ViewCount *IntCriterionInput `json:"view_count"`
+	// Filter by last viewed at
+	LastViewedAt *TimestampCriterionInput `json:"last_viewed_at"`
 	// Filter by organized
 	Organized *bool `json:"organized"`
 	// Filter by average image resolution
@@ -63,6 +69,9 @@ type GalleryFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type GalleryUpdateInput struct {
@@ -84,6 +93,8 @@ type GalleryUpdateInput struct {
 
 	// deprecated
 	URL *string `json:"url"`
+
+	CustomFields CustomFieldsInput `json:"custom_fields"`
 }
 
 type GalleryDestroyInput struct {
@@ -35,6 +35,12 @@ type Gallery struct {
 	CreatedAt    json.JSONTime    `json:"created_at,omitempty"`
 	UpdatedAt    json.JSONTime    `json:"updated_at,omitempty"`
 
+	ViewHistory    []json.JSONTime `json:"view_history,omitempty"`
+	OHistory       []json.JSONTime `json:"o_history,omitempty"`
+	LastImageIndex *int            `json:"last_image_index,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
 	// deprecated - for import only
 	URL string `json:"url,omitempty"`
 }
@@ -33,6 +33,8 @@ type Group struct {
 	CreatedAt  json.JSONTime         `json:"created_at,omitempty"`
 	UpdatedAt  json.JSONTime         `json:"updated_at,omitempty"`
 
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
 	// deprecated - for import only
 	URL string `json:"url,omitempty"`
 }
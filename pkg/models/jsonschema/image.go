@@ -23,13 +23,20 @@ type Image struct {
 	Details      string        `json:"details,omitempty"`
 	Photographer string        `json:"photographer,omitempty"`
 	Organized    bool          `json:"organized,omitempty"`
-	OCounter     int           `json:"o_counter,omitempty"`
 	Galleries    []GalleryRef  `json:"galleries,omitempty"`
 	Performers   []string      `json:"performers,omitempty"`
 	Tags         []string      `json:"tags,omitempty"`
 	Files        []string      `json:"files,omitempty"`
 	CreatedAt    json.JSONTime `json:"created_at,omitempty"`
 	UpdatedAt    json.JSONTime `json:"updated_at,omitempty"`
+
+	// deprecated - for import only
+	OCounter int `json:"o_counter,omitempty"`
+
+	ViewHistory []json.JSONTime `json:"view_history,omitempty"`
+	OHistory    []json.JSONTime `json:"o_history,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func (s Image) Filename(basename string, hash string) string {
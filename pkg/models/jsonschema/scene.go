@@ -79,6 +79,11 @@ type Scene struct {
 
 	PlayDuration float64          `json:"play_duration,omitempty"`
 	StashIDs     []models.StashID `json:"stash_ids,omitempty"`
+
+	StartPoint *float64 `json:"start_point,omitempty"`
+	EndPoint   *float64 `json:"end_point,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func (s Scene) Filename(id int, basename string, hash string) string {
@@ -19,6 +19,8 @@ type Tag struct {
 	IgnoreAutoTag bool          `json:"ignore_auto_tag,omitempty"`
 	CreatedAt     json.JSONTime `json:"created_at,omitempty"`
 	UpdatedAt     json.JSONTime `json:"updated_at,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func (s Tag) Filename() string {
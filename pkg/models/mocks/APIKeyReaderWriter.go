@@ -0,0 +1,128 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// APIKeyReaderWriter is an autogenerated mock type for the APIKeyReaderWriter type
+type APIKeyReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx
+func (_m *APIKeyReaderWriter) All(ctx context.Context) ([]*models.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, obj
+func (_m *APIKeyReaderWriter) Create(ctx context.Context, obj *models.APIKey) error {
+	ret := _m.Called(ctx, obj)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIKey) error); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Destroy provides a mock function with given fields: ctx, id
+func (_m *APIKeyReaderWriter) Destroy(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *APIKeyReaderWriter) Find(ctx context.Context, id int) (*models.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *APIKeyReaderWriter) FindByUserID(ctx context.Context, userID *int) ([]*models.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, *int) []*models.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastUsed provides a mock function with given fields: ctx, id, lastUsedAt
+func (_m *APIKeyReaderWriter) UpdateLastUsed(ctx context.Context, id int, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, id, lastUsedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
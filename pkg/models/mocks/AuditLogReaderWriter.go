@@ -0,0 +1,121 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AuditLogReaderWriter is an autogenerated mock type for the AuditLogReaderWriter type
+type AuditLogReaderWriter struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, entry
+func (_m *AuditLogReaderWriter) Create(ctx context.Context, entry *models.AuditLogEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLogEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CurrentValues provides a mock function with given fields: ctx, objectType, id
+func (_m *AuditLogReaderWriter) CurrentValues(ctx context.Context, objectType models.AuditObjectType, id int) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, objectType, id)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditObjectType, int) map[string]interface{}); ok {
+		r0 = rf(ctx, objectType, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditObjectType, int) error); ok {
+		r1 = rf(ctx, objectType, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *AuditLogReaderWriter) Find(ctx context.Context, id int) (*models.AuditLogEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.AuditLogEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.AuditLogEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuditLogEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Query provides a mock function with given fields: ctx, filter, findFilter
+func (_m *AuditLogReaderWriter) Query(ctx context.Context, filter models.AuditLogFilter, findFilter *models.FindFilterType) ([]*models.AuditLogEntry, int, error) {
+	ret := _m.Called(ctx, filter, findFilter)
+
+	var r0 []*models.AuditLogEntry
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditLogFilter, *models.FindFilterType) []*models.AuditLogEntry); ok {
+		r0 = rf(ctx, filter, findFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditLogEntry)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditLogFilter, *models.FindFilterType) int); ok {
+		r1 = rf(ctx, filter, findFilter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, models.AuditLogFilter, *models.FindFilterType) error); ok {
+		r2 = rf(ctx, filter, findFilter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetReverted provides a mock function with given fields: ctx, id, revertedAt
+func (_m *AuditLogReaderWriter) SetReverted(ctx context.Context, id int, revertedAt time.Time) error {
+	ret := _m.Called(ctx, id, revertedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, revertedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobReaderWriter is an autogenerated mock type for the JobReaderWriter type
+type JobReaderWriter struct {
+	mock.Mock
+}
+
+// DestroyFinished provides a mock function with given fields: ctx, keep
+func (_m *JobReaderWriter) DestroyFinished(ctx context.Context, keep int) error {
+	ret := _m.Called(ctx, keep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *JobReaderWriter) Find(ctx context.Context, id int) (*models.JobRecord, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.JobRecord
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.JobRecord); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByStatus provides a mock function with given fields: ctx, statuses
+func (_m *JobReaderWriter) FindByStatus(ctx context.Context, statuses []string) ([]*models.JobRecord, error) {
+	ret := _m.Called(ctx, statuses)
+
+	var r0 []*models.JobRecord
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.JobRecord); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.JobRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRecent provides a mock function with given fields: ctx, limit
+func (_m *JobReaderWriter) FindRecent(ctx context.Context, limit int) ([]*models.JobRecord, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []*models.JobRecord
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.JobRecord); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.JobRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MaxID provides a mock function with given fields: ctx
+func (_m *JobReaderWriter) MaxID(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, job
+func (_m *JobReaderWriter) Save(ctx context.Context, job *models.JobRecord) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JobRecord) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -22,6 +22,10 @@ type Database struct {
 	Studio         *StudioReaderWriter
 	Tag            *TagReaderWriter
 	SavedFilter    *SavedFilterReaderWriter
+	Job            *JobReaderWriter
+	User           *UserReaderWriter
+	APIKey         *APIKeyReaderWriter
+	AuditLog       *AuditLogReaderWriter
 }
 
 func (*Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -71,6 +75,10 @@ func NewDatabase() *Database {
 		Studio:         &StudioReaderWriter{},
 		Tag:            &TagReaderWriter{},
 		SavedFilter:    &SavedFilterReaderWriter{},
+		Job:            &JobReaderWriter{},
+		User:           &UserReaderWriter{},
+		APIKey:         &APIKeyReaderWriter{},
+		AuditLog:       &AuditLogReaderWriter{},
 	}
 }
 
@@ -87,6 +95,10 @@ func (db *Database) AssertExpectations(t mock.TestingT) {
 	db.Studio.AssertExpectations(t)
 	db.Tag.AssertExpectations(t)
 	db.SavedFilter.AssertExpectations(t)
+	db.Job.AssertExpectations(t)
+	db.User.AssertExpectations(t)
+	db.APIKey.AssertExpectations(t)
+	db.AuditLog.AssertExpectations(t)
 }
 
 func (db *Database) Repository() models.Repository {
@@ -104,5 +116,9 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		Job:            db.Job,
+		User:           db.User,
+		APIKey:         db.APIKey,
+		AuditLog:       db.AuditLog,
 	}
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// APIKey is a named API key. The key itself is not stored; keys are signed
+// tokens which contain the id of the APIKey.
+type APIKey struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// UserID is the id of the user the key belongs to. Nil for the user
+	// configured in the configuration.
+	UserID     *int        `json:"user_id"`
+	Scope      APIKeyScope `json:"scope"`
+	CreatedAt  time.Time   `json:"created_at"`
+	ExpiresAt  *time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time  `json:"last_used_at"`
+}
+
+func NewAPIKey() APIKey {
+	return APIKey{
+		Scope:     APIKeyScopeReadWrite,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsExpired returns true if the key has an expiry time before t.
+func (k APIKey) IsExpired(t time.Time) bool {
+	return k.ExpiresAt != nil && !t.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type AuditObjectType string
+
+const (
+	AuditObjectTypeScene     AuditObjectType = "SCENE"
+	AuditObjectTypeImage     AuditObjectType = "IMAGE"
+	AuditObjectTypeGallery   AuditObjectType = "GALLERY"
+	AuditObjectTypePerformer AuditObjectType = "PERFORMER"
+	AuditObjectTypeStudio    AuditObjectType = "STUDIO"
+	AuditObjectTypeTag       AuditObjectType = "TAG"
+	AuditObjectTypeGroup     AuditObjectType = "GROUP"
+)
+
+var AllAuditObjectType = []AuditObjectType{
+	AuditObjectTypeScene,
+	AuditObjectTypeImage,
+	AuditObjectTypeGallery,
+	AuditObjectTypePerformer,
+	AuditObjectTypeStudio,
+	AuditObjectTypeTag,
+	AuditObjectTypeGroup,
+}
+
+func (e AuditObjectType) IsValid() bool {
+	switch e {
+	case AuditObjectTypeScene, AuditObjectTypeImage, AuditObjectTypeGallery, AuditObjectTypePerformer, AuditObjectTypeStudio, AuditObjectTypeTag, AuditObjectTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (e AuditObjectType) String() string {
+	return string(e)
+}
+
+func (e *AuditObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditObjectType", str)
+	}
+	return nil
+}
+
+func (e AuditObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "CREATE"
+	AuditActionUpdate  AuditAction = "UPDATE"
+	AuditActionDestroy AuditAction = "DESTROY"
+)
+
+var AllAuditAction = []AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionDestroy,
+}
+
+func (e AuditAction) IsValid() bool {
+	switch e {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDestroy:
+		return true
+	}
+	return false
+}
+
+func (e AuditAction) String() string {
+	return string(e)
+}
+
+func (e *AuditAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditAction", str)
+	}
+	return nil
+}
+
+func (e AuditAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// AuditLogEntry records a change made to an object.
+type AuditLogEntry struct {
+	ID         int             `json:"id"`
+	ObjectType AuditObjectType `json:"object_type"`
+	ObjectID   int             `json:"object_id"`
+	Action     AuditAction     `json:"action"`
+	// OldValues contains the values of the changed fields before the change.
+	// Nil for created objects.
+	OldValues map[string]interface{} `json:"old_values"`
+	// NewValues contains the values of the changed fields after the change.
+	// Nil for destroyed objects.
+	NewValues map[string]interface{} `json:"new_values"`
+	// UserID is the user that made the change. Nil for the user configured
+	// in the configuration.
+	UserID *int `json:"user_id"`
+	// JobID is the job that made the change. Nil if the change was not made
+	// by a job.
+	JobID *int `json:"job_id"`
+	// Source is the name of the mutation or task that made the change.
+	Source     string     `json:"source"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevertedAt *time.Time `json:"reverted_at"`
+}
@@ -130,6 +130,10 @@ type BaseFile struct {
 
 	Size int64 `json:"size"`
 
+	// MissingSince is set when the file is found missing from disk without
+	// being cleaned, and cleared when it is scanned again.
+	MissingSince *time.Time `json:"missing_since"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -277,6 +281,56 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
+
+	// AudioStreams and SubtitleStreams are nil if the streams have not been
+	// probed, and empty if the file has no streams of the type.
+	AudioStreams    []VideoFileStream `json:"audio_streams"`
+	SubtitleStreams []VideoFileStream `json:"subtitle_streams"`
+}
+
+// VideoFileStream represents an audio or subtitle stream in a video file.
+type VideoFileStream struct {
+	// Index is the index of the stream among the streams of the same type.
+	Index    int    `json:"index"`
+	Codec    string `json:"codec"`
+	Language string `json:"language,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Default  bool   `json:"default,omitempty"`
+	Forced   bool   `json:"forced,omitempty"`
+}
+
+// DefaultAudioStream returns the index of the audio stream that is played by
+// default. Returns 0 if no stream is marked as the default.
+func (f VideoFile) DefaultAudioStream() int {
+	for _, s := range f.AudioStreams {
+		if s.Default {
+			return s.Index
+		}
+	}
+
+	return 0
+}
+
+// GetAudioStream returns the audio stream with the given index, or nil if
+// it does not exist.
+func (f VideoFile) GetAudioStream(index int) *VideoFileStream {
+	return findVideoFileStream(f.AudioStreams, index)
+}
+
+// GetSubtitleStream returns the subtitle stream with the given index, or nil
+// if it does not exist.
+func (f VideoFile) GetSubtitleStream(index int) *VideoFileStream {
+	return findVideoFileStream(f.SubtitleStreams, index)
+}
+
+func findVideoFileStream(streams []VideoFileStream, index int) *VideoFileStream {
+	for i := range streams {
+		if streams[i].Index == index {
+			return &streams[i]
+		}
+	}
+
+	return nil
 }
 
 func (f VideoFile) GetWidth() int {
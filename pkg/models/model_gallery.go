@@ -70,6 +70,8 @@ type GalleryPartial struct {
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
 	PrimaryFileID *FileID
+
+	CustomFields CustomFieldsInput
 }
 
 func NewGalleryPartial() GalleryPartial {
@@ -79,6 +81,32 @@ func NewGalleryPartial() GalleryPartial {
 	}
 }
 
+// UpdateInput constructs a GalleryUpdateInput using the populated fields in the GalleryPartial object.
+func (g GalleryPartial) UpdateInput(id int) GalleryUpdateInput {
+	var dateStr *string
+	if g.Date.Set {
+		d := g.Date.Value
+		v := d.String()
+		dateStr = &v
+	}
+
+	return GalleryUpdateInput{
+		ID:           strconv.Itoa(id),
+		Title:        g.Title.Ptr(),
+		Code:         g.Code.Ptr(),
+		Urls:         g.URLs.Strings(),
+		Date:         dateStr,
+		Details:      g.Details.Ptr(),
+		Photographer: g.Photographer.Ptr(),
+		Rating100:    g.Rating.Ptr(),
+		Organized:    g.Organized.Ptr(),
+		SceneIds:     g.SceneIDs.IDStrings(),
+		StudioID:     g.StudioID.StringPtr(),
+		TagIds:       g.TagIDs.IDStrings(),
+		PerformerIds: g.PerformerIDs.IDStrings(),
+	}
+}
+
 // IsUserCreated returns true if the gallery was created by the user.
 // This is determined by whether the gallery has a primary file or folder.
 func (g *Gallery) IsUserCreated() bool {
@@ -74,6 +74,8 @@ type GroupPartial struct {
 	SubGroups        *UpdateGroupDescriptions
 	CreatedAt        OptionalTime
 	UpdatedAt        OptionalTime
+
+	CustomFields CustomFieldsInput
 }
 
 func NewGroupPartial() GroupPartial {
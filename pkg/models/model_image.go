@@ -18,7 +18,6 @@ type Image struct {
 	// Rating expressed in 1-100 scale
 	Rating    *int           `json:"rating"`
 	Organized bool           `json:"organized"`
-	OCounter  int            `json:"o_counter"`
 	StudioID  *int           `json:"studio_id"`
 	URLs      RelatedStrings `json:"urls"`
 	Date      *Date          `json:"date"`
@@ -57,7 +56,6 @@ type ImagePartial struct {
 	Details      OptionalString
 	Photographer OptionalString
 	Organized    OptionalBool
-	OCounter     OptionalInt
 	StudioID     OptionalInt
 	CreatedAt    OptionalTime
 	UpdatedAt    OptionalTime
@@ -66,6 +64,8 @@ type ImagePartial struct {
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
 	PrimaryFileID *FileID
+
+	CustomFields CustomFieldsInput
 }
 
 func NewImagePartial() ImagePartial {
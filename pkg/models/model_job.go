@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// JobRecord is a job that has been persisted to the database.
+type JobRecord struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	Input       []byte     `json:"input"`
+	Progress    float64    `json:"progress"`
+	Error       *string    `json:"error"`
+	AddTime     time.Time  `json:"add_time"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -37,6 +38,14 @@ type Scene struct {
 	ResumeTime   float64 `json:"resume_time"`
 	PlayDuration float64 `json:"play_duration"`
 
+	// StartPoint and EndPoint trim the playable range of the primary file,
+	// in seconds. Nil if the scene is not trimmed at the start or end.
+	StartPoint *float64 `json:"start_point"`
+	EndPoint   *float64 `json:"end_point"`
+	// TrimmedPhash is the phash of the trimmed range of the primary file.
+	// The phash fingerprint of the file itself always covers the whole file.
+	TrimmedPhash *int64 `json:"trimmed_phash"`
+
 	URLs         RelatedStrings  `json:"urls"`
 	GalleryIDs   RelatedIDs      `json:"gallery_ids"`
 	TagIDs       RelatedIDs      `json:"tag_ids"`
@@ -69,6 +78,9 @@ type ScenePartial struct {
 	UpdatedAt    OptionalTime
 	ResumeTime   OptionalFloat64
 	PlayDuration OptionalFloat64
+	StartPoint   OptionalFloat64
+	EndPoint     OptionalFloat64
+	TrimmedPhash OptionalInt64
 
 	URLs          *UpdateStrings
 	GalleryIDs    *UpdateIDs
@@ -77,6 +89,8 @@ type ScenePartial struct {
 	GroupIDs      *UpdateGroupIDs
 	StashIDs      *UpdateStashIDs
 	PrimaryFileID *FileID
+
+	CustomFields CustomFieldsInput
 }
 
 func NewScenePartial() ScenePartial {
@@ -251,6 +265,47 @@ func (s Scene) GetHash(hashAlgorithm HashAlgorithm) string {
 	return ""
 }
 
+// GetGeneratedHash returns the hash used to name the generated previews and
+// sprites of the scene. The start and end points are appended for trimmed
+// scenes, so that trimmed scenes sharing a file with other scenes do not
+// overwrite their generated files.
+func (s Scene) GetGeneratedHash(hashAlgorithm HashAlgorithm) string {
+	hash := s.GetHash(hashAlgorithm)
+	if hash == "" || !s.IsTrimmed() {
+		return hash
+	}
+
+	var start, end float64
+	if s.StartPoint != nil {
+		start = *s.StartPoint
+	}
+	if s.EndPoint != nil {
+		end = *s.EndPoint
+	}
+
+	return fmt.Sprintf("%s-%s-%s", hash, strconv.FormatFloat(start, 'f', -1, 64), strconv.FormatFloat(end, 'f', -1, 64))
+}
+
+// IsTrimmed returns true if the scene has a start or end point.
+func (s Scene) IsTrimmed() bool {
+	return s.StartPoint != nil || s.EndPoint != nil
+}
+
+// TrimmedRange returns the start and end times in seconds of the playable
+// range of the scene, for a primary file with the given duration. The end
+// point is ignored if it is past the end of the file.
+func (s Scene) TrimmedRange(duration float64) (start float64, end float64) {
+	end = duration
+	if s.EndPoint != nil && (*s.EndPoint < duration || duration == 0) {
+		end = *s.EndPoint
+	}
+	if s.StartPoint != nil && *s.StartPoint < end {
+		start = *s.StartPoint
+	}
+
+	return start, end
+}
+
 // SceneFileType represents the file metadata for a scene.
 type SceneFileType struct {
 	Size       *string  `graphql:"size" json:"size"`
@@ -79,3 +79,51 @@ func TestScenePartial_UpdateInput(t *testing.T) {
 		})
 	}
 }
+
+func TestScene_TrimmedRange(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		s         Scene
+		duration  float64
+		wantStart float64
+		wantEnd   float64
+	}{
+		{"untrimmed", Scene{}, 100, 0, 100},
+		{"start and end", Scene{StartPoint: ptr(10), EndPoint: ptr(90)}, 100, 10, 90},
+		{"end past duration", Scene{EndPoint: ptr(120)}, 100, 0, 100},
+		{"start past end", Scene{StartPoint: ptr(95), EndPoint: ptr(90)}, 100, 0, 90},
+		{"unknown duration", Scene{StartPoint: ptr(10), EndPoint: ptr(90)}, 0, 10, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.s.TrimmedRange(tt.duration)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("Scene.TrimmedRange() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestScene_GetGeneratedHash(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name string
+		s    Scene
+		want string
+	}{
+		{"untrimmed", Scene{OSHash: "abc"}, "abc"},
+		{"start and end", Scene{OSHash: "abc", StartPoint: ptr(10.5), EndPoint: ptr(90)}, "abc-10.5-90"},
+		{"start only", Scene{OSHash: "abc", StartPoint: ptr(10)}, "abc-10-0"},
+		{"no hash", Scene{StartPoint: ptr(10)}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.GetGeneratedHash(HashAlgorithmOshash); got != tt.want {
+				t.Errorf("Scene.GetGeneratedHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
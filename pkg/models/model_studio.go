@@ -48,6 +48,8 @@ type StudioPartial struct {
 	Aliases  *UpdateStrings
 	TagIDs   *UpdateIDs
 	StashIDs *UpdateStashIDs
+
+	CustomFields CustomFieldsInput
 }
 
 func NewStudioPartial() StudioPartial {
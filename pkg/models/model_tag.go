@@ -56,6 +56,8 @@ type TagPartial struct {
 	Aliases   *UpdateStrings
 	ParentIDs *UpdateIDs
 	ChildIDs  *UpdateIDs
+
+	CustomFields CustomFieldsInput
 }
 
 func NewTagPartial() TagPartial {
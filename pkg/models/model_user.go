@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// User is a user account stored in the database, in addition to the account
+// configured with the username and password in the configuration.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	// bcrypt hash of the password
+	Password  string    `json:"-"`
+	Role      UserRole  `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewUser() User {
+	currentTime := time.Now()
+	return User{
+		Role:      UserRoleViewer,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
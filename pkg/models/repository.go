@@ -27,6 +27,10 @@ type Repository struct {
 	Studio         StudioReaderWriter
 	Tag            TagReaderWriter
 	SavedFilter    SavedFilterReaderWriter
+	Job            JobReaderWriter
+	User           UserReaderWriter
+	APIKey         APIKeyReaderWriter
+	AuditLog       AuditLogReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// AuditLogFilter restricts the audit log entries returned by a query.
+// Nil fields are not used to filter.
+type AuditLogFilter struct {
+	ObjectType *AuditObjectType
+	ObjectID   *int
+	JobID      *int
+	UserID     *int
+}
+
+// AuditLogReader provides all methods to read the audit log.
+type AuditLogReader interface {
+	Find(ctx context.Context, id int) (*AuditLogEntry, error)
+	// Query returns the entries matching the filter, most recent first,
+	// and the total number of matching entries.
+	Query(ctx context.Context, filter AuditLogFilter, findFilter *FindFilterType) ([]*AuditLogEntry, int, error)
+	// CurrentValues returns the current audited values of the object, in
+	// the same form as the values of audit log entries.
+	CurrentValues(ctx context.Context, objectType AuditObjectType, id int) (map[string]interface{}, error)
+}
+
+// AuditLogWriter provides all methods to modify the audit log.
+type AuditLogWriter interface {
+	Create(ctx context.Context, entry *AuditLogEntry) error
+	SetReverted(ctx context.Context, id int, revertedAt time.Time) error
+}
+
+// AuditLogReaderWriter provides all audit log methods.
+type AuditLogReaderWriter interface {
+	AuditLogReader
+	AuditLogWriter
+}
@@ -45,6 +45,8 @@ type GalleryUpdater interface {
 	Update(ctx context.Context, updatedGallery *Gallery) error
 	UpdatePartial(ctx context.Context, id int, updatedGallery GalleryPartial) (*Gallery, error)
 	UpdateImages(ctx context.Context, galleryID int, imageIDs []int) error
+
+	CustomFieldsWriter
 }
 
 // GalleryDestroyer provides methods to destroy galleries.
@@ -64,6 +66,8 @@ type GalleryReader interface {
 	GalleryCounter
 
 	URLLoader
+	ViewDateReader
+	ODateReader
 	FileIDLoader
 	ImageIDLoader
 	SceneIDLoader
@@ -71,7 +75,10 @@ type GalleryReader interface {
 	TagIDLoader
 	FileLoader
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Gallery, error)
+	GetLastImageIndex(ctx context.Context, id int) (*int, error)
 }
 
 // GalleryWriter provides all methods to modify galleries.
@@ -85,6 +92,10 @@ type GalleryWriter interface {
 	RemoveImages(ctx context.Context, galleryID int, imageIDs ...int) error
 	SetCover(ctx context.Context, galleryID int, coverImageID int) error
 	ResetCover(ctx context.Context, galleryID int) error
+
+	OHistoryWriter
+	ViewHistoryWriter
+	SaveActivity(ctx context.Context, id int, lastImageIndex *int) (bool, error)
 }
 
 // GalleryReaderWriter provides all gallery methods.
@@ -42,6 +42,8 @@ type GroupUpdater interface {
 	UpdatePartial(ctx context.Context, id int, updatedGroup GroupPartial) (*Group, error)
 	UpdateFrontImage(ctx context.Context, groupID int, frontImage []byte) error
 	UpdateBackImage(ctx context.Context, groupID int, backImage []byte) error
+
+	CustomFieldsWriter
 }
 
 // GroupDestroyer provides methods to destroy groups.
@@ -69,6 +71,8 @@ type GroupReader interface {
 	ContainingGroupLoader
 	SubGroupLoader
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Group, error)
 	GetFrontImage(ctx context.Context, groupID int) ([]byte, error)
 	HasFrontImage(ctx context.Context, groupID int) (bool, error)
@@ -19,6 +19,7 @@ type ImageFinder interface {
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]*Image, error)
 	FindByGalleryID(ctx context.Context, galleryID int) ([]*Image, error)
 	FindByGalleryIDIndex(ctx context.Context, galleryID int, index uint) (*Image, error)
+	FindDuplicates(ctx context.Context, distance int) ([][]*Image, error)
 }
 
 // ImageQueryer provides methods to query images.
@@ -36,7 +37,6 @@ type ImageCounter interface {
 	Count(ctx context.Context) (int, error)
 	CountByFileID(ctx context.Context, fileID FileID) (int, error)
 	CountByGalleryID(ctx context.Context, galleryID int) (int, error)
-	OCount(ctx context.Context) (int, error)
 	OCountByPerformerID(ctx context.Context, performerID int) (int, error)
 }
 
@@ -51,6 +51,8 @@ type ImageUpdater interface {
 	UpdatePartial(ctx context.Context, id int, partial ImagePartial) (*Image, error)
 	UpdatePerformers(ctx context.Context, imageID int, performerIDs []int) error
 	UpdateTags(ctx context.Context, imageID int, tagIDs []int) error
+
+	CustomFieldsWriter
 }
 
 // ImageDestroyer provides methods to destroy images.
@@ -70,6 +72,8 @@ type ImageReader interface {
 	ImageCounter
 
 	URLLoader
+	ViewDateReader
+	ODateReader
 	FileIDLoader
 	GalleryIDLoader
 	PerformerIDLoader
@@ -78,6 +82,8 @@ type ImageReader interface {
 
 	GalleryCoverFinder
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Image, error)
 	Size(ctx context.Context) (float64, error)
 }
@@ -90,9 +96,9 @@ type ImageWriter interface {
 
 	AddFileID(ctx context.Context, id int, fileID FileID) error
 	RemoveFileID(ctx context.Context, id int, fileID FileID) error
-	IncrementOCounter(ctx context.Context, id int) (int, error)
-	DecrementOCounter(ctx context.Context, id int) (int, error)
-	ResetOCounter(ctx context.Context, id int) (int, error)
+
+	OHistoryWriter
+	ViewHistoryWriter
 }
 
 // ImageReaderWriter provides all image methods.
@@ -0,0 +1,23 @@
+package models
+
+import "context"
+
+// JobReader provides all methods to read jobs.
+type JobReader interface {
+	Find(ctx context.Context, id int) (*JobRecord, error)
+	FindByStatus(ctx context.Context, statuses []string) ([]*JobRecord, error)
+	FindRecent(ctx context.Context, limit int) ([]*JobRecord, error)
+	MaxID(ctx context.Context) (int, error)
+}
+
+// JobWriter provides all methods to modify jobs.
+type JobWriter interface {
+	Save(ctx context.Context, job *JobRecord) error
+	DestroyFinished(ctx context.Context, keep int) error
+}
+
+// JobReaderWriter provides all job methods.
+type JobReaderWriter interface {
+	JobReader
+	JobWriter
+}
@@ -53,6 +53,8 @@ type SceneUpdater interface {
 	Update(ctx context.Context, updatedScene *Scene) error
 	UpdatePartial(ctx context.Context, id int, updatedScene ScenePartial) (*Scene, error)
 	UpdateCover(ctx context.Context, sceneID int, cover []byte) error
+
+	CustomFieldsWriter
 }
 
 // SceneDestroyer provides methods to destroy scenes.
@@ -100,6 +102,8 @@ type SceneReader interface {
 	StashIDLoader
 	VideoFileLoader
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Scene, error)
 	Wall(ctx context.Context, q *string) ([]*Scene, error)
 	Size(ctx context.Context) (float64, error)
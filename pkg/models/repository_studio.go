@@ -50,6 +50,8 @@ type StudioUpdater interface {
 	Update(ctx context.Context, updatedStudio *Studio) error
 	UpdatePartial(ctx context.Context, updatedStudio StudioPartial) (*Studio, error)
 	UpdateImage(ctx context.Context, studioID int, image []byte) error
+
+	CustomFieldsWriter
 }
 
 // StudioDestroyer provides methods to destroy studios.
@@ -78,6 +80,8 @@ type StudioReader interface {
 	StashIDLoader
 	TagIDLoader
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Studio, error)
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 	HasImage(ctx context.Context, studioID int) (bool, error)
@@ -88,6 +92,8 @@ type StudioWriter interface {
 	StudioCreator
 	StudioUpdater
 	StudioDestroyer
+
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // StudioReaderWriter provides all studio methods.
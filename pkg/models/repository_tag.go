@@ -61,6 +61,8 @@ type TagUpdater interface {
 	UpdateImage(ctx context.Context, tagID int, image []byte) error
 	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
 	UpdateChildTags(ctx context.Context, tagID int, parentIDs []int) error
+
+	CustomFieldsWriter
 }
 
 // TagDestroyer provides methods to destroy tags.
@@ -88,6 +90,8 @@ type TagReader interface {
 	AliasLoader
 	TagRelationLoader
 
+	CustomFieldsReader
+
 	All(ctx context.Context) ([]*Tag, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	HasImage(ctx context.Context, tagID int) (bool, error)
@@ -115,6 +115,9 @@ type SceneFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type SceneQueryOptions struct {
@@ -168,7 +171,11 @@ type SceneCreateInput struct {
 	// The first id will be assigned as primary.
 	// Files will be reassigned from existing scenes if applicable.
 	// Files must not already be primary for another scene.
-	FileIds []string `json:"file_ids"`
+	FileIds    []string `json:"file_ids"`
+	StartPoint *float64 `json:"start_point"`
+	EndPoint   *float64 `json:"end_point"`
+
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 type SceneUpdateInput struct {
@@ -197,6 +204,10 @@ type SceneUpdateInput struct {
 	PlayDuration  *float64       `json:"play_duration"`
 	PlayCount     *int           `json:"play_count"`
 	PrimaryFileID *string        `json:"primary_file_id"`
+	StartPoint    *float64       `json:"start_point"`
+	EndPoint      *float64       `json:"end_point"`
+
+	CustomFields CustomFieldsInput `json:"custom_fields"`
 }
 
 type SceneDestroyInput struct {
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SceneSplitField is a field copied from the source scene when splitting a scene.
+type SceneSplitField string
+
+const (
+	SceneSplitFieldTitle      SceneSplitField = "TITLE"
+	SceneSplitFieldCode       SceneSplitField = "CODE"
+	SceneSplitFieldDetails    SceneSplitField = "DETAILS"
+	SceneSplitFieldDirector   SceneSplitField = "DIRECTOR"
+	SceneSplitFieldDate       SceneSplitField = "DATE"
+	SceneSplitFieldRating     SceneSplitField = "RATING"
+	SceneSplitFieldOrganized  SceneSplitField = "ORGANIZED"
+	SceneSplitFieldStudio     SceneSplitField = "STUDIO"
+	SceneSplitFieldURLs       SceneSplitField = "URLS"
+	SceneSplitFieldPerformers SceneSplitField = "PERFORMERS"
+	SceneSplitFieldTags       SceneSplitField = "TAGS"
+	SceneSplitFieldGroups     SceneSplitField = "GROUPS"
+	SceneSplitFieldGalleries  SceneSplitField = "GALLERIES"
+)
+
+var AllSceneSplitField = []SceneSplitField{
+	SceneSplitFieldTitle,
+	SceneSplitFieldCode,
+	SceneSplitFieldDetails,
+	SceneSplitFieldDirector,
+	SceneSplitFieldDate,
+	SceneSplitFieldRating,
+	SceneSplitFieldOrganized,
+	SceneSplitFieldStudio,
+	SceneSplitFieldURLs,
+	SceneSplitFieldPerformers,
+	SceneSplitFieldTags,
+	SceneSplitFieldGroups,
+	SceneSplitFieldGalleries,
+}
+
+func (e SceneSplitField) IsValid() bool {
+	switch e {
+	case SceneSplitFieldTitle, SceneSplitFieldCode, SceneSplitFieldDetails, SceneSplitFieldDirector,
+		SceneSplitFieldDate, SceneSplitFieldRating, SceneSplitFieldOrganized, SceneSplitFieldStudio,
+		SceneSplitFieldURLs, SceneSplitFieldPerformers, SceneSplitFieldTags, SceneSplitFieldGroups,
+		SceneSplitFieldGalleries:
+		return true
+	}
+	return false
+}
+
+func (e SceneSplitField) String() string {
+	return string(e)
+}
+
+func (e *SceneSplitField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneSplitField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneSplitField", str)
+	}
+	return nil
+}
+
+func (e SceneSplitField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
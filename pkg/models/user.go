@@ -0,0 +1,164 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type UserRole string
+
+const (
+	// UserRoleAdmin can perform all operations, including configuration and
+	// metadata tasks.
+	UserRoleAdmin UserRole = "ADMIN"
+	// UserRoleEditor can view and edit content.
+	UserRoleEditor UserRole = "EDITOR"
+	// UserRoleViewer can view content, and record their own play history
+	// and ratings.
+	UserRoleViewer UserRole = "VIEWER"
+)
+
+var AllUserRole = []UserRole{
+	UserRoleAdmin,
+	UserRoleEditor,
+	UserRoleViewer,
+}
+
+func (e UserRole) IsValid() bool {
+	switch e {
+	case UserRoleAdmin, UserRoleEditor, UserRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e UserRole) String() string {
+	return string(e)
+}
+
+func (e *UserRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRole", str)
+	}
+	return nil
+}
+
+func (e UserRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e UserRole) level() int {
+	switch e {
+	case UserRoleAdmin:
+		return 2
+	case UserRoleEditor:
+		return 1
+	}
+	return 0
+}
+
+// Includes returns true if the role has at least the permissions of the
+// other role.
+func (e UserRole) Includes(other UserRole) bool {
+	return e.level() >= other.level()
+}
+
+// RoleRestrictions hides content from users with a role. Content with any of
+// the excluded tags or studios, or their child tags or studios, is excluded.
+type RoleRestrictions struct {
+	Role              UserRole `json:"role"`
+	ExcludedTagIDs    []int    `json:"excluded_tag_ids"`
+	ExcludedStudioIDs []int    `json:"excluded_studio_ids"`
+}
+
+// IsEmpty returns true if the restrictions do not exclude any content.
+func (r RoleRestrictions) IsEmpty() bool {
+	return len(r.ExcludedTagIDs) == 0 && len(r.ExcludedStudioIDs) == 0
+}
+
+type UserReader interface {
+	All(ctx context.Context) ([]*User, error)
+	Find(ctx context.Context, id int) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+}
+
+type UserWriter interface {
+	Create(ctx context.Context, obj *User) error
+	Update(ctx context.Context, obj *User) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
+
+type userContextKey struct{}
+
+// WithUserID returns a context for the user with the provided id. Play
+// history, O history, resume points and ratings are stored separately for
+// each user.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userContextKey{}, userID)
+}
+
+// UserIDFromContext returns the id of the user set with WithUserID. Returns
+// nil for the user configured in the configuration, which owns the shared
+// history and ratings.
+func UserIDFromContext(ctx context.Context) *int {
+	if v, ok := ctx.Value(userContextKey{}).(int); ok {
+		return &v
+	}
+
+	return nil
+}
+
+type roleContextKey struct{}
+
+type roleContextValue struct {
+	role         UserRole
+	restrictions *RoleRestrictions
+}
+
+// WithRole returns a context for a user with the provided role. If
+// restrictions is not nil, then content excluded by the restrictions is
+// hidden from queries using the context.
+func WithRole(ctx context.Context, role UserRole, restrictions *RoleRestrictions) context.Context {
+	if restrictions != nil && restrictions.IsEmpty() {
+		restrictions = nil
+	}
+
+	return context.WithValue(ctx, roleContextKey{}, roleContextValue{
+		role:         role,
+		restrictions: restrictions,
+	})
+}
+
+// RoleFromContext returns the role set with WithRole. Returns UserRoleAdmin
+// if no role is set, which is the case for the configured user, API key
+// requests and internal tasks.
+func RoleFromContext(ctx context.Context) UserRole {
+	if v, ok := ctx.Value(roleContextKey{}).(roleContextValue); ok {
+		return v.role
+	}
+
+	return UserRoleAdmin
+}
+
+// RestrictionsFromContext returns the content restrictions set with
+// WithRole. Returns nil if content is not restricted.
+func RestrictionsFromContext(ctx context.Context) *RoleRestrictions {
+	if v, ok := ctx.Value(roleContextKey{}).(roleContextValue); ok {
+		return v.restrictions
+	}
+
+	return nil
+}
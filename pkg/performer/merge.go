@@ -0,0 +1,243 @@
+package performer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+var ErrMergeDestinationInSource = errors.New("destination performer cannot be in source list")
+
+// Merge merges the source performers into the destination performer, and
+// destroys the source performers.
+//
+// The scenes, images and galleries of the source performers are moved to the
+// destination performer. Groups are related to performers through their
+// scenes, so they follow the moved scenes.
+//
+// The aliases, URLs, tags and stash IDs of all performers are combined, and
+// the names of the performers other than the resulting name are added as
+// aliases. Custom fields are combined, with the destination value used where
+// a field is set on more than one performer. If the destination performer has
+// no image, the image of the first source performer with an image is used.
+//
+// Fields set in values override the merged values, so that the value of
+// each field can be chosen from either side.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.PerformerPartial, qb models.PerformerReaderWriter) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	if slices.Contains(sourceIDs, destinationID) {
+		return ErrMergeDestinationInSource
+	}
+
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	dest, err := qb.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination performer %d: %w", destinationID, err)
+	}
+	if dest == nil {
+		return &NotFoundError{destinationID}
+	}
+
+	sources, err := qb.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source performers: %w", err)
+	}
+
+	// performers hidden by content restrictions are not found
+	for i, src := range sources {
+		if src == nil {
+			return &NotFoundError{sourceIDs[i]}
+		}
+	}
+
+	merged, err := mergeValues(ctx, dest, sources, values, qb)
+	if err != nil {
+		return err
+	}
+
+	image, err := mergeImage(ctx, dest, sources, qb)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging performers: %w", err)
+	}
+
+	// validate after the source performers are destroyed, so that their
+	// names may be used by the destination performer
+	if err := ValidateUpdate(ctx, destinationID, merged, qb); err != nil {
+		return err
+	}
+
+	if _, err := qb.UpdatePartial(ctx, destinationID, merged); err != nil {
+		return fmt.Errorf("updating performer: %w", err)
+	}
+
+	if image != nil {
+		if err := qb.UpdateImage(ctx, destinationID, image); err != nil {
+			return fmt.Errorf("updating performer image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeValues returns values, with the relationships which are not set in
+// values combined from the destination and source performers.
+func mergeValues(ctx context.Context, dest *models.Performer, sources []*models.Performer, values models.PerformerPartial, qb models.PerformerReader) (models.PerformerPartial, error) {
+	all := append([]*models.Performer{dest}, sources...)
+	for _, p := range all {
+		if err := p.LoadRelationships(ctx, qb); err != nil {
+			return values, fmt.Errorf("loading performer relationships from %d: %w", p.ID, err)
+		}
+		if err := p.LoadURLs(ctx, qb); err != nil {
+			return values, fmt.Errorf("loading performer urls from %d: %w", p.ID, err)
+		}
+	}
+
+	ret := values
+
+	if ret.Aliases == nil {
+		name := dest.Name
+		if ret.Name.Set {
+			name = ret.Name.Value
+		}
+
+		var aliases []string
+		for _, p := range all {
+			aliases = append(aliases, p.Name)
+			aliases = append(aliases, p.Aliases.List()...)
+		}
+
+		// remove the name of the merged performer from its aliases
+		aliases = slices.DeleteFunc(stringslice.UniqueFold(aliases), func(alias string) bool {
+			return strings.EqualFold(alias, name)
+		})
+
+		ret.Aliases = &models.UpdateStrings{
+			Values: aliases,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	if ret.URLs == nil {
+		var urls []string
+		for _, p := range all {
+			urls = sliceutil.AppendUniques(urls, p.URLs.List())
+		}
+
+		ret.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	if ret.TagIDs == nil {
+		var tagIDs []int
+		for _, p := range all {
+			tagIDs = sliceutil.AppendUniques(tagIDs, p.TagIDs.List())
+		}
+
+		ret.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	if ret.StashIDs == nil {
+		stashIDs := &models.UpdateStashIDs{
+			Mode: models.RelationshipUpdateModeSet,
+		}
+		for _, p := range all {
+			for _, s := range p.StashIDs.List() {
+				stashIDs.AddUnique(s)
+			}
+		}
+
+		ret.StashIDs = stashIDs
+	}
+
+	if ret.CustomFields.Full == nil {
+		customFields, err := mergeCustomFields(ctx, dest, sources, qb)
+		if err != nil {
+			return values, err
+		}
+
+		// fields set in values take precedence
+		for k, v := range ret.CustomFields.Partial {
+			customFields[k] = v
+		}
+
+		ret.CustomFields.Partial = customFields
+	}
+
+	return ret, nil
+}
+
+// mergeCustomFields returns the custom fields of the source performers which
+// are not set on the destination performer. If a field is set on more than
+// one source performer, the value of the first is used.
+func mergeCustomFields(ctx context.Context, dest *models.Performer, sources []*models.Performer, qb models.CustomFieldsReader) (map[string]interface{}, error) {
+	destFields, err := qb.GetCustomFields(ctx, dest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting custom fields for performer %d: %w", dest.ID, err)
+	}
+
+	ret := make(map[string]interface{})
+	for _, src := range sources {
+		fields, err := qb.GetCustomFields(ctx, src.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting custom fields for performer %d: %w", src.ID, err)
+		}
+
+		for k, v := range fields {
+			if _, found := destFields[k]; found {
+				continue
+			}
+			if _, found := ret[k]; found {
+				continue
+			}
+
+			ret[k] = v
+		}
+	}
+
+	return ret, nil
+}
+
+// mergeImage returns the image of the first source performer with an image,
+// if the destination performer has no image. Returns nil otherwise.
+func mergeImage(ctx context.Context, dest *models.Performer, sources []*models.Performer, qb models.PerformerReader) ([]byte, error) {
+	hasImage, err := qb.HasImage(ctx, dest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("checking image for performer %d: %w", dest.ID, err)
+	}
+	if hasImage {
+		return nil, nil
+	}
+
+	for _, src := range sources {
+		image, err := qb.GetImage(ctx, src.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting image for performer %d: %w", src.ID, err)
+		}
+
+		if len(image) > 0 {
+			return image, nil
+		}
+	}
+
+	return nil, nil
+}
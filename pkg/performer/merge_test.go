@@ -0,0 +1,73 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeValues(t *testing.T) {
+	const (
+		destID = iota + 1
+		srcID1
+		srcID2
+	)
+
+	newPerformer := func(id int, name string, aliases []string, urls []string, tagIDs []int, stashIDs []models.StashID) *models.Performer {
+		return &models.Performer{
+			ID:       id,
+			Name:     name,
+			Aliases:  models.NewRelatedStrings(aliases),
+			URLs:     models.NewRelatedStrings(urls),
+			TagIDs:   models.NewRelatedIDs(tagIDs),
+			StashIDs: models.NewRelatedStashIDs(stashIDs),
+		}
+	}
+
+	stashID1 := models.StashID{Endpoint: "endpoint", StashID: "1"}
+	stashID2 := models.StashID{Endpoint: "endpoint", StashID: "2"}
+
+	dest := newPerformer(destID, "Jane Doe", []string{"Jane"}, []string{"url1"}, []int{1}, []models.StashID{stashID1})
+	src1 := newPerformer(srcID1, "Jane Doe (II)", []string{"jane", "Jane D"}, []string{"url1", "url2"}, []int{1, 2}, []models.StashID{stashID1, stashID2})
+	src2 := newPerformer(srcID2, "jane doe", []string{}, []string{}, []int{3}, []models.StashID{})
+	sources := []*models.Performer{src1, src2}
+
+	db := mocks.NewDatabase()
+	db.Performer.On("GetCustomFields", testCtx, destID).Return(map[string]interface{}{"a": "dest"}, nil)
+	db.Performer.On("GetCustomFields", testCtx, srcID1).Return(map[string]interface{}{"a": "src1", "b": "src1"}, nil)
+	db.Performer.On("GetCustomFields", testCtx, srcID2).Return(map[string]interface{}{"b": "src2", "c": "src2"}, nil)
+
+	t.Run("merged", func(t *testing.T) {
+		got, err := mergeValues(testCtx, dest, sources, models.NewPerformerPartial(), db.Performer)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, []string{"Jane", "Jane Doe (II)", "Jane D"}, got.Aliases.Values)
+		assert.Equal(t, []string{"url1", "url2"}, got.URLs.Values)
+		assert.Equal(t, []int{1, 2, 3}, got.TagIDs.IDs)
+		assert.Equal(t, []models.StashID{stashID1, stashID2}, got.StashIDs.StashIDs)
+		assert.Equal(t, map[string]interface{}{"b": "src1", "c": "src2"}, got.CustomFields.Partial)
+	})
+
+	t.Run("values override", func(t *testing.T) {
+		values := models.NewPerformerPartial()
+		values.Name = models.NewOptionalString("Jane D")
+		values.URLs = &models.UpdateStrings{
+			Values: []string{"url3"},
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+		values.CustomFields.Partial = map[string]interface{}{"c": "values"}
+
+		got, err := mergeValues(testCtx, dest, sources, values, db.Performer)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, []string{"Jane Doe", "Jane", "Jane Doe (II)"}, got.Aliases.Values)
+		assert.Equal(t, []string{"url3"}, got.URLs.Values)
+		assert.Equal(t, map[string]interface{}{"b": "src1", "c": "values"}, got.CustomFields.Partial)
+	})
+}
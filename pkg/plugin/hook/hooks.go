@@ -39,10 +39,12 @@ const (
 	PerformerCreatePost  TriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  TriggerEnum = "Performer.Update.Post"
 	PerformerDestroyPost TriggerEnum = "Performer.Destroy.Post"
+	PerformerMergePost   TriggerEnum = "Performer.Merge.Post"
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  TriggerEnum = "Studio.Update.Post"
 	StudioDestroyPost TriggerEnum = "Studio.Destroy.Post"
+	StudioMergePost   TriggerEnum = "Studio.Merge.Post"
 
 	TagCreatePost  TriggerEnum = "Tag.Create.Post"
 	TagUpdatePost  TriggerEnum = "Tag.Update.Post"
@@ -78,10 +80,12 @@ var AllHookTriggerEnum = []TriggerEnum{
 	PerformerCreatePost,
 	PerformerUpdatePost,
 	PerformerDestroyPost,
+	PerformerMergePost,
 
 	StudioCreatePost,
 	StudioUpdatePost,
 	StudioDestroyPost,
+	StudioMergePost,
 
 	TagCreatePost,
 	TagUpdatePost,
@@ -119,10 +123,12 @@ func (e TriggerEnum) IsValid() bool {
 		PerformerCreatePost,
 		PerformerUpdatePost,
 		PerformerDestroyPost,
+		PerformerMergePost,
 
 		StudioCreatePost,
 		StudioUpdatePost,
 		StudioDestroyPost,
+		StudioMergePost,
 
 		TagCreatePost,
 		TagUpdatePost,
@@ -37,19 +37,8 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		}
 	}
 
-	var files []string
-
-	streamPreviewPath := d.Paths.Scene.GetVideoPreviewPath(sceneHash)
-	exists, _ = fsutil.FileExists(streamPreviewPath)
-	if exists {
-		files = append(files, streamPreviewPath)
-	}
-
-	streamPreviewImagePath := d.Paths.Scene.GetWebpPreviewPath(sceneHash)
-	exists, _ = fsutil.FileExists(streamPreviewImagePath)
-	if exists {
-		files = append(files, streamPreviewImagePath)
-	}
+	// previews and sprites of trimmed scenes are named by the trimmed range
+	files := d.rangeFiles(scene)
 
 	transcodePath := d.Paths.Scene.GetTranscodePath(sceneHash)
 	exists, _ = fsutil.FileExists(transcodePath)
@@ -57,18 +46,6 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		files = append(files, transcodePath)
 	}
 
-	spritePath := d.Paths.Scene.GetSpriteImageFilePath(sceneHash)
-	exists, _ = fsutil.FileExists(spritePath)
-	if exists {
-		files = append(files, spritePath)
-	}
-
-	vttPath := d.Paths.Scene.GetSpriteVttFilePath(sceneHash)
-	exists, _ = fsutil.FileExists(vttPath)
-	if exists {
-		files = append(files, vttPath)
-	}
-
 	heatmapPath := d.Paths.Scene.GetInteractiveHeatmapPath(sceneHash)
 	exists, _ = fsutil.FileExists(heatmapPath)
 	if exists {
@@ -78,6 +55,41 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 	return d.Files(files)
 }
 
+// MarkRangeFiles marks for deletion the previews and sprites generated for
+// the trimmed range of the provided scene. The previews and sprites of an
+// untrimmed scene are named by the file hash and may be shared with other
+// scenes of the same file, so they are not marked.
+func (d *FileDeleter) MarkRangeFiles(scene *models.Scene) error {
+	if !scene.IsTrimmed() || scene.GetHash(d.FileNamingAlgo) == "" {
+		return nil
+	}
+
+	return d.Files(d.rangeFiles(scene))
+}
+
+// rangeFiles returns the existing previews and sprites named by the
+// generated hash of the scene.
+func (d *FileDeleter) rangeFiles(scene *models.Scene) []string {
+	generatedHash := scene.GetGeneratedHash(d.FileNamingAlgo)
+
+	paths := []string{
+		d.Paths.Scene.GetVideoPreviewPath(generatedHash),
+		d.Paths.Scene.GetWebpPreviewPath(generatedHash),
+		d.Paths.Scene.GetSpriteImageFilePath(generatedHash),
+		d.Paths.Scene.GetSpriteVttFilePath(generatedHash),
+	}
+
+	var files []string
+	for _, p := range paths {
+		exists, _ := fsutil.FileExists(p)
+		if exists {
+			files = append(files, p)
+		}
+	}
+
+	return files
+}
+
 // MarkMarkerFiles deletes generated files for a scene marker with the
 // provided scene and timestamp.
 func (d *FileDeleter) MarkMarkerFiles(scene *models.Scene, seconds int) error {
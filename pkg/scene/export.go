@@ -17,6 +17,7 @@ import (
 type ExportGetter interface {
 	models.ViewDateReader
 	models.ODateReader
+	models.CustomFieldsReader
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 }
 
@@ -31,13 +32,15 @@ type TagFinder interface {
 // of cover image.
 func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene) (*jsonschema.Scene, error) {
 	newSceneJSON := jsonschema.Scene{
-		Title:     scene.Title,
-		Code:      scene.Code,
-		URLs:      scene.URLs.List(),
-		Details:   scene.Details,
-		Director:  scene.Director,
-		CreatedAt: json.JSONTime{Time: scene.CreatedAt},
-		UpdatedAt: json.JSONTime{Time: scene.UpdatedAt},
+		Title:      scene.Title,
+		Code:       scene.Code,
+		URLs:       scene.URLs.List(),
+		Details:    scene.Details,
+		Director:   scene.Director,
+		CreatedAt:  json.JSONTime{Time: scene.CreatedAt},
+		UpdatedAt:  json.JSONTime{Time: scene.UpdatedAt},
+		StartPoint: scene.StartPoint,
+		EndPoint:   scene.EndPoint,
 	}
 
 	if scene.Date != nil {
@@ -92,6 +95,11 @@ func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene)
 		newSceneJSON.OHistory = append(newSceneJSON.OHistory, json.JSONTime{Time: date})
 	}
 
+	newSceneJSON.CustomFields, err = reader.GetCustomFields(ctx, scene.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting scene custom fields: %v", err)
+	}
+
 	return &newSceneJSON, nil
 }
 
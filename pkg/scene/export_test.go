@@ -43,6 +43,11 @@ var (
 	rating     = 5
 	organized  = true
 	details    = "details"
+
+	customFields = map[string]interface{}{
+		"field1": "value1",
+		"field2": 2,
+	}
 )
 
 var (
@@ -142,6 +147,7 @@ func createFullJSONScene(image string) *jsonschema.Scene {
 		StashIDs: []models.StashID{
 			stashID,
 		},
+		CustomFields: customFields,
 	}
 }
 
@@ -193,6 +199,9 @@ func TestToJSON(t *testing.T) {
 	db.Scene.On("GetCover", testCtx, errImageID).Return(nil, imageErr).Once()
 	db.Scene.On("GetViewDates", testCtx, mock.Anything).Return(nil, nil)
 	db.Scene.On("GetODates", testCtx, mock.Anything).Return(nil, nil)
+	db.Scene.On("GetCustomFields", testCtx, sceneID).Return(customFields, nil).Once()
+	db.Scene.On("GetCustomFields", testCtx, noImageID).Return(nil, nil).Once()
+	db.Scene.On("GetCustomFields", testCtx, errImageID).Return(customFields, nil).Once()
 
 	for i, s := range scenarios {
 		scene := s.input
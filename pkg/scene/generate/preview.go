@@ -68,7 +68,9 @@ func (g PreviewOptions) getStepSizeAndOffset(videoDuration float64) (stepSize fl
 	return
 }
 
-func (g Generator) PreviewVideo(ctx context.Context, input string, videoDuration float64, hash string, options PreviewOptions, fallback bool, useVsync2 bool) error {
+// PreviewVideo generates the preview video of the range of the input video
+// starting at start and lasting videoDuration seconds.
+func (g Generator) PreviewVideo(ctx context.Context, input string, start float64, videoDuration float64, hash string, options PreviewOptions, fallback bool, useVsync2 bool) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -81,7 +83,7 @@ func (g Generator) PreviewVideo(ctx context.Context, input string, videoDuration
 
 	logger.Infof("[generator] generating video preview for %s", input)
 
-	if err := g.generateFile(lockCtx, g.ScenePaths, mp4Pattern, output, g.previewVideo(input, videoDuration, options, fallback, useVsync2)); err != nil {
+	if err := g.generateFile(lockCtx, g.ScenePaths, mp4Pattern, output, g.previewVideo(input, start, videoDuration, options, fallback, useVsync2)); err != nil {
 		return err
 	}
 
@@ -90,10 +92,10 @@ func (g Generator) PreviewVideo(ctx context.Context, input string, videoDuration
 	return nil
 }
 
-func (g *Generator) previewVideo(input string, videoDuration float64, options PreviewOptions, fallback bool, useVsync2 bool) generateFn {
+func (g *Generator) previewVideo(input string, start float64, videoDuration float64, options PreviewOptions, fallback bool, useVsync2 bool) generateFn {
 	// #2496 - generate a single preview video for videos shorter than segments * segment duration
 	if videoDuration < options.SegmentDuration*float64(options.Segments) {
-		return g.previewVideoSingle(input, videoDuration, options, fallback, useVsync2)
+		return g.previewVideoSingle(input, start, videoDuration, options, fallback, useVsync2)
 	}
 
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
@@ -121,7 +123,7 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 
 			tmpFiles = append(tmpFiles, chunkFile.Name())
 
-			time := offset + (float64(i) * stepSize)
+			time := start + offset + (float64(i) * stepSize)
 
 			chunkOptions := previewChunkOptions{
 				StartTime:  time,
@@ -150,10 +152,10 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 	}
 }
 
-func (g *Generator) previewVideoSingle(input string, videoDuration float64, options PreviewOptions, fallback bool, useVsync2 bool) generateFn {
+func (g *Generator) previewVideoSingle(input string, start float64, videoDuration float64, options PreviewOptions, fallback bool, useVsync2 bool) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		chunkOptions := previewChunkOptions{
-			StartTime:  0,
+			StartTime:  start,
 			Duration:   videoDuration,
 			OutputPath: tmpFn,
 			Audio:      options.Audio,
@@ -115,6 +115,8 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 	newScene.UpdatedAt = sceneJSON.UpdatedAt.GetTime()
 	newScene.ResumeTime = sceneJSON.ResumeTime
 	newScene.PlayDuration = sceneJSON.PlayDuration
+	newScene.StartPoint = sceneJSON.StartPoint
+	newScene.EndPoint = sceneJSON.EndPoint
 
 	return newScene
 }
@@ -492,6 +494,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating scene: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.scene.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.scene.ID
 	i.ID = id
 	return &id, nil
@@ -505,6 +511,24 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing scene: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting scene custom fields: %v", err)
+	}
+
 	return nil
 }
 
@@ -37,12 +37,22 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 	if err != nil {
 		return fmt.Errorf("finding destination scene ID %d: %w", destinationID, err)
 	}
+	if dest == nil {
+		return fmt.Errorf("scene with id %d not found", destinationID)
+	}
 
 	sources, err := s.Repository.FindMany(ctx, sourceIDs)
 	if err != nil {
 		return fmt.Errorf("finding source scenes: %w", err)
 	}
 
+	// scenes hidden by content restrictions are not found
+	for i, src := range sources {
+		if src == nil {
+			return fmt.Errorf("scene with id %d not found", sourceIDs[i])
+		}
+	}
+
 	var fileIDs []models.FileID
 
 	for _, src := range sources {
@@ -134,6 +144,13 @@ func (s *Service) mergeSceneMarkers(ctx context.Context, dest *models.Scene, src
 		return fmt.Errorf("finding scene markers: %w", err)
 	}
 
+	return s.moveSceneMarkers(ctx, markers, src, dest, 0)
+}
+
+// moveSceneMarkers moves markers from src to dest, subtracting offset from
+// the marker times. The generated marker files are moved after the
+// transaction is committed.
+func (s *Service) moveSceneMarkers(ctx context.Context, markers []*models.SceneMarker, src *models.Scene, dest *models.Scene, offset float64) error {
 	type rename struct {
 		src  string
 		dest string
@@ -141,31 +158,43 @@ func (s *Service) mergeSceneMarkers(ctx context.Context, dest *models.Scene, src
 
 	var toRename []rename
 
+	srcHash := src.GetHash(s.Config.GetVideoFileNamingAlgorithm())
 	destHash := dest.GetHash(s.Config.GetVideoFileNamingAlgorithm())
 
 	for _, m := range markers {
-		srcHash := src.GetHash(s.Config.GetVideoFileNamingAlgorithm())
+		srcSeconds := int(m.Seconds)
 
 		// updated the scene id
 		m.SceneID = dest.ID
+		m.Seconds -= offset
+		if m.EndSeconds != nil {
+			endSeconds := *m.EndSeconds - offset
+			m.EndSeconds = &endSeconds
+		}
 
 		if err := s.MarkerRepository.Update(ctx, m); err != nil {
 			return fmt.Errorf("updating scene marker %d: %w", m.ID, err)
 		}
 
+		destSeconds := int(m.Seconds)
+		if srcHash == destHash && srcSeconds == destSeconds {
+			// generated files are unchanged
+			continue
+		}
+
 		// move generated files to new location
 		toRename = append(toRename, []rename{
 			{
-				src:  s.Paths.SceneMarkers.GetScreenshotPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetScreenshotPath(destHash, int(m.Seconds)),
+				src:  s.Paths.SceneMarkers.GetScreenshotPath(srcHash, srcSeconds),
+				dest: s.Paths.SceneMarkers.GetScreenshotPath(destHash, destSeconds),
 			},
 			{
-				src:  s.Paths.SceneMarkers.GetThumbnailPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetThumbnailPath(destHash, int(m.Seconds)),
+				src:  s.Paths.SceneMarkers.GetThumbnailPath(srcHash, srcSeconds),
+				dest: s.Paths.SceneMarkers.GetThumbnailPath(destHash, destSeconds),
 			},
 			{
-				src:  s.Paths.SceneMarkers.GetWebpPreviewPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetWebpPreviewPath(destHash, int(m.Seconds)),
+				src:  s.Paths.SceneMarkers.GetWebpPreviewPath(srcHash, srcSeconds),
+				dest: s.Paths.SceneMarkers.GetWebpPreviewPath(destHash, destSeconds),
 			},
 		}...)
 	}
@@ -0,0 +1,267 @@
+package scene
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+// SplitRange is the time range of a new scene split from a source scene.
+type SplitRange struct {
+	Start float64
+	End   float64
+
+	// Title is the title of the new scene. If empty, the title is
+	// derived from the title of the source scene, if it is copied.
+	Title string
+
+	// FileID is the file of the new scene, cut from the source file at the
+	// range. If nil, the new scene references the files of the source scene,
+	// with its start and end points set to the range.
+	FileID *models.FileID
+}
+
+func (r SplitRange) contains(seconds float64) bool {
+	return seconds >= r.Start && seconds < r.End
+}
+
+type SplitOptions struct {
+	// CopyFields are the fields copied from the source scene to the new scenes.
+	CopyFields []models.SceneSplitField
+	// DestroySource destroys the source scene after splitting.
+	// The files of the source scene are not deleted.
+	DestroySource bool
+}
+
+// MarkerSplitRanges returns the split ranges defined by the given markers.
+// A range ends at the end time of the marker if set, otherwise at the
+// start of the next marker or the end of the scene.
+func MarkerSplitRanges(markers []*models.SceneMarker, duration float64) []SplitRange {
+	sorted := slices.Clone(markers)
+	slices.SortFunc(sorted, func(a, b *models.SceneMarker) int {
+		return cmp.Compare(a.Seconds, b.Seconds)
+	})
+
+	ret := make([]SplitRange, len(sorted))
+	for i, m := range sorted {
+		end := duration
+		switch {
+		case m.EndSeconds != nil:
+			end = *m.EndSeconds
+		case i+1 < len(sorted):
+			end = sorted[i+1].Seconds
+		}
+
+		ret[i] = SplitRange{
+			Start: m.Seconds,
+			End:   end,
+			Title: m.Title,
+		}
+	}
+
+	return ret
+}
+
+// SortSplitRanges returns the ranges sorted by start time, and returns an
+// error if any of the ranges are invalid or overlap. The duration of the
+// source scene is ignored if zero.
+func SortSplitRanges(ranges []SplitRange, duration float64) ([]SplitRange, error) {
+	ranges = slices.Clone(ranges)
+	slices.SortFunc(ranges, func(a, b SplitRange) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	if err := validateSplitRanges(ranges, duration); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+func validateSplitRanges(ranges []SplitRange, duration float64) error {
+	if len(ranges) == 0 {
+		return errors.New("at least one range must be provided")
+	}
+
+	for i, r := range ranges {
+		if r.Start < 0 || r.End <= r.Start {
+			return fmt.Errorf("invalid range %v-%v", r.Start, r.End)
+		}
+		if duration > 0 && r.Start >= duration {
+			return fmt.Errorf("range %v-%v starts after the end of the scene", r.Start, r.End)
+		}
+		if i > 0 && r.Start < ranges[i-1].End {
+			return fmt.Errorf("range %v-%v overlaps range %v-%v", r.Start, r.End, ranges[i-1].Start, ranges[i-1].End)
+		}
+	}
+
+	return nil
+}
+
+// Split creates a new scene for each of the given time ranges of the source
+// scene. The chosen fields are copied from the source scene, and the markers
+// of the source scene are moved to the new scene that they fall in. Marker
+// times are rebased to the start of the range if the new scene has its own
+// cut file. Otherwise, the new scene is trimmed to the range of the source
+// files. Returns the new scenes in range order.
+func (s *Service) Split(ctx context.Context, sourceID int, ranges []SplitRange, fileDeleter *FileDeleter, options SplitOptions) ([]*models.Scene, error) {
+	src, err := s.Repository.Find(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding source scene ID %d: %w", sourceID, err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("scene with id %d not found", sourceID)
+	}
+
+	if err := src.LoadRelationships(ctx, s.Repository); err != nil {
+		return nil, fmt.Errorf("loading scene relationships from %d: %w", src.ID, err)
+	}
+
+	var duration float64
+	if f := src.Files.Primary(); f != nil {
+		duration = f.Duration
+	}
+
+	ranges, err = SortSplitRanges(ranges, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	markers, err := s.MarkerRepository.FindBySceneID(ctx, src.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scene markers: %w", err)
+	}
+
+	// the primary file of the source scene is the primary file of the new scenes
+	var srcFileIDs []models.FileID
+	if f := src.Files.Primary(); f != nil {
+		srcFileIDs = append(srcFileIDs, f.ID)
+	}
+	for _, f := range src.Files.List() {
+		if !slices.Contains(srcFileIDs, f.ID) {
+			srcFileIDs = append(srcFileIDs, f.ID)
+		}
+	}
+
+	ret := make([]*models.Scene, len(ranges))
+	for i, r := range ranges {
+		newScene := splitScene(src, i, r, options.CopyFields)
+
+		fileIDs := srcFileIDs
+		offset := 0.0
+		if r.FileID != nil {
+			fileIDs = []models.FileID{*r.FileID}
+			offset = r.Start
+		}
+
+		if newScene.Title == "" && len(fileIDs) == 0 {
+			return nil, errors.New("title must be set if scene has no files")
+		}
+
+		if err := s.Repository.Create(ctx, newScene, fileIDs); err != nil {
+			return nil, fmt.Errorf("creating new scene: %w", err)
+		}
+
+		var rangeMarkers []*models.SceneMarker
+		for _, m := range markers {
+			if r.contains(m.Seconds) {
+				rangeMarkers = append(rangeMarkers, m)
+			}
+		}
+
+		if err := s.moveSceneMarkers(ctx, rangeMarkers, src, newScene, offset); err != nil {
+			return nil, err
+		}
+
+		s.PluginCache.RegisterPostHooks(ctx, newScene.ID, hook.SceneCreatePost, nil, nil)
+
+		ret[i] = newScene
+	}
+
+	if options.DestroySource {
+		// generated files are shared with new scenes referencing the same files
+		deleteGenerated := !slices.ContainsFunc(ranges, func(r SplitRange) bool {
+			return r.FileID == nil
+		})
+		const deleteFile = false
+		if err := s.Destroy(ctx, src, fileDeleter, deleteGenerated, deleteFile); err != nil {
+			return nil, fmt.Errorf("deleting scene %d: %w", src.ID, err)
+		}
+	}
+
+	return ret, nil
+}
+
+// splitScene returns the new scene for the range at index i,
+// with the chosen fields copied from src. A new scene without its own cut
+// file shares the files of src, and stores the range as its start and end
+// points.
+func splitScene(src *models.Scene, i int, r SplitRange, fields []models.SceneSplitField) *models.Scene {
+	now := time.Now()
+	ret := &models.Scene{
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if r.FileID == nil {
+		start, end := r.Start, r.End
+		ret.StartPoint = &start
+		ret.EndPoint = &end
+	}
+
+	copyField := func(f models.SceneSplitField) bool {
+		return slices.Contains(fields, f)
+	}
+
+	switch {
+	case r.Title != "":
+		ret.Title = r.Title
+	case copyField(models.SceneSplitFieldTitle) && src.Title != "":
+		ret.Title = fmt.Sprintf("%s - Part %d", src.Title, i+1)
+	}
+
+	if copyField(models.SceneSplitFieldCode) {
+		ret.Code = src.Code
+	}
+	if copyField(models.SceneSplitFieldDetails) {
+		ret.Details = src.Details
+	}
+	if copyField(models.SceneSplitFieldDirector) {
+		ret.Director = src.Director
+	}
+	if copyField(models.SceneSplitFieldDate) {
+		ret.Date = src.Date
+	}
+	if copyField(models.SceneSplitFieldRating) {
+		ret.Rating = src.Rating
+	}
+	if copyField(models.SceneSplitFieldOrganized) {
+		ret.Organized = src.Organized
+	}
+	if copyField(models.SceneSplitFieldStudio) {
+		ret.StudioID = src.StudioID
+	}
+	if copyField(models.SceneSplitFieldURLs) {
+		ret.URLs = models.NewRelatedStrings(src.URLs.List())
+	}
+	if copyField(models.SceneSplitFieldPerformers) {
+		ret.PerformerIDs = models.NewRelatedIDs(src.PerformerIDs.List())
+	}
+	if copyField(models.SceneSplitFieldTags) {
+		ret.TagIDs = models.NewRelatedIDs(src.TagIDs.List())
+	}
+	if copyField(models.SceneSplitFieldGroups) {
+		ret.Groups = models.NewRelatedGroups(src.Groups.List())
+	}
+	if copyField(models.SceneSplitFieldGalleries) {
+		ret.GalleryIDs = models.NewRelatedIDs(src.GalleryIDs.List())
+	}
+
+	return ret
+}
@@ -0,0 +1,131 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkerSplitRanges(t *testing.T) {
+	end := 25.0
+	markers := []*models.SceneMarker{
+		{Title: "third", Seconds: 40},
+		{Title: "first", Seconds: 10, EndSeconds: &end},
+		{Title: "second", Seconds: 30},
+	}
+
+	got := MarkerSplitRanges(markers, 100)
+	want := []SplitRange{
+		{Start: 10, End: 25, Title: "first"},
+		{Start: 30, End: 40, Title: "second"},
+		{Start: 40, End: 100, Title: "third"},
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestSortSplitRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		ranges   []SplitRange
+		duration float64
+		want     []SplitRange
+		wantErr  bool
+	}{
+		{
+			"sorted",
+			[]SplitRange{{Start: 50, End: 60}, {Start: 0, End: 50}},
+			100,
+			[]SplitRange{{Start: 0, End: 50}, {Start: 50, End: 60}},
+			false,
+		},
+		{
+			"empty",
+			nil,
+			100,
+			nil,
+			true,
+		},
+		{
+			"end before start",
+			[]SplitRange{{Start: 20, End: 10}},
+			100,
+			nil,
+			true,
+		},
+		{
+			"overlapping",
+			[]SplitRange{{Start: 0, End: 50}, {Start: 40, End: 60}},
+			100,
+			nil,
+			true,
+		},
+		{
+			"after end of scene",
+			[]SplitRange{{Start: 100, End: 110}},
+			100,
+			nil,
+			true,
+		},
+		{
+			"unknown duration",
+			[]SplitRange{{Start: 100, End: 110}},
+			0,
+			[]SplitRange{{Start: 100, End: 110}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SortSplitRanges(tt.ranges, tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SortSplitRanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitScene(t *testing.T) {
+	studioID := 2
+	src := &models.Scene{
+		Title:        "Compilation",
+		Details:      "details",
+		StudioID:     &studioID,
+		URLs:         models.NewRelatedStrings([]string{"https://example.com"}),
+		PerformerIDs: models.NewRelatedIDs([]int{3}),
+		TagIDs:       models.NewRelatedIDs([]int{4}),
+	}
+
+	fields := []models.SceneSplitField{
+		models.SceneSplitFieldTitle,
+		models.SceneSplitFieldStudio,
+		models.SceneSplitFieldTags,
+	}
+
+	got := splitScene(src, 1, SplitRange{Start: 10, End: 20}, fields)
+
+	// the range of a scene sharing the source files is stored
+	if assert.NotNil(t, got.StartPoint) && assert.NotNil(t, got.EndPoint) {
+		assert.Equal(t, 10.0, *got.StartPoint)
+		assert.Equal(t, 20.0, *got.EndPoint)
+	}
+	assert.Equal(t, "Compilation - Part 2", got.Title)
+	assert.Equal(t, &studioID, got.StudioID)
+	assert.Equal(t, []int{4}, got.TagIDs.List())
+	assert.Equal(t, "", got.Details)
+	assert.False(t, got.URLs.Loaded())
+	assert.False(t, got.PerformerIDs.Loaded())
+
+	fileID := models.FileID(5)
+	got = splitScene(src, 0, SplitRange{Start: 0, End: 10, Title: "Intro", FileID: &fileID}, nil)
+	assert.Equal(t, "Intro", got.Title)
+	assert.Nil(t, got.StudioID)
+
+	// a scene with its own cut file is not trimmed
+	assert.Nil(t, got.StartPoint)
+	assert.Nil(t, got.EndPoint)
+}
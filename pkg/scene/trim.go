@@ -0,0 +1,127 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ValidateTrim returns an error if the start and end points of a scene are
+// negative, or if the end point is not after the start point.
+func ValidateTrim(start *float64, end *float64) error {
+	if start != nil && *start < 0 {
+		return fmt.Errorf("start point %v must not be negative", *start)
+	}
+	if end != nil && *end <= 0 {
+		return fmt.Errorf("end point %v must be positive", *end)
+	}
+	if start != nil && end != nil && *end <= *start {
+		return fmt.Errorf("end point %v must be after start point %v", *end, *start)
+	}
+
+	return nil
+}
+
+// ValidateUpdateTrim validates the start and end points of the existing
+// scene after applying the partial update.
+func ValidateUpdateTrim(existing models.Scene, partial models.ScenePartial) error {
+	start := existing.StartPoint
+	if partial.StartPoint.Set {
+		start = partial.StartPoint.Ptr()
+	}
+	end := existing.EndPoint
+	if partial.EndPoint.Set {
+		end = partial.EndPoint.Ptr()
+	}
+
+	return ValidateTrim(start, end)
+}
+
+// TrimChanged returns true if the partial update changes the start or end
+// point of the existing scene.
+func TrimChanged(existing models.Scene, partial models.ScenePartial) bool {
+	changed := func(v *float64, o models.OptionalFloat64) bool {
+		if !o.Set {
+			return false
+		}
+
+		n := o.Ptr()
+		if v == nil || n == nil {
+			return (v == nil) != (n == nil)
+		}
+
+		return *v != *n
+	}
+
+	return changed(existing.StartPoint, partial.StartPoint) || changed(existing.EndPoint, partial.EndPoint)
+}
+
+// ReplaceTrimmedFile makes the file cut from the trimmed range of the scene
+// the primary file of the scene, and clears the start and end points of the
+// scene. The previews and sprites generated for the trimmed range are
+// deleted. The previous primary file remains associated with the scene.
+// Markers are rebased to the start of the new file, and markers outside of
+// the trimmed range are deleted.
+func (s *Service) ReplaceTrimmedFile(ctx context.Context, sceneID int, fileID models.FileID, fileDeleter *FileDeleter) error {
+	scene, err := s.Repository.Find(ctx, sceneID)
+	if err != nil {
+		return fmt.Errorf("finding scene %d: %w", sceneID, err)
+	}
+	if scene == nil {
+		return fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	if err := scene.LoadPrimaryFile(ctx, s.File); err != nil {
+		return fmt.Errorf("loading primary file: %w", err)
+	}
+
+	var duration float64
+	if f := scene.Files.Primary(); f != nil {
+		duration = f.Duration
+	}
+	start, end := scene.TrimmedRange(duration)
+
+	if err := s.Repository.AssignFiles(ctx, sceneID, []models.FileID{fileID}); err != nil {
+		return fmt.Errorf("assigning file %d to scene: %w", fileID, err)
+	}
+
+	partial := models.NewScenePartial()
+	partial.PrimaryFileID = &fileID
+	partial.StartPoint = models.OptionalFloat64{Set: true, Null: true}
+	partial.EndPoint = models.OptionalFloat64{Set: true, Null: true}
+	partial.TrimmedPhash = models.OptionalInt64{Set: true, Null: true}
+
+	updated, err := s.Repository.UpdatePartial(ctx, sceneID, partial)
+	if err != nil {
+		return fmt.Errorf("updating scene: %w", err)
+	}
+
+	// the previews and sprites of the trimmed range no longer apply
+	if err := fileDeleter.MarkRangeFiles(scene); err != nil {
+		return fmt.Errorf("deleting generated files: %w", err)
+	}
+
+	markers, err := s.MarkerRepository.FindBySceneID(ctx, sceneID)
+	if err != nil {
+		return fmt.Errorf("finding scene markers: %w", err)
+	}
+
+	var toMove []*models.SceneMarker
+	for _, m := range markers {
+		if m.Seconds < start || m.Seconds >= end {
+			if err := DestroyMarker(ctx, scene, m, s.MarkerRepository, fileDeleter); err != nil {
+				return fmt.Errorf("deleting scene marker %d: %w", m.ID, err)
+			}
+			continue
+		}
+
+		if m.EndSeconds != nil && *m.EndSeconds > end {
+			endSeconds := end
+			m.EndSeconds = &endSeconds
+		}
+		toMove = append(toMove, m)
+	}
+
+	return s.moveSceneMarkers(ctx, toMove, scene, updated, start)
+}
@@ -0,0 +1,66 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestValidateUpdateTrim(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	existing := models.Scene{
+		StartPoint: ptr(10),
+		EndPoint:   ptr(90),
+	}
+
+	tests := []struct {
+		name    string
+		partial models.ScenePartial
+		wantErr bool
+	}{
+		{"unchanged", models.ScenePartial{}, false},
+		{"new start", models.ScenePartial{StartPoint: models.NewOptionalFloat64(20)}, false},
+		{"start after existing end", models.ScenePartial{StartPoint: models.NewOptionalFloat64(95)}, true},
+		{"end before existing start", models.ScenePartial{EndPoint: models.NewOptionalFloat64(5)}, true},
+		{"negative start", models.ScenePartial{StartPoint: models.NewOptionalFloat64(-1)}, true},
+		{"cleared end", models.ScenePartial{EndPoint: models.OptionalFloat64{Set: true, Null: true}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateUpdateTrim(existing, tt.partial); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdateTrim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrimChanged(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	existing := models.Scene{
+		StartPoint: ptr(10),
+	}
+
+	tests := []struct {
+		name    string
+		partial models.ScenePartial
+		want    bool
+	}{
+		{"unchanged", models.ScenePartial{}, false},
+		{"same start", models.ScenePartial{StartPoint: models.NewOptionalFloat64(10)}, false},
+		{"new start", models.ScenePartial{StartPoint: models.NewOptionalFloat64(20)}, true},
+		{"cleared start", models.ScenePartial{StartPoint: models.OptionalFloat64{Set: true, Null: true}}, true},
+		{"cleared unset end", models.ScenePartial{EndPoint: models.OptionalFloat64{Set: true, Null: true}}, false},
+		{"new end", models.ScenePartial{EndPoint: models.NewOptionalFloat64(90)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimChanged(existing, tt.partial); got != tt.want {
+				t.Errorf("TrimChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
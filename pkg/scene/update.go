@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -29,7 +30,8 @@ type UpdateSet struct {
 func (u *UpdateSet) IsEmpty() bool {
 	withoutID := u.Partial
 
-	return withoutID == models.ScenePartial{} &&
+	// partial is not comparable since it contains custom fields
+	return reflect.DeepEqual(withoutID, models.ScenePartial{}) &&
 		u.CoverImage == nil
 }
 
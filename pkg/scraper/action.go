@@ -10,15 +10,17 @@ import (
 type scraperAction string
 
 const (
-	scraperActionScript scraperAction = "script"
-	scraperActionStash  scraperAction = "stash"
-	scraperActionXPath  scraperAction = "scrapeXPath"
-	scraperActionJson   scraperAction = "scrapeJson"
+	scraperActionScript  scraperAction = "script"
+	scraperActionStash   scraperAction = "stash"
+	scraperActionXPath   scraperAction = "scrapeXPath"
+	scraperActionJson    scraperAction = "scrapeJson"
+	scraperActionGraphQL scraperAction = "scrapeGraphQL"
+	scraperActionJS      scraperAction = "js"
 )
 
 func (e scraperAction) IsValid() bool {
 	switch e {
-	case scraperActionScript, scraperActionStash, scraperActionXPath, scraperActionJson:
+	case scraperActionScript, scraperActionStash, scraperActionXPath, scraperActionJson, scraperActionGraphQL, scraperActionJS:
 		return true
 	}
 	return false
@@ -31,6 +33,7 @@ type scraperActionImpl interface {
 
 	scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error)
 	scrapeGalleryByGallery(ctx context.Context, gallery *models.Gallery) (*ScrapedGallery, error)
+	scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error)
 }
 
 func (c config) getScraper(scraper scraperTypeConfig, client *http.Client, globalConfig GlobalConfig) scraperActionImpl {
@@ -43,6 +46,10 @@ func (c config) getScraper(scraper scraperTypeConfig, client *http.Client, globa
 		return newXpathScraper(scraper, client, c, globalConfig)
 	case scraperActionJson:
 		return newJsonScraper(scraper, client, c, globalConfig)
+	case scraperActionGraphQL:
+		return newGraphQLScraper(scraper, client, c, globalConfig)
+	case scraperActionJS:
+		return newJSScraper(scraper, client, c, globalConfig)
 	}
 
 	panic("unknown scraper action: " + scraper.Action)
@@ -40,6 +40,7 @@ type GlobalConfig interface {
 	GetScraperCertCheck() bool
 	GetPythonPath() string
 	GetProxy() string
+	GetCachePath() string
 }
 
 func isCDPPathHTTP(c GlobalConfig) bool {
@@ -77,11 +78,18 @@ type GalleryFinder interface {
 	models.URLLoader
 }
 
+type ImageFinder interface {
+	models.ImageGetter
+	models.FileLoader
+	models.URLLoader
+}
+
 type Repository struct {
 	TxnManager models.TxnManager
 
 	SceneFinder     SceneFinder
 	GalleryFinder   GalleryFinder
+	ImageFinder     ImageFinder
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
 	GroupFinder     match.GroupNamesFinder
@@ -93,6 +101,7 @@ func NewRepository(repo models.Repository) Repository {
 		TxnManager:      repo.TxnManager,
 		SceneFinder:     repo.Scene,
 		GalleryFinder:   repo.Gallery,
+		ImageFinder:     repo.Image,
 		TagFinder:       repo.Tag,
 		PerformerFinder: repo.Performer,
 		GroupFinder:     repo.Group,
@@ -186,6 +195,33 @@ func (c *Cache) ReloadScrapers() {
 	c.scrapers = scrapers
 }
 
+// LoadScraperFile loads the scraper configuration from the yml file at path
+// and adds it to the cache, replacing any scraper with the same id. Returns
+// the loaded scraper.
+func (c *Cache) LoadScraperFile(path string) (*Scraper, error) {
+	conf, err := loadConfigFromYAMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.scrapers == nil {
+		c.scrapers = make(map[string]scraper)
+	}
+
+	s := newGroupScraper(*conf, c.globalConfig)
+	spec := s.spec()
+	c.scrapers[spec.ID] = s
+
+	return &spec, nil
+}
+
+// WrapTransport replaces the transport used by scrapers for http requests
+// with the transport returned by fn, which is passed the current transport.
+// Used to record and replay requests when testing scrapers.
+func (c *Cache) WrapTransport(fn func(base http.RoundTripper) http.RoundTripper) {
+	c.client.Transport = fn(c.client.Transport)
+}
+
 // ListScrapers lists scrapers matching one of the given types.
 // Returns a list of scrapers, sorted by their name.
 func (c Cache) ListScrapers(tys []ScrapeContentType) []*Scraper {
@@ -306,6 +342,35 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 	return nil, nil
 }
 
+// ScrapeIDURL scrapes a given url for the given content using the scraper
+// with the given id. Returns nil if the scraper does not support the url.
+func (c Cache) ScrapeIDURL(ctx context.Context, scraperID string, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	s := c.findScraper(scraperID)
+	if s == nil {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, scraperID)
+	}
+
+	if !s.supportsURL(url, ty) {
+		return nil, nil
+	}
+
+	ul, ok := s.(urlScraper)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot use scraper %s as an url scraper", ErrNotSupported, scraperID)
+	}
+
+	ret, err := ul.viaURL(ctx, c.client, url, ty)
+	if err != nil {
+		return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
+	}
+
+	if ret == nil {
+		return ret, nil
+	}
+
+	return c.postScrape(ctx, ret)
+}
+
 func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty ScrapeContentType) (ScrapedContent, error) {
 	s := c.findScraper(scraperID)
 	if s == nil {
@@ -357,6 +422,27 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
 		}
 
+		if scraped != nil {
+			ret = scraped
+		}
+	case ScrapeContentTypeImage:
+		is, ok := s.(imageScraper)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot use scraper %s as an image scraper", ErrNotSupported, scraperID)
+		}
+
+		image, err := c.getImage(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: unable to load image id %v: %w", scraperID, id, err)
+		}
+
+		// don't assign nil concrete pointer to ret interface, otherwise nil
+		// detection is harder
+		scraped, err := is.viaImage(ctx, c.client, image)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
+		}
+
 		if scraped != nil {
 			ret = scraped
 		}
@@ -426,3 +512,34 @@ func (c Cache) getGallery(ctx context.Context, galleryID int) (*models.Gallery,
 	}
 	return ret, nil
 }
+
+func (c Cache) getImage(ctx context.Context, imageID int) (*models.Image, error) {
+	var ret *models.Image
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.ImageFinder
+
+		var err error
+		ret, err = qb.Find(ctx, imageID)
+		if err != nil {
+			return err
+		}
+
+		if ret == nil {
+			return fmt.Errorf("image with id %d not found", imageID)
+		}
+
+		if err := ret.LoadURLs(ctx, qb); err != nil {
+			return err
+		}
+
+		if err := ret.LoadFiles(ctx, qb); err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
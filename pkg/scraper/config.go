@@ -33,6 +33,12 @@ type config struct {
 	// Configuration for querying gallery by a Gallery fragment
 	GalleryByFragment *scraperTypeConfig `yaml:"galleryByFragment"`
 
+	// Configuration for querying images by an Image fragment
+	ImageByFragment *scraperTypeConfig `yaml:"imageByFragment"`
+
+	// Configuration for querying images by name
+	ImageByName *scraperTypeConfig `yaml:"imageByName"`
+
 	// Configuration for querying scenes by name
 	SceneByName *scraperTypeConfig `yaml:"sceneByName"`
 
@@ -45,6 +51,9 @@ type config struct {
 	// Configuration for querying a gallery by a URL
 	GalleryByURL []*scrapeByURLConfig `yaml:"galleryByURL"`
 
+	// Configuration for querying an image by a URL
+	ImageByURL []*scrapeByURLConfig `yaml:"imageByURL"`
+
 	// Configuration for querying a movie by a URL - deprecated, use GroupByURL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 	GroupByURL []*scrapeByURLConfig `yaml:"groupByURL"`
@@ -63,6 +72,9 @@ type config struct {
 
 	// Scraping driver options
 	DriverOptions *scraperDriverOptions `yaml:"driver"`
+
+	// HTTP request options
+	HTTPOptions *scraperHTTPOptions `yaml:"http"`
 }
 
 func (c config) validate() error {
@@ -82,9 +94,11 @@ func (c config) validate() error {
 		}
 	}
 
-	if c.SceneByFragment != nil {
-		if err := c.SceneByFragment.validate(); err != nil {
-			return err
+	for _, s := range []*scraperTypeConfig{c.SceneByFragment, c.SceneByName, c.SceneByQueryFragment, c.GalleryByFragment, c.ImageByFragment, c.ImageByName} {
+		if s != nil {
+			if err := s.validate(); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -94,7 +108,13 @@ func (c config) validate() error {
 		}
 	}
 
-	for _, s := range c.SceneByURL {
+	for _, s := range append(c.SceneByURL, c.GalleryByURL...) {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range c.ImageByURL {
 		if err := s.validate(); err != nil {
 			return err
 		}
@@ -110,6 +130,12 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.HTTPOptions != nil {
+		if err := c.HTTPOptions.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -126,6 +152,9 @@ type scraperTypeConfig struct {
 	// for xpath name scraper only
 	QueryURL             string               `yaml:"queryURL"`
 	QueryURLReplacements queryURLReplacements `yaml:"queryURLReplace"`
+
+	// for graphql scraper only
+	GraphQL *graphQLRequestConfig `yaml:"graphQL"`
 }
 
 func (c scraperTypeConfig) validate() error {
@@ -137,6 +166,19 @@ func (c scraperTypeConfig) validate() error {
 		return errors.New("script is mandatory for script scraper action")
 	}
 
+	if c.Action == scraperActionJS && len(c.Script) != 1 {
+		return errors.New("script must contain a single file for js scraper action")
+	}
+
+	if c.Action == scraperActionGraphQL {
+		if c.GraphQL == nil {
+			return errors.New("graphQL is mandatory for graphql scraper action")
+		}
+		if err := c.GraphQL.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -295,6 +337,24 @@ func (c config) spec() Scraper {
 		ret.Gallery = &gallery
 	}
 
+	image := ScraperSpec{}
+	if c.ImageByFragment != nil {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeFragment)
+	}
+	if c.ImageByName != nil {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeName)
+	}
+	if len(c.ImageByURL) > 0 {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range c.ImageByURL {
+			image.Urls = append(image.Urls, v.URL...)
+		}
+	}
+
+	if len(image.SupportedScrapes) > 0 {
+		ret.Image = &image
+	}
+
 	group := ScraperSpec{}
 	if len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0 {
 		group.SupportedScrapes = append(group.SupportedScrapes, ScrapeTypeURL)
@@ -319,6 +379,8 @@ func (c config) supports(ty ScrapeContentType) bool {
 		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
 	case ScrapeContentTypeGallery:
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
+	case ScrapeContentTypeImage:
+		return c.ImageByFragment != nil || c.ImageByName != nil || len(c.ImageByURL) > 0
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		return len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0
 	}
@@ -346,6 +408,12 @@ func (c config) matchesURL(url string, ty ScrapeContentType) bool {
 				return true
 			}
 		}
+	case ScrapeContentTypeImage:
+		for _, scraper := range c.ImageByURL {
+			if scraper.matchesURL(url) {
+				return true
+			}
+		}
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		for _, scraper := range c.MovieByURL {
 			if scraper.matchesURL(url) {
@@ -0,0 +1,159 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// ErrFixtureNotFound is returned when a request has no recorded fixture.
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+// fixtureFilename returns the filename of the fixture for the request, based
+// on the method, url and body of the request.
+func fixtureFilename(req *http.Request) (string, error) {
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.Method+" "+req.URL.String()+"\n")
+
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+
+		if _, err := io.Copy(h, body); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) + ".json", nil
+}
+
+type fixtureFileTransport struct {
+	body []byte
+}
+
+func (t fixtureFileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return cachedResponse{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       t.body,
+	}.response(req), nil
+}
+
+type fixtureDirTransport struct {
+	dir string
+}
+
+func (t fixtureDirTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fn, err := fixtureFilename(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, fn))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s %s", ErrFixtureNotFound, req.Method, req.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var r cachedResponse
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decoding fixture %s: %w", fn, err)
+	}
+
+	return r.response(req), nil
+}
+
+// NewFixtureTransport returns a RoundTripper which responds to requests using
+// fixtures instead of making requests. If path is a file, then every request
+// receives the contents of the file. If path is a directory, then responses
+// recorded using NewRecordingTransport are returned, and requests which were
+// not recorded fail with ErrFixtureNotFound.
+func NewFixtureTransport(path string) (http.RoundTripper, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return fixtureDirTransport{dir: path}, nil
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return fixtureFileTransport{body: body}, nil
+}
+
+type recordingTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fn, err := fixtureFilename(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := cachedResponse{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fsutil.EnsureDirAll(t.dir); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(t.dir, fn), data, 0644); err != nil {
+		return nil, fmt.Errorf("writing fixture: %w", err)
+	}
+
+	return r.response(req), nil
+}
+
+// NewRecordingTransport returns a RoundTripper which makes requests using
+// base, and records the responses in dir. The recorded responses may be
+// replayed using NewFixtureTransport.
+func NewRecordingTransport(base http.RoundTripper, dir string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return recordingTransport{
+		base: base,
+		dir:  dir,
+	}
+}
@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordFixtures(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"path": "`+r.URL.Path+`", "body": "`+string(body)+`"}`)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: NewRecordingTransport(nil, dir)}
+
+	post := func(client *http.Client, body string) (string, error) {
+		resp, err := client.Post(ts.URL+"/graphql", "application/json", bytes.NewReader([]byte(body)))
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		return string(data), err
+	}
+
+	recorded, err := post(recording, "a")
+	if err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+	if _, err := post(recording, "b"); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(t, files, 2)
+
+	transport, err := NewFixtureTransport(dir)
+	if err != nil {
+		t.Fatalf("NewFixtureTransport() error = %v", err)
+	}
+	replay := &http.Client{Transport: transport}
+
+	// requests are matched by body as well as url
+	got, err := post(replay, "a")
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	assert.Equal(t, recorded, got)
+
+	_, err = post(replay, "c")
+	assert.True(t, errors.Is(err, ErrFixtureNotFound))
+}
+
+func TestFixtureFile(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "page.html")
+	if err := os.WriteFile(fn, []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := NewFixtureTransport(fn)
+	if err != nil {
+		t.Fatalf("NewFixtureTransport() error = %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get("https://example.com/any")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "<html></html>", string(body))
+}
@@ -0,0 +1,354 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type graphQLRequestConfig struct {
+	// Endpoint is the URL of the GraphQL API. May contain placeholders.
+	Endpoint string `yaml:"endpoint"`
+
+	// Query is the GraphQL query document.
+	Query string `yaml:"query"`
+
+	// OperationName selects the operation in the query document to execute.
+	// Only required if the document contains multiple operations.
+	OperationName string `yaml:"operationName"`
+
+	// Variables are the query variables. Placeholders in string values are
+	// replaced with the input values.
+	Variables map[string]interface{} `yaml:"variables"`
+}
+
+func (c graphQLRequestConfig) validate() error {
+	if strings.TrimSpace(c.Endpoint) == "" {
+		return errors.New("endpoint is mandatory for graphql scraper action")
+	}
+	if strings.TrimSpace(c.Query) == "" {
+		return errors.New("query is mandatory for graphql scraper action")
+	}
+
+	return nil
+}
+
+// graphQLScraper queries a GraphQL API, and scrapes the data in the response
+// using the json scraper with the configured name.
+type graphQLScraper struct {
+	scraper      scraperTypeConfig
+	config       config
+	globalConfig GlobalConfig
+	client       *http.Client
+
+	// json is used to run the mapped scraper and any sub-scrapes
+	json *jsonScraper
+}
+
+func newGraphQLScraper(scraper scraperTypeConfig, client *http.Client, config config, globalConfig GlobalConfig) *graphQLScraper {
+	return &graphQLScraper{
+		scraper:      scraper,
+		config:       config,
+		client:       client,
+		globalConfig: globalConfig,
+		json:         newJsonScraper(scraper, client, config, globalConfig),
+	}
+}
+
+func (s *graphQLScraper) getMappedScraper() (*mappedScraper, error) {
+	ret := s.json.getJsonScraper()
+	if ret == nil {
+		return nil, fmt.Errorf("%w: json scraper with name %s not found in config", ErrNotFound, s.scraper.Scraper)
+	}
+
+	return ret, nil
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// query executes the configured query, replacing placeholders in the endpoint
+// and variables with params. Returns the data object of the response.
+func (s *graphQLScraper) query(ctx context.Context, params queryURLParameters) (*jsonQuery, error) {
+	if s.scraper.QueryURLReplacements != nil {
+		params.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+
+	c := s.scraper.GraphQL
+	endpoint := params.constructURL(c.Endpoint)
+
+	variables, ok := params.replaceValue(c.Variables).(map[string]interface{})
+	if !ok {
+		variables = nil
+	}
+
+	body, err := json.Marshal(graphQLRequest{
+		Query:         c.Query,
+		OperationName: c.OperationName,
+		Variables:     variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding graphql request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	logger.Infof("graphql query (%s)", endpoint)
+
+	r, err := doRequest(req, s.client, s.config, s.globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	docStr := string(doc)
+	if !gjson.Valid(docStr) {
+		return nil, errors.New("not valid json")
+	}
+
+	if s.config.DebugOptions != nil && s.config.DebugOptions.PrintHTML {
+		logger.Infof("graphql query (%s) response: \n%s", endpoint, docStr)
+	}
+
+	data := gjson.Get(docStr, "data")
+	if errs := gjson.Get(docStr, "errors.#.message"); len(errs.Array()) > 0 {
+		var messages []string
+		for _, e := range errs.Array() {
+			messages = append(messages, e.String())
+		}
+
+		// partial results are scraped if present
+		if data.Type == gjson.Null {
+			return nil, fmt.Errorf("graphql error: %s", strings.Join(messages, "; "))
+		}
+		logger.Warnf("graphql query (%s) returned errors: %s", endpoint, strings.Join(messages, "; "))
+	}
+
+	if !data.IsObject() {
+		return nil, errors.New("graphql response has no data")
+	}
+
+	return s.json.getJsonQuery(data.Raw), nil
+}
+
+func (s *graphQLScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.query(ctx, queryURLParameterFromURL(url))
+	if err != nil {
+		return nil, err
+	}
+
+	// if these just return the return values from scraper.scrape* functions then
+	// it ends up returning ScrapedContent(nil) rather than nil
+	switch ty {
+	case ScrapeContentTypePerformer:
+		ret, err := scraper.scrapePerformer(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeScene:
+		ret, err := scraper.scrapeScene(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeGallery:
+		ret, err := scraper.scrapeGallery(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeImage:
+		ret, err := scraper.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
+		ret, err := scraper.scrapeGroup(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *graphQLScraper) scrapeByName(ctx context.Context, name string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.query(ctx, queryURLParameters{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	q.setType(SearchQuery)
+
+	var content []ScrapedContent
+	switch ty {
+	case ScrapeContentTypePerformer:
+		performers, err := scraper.scrapePerformers(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range performers {
+			content = append(content, p)
+		}
+
+		return content, nil
+	case ScrapeContentTypeScene:
+		scenes, err := scraper.scrapeScenes(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range scenes {
+			content = append(content, s)
+		}
+
+		return content, nil
+	case ScrapeContentTypeImage:
+		images, err := scraper.scrapeImages(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range images {
+			content = append(content, i)
+		}
+
+		return content, nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *graphQLScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.query(ctx, queryURLParametersFromScene(scene))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeScene(ctx, q)
+}
+
+func (s *graphQLScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case input.Performer != nil:
+		q, err := s.query(ctx, queryURLParametersFromScrapedPerformer(*input.Performer))
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := scraper.scrapePerformer(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case input.Gallery != nil:
+		q, err := s.query(ctx, queryURLParametersFromScrapedGallery(*input.Gallery))
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := scraper.scrapeGallery(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case input.Image != nil:
+		q, err := s.query(ctx, queryURLParametersFromScrapedImage(*input.Image))
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := scraper.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case input.Scene != nil:
+		q, err := s.query(ctx, queryURLParametersFromScrapedScene(*input.Scene))
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := scraper.scrapeScene(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	return nil, fmt.Errorf("%w: input is nil", ErrNotSupported)
+}
+
+func (s *graphQLScraper) scrapeGalleryByGallery(ctx context.Context, gallery *models.Gallery) (*ScrapedGallery, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.query(ctx, queryURLParametersFromGallery(gallery))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeGallery(ctx, q)
+}
+
+func (s *graphQLScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.query(ctx, queryURLParametersFromImage(image))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeImage(ctx, q)
+}
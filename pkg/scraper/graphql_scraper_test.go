@@ -0,0 +1,136 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLSceneScraper(t *testing.T) {
+	var got graphQLRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+
+		_, _ = io.WriteString(w, `{
+	"data": {
+		"findScene": {
+			"title": "Scene title",
+			"date": "2024-01-02",
+			"tags": [{"name": "tag 1"}, {"name": "tag 2"}]
+		}
+	}
+}`)
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+sceneByURL:
+  - action: scrapeGraphQL
+    url:
+      - example.com/scenes/
+    scraper: sceneScraper
+    queryURLReplace:
+      url:
+        - regex: .*/scenes/(\d+)$
+          with: $1
+    graphQL:
+      endpoint: ` + ts.URL + `/graphql
+      query: |
+        query FindScene($id: ID!, $limit: Int) {
+          findScene(id: $id) { title date tags(limit: $limit) { name } }
+        }
+      variables:
+        id: "{url}"
+        limit: 10
+        title: "{title}"
+jsonScrapers:
+  sceneScraper:
+    scene:
+      Title: findScene.title
+      Date: findScene.date
+      Tags:
+        Name: findScene.tags.#.name
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("loadConfigFromYAML() error = %v", err)
+	}
+
+	s := newGraphQLScraper(c.SceneByURL[0].scraperTypeConfig, &http.Client{}, *c, mockGlobalConfig{})
+	content, err := s.scrapeByURL(context.Background(), "https://example.com/scenes/123", ScrapeContentTypeScene)
+	if err != nil {
+		t.Fatalf("scrapeByURL() error = %v", err)
+	}
+
+	assert.Contains(t, got.Query, "findScene(id: $id)")
+	assert.Equal(t, map[string]interface{}{
+		"id":    "123",
+		"limit": float64(10),
+		"title": nil,
+	}, got.Variables)
+
+	scene, ok := content.(*ScrapedScene)
+	if !ok {
+		t.Fatalf("scrapeByURL() returned %T", content)
+	}
+
+	assert.Equal(t, "Scene title", *scene.Title)
+	assert.Equal(t, "2024-01-02", *scene.Date)
+	if assert.Len(t, scene.Tags, 2) {
+		assert.Equal(t, "tag 1", scene.Tags[0].Name)
+		assert.Equal(t, "tag 2", scene.Tags[1].Name)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data": null, "errors": [{"message": "not found"}]}`)
+	}))
+	defer ts.Close()
+
+	s := newGraphQLScraper(scraperTypeConfig{
+		Action:  scraperActionGraphQL,
+		Scraper: "performerScraper",
+		GraphQL: &graphQLRequestConfig{
+			Endpoint: ts.URL,
+			Query:    "query { findPerformer { name } }",
+		},
+	}, &http.Client{}, config{
+		JsonScrapers: mappedScrapers{
+			"performerScraper": &mappedScraper{},
+		},
+	}, mockGlobalConfig{})
+
+	name := "performer"
+	_, err := s.scrapeByFragment(context.Background(), Input{
+		Performer: &ScrapedPerformerInput{Name: &name},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not found")
+	}
+}
+
+func TestLoadInvalidGraphQLScraper(t *testing.T) {
+	const yamlStr = `name: Test
+sceneByName:
+  action: scrapeGraphQL
+  scraper: sceneScraper
+  graphQL:
+    endpoint: https://example.com/graphql
+`
+
+	_, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	assert.Error(t, err)
+}
@@ -12,12 +12,16 @@ type group struct {
 	config config
 
 	globalConf GlobalConfig
+
+	// transport applies the scraper's http options to requests. May be nil.
+	transport *scraperTransport
 }
 
 func newGroupScraper(c config, globalConfig GlobalConfig) scraper {
 	return group{
 		config:     c,
 		globalConf: globalConfig,
+		transport:  newScraperTransport(c.ID, c.HTTPOptions, globalConfig.GetCachePath()),
 	}
 }
 
@@ -33,6 +37,8 @@ func (g group) fragmentScraper(input Input) *scraperTypeConfig {
 	case input.Gallery != nil:
 		// TODO - this should be galleryByQueryFragment
 		return g.config.GalleryByFragment
+	case input.Image != nil:
+		return g.config.ImageByFragment
 	case input.Scene != nil:
 		return g.config.SceneByQueryFragment
 	}
@@ -53,7 +59,7 @@ func (g group) viaFragment(ctx context.Context, client *http.Client, input Input
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*stc, client, g.globalConf)
+	s := g.config.getScraper(*stc, g.transport.client(client), g.globalConf)
 	return s.scrapeByFragment(ctx, input)
 }
 
@@ -62,7 +68,7 @@ func (g group) viaScene(ctx context.Context, client *http.Client, scene *models.
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*g.config.SceneByFragment, client, g.globalConf)
+	s := g.config.getScraper(*g.config.SceneByFragment, g.transport.client(client), g.globalConf)
 	return s.scrapeSceneByScene(ctx, scene)
 }
 
@@ -71,10 +77,19 @@ func (g group) viaGallery(ctx context.Context, client *http.Client, gallery *mod
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*g.config.GalleryByFragment, client, g.globalConf)
+	s := g.config.getScraper(*g.config.GalleryByFragment, g.transport.client(client), g.globalConf)
 	return s.scrapeGalleryByGallery(ctx, gallery)
 }
 
+func (g group) viaImage(ctx context.Context, client *http.Client, image *models.Image) (*ScrapedImage, error) {
+	if g.config.ImageByFragment == nil {
+		return nil, ErrNotSupported
+	}
+
+	s := g.config.getScraper(*g.config.ImageByFragment, g.transport.client(client), g.globalConf)
+	return s.scrapeImageByImage(ctx, image)
+}
+
 func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 	switch ty {
 	case ScrapeContentTypePerformer:
@@ -85,6 +100,8 @@ func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 		return append(c.MovieByURL, c.GroupByURL...)
 	case ScrapeContentTypeGallery:
 		return c.GalleryByURL
+	case ScrapeContentTypeImage:
+		return c.ImageByURL
 	}
 
 	panic("loadUrlCandidates: unreachable")
@@ -94,7 +111,7 @@ func (g group) viaURL(ctx context.Context, client *http.Client, url string, ty S
 	candidates := loadUrlCandidates(g.config, ty)
 	for _, scraper := range candidates {
 		if scraper.matchesURL(url) {
-			s := g.config.getScraper(scraper.scraperTypeConfig, client, g.globalConf)
+			s := g.config.getScraper(scraper.scraperTypeConfig, g.transport.client(client), g.globalConf)
 			ret, err := s.scrapeByURL(ctx, url, ty)
 			if err != nil {
 				return nil, err
@@ -116,14 +133,21 @@ func (g group) viaName(ctx context.Context, client *http.Client, name string, ty
 			break
 		}
 
-		s := g.config.getScraper(*g.config.PerformerByName, client, g.globalConf)
+		s := g.config.getScraper(*g.config.PerformerByName, g.transport.client(client), g.globalConf)
 		return s.scrapeByName(ctx, name, ty)
 	case ScrapeContentTypeScene:
 		if g.config.SceneByName == nil {
 			break
 		}
 
-		s := g.config.getScraper(*g.config.SceneByName, client, g.globalConf)
+		s := g.config.getScraper(*g.config.SceneByName, g.transport.client(client), g.globalConf)
+		return s.scrapeByName(ctx, name, ty)
+	case ScrapeContentTypeImage:
+		if g.config.ImageByName == nil {
+			break
+		}
+
+		s := g.config.getScraper(*g.config.ImageByName, g.transport.client(client), g.globalConf)
 		return s.scrapeByName(ctx, name, ty)
 	}
 
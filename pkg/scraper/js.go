@@ -0,0 +1,257 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+	"github.com/dop251/goja"
+
+	"github.com/stashapp/stash/pkg/javascript"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// jsRunner runs scraper scripts in the embedded javascript VM. The script
+// receives the same input as an external scraper script in the input global,
+// and its completion value is used as the output.
+type jsRunner struct {
+	scraper      scraperTypeConfig
+	config       config
+	globalConfig GlobalConfig
+	client       *http.Client
+}
+
+// newJSScraper returns a script scraper which runs the script in the
+// javascript VM instead of an external process.
+func newJSScraper(scraper scraperTypeConfig, client *http.Client, config config, globalConfig GlobalConfig) *scriptScraper {
+	return &scriptScraper{
+		scraper:      scraper,
+		config:       config,
+		globalConfig: globalConfig,
+		runner: &jsRunner{
+			scraper:      scraper,
+			config:       config,
+			globalConfig: globalConfig,
+			client:       client,
+		},
+	}
+}
+
+func (r *jsRunner) scriptPath() string {
+	fn := r.scraper.Script[0]
+	if filepath.IsAbs(fn) {
+		return fn
+	}
+
+	return filepath.Join(filepath.Dir(r.config.path), fn)
+}
+
+func (r *jsRunner) initVM(ctx context.Context, vm *javascript.VM, inString string, progress chan float64) error {
+	var input interface{}
+	if err := json.Unmarshal([]byte(inString), &input); err != nil {
+		return fmt.Errorf("decoding input: %w", err)
+	}
+
+	if err := vm.Set("input", input); err != nil {
+		return fmt.Errorf("error setting input: %w", err)
+	}
+
+	const scraperPrefix = "[Scrape / %s] "
+
+	log := &javascript.Log{
+		Logger:       logger.Logger,
+		Prefix:       fmt.Sprintf(scraperPrefix, r.config.Name),
+		ProgressChan: progress,
+	}
+
+	if err := log.AddToVM("log", vm); err != nil {
+		return fmt.Errorf("error adding log API: %w", err)
+	}
+
+	util := &javascript.Util{}
+	if err := util.AddToVM("util", vm); err != nil {
+		return fmt.Errorf("error adding util API: %w", err)
+	}
+
+	h := &jsHTTP{
+		ctx:          ctx,
+		client:       r.client,
+		config:       r.config,
+		globalConfig: r.globalConfig,
+	}
+	if err := h.AddToVM("http", vm); err != nil {
+		return fmt.Errorf("error adding http API: %w", err)
+	}
+
+	if err := (&jsHTML{}).AddToVM("html", vm); err != nil {
+		return fmt.Errorf("error adding html API: %w", err)
+	}
+
+	return nil
+}
+
+func (r *jsRunner) runScraperScript(ctx context.Context, inString string, out interface{}) error {
+	script, err := javascript.Compile(r.scriptPath())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScraperScript, err)
+	}
+
+	vm := javascript.NewVM()
+
+	// progress is not reported for scrapers
+	progress := make(chan float64)
+	go func() {
+		for range progress {
+		}
+	}()
+	defer close(progress)
+
+	if err := r.initVM(ctx, vm, inString, progress); err != nil {
+		return err
+	}
+
+	// stop the script if the context is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			vm.Interrupt(ctx.Err())
+		case <-done:
+		}
+	}()
+
+	logger.Debugf("Scraper script <%s> started", r.scriptPath())
+
+	output, err := vm.RunProgram(script)
+	logger.Debugf("Scraper script finished")
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScraperScript, err)
+	}
+
+	var exported interface{}
+	if output != nil && !goja.IsUndefined(output) && !goja.IsNull(output) {
+		exported = output.Export()
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return fmt.Errorf("could not marshal script output: %w", err)
+	}
+
+	// First, perform a decode where unknown fields are disallowed.
+	d := json.NewDecoder(strings.NewReader(string(data)))
+	d.DisallowUnknownFields()
+	if strictErr := d.Decode(out); strictErr != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("could not unmarshal json from script output: %w", err)
+		}
+
+		// Lenient decode succeeded, print a warning, but use the decode
+		logger.Warnf("reading script result: %v", strictErr)
+	}
+
+	return nil
+}
+
+// jsHTTP provides http requests to scraper scripts, using the scraper's http
+// client, cookies and headers.
+type jsHTTP struct {
+	ctx          context.Context
+	client       *http.Client
+	config       config
+	globalConfig GlobalConfig
+}
+
+func (h *jsHTTP) do(method string, url string, body io.Reader, headers map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(h.ctx, method, url, body)
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	logger.Debugf("[scraper] js %s (%s)", method, url)
+
+	r, err := doRequest(req, h.client, h.config, h.globalConfig)
+	if err != nil {
+		return "", err
+	}
+
+	ret, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
+func (h *jsHTTP) get(url string, headers map[string]string) (string, error) {
+	return h.do(http.MethodGet, url, nil, headers)
+}
+
+func (h *jsHTTP) post(url string, body string, headers map[string]string) (string, error) {
+	return h.do(http.MethodPost, url, strings.NewReader(body), headers)
+}
+
+func (h *jsHTTP) AddToVM(globalName string, vm *javascript.VM) error {
+	obj := vm.NewObject()
+	if err := javascript.SetAll(obj,
+		javascript.ObjectValueDef{Name: "Get", Value: h.get},
+		javascript.ObjectValueDef{Name: "Post", Value: h.post},
+	); err != nil {
+		return err
+	}
+
+	if err := vm.Set(globalName, obj); err != nil {
+		return fmt.Errorf("unable to set %s: %w", globalName, err)
+	}
+
+	return nil
+}
+
+// jsHTML provides HTML queries to scraper scripts.
+type jsHTML struct{}
+
+// xpath parses doc as HTML, and returns the text of the nodes matching
+// selector. The text is processed in the same way as xpath scrapers.
+func (*jsHTML) xpath(doc string, selector string) ([]string, error) {
+	n, err := htmlquery.Parse(strings.NewReader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("parsing html: %w", err)
+	}
+
+	q := &xpathQuery{doc: n}
+	ret, err := q.runQuery(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// return an empty array rather than null if nothing matched
+	if ret == nil {
+		ret = []string{}
+	}
+
+	return ret, nil
+}
+
+func (h *jsHTML) AddToVM(globalName string, vm *javascript.VM) error {
+	obj := vm.NewObject()
+	if err := obj.Set("XPath", h.xpath); err != nil {
+		return fmt.Errorf("unable to set XPath func: %w", err)
+	}
+
+	if err := vm.Set(globalName, obj); err != nil {
+		return fmt.Errorf("unable to set %s: %w", globalName, err)
+	}
+
+	return nil
+}
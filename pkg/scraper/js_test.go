@@ -0,0 +1,159 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestJSScraper(t *testing.T) {
+	const sceneHTML = `<html><body>
+	<h1> Scene   title </h1>
+	<span class="tag">tag 1</span>
+	<span class="tag">tag 2</span>
+</body></html>`
+
+	var header string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Test")
+
+		switch r.URL.Path {
+		case "/search":
+			fmt.Fprintf(w, `[{"name": "%s 1"}, {"name": "%s 2"}]`, r.URL.Query().Get("q"), r.URL.Query().Get("q"))
+		default:
+			fmt.Fprint(w, sceneHTML)
+		}
+	}))
+	defer ts.Close()
+
+	const script = `(function() {
+	if (input.url) {
+		var doc = http.Get(input.url);
+		return {
+			title: html.XPath(doc, "//h1")[0],
+			tags: html.XPath(doc, "//span[@class='tag']").map(function(t) { return { name: t }; }),
+			missing: html.XPath(doc, "//h2").length
+		};
+	}
+
+	var results = JSON.parse(http.Get(input.base + "/search?q=" + encodeURIComponent(input.name)));
+	return results;
+})();`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.js"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	yamlStr := `name: Test
+performerByName:
+  action: js
+  script:
+    - test.js
+sceneByURL:
+  - action: js
+    url:
+      - ` + ts.URL + `
+    script:
+      - test.js
+driver:
+  headers:
+    - Key: X-Test
+      Value: header value
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("loadConfigFromYAML() error = %v", err)
+	}
+	c.path = filepath.Join(dir, "test.yml")
+
+	gc := mockGlobalConfig{}
+	ctx := context.Background()
+
+	s := newJSScraper(c.SceneByURL[0].scraperTypeConfig, &http.Client{}, *c, gc)
+	content, err := s.scrapeByURL(ctx, ts.URL+"/scene", ScrapeContentTypeScene)
+	if err != nil {
+		t.Fatalf("scrapeByURL() error = %v", err)
+	}
+
+	scene, ok := content.(*ScrapedScene)
+	if !ok {
+		t.Fatalf("scrapeByURL() returned %T", content)
+	}
+
+	assert.Equal(t, "Scene title", *scene.Title)
+	if assert.Len(t, scene.Tags, 2) {
+		assert.Equal(t, "tag 1", scene.Tags[0].Name)
+		assert.Equal(t, "tag 2", scene.Tags[1].Name)
+	}
+	assert.Equal(t, "header value", header)
+
+	// name input doesn't include the server url, so run the script directly
+	var performers []models.ScrapedPerformer
+	in := `{"name": "performer", "base": "` + ts.URL + `"}`
+	if err := s.runScraperScript(ctx, in, &performers); err != nil {
+		t.Fatalf("runScraperScript() error = %v", err)
+	}
+
+	if assert.Len(t, performers, 2) {
+		assert.Equal(t, "performer 1", *performers[0].Name)
+		assert.Equal(t, "performer 2", *performers[1].Name)
+	}
+}
+
+func TestJSScraperErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, script string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("throw.js", `throw new Error("scrape failed");`)
+	write("fetch.js", `http.Get("http://127.0.0.1:0/");`)
+	write("loop.js", `while (true) {}`)
+
+	run := func(ctx context.Context, fn string) error {
+		s := newJSScraper(scraperTypeConfig{
+			Action: scraperActionJS,
+			Script: []string{fn},
+		}, &http.Client{}, config{path: filepath.Join(dir, "test.yml")}, mockGlobalConfig{})
+
+		var out *ScrapedScene
+		return s.runScraperScript(ctx, `{}`, &out)
+	}
+
+	err := run(context.Background(), "throw.js")
+	if assert.ErrorIs(t, err, ErrScraperScript) {
+		assert.Contains(t, err.Error(), "scrape failed")
+	}
+
+	assert.ErrorIs(t, run(context.Background(), "fetch.js"), ErrScraperScript)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, run(ctx, "loop.js"), ErrScraperScript)
+}
+
+func TestLoadInvalidJSScraper(t *testing.T) {
+	const yamlStr = `name: Test
+sceneByURL:
+  - action: js
+    url:
+      - example.com
+`
+
+	_, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	assert.Error(t, err)
+}
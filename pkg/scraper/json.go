@@ -102,6 +102,12 @@ func (s *jsonScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCont
 			return nil, err
 		}
 		return ret, nil
+	case ScrapeContentTypeImage:
+		ret, err := scraper.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		ret, err := scraper.scrapeGroup(ctx, q)
 		if err != nil || ret == nil {
@@ -160,6 +166,17 @@ func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeCo
 			content = append(content, s)
 		}
 
+		return content, nil
+	case ScrapeContentTypeImage:
+		images, err := scraper.scrapeImages(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range images {
+			content = append(content, i)
+		}
+
 		return content, nil
 	}
 
@@ -196,6 +213,8 @@ func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (Scrape
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a gallery fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a performer fragment scraper", ErrNotSupported)
+	case input.Image != nil:
+		return s.scrapeImageByFragment(ctx, *input.Image)
 	case input.Scene == nil:
 		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
 	}
@@ -249,6 +268,58 @@ func (s *jsonScraper) scrapeGalleryByGallery(ctx context.Context, gallery *model
 	return scraper.scrapeGallery(ctx, q)
 }
 
+func (s *jsonScraper) scrapeImageByFragment(ctx context.Context, image ScrapedImageInput) (ScrapedContent, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	ret, err := scraper.scrapeImage(ctx, q)
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *jsonScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeImage(ctx, q)
+}
+
 func (s *jsonScraper) getJsonQuery(doc string) *jsonQuery {
 	return &jsonQuery{
 		doc:     doc,
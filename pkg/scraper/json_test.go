@@ -2,8 +2,12 @@ package scraper
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
 
@@ -116,3 +120,110 @@ jsonScrapers:
 		t.Errorf("expected nil scraped performer when not found, got %v", scrapedPerformer)
 	}
 }
+
+func TestJsonImageScraper(t *testing.T) {
+	const searchJSON = `{
+	"results": [
+		{"title": "Image 1", "url": "https://example.com/images/1", "studio": "Studio 1"},
+		{"title": "Image 2", "url": "https://example.com/images/2", "studio": "Studio 2"}
+	]
+}`
+
+	const imageJSON = `{
+	"title": "Image 1",
+	"photographer": "Photographer",
+	"tags": [{"name": "tag 1"}, {"name": "tag 2"}]
+}`
+
+	var fragmentQuery string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			fmt.Fprint(w, searchJSON)
+		default:
+			fragmentQuery = r.URL.Query().Get("title")
+			fmt.Fprint(w, imageJSON)
+		}
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+imageByName:
+  action: scrapeJson
+  queryURL: ` + ts.URL + `/search?q={}
+  scraper: imageSearch
+imageByFragment:
+  action: scrapeJson
+  queryURL: ` + ts.URL + `/image?title={title}
+  scraper: imageScraper
+jsonScrapers:
+  imageSearch:
+    image:
+      Title: results.#.title
+      URL: results.#.url
+      Studio:
+        Name: results.#.studio
+  imageScraper:
+    image:
+      Title: title
+      Photographer: photographer
+      Tags:
+        Name: tags.#.name
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+
+	s := newGroupScraper(*c, mockGlobalConfig{})
+	assert.True(t, s.supports(ScrapeContentTypeImage))
+	if spec := s.spec(); assert.NotNil(t, spec.Image) {
+		assert.ElementsMatch(t, []ScrapeType{ScrapeTypeName, ScrapeTypeFragment}, spec.Image.SupportedScrapes)
+	}
+
+	ctx := context.Background()
+	client := &http.Client{}
+
+	results, err := s.(nameScraper).viaName(ctx, client, "image", ScrapeContentTypeImage)
+	if err != nil {
+		t.Fatalf("viaName() error = %v", err)
+	}
+
+	if assert.Len(t, results, 2) {
+		for i, r := range results {
+			image, ok := r.(*ScrapedImage)
+			if !ok {
+				t.Fatalf("viaName() returned %T", r)
+			}
+
+			n := i + 1
+			assert.Equal(t, fmt.Sprintf("Image %d", n), *image.Title)
+			assert.Equal(t, fmt.Sprintf("https://example.com/images/%d", n), *image.URL)
+			if assert.NotNil(t, image.Studio) {
+				assert.Equal(t, fmt.Sprintf("Studio %d", n), image.Studio.Name)
+			}
+		}
+	}
+
+	title := "image-1"
+	content, err := s.(fragmentScraper).viaFragment(ctx, client, Input{
+		Image: &ScrapedImageInput{Title: &title},
+	})
+	if err != nil {
+		t.Fatalf("viaFragment() error = %v", err)
+	}
+
+	assert.Equal(t, title, fragmentQuery)
+
+	image, ok := content.(*ScrapedImage)
+	if !ok {
+		t.Fatalf("viaFragment() returned %T", content)
+	}
+
+	assert.Equal(t, "Photographer", *image.Photographer)
+	if assert.Len(t, image.Tags, 2) {
+		assert.Equal(t, "tag 1", image.Tags[0].Name)
+		assert.Equal(t, "tag 2", image.Tags[1].Name)
+	}
+}
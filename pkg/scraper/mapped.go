@@ -779,12 +779,16 @@ func (c mappedScraperAttrConfig) postProcess(ctx context.Context, value string,
 	return value
 }
 
+// mappedImageScraperConfig has the same fields as the gallery configuration.
+type mappedImageScraperConfig = mappedGalleryScraperConfig
+
 type mappedScrapers map[string]*mappedScraper
 
 type mappedScraper struct {
 	Common    commonMappedConfig            `yaml:"common"`
 	Scene     *mappedSceneScraperConfig     `yaml:"scene"`
 	Gallery   *mappedGalleryScraperConfig   `yaml:"gallery"`
+	Image     *mappedImageScraperConfig     `yaml:"image"`
 	Performer *mappedPerformerScraperConfig `yaml:"performer"`
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
 }
@@ -1079,6 +1083,90 @@ func (s mappedScraper) scrapeGallery(ctx context.Context, q mappedQuery) (*Scrap
 	return &ret, nil
 }
 
+func (s mappedScraper) processImageRelationships(ctx context.Context, q mappedQuery, resultIndex int, ret *ScrapedImage) bool {
+	imageScraperConfig := s.Image
+
+	imagePerformersMap := imageScraperConfig.Performers
+	imageTagsMap := imageScraperConfig.Tags
+	imageStudioMap := imageScraperConfig.Studio
+
+	if imagePerformersMap != nil {
+		logger.Debug(`Processing image performers:`)
+		ret.Performers = processRelationships[models.ScrapedPerformer](ctx, s, imagePerformersMap, q)
+	}
+
+	if imageTagsMap != nil {
+		logger.Debug(`Processing image tags:`)
+		ret.Tags = processRelationships[models.ScrapedTag](ctx, s, imageTagsMap, q)
+	}
+
+	if imageStudioMap != nil {
+		logger.Debug(`Processing image studio:`)
+		studioResults := imageStudioMap.process(ctx, q, s.Common)
+
+		if len(studioResults) > 0 && resultIndex < len(studioResults) {
+			studio := &models.ScrapedStudio{}
+			// when doing a `search` scrape get the related studio
+			studioResults[resultIndex].apply(studio)
+			ret.Studio = studio
+		}
+	}
+
+	return len(ret.Performers) > 0 || len(ret.Tags) > 0 || ret.Studio != nil
+}
+
+func (s mappedScraper) scrapeImages(ctx context.Context, q mappedQuery) ([]*ScrapedImage, error) {
+	var ret []*ScrapedImage
+
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil {
+		return nil, nil
+	}
+
+	imageMap := imageScraperConfig.mappedConfig
+	if imageMap == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing images:`)
+	results := imageMap.process(ctx, q, s.Common)
+	for i, r := range results {
+		logger.Debug(`Processing image:`)
+
+		var thisImage ScrapedImage
+		r.apply(&thisImage)
+		s.processImageRelationships(ctx, q, i, &thisImage)
+		ret = append(ret, &thisImage)
+	}
+
+	return ret, nil
+}
+
+func (s mappedScraper) scrapeImage(ctx context.Context, q mappedQuery) (*ScrapedImage, error) {
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil {
+		return nil, nil
+	}
+
+	imageMap := imageScraperConfig.mappedConfig
+
+	logger.Debug(`Processing image:`)
+	results := imageMap.process(ctx, q, s.Common)
+
+	var ret ScrapedImage
+	if len(results) > 0 {
+		results[0].apply(&ret)
+	}
+	hasRelationships := s.processImageRelationships(ctx, q, 0, &ret)
+
+	// only return if we have results or relationships
+	if len(results) > 0 || hasRelationships {
+		return &ret, nil
+	}
+
+	return nil, nil
+}
+
 func (s mappedScraper) scrapeGroup(ctx context.Context, q mappedQuery) (*models.ScrapedMovie, error) {
 	var ret models.ScrapedMovie
 
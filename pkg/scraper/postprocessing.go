@@ -33,6 +33,12 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		}
 	case ScrapedGallery:
 		return c.postScrapeGallery(ctx, v)
+	case *ScrapedImage:
+		if v != nil {
+			return c.postScrapeImage(ctx, *v)
+		}
+	case ScrapedImage:
+		return c.postScrapeImage(ctx, v)
 	case *models.ScrapedMovie:
 		if v != nil {
 			return c.postScrapeMovie(ctx, *v)
@@ -315,6 +321,49 @@ func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (Scraped
 	return g, nil
 }
 
+func (c Cache) postScrapeImage(ctx context.Context, i ScrapedImage) (ScrapedContent, error) {
+	// move the URL set by mapped scrapers to URLs
+	if i.URL != nil {
+		if len(i.URLs) == 0 {
+			i.URLs = []string{*i.URL}
+		}
+		i.URL = nil
+	}
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		pqb := r.PerformerFinder
+		tqb := r.TagFinder
+		sqb := r.StudioFinder
+
+		for _, p := range i.Performers {
+			err := match.ScrapedPerformer(ctx, pqb, p, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		tags, err := postProcessTags(ctx, tqb, i.Tags)
+		if err != nil {
+			return err
+		}
+		i.Tags = tags
+
+		if i.Studio != nil {
+			err := match.ScrapedStudio(ctx, sqb, i.Studio, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
 func postProcessTags(ctx context.Context, tqb models.TagQueryer, scrapedTags []*models.ScrapedTag) ([]*models.ScrapedTag, error) {
 	var ret []*models.ScrapedTag
 
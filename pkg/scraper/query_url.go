@@ -1,6 +1,7 @@
 package scraper
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -49,6 +50,70 @@ func queryURLParametersFromScrapedScene(scene ScrapedSceneInput) queryURLParamet
 	return ret
 }
 
+func queryURLParametersFromScrapedPerformer(performer ScrapedPerformerInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("name", performer.Name)
+	setField("disambiguation", performer.Disambiguation)
+	setField("gender", performer.Gender)
+	if len(performer.URLs) > 0 {
+		setField("url", &performer.URLs[0])
+	} else {
+		setField("url", performer.URL)
+	}
+	setField("birthdate", performer.Birthdate)
+	setField("remote_site_id", performer.RemoteSiteID)
+	return ret
+}
+
+func queryURLParametersFromScrapedGallery(gallery ScrapedGalleryInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("title", gallery.Title)
+	setField("code", gallery.Code)
+	if len(gallery.URLs) > 0 {
+		setField("url", &gallery.URLs[0])
+	} else {
+		setField("url", gallery.URL)
+	}
+	setField("date", gallery.Date)
+	setField("details", gallery.Details)
+	setField("photographer", gallery.Photographer)
+	return ret
+}
+
+func queryURLParametersFromScrapedImage(image ScrapedImageInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("title", image.Title)
+	setField("code", image.Code)
+	if len(image.URLs) > 0 {
+		setField("url", &image.URLs[0])
+	}
+	setField("date", image.Date)
+	setField("details", image.Details)
+	setField("photographer", image.Photographer)
+	return ret
+}
+
 func queryURLParameterFromURL(url string) queryURLParameters {
 	ret := make(queryURLParameters)
 	ret["url"] = url
@@ -73,6 +138,24 @@ func queryURLParametersFromGallery(gallery *models.Gallery) queryURLParameters {
 	return ret
 }
 
+func queryURLParametersFromImage(image *models.Image) queryURLParameters {
+	ret := make(queryURLParameters)
+	ret["checksum"] = image.Checksum
+
+	if image.Path != "" {
+		ret["filename"] = filepath.Base(image.Path)
+	}
+	if image.Title != "" {
+		ret["title"] = image.Title
+	}
+
+	if len(image.URLs.List()) > 0 {
+		ret["url"] = image.URLs.List()[0]
+	}
+
+	return ret
+}
+
 func (p queryURLParameters) applyReplacements(r queryURLReplacements) {
 	for k, v := range p {
 		rpl, found := r[k]
@@ -91,6 +174,41 @@ func (p queryURLParameters) constructURL(url string) string {
 	return ret
 }
 
+// replaceValue replaces the placeholders in the string values of v, which
+// may be a map or slice as decoded from yaml. A string which consists only of
+// a placeholder for a missing parameter is replaced with nil.
+func (p queryURLParameters) replaceValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}") && strings.Count(v, "{") == 1 {
+			if _, found := p[v[1:len(v)-1]]; !found {
+				return nil
+			}
+		}
+		return p.constructURL(v)
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			ret[k] = p.replaceValue(vv)
+		}
+		return ret
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			ret[fmt.Sprint(k)] = p.replaceValue(vv)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, vv := range v {
+			ret[i] = p.replaceValue(vv)
+		}
+		return ret
+	}
+
+	return v
+}
+
 // replaceURL does a partial URL Replace ( only url parameter is used)
 func replaceURL(url string, scraperConfig scraperTypeConfig) string {
 	u := url
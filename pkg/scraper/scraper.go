@@ -32,6 +32,7 @@ type ScrapeContentType string
 
 const (
 	ScrapeContentTypeGallery   ScrapeContentType = "GALLERY"
+	ScrapeContentTypeImage     ScrapeContentType = "IMAGE"
 	ScrapeContentTypeMovie     ScrapeContentType = "MOVIE"
 	ScrapeContentTypeGroup     ScrapeContentType = "GROUP"
 	ScrapeContentTypePerformer ScrapeContentType = "PERFORMER"
@@ -40,6 +41,7 @@ const (
 
 var AllScrapeContentType = []ScrapeContentType{
 	ScrapeContentTypeGallery,
+	ScrapeContentTypeImage,
 	ScrapeContentTypeMovie,
 	ScrapeContentTypeGroup,
 	ScrapeContentTypePerformer,
@@ -48,7 +50,7 @@ var AllScrapeContentType = []ScrapeContentType{
 
 func (e ScrapeContentType) IsValid() bool {
 	switch e {
-	case ScrapeContentTypeGallery, ScrapeContentTypeMovie, ScrapeContentTypeGroup, ScrapeContentTypePerformer, ScrapeContentTypeScene:
+	case ScrapeContentTypeGallery, ScrapeContentTypeImage, ScrapeContentTypeMovie, ScrapeContentTypeGroup, ScrapeContentTypePerformer, ScrapeContentTypeScene:
 		return true
 	}
 	return false
@@ -84,6 +86,8 @@ type Scraper struct {
 	Scene *ScraperSpec `json:"scene"`
 	// Details for gallery scraper
 	Gallery *ScraperSpec `json:"gallery"`
+	// Details for image scraper
+	Image *ScraperSpec `json:"image"`
 	// Details for movie scraper
 	Group *ScraperSpec `json:"group"`
 	// Details for movie scraper
@@ -161,6 +165,7 @@ type Input struct {
 	Performer *ScrapedPerformerInput
 	Scene     *ScrapedSceneInput
 	Gallery   *ScrapedGalleryInput
+	Image     *ScrapedImageInput
 }
 
 // populateURL populates the URL field of the input based on the
@@ -232,3 +237,11 @@ type galleryScraper interface {
 
 	viaGallery(ctx context.Context, client *http.Client, gallery *models.Gallery) (*ScrapedGallery, error)
 }
+
+// imageScraper is a scraper which supports image scrapes with
+// image data as the input.
+type imageScraper interface {
+	scraper
+
+	viaImage(ctx context.Context, client *http.Client, image *models.Image) (*ScrapedImage, error)
+}
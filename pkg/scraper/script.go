@@ -205,12 +205,64 @@ func galleryInputFromGallery(gallery *models.Gallery) galleryInput {
 	return ret
 }
 
+type imageInput struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Code         string   `json:"code,omitempty"`
+	Details      string   `json:"details"`
+	Photographer string   `json:"photographer,omitempty"`
+	Urls         []string `json:"urls"`
+	Date         *string  `json:"date"`
+
+	Files []fileInput `json:"files,omitempty"`
+}
+
+func imageInputFromImage(image *models.Image) imageInput {
+	dateToStringPtr := func(s *models.Date) *string {
+		if s != nil {
+			v := s.String()
+			return &v
+		}
+
+		return nil
+	}
+
+	// fallback to file basename if title is empty
+	title := image.GetTitle()
+
+	ret := imageInput{
+		ID:           strconv.Itoa(image.ID),
+		Title:        title,
+		Code:         image.Code,
+		Details:      image.Details,
+		Photographer: image.Photographer,
+		Urls:         image.URLs.List(),
+		Date:         dateToStringPtr(image.Date),
+	}
+
+	for _, f := range image.Files.List() {
+		fi := fileInputFromFile(*f.Base())
+		ret.Files = append(ret.Files, fi)
+	}
+
+	return ret
+}
+
 var ErrScraperScript = errors.New("scraper script error")
 
+// scriptRunner runs a scraper script with the given json input, decoding the
+// json output of the script into out.
+type scriptRunner interface {
+	runScraperScript(ctx context.Context, inString string, out interface{}) error
+}
+
 type scriptScraper struct {
 	scraper      scraperTypeConfig
 	config       config
 	globalConfig GlobalConfig
+
+	// runner runs the script. If nil, the script is run as an external process.
+	runner scriptRunner
 }
 
 func newScriptScraper(scraper scraperTypeConfig, config config, globalConfig GlobalConfig) *scriptScraper {
@@ -222,6 +274,14 @@ func newScriptScraper(scraper scraperTypeConfig, config config, globalConfig Glo
 }
 
 func (s *scriptScraper) runScraperScript(ctx context.Context, inString string, out interface{}) error {
+	if s.runner != nil {
+		return s.runner.runScraperScript(ctx, inString, out)
+	}
+
+	return s.runScraperProcess(ctx, inString, out)
+}
+
+func (s *scriptScraper) runScraperProcess(ctx context.Context, inString string, out interface{}) error {
 	command := s.scraper.Script
 
 	var cmd *exec.Cmd
@@ -336,6 +396,15 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 				ret = append(ret, &v)
 			}
 		}
+	case ScrapeContentTypeImage:
+		var images []ScrapedImage
+		err = s.runScraperScript(ctx, input, &images)
+		if err == nil {
+			for _, i := range images {
+				v := i
+				ret = append(ret, &v)
+			}
+		}
 	default:
 		return nil, ErrNotSupported
 	}
@@ -354,6 +423,9 @@ func (s *scriptScraper) scrapeByFragment(ctx context.Context, input Input) (Scra
 	case input.Gallery != nil:
 		inString, err = json.Marshal(*input.Gallery)
 		ty = ScrapeContentTypeGallery
+	case input.Image != nil:
+		inString, err = json.Marshal(*input.Image)
+		ty = ScrapeContentTypeImage
 	case input.Scene != nil:
 		inString, err = json.Marshal(*input.Scene)
 		ty = ScrapeContentTypeScene
@@ -380,6 +452,10 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var gallery *ScrapedGallery
 		err := s.runScraperScript(ctx, input, &gallery)
 		return gallery, err
+	case ScrapeContentTypeImage:
+		var image *ScrapedImage
+		err := s.runScraperScript(ctx, input, &image)
+		return image, err
 	case ScrapeContentTypeScene:
 		var scene *ScrapedScene
 		err := s.runScraperScript(ctx, input, &scene)
@@ -421,6 +497,20 @@ func (s *scriptScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mod
 	return ret, err
 }
 
+func (s *scriptScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	inString, err := json.Marshal(imageInputFromImage(image))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *ScrapedImage
+
+	err = s.runScraperScript(ctx, string(inString), &ret)
+
+	return ret, err
+}
+
 func handleScraperStderr(name string, scraperOutputReader io.ReadCloser) {
 	const scraperPrefix = "[Scrape / %s] "
 
@@ -0,0 +1,526 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// scraperCacheDir is the subdirectory of the cache path where scraper
+	// responses are stored.
+	scraperCacheDir = "scrapers"
+
+	// defaultRetryBackoff is the initial delay between retries if retryBackoff
+	// is not set.
+	defaultRetryBackoff = time.Second
+)
+
+type scraperHTTPOptions struct {
+	// CacheTTL is the length of time that successful GET responses are
+	// cached on disk. Responses are not cached if zero.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
+
+	// RateLimit is the maximum number of requests per second made to each
+	// host. Requests are not rate limited if zero.
+	RateLimit float64 `yaml:"rateLimit"`
+
+	// MaxConcurrent is the maximum number of concurrent requests made to
+	// each host. Concurrent requests are not limited if zero.
+	MaxConcurrent int `yaml:"maxConcurrent"`
+
+	// MaxRetries is the maximum number of times a request is retried if the
+	// response has a 429 or 5xx status.
+	MaxRetries int `yaml:"maxRetries"`
+
+	// RetryBackoff is the delay before the first retry. The delay is doubled
+	// for each subsequent retry. A Retry-After header in the response takes
+	// precedence.
+	RetryBackoff time.Duration `yaml:"retryBackoff"`
+}
+
+func (o scraperHTTPOptions) validate() error {
+	if o.CacheTTL < 0 {
+		return fmt.Errorf("cacheTTL must not be negative")
+	}
+	if o.RateLimit < 0 {
+		return fmt.Errorf("rateLimit must not be negative")
+	}
+	if o.MaxConcurrent < 0 {
+		return fmt.Errorf("maxConcurrent must not be negative")
+	}
+	if o.MaxRetries < 0 {
+		return fmt.Errorf("maxRetries must not be negative")
+	}
+	if o.RetryBackoff < 0 {
+		return fmt.Errorf("retryBackoff must not be negative")
+	}
+
+	return nil
+}
+
+// scraperTransport holds the response cache and per-host limits of a single
+// scraper. It is shared between all requests made by the scraper.
+type scraperTransport struct {
+	options  scraperHTTPOptions
+	cacheDir string
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// newScraperTransport returns a scraperTransport for the scraper with the
+// provided options. Returns nil if the options do not change how requests are
+// made. Responses are stored in a subdirectory of cachePath named after the
+// scraper id, from which expired responses are removed in the background.
+// Responses are not cached if cachePath is empty.
+func newScraperTransport(id string, options *scraperHTTPOptions, cachePath string) *scraperTransport {
+	if options == nil {
+		return nil
+	}
+
+	ret := &scraperTransport{
+		options: *options,
+		hosts:   make(map[string]*hostLimiter),
+	}
+
+	if options.CacheTTL > 0 && cachePath != "" {
+		ret.cacheDir = filepath.Join(cachePath, scraperCacheDir, id)
+	}
+
+	if ret.cacheDir == "" && options.RateLimit == 0 && options.MaxConcurrent == 0 && options.MaxRetries == 0 {
+		return nil
+	}
+
+	if ret.cacheDir != "" {
+		go ret.sweepCache()
+	}
+
+	return ret
+}
+
+// sweepCache removes the expired responses from the cache directory.
+// Otherwise, expired responses are only removed when they are requested
+// again.
+func (t *scraperTransport) sweepCache() {
+	entries, err := os.ReadDir(t.cacheDir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Warnf("[scraper] error reading response cache: %v", err)
+		}
+		return
+	}
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || info.IsDir() || time.Since(info.ModTime()) <= t.options.CacheTTL {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(t.cacheDir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warnf("[scraper] error removing expired cached response: %v", err)
+		}
+	}
+}
+
+// client returns a shallow copy of client which makes its requests through
+// the scraperTransport. Returns client if t is nil.
+func (t *scraperTransport) client(client *http.Client) *http.Client {
+	if t == nil {
+		return client
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ret := *client
+	ret.Transport = &roundTripper{
+		scraperTransport: t,
+		base:             base,
+	}
+	return &ret
+}
+
+func (t *scraperTransport) hostLimiter(host string) *hostLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ret := t.hosts[host]
+	if ret == nil {
+		ret = newHostLimiter(t.options.RateLimit, t.options.MaxConcurrent)
+		t.hosts[host] = ret
+	}
+
+	return ret
+}
+
+// acquireHost waits until a request to the host of u may be made under the
+// limits of the scraper making requests with client. It is used for requests
+// which are not made by the client, such as CDP page loads. The returned
+// function must be called once the request is complete.
+func acquireHost(ctx context.Context, client *http.Client, u string) (func(), error) {
+	t, ok := client.Transport.(*roundTripper)
+	if !ok {
+		return func() {}, nil
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.hostLimiter(parsed.Host).acquire(ctx)
+}
+
+type roundTripper struct {
+	*scraperTransport
+	base http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	cacheable := t.cacheDir != "" && req.Method == http.MethodGet
+	if cacheable {
+		if resp := t.getCached(req); resp != nil {
+			logger.Debugf("[scraper] using cached response for %s", req.URL)
+			return resp, nil
+		}
+	}
+
+	resp, err := t.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable && resp.StatusCode == http.StatusOK {
+		return t.setCached(req, resp)
+	}
+
+	return resp, nil
+}
+
+// roundTrip performs the request, waiting for the host limits and retrying
+// if the response status indicates that the request may succeed later.
+func (t *roundTripper) roundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	limiter := t.hostLimiter(req.URL.Host)
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		release, err := limiter.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if err != nil {
+			release()
+			return nil, err
+		}
+
+		// requests with a body can only be retried if the body can be reread
+		canRetry := attempt < t.options.MaxRetries && (req.Body == nil || req.GetBody != nil)
+		if !canRetry || !retryableStatus(resp.StatusCode) {
+			resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+
+		delay, ok := retryAfter(resp.Header, time.Now())
+		if ok {
+			// hold off all requests to the host
+			limiter.delay(delay)
+		} else {
+			delay = t.backoff(attempt)
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		release()
+
+		logger.Debugf("[scraper] http error %d from %s, retrying in %v", resp.StatusCode, req.URL, delay)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *roundTripper) backoff(attempt int) time.Duration {
+	backoff := t.options.RetryBackoff
+	if backoff == 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	return time.Duration(float64(backoff) * math.Pow(2, float64(attempt)))
+}
+
+// cachedResponse is the on-disk representation of a cached response.
+type cachedResponse struct {
+	URL        string      `json:"url,omitempty"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+func (r cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header,
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+func (t *roundTripper) cachePath(req *http.Request) string {
+	hash := sha256.Sum256([]byte(req.URL.String()))
+	return filepath.Join(t.cacheDir, hex.EncodeToString(hash[:]))
+}
+
+// getCached returns the cached response for the request. Returns nil if the
+// response is not cached or has expired.
+func (t *roundTripper) getCached(req *http.Request) *http.Response {
+	fn := t.cachePath(req)
+	info, err := os.Stat(fn)
+	if err != nil {
+		return nil
+	}
+
+	if time.Since(info.ModTime()) > t.options.CacheTTL {
+		if err := os.Remove(fn); err != nil {
+			logger.Warnf("[scraper] error removing expired cached response: %v", err)
+		}
+		return nil
+	}
+
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		logger.Warnf("[scraper] error reading cached response: %v", err)
+		return nil
+	}
+
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil {
+		logger.Warnf("[scraper] error decoding cached response: %v", err)
+		return nil
+	}
+
+	return cached.response(req)
+}
+
+// setCached reads the body of the response and stores it in the cache.
+// Returns a copy of the response with the body replaced.
+func (t *roundTripper) setCached(req *http.Request, resp *http.Response) (*http.Response, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := *resp
+	ret.Body = io.NopCloser(bytes.NewReader(body))
+	ret.ContentLength = int64(len(body))
+
+	data, err := json.Marshal(cachedResponse{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	if err == nil {
+		err = t.writeCache(t.cachePath(req), data)
+	}
+	if err != nil {
+		logger.Warnf("[scraper] error caching response for %s: %v", req.URL, err)
+	}
+
+	return &ret, nil
+}
+
+func (t *roundTripper) writeCache(fn string, data []byte) error {
+	if err := fsutil.EnsureDirAll(t.cacheDir); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that concurrent readers never see
+	// a partial response
+	tmp, err := os.CreateTemp(t.cacheDir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fn)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// hostLimiter limits the rate and concurrency of requests to a single host.
+type hostLimiter struct {
+	interval time.Duration
+	sem      chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newHostLimiter(rateLimit float64, maxConcurrent int) *hostLimiter {
+	ret := &hostLimiter{}
+	if rateLimit > 0 {
+		ret.interval = time.Duration(float64(time.Second) / rateLimit)
+	}
+	if maxConcurrent > 0 {
+		ret.sem = make(chan struct{}, maxConcurrent)
+	}
+
+	return ret
+}
+
+// acquire waits until a request may be made to the host. The returned
+// function must be called once the request is complete.
+func (l *hostLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-l.sem })
+		}
+	}
+
+	if err := sleepContext(ctx, l.reserve()); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// reserve reserves the next request slot, returning the time to wait until
+// the slot.
+func (l *hostLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// delay prevents requests to the host for the provided duration.
+func (l *hostLimiter) delay(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// releaseBody releases the host limiter when the response body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter returns the delay from the Retry-After header, which may be
+// either a number of seconds or a date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
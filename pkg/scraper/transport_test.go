@@ -0,0 +1,321 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("client.Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll() error = %v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+func TestScraperTransportCache(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, r.URL.Path+" "+strconv.Itoa(int(n)))
+	}))
+	defer ts.Close()
+
+	cachePath := t.TempDir()
+	options := &scraperHTTPOptions{CacheTTL: time.Hour}
+	client := newScraperTransport("test", options, cachePath).client(&http.Client{})
+
+	_, body := get(t, client, ts.URL+"/a")
+	assert.Equal(t, "/a 1", body)
+
+	// second request is served from the cache
+	_, body = get(t, client, ts.URL+"/a")
+	assert.Equal(t, "/a 1", body)
+
+	_, body = get(t, client, ts.URL+"/b")
+	assert.Equal(t, "/b 2", body)
+
+	// error responses are not cached
+	status, _ := get(t, client, ts.URL+"/error")
+	assert.Equal(t, http.StatusNotFound, status)
+	get(t, client, ts.URL+"/error")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+
+	// the cache is scoped to the scraper
+	other := newScraperTransport("other", options, cachePath)
+	_, body = get(t, other.client(&http.Client{}), ts.URL+"/a")
+	assert.Equal(t, "/a 5", body)
+}
+
+func TestScraperTransportSweepCache(t *testing.T) {
+	cachePath := t.TempDir()
+	transport := &scraperTransport{
+		options:  scraperHTTPOptions{CacheTTL: time.Hour},
+		cacheDir: filepath.Join(cachePath, scraperCacheDir, "test"),
+	}
+
+	if err := os.MkdirAll(transport.cacheDir, 0755); err != nil {
+		t.Fatalf("os.MkdirAll() error = %v", err)
+	}
+
+	expired := filepath.Join(transport.cacheDir, "expired")
+	current := filepath.Join(transport.cacheDir, "current")
+	for _, fn := range []string{expired, current} {
+		if err := os.WriteFile(fn, []byte("{}"), 0644); err != nil {
+			t.Fatalf("os.WriteFile() error = %v", err)
+		}
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(expired, old, old); err != nil {
+		t.Fatalf("os.Chtimes() error = %v", err)
+	}
+
+	transport.sweepCache()
+
+	assert.NoFileExists(t, expired)
+	assert.FileExists(t, current)
+}
+
+func TestScraperTransportRetry(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&hits, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	defer ts.Close()
+
+	options := &scraperHTTPOptions{
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	client := newScraperTransport("test", options, "").client(&http.Client{})
+
+	status, body := get(t, client, ts.URL)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", body)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	// gives up after the maximum number of retries
+	atomic.StoreInt32(&hits, 0)
+	options.MaxRetries = 1
+	client = newScraperTransport("test", options, "").client(&http.Client{})
+
+	status, _ = get(t, client, ts.URL)
+	assert.Equal(t, http.StatusBadGateway, status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestScraperTransportLimits(t *testing.T) {
+	var current, peak int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	options := &scraperHTTPOptions{
+		RateLimit:     50,
+		MaxConcurrent: 2,
+	}
+	client := newScraperTransport("test", options, "").client(&http.Client{})
+
+	const requests = 6
+	start := time.Now()
+	done := make(chan struct{})
+	for i := 0; i < requests; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			resp, err := client.Get(ts.URL)
+			if err != nil {
+				t.Errorf("client.Get() error = %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	for i := 0; i < requests; i++ {
+		<-done
+	}
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	// requests are spaced 20ms apart
+	assert.GreaterOrEqual(t, time.Since(start), (requests-1)*20*time.Millisecond)
+}
+
+func TestScraperTransportErrorStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer ts.Close()
+
+	options := &scraperHTTPOptions{MaxConcurrent: 1}
+	client := newScraperTransport("test", options, "").client(&http.Client{})
+
+	do := func(path string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest() error = %v", err)
+		}
+
+		r, err := doRequest(req, client, config{}, mockGlobalConfig{})
+		if err != nil {
+			return "", err
+		}
+
+		body, err := io.ReadAll(r)
+		return string(body), err
+	}
+
+	// error responses must release the host limits
+	for i := 0; i < 2; i++ {
+		_, err := do("/error")
+		assert.ErrorContains(t, err, "http error 404")
+	}
+
+	body, err := do("/")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", body)
+}
+
+func TestAcquireHost(t *testing.T) {
+	options := &scraperHTTPOptions{MaxConcurrent: 1}
+	client := newScraperTransport("test", options, "").client(&http.Client{})
+
+	release, err := acquireHost(context.Background(), client, "http://example.com/a")
+	if err != nil {
+		t.Fatalf("acquireHost() error = %v", err)
+	}
+
+	// a second request to the host waits for the first
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = acquireHost(ctx, client, "http://example.com/b")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// other hosts are not limited
+	other, err := acquireHost(context.Background(), client, "http://example.org/")
+	if err != nil {
+		t.Fatalf("acquireHost() error = %v", err)
+	}
+	other()
+
+	release()
+	release, err = acquireHost(context.Background(), client, "http://example.com/b")
+	if err != nil {
+		t.Fatalf("acquireHost() error = %v", err)
+	}
+	release()
+
+	// clients without a scraper transport are not limited
+	release, err = acquireHost(context.Background(), &http.Client{}, "http://example.com/")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "120", 2 * time.Minute, true},
+		{"date", now.Add(time.Minute).Format(http.TimeFormat), time.Minute, true},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"invalid", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.value != "" {
+				h.Set("Retry-After", tt.value)
+			}
+
+			got, ok := retryAfter(h, now)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestLoadHTTPOptions(t *testing.T) {
+	const yamlStr = `name: Test
+http:
+  cacheTTL: 24h
+  rateLimit: 0.5
+  maxConcurrent: 1
+  maxRetries: 3
+  retryBackoff: 2s
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("loadConfigFromYAML() error = %v", err)
+	}
+
+	assert.Equal(t, &scraperHTTPOptions{
+		CacheTTL:      24 * time.Hour,
+		RateLimit:     0.5,
+		MaxConcurrent: 1,
+		MaxRetries:    3,
+		RetryBackoff:  2 * time.Second,
+	}, c.HTTPOptions)
+
+	_, err = loadConfigFromYAML("test", strings.NewReader("name: Test\nhttp:\n  maxRetries: -1\n"))
+	assert.Error(t, err)
+}
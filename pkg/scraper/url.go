@@ -28,6 +28,14 @@ const scrapeDefaultSleep = time.Second * 2
 func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
 	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil && driverOptions.UseCDP {
+		// the page is not loaded by the client, so it is not cached or
+		// retried, but the host limits still apply
+		release, err := acquireHost(ctx, client, loadURL)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
 		// get the page using chrome dp
 		return urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
 	}
@@ -37,18 +45,21 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		return nil, err
 	}
 
+	return doRequest(req, client, scraperConfig, globalConfig)
+}
+
+// doRequest adds the scraper cookies and headers to req and performs the
+// request, returning a reader for the response body.
+func doRequest(req *http.Request, client *http.Client, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	driverOptions := scraperConfig.DriverOptions
+
 	jar, err := scraperConfig.jar()
 	if err != nil {
 		return nil, fmt.Errorf("error creating cookie jar: %w", err)
 	}
 
-	u, err := url.Parse(loadURL)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing url %s: %w", loadURL, err)
-	}
-
-	// Fetch relevant cookies from the jar for url u and add them to the request
-	cookies := jar.Cookies(u)
+	// Fetch relevant cookies from the jar for the request url and add them to the request
+	cookies := jar.Cookies(req.URL)
 	for _, cookie := range cookies {
 		req.AddCookie(cookie)
 	}
@@ -71,12 +82,13 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 	if err != nil {
 		return nil, err
 	}
+	// the body must be closed to release the request's host limits
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 400 {
 		return nil, fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	defer resp.Body.Close()
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
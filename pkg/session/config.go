@@ -1,5 +1,7 @@
 package session
 
+import "context"
+
 type ExternalAccessConfig interface {
 	HasCredentials() bool
 	GetDangerousAllowPublicWithoutAuth() bool
@@ -15,3 +17,8 @@ type SessionConfig interface {
 	GetMaxSessionAge() int
 	ValidateCredentials(username string, password string) bool
 }
+
+// UserAuthenticator authenticates the user accounts stored in the database.
+type UserAuthenticator interface {
+	AuthenticateUser(ctx context.Context, username string, password string) (bool, error)
+}
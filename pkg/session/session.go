@@ -47,12 +47,16 @@ var ErrUnauthorized = errors.New("unauthorized")
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	users        UserAuthenticator
 }
 
-func NewStore(c SessionConfig) *Store {
+// NewStore returns a new session store. users is used to authenticate users
+// other than the configured user, and may be nil.
+func NewStore(c SessionConfig, users UserAuthenticator) *Store {
 	ret := &Store{
 		sessionStore: sessions.NewCookieStore(c.GetSessionStoreKey()),
 		config:       c,
+		users:        users,
 	}
 
 	ret.sessionStore.MaxAge(c.GetMaxSessionAge())
@@ -69,11 +73,20 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	password := r.FormValue(passwordFormKey)
 
 	// authenticate the user
-	if !s.config.ValidateCredentials(username, password) {
+	valid := s.config.ValidateCredentials(username, password)
+	if !valid && s.users != nil {
+		var err error
+		valid, err = s.users.AuthenticateUser(r.Context(), username, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !valid {
 		return &InvalidCredentialsError{Username: username}
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Info("User logged in")
 
 	newSession.Values[userIDKey] = username
@@ -100,7 +113,7 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Infof("User logged out")
 
 	return nil
@@ -146,10 +159,9 @@ func GetCurrentUserID(ctx context.Context) *string {
 	return nil
 }
 
-func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
-	c := s.config
-
-	// translate api key into current user, if present
+// APIKeyFromRequest returns the API key provided in the request header, or in
+// the query parameters. Returns an empty string if no key was provided.
+func APIKeyFromRequest(r *http.Request) string {
 	apiKey := r.Header.Get(ApiKeyHeader)
 
 	// try getting the api key as a query parameter
@@ -157,6 +169,15 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 		apiKey = r.URL.Query().Get(ApiKeyParameter)
 	}
 
+	return apiKey
+}
+
+func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
+	c := s.config
+
+	// translate api key into current user, if present
+	apiKey := APIKeyFromRequest(r)
+
 	if apiKey != "" {
 		// match against configured API and set userID to the
 		// configured username. In future, we'll want to
@@ -167,3 +167,8 @@ func ValuesToPtrs[T any](vs []T) []*T {
 	}
 	return ret
 }
+
+// OmitNil returns a slice containing the non-nil elements of the vs slice.
+func OmitNil[T any](vs []*T) []*T {
+	return Filter(vs, func(v *T) bool { return v != nil })
+}
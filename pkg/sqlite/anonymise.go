@@ -49,6 +49,8 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.deleteStashIDs() },
 			func() error { return db.clearOHistory() },
 			func() error { return db.clearWatchHistory() },
+			func() error { return db.clearJobs() },
+			func() error { return db.clearUsers() },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseCaptions(ctx) },
@@ -108,12 +110,29 @@ func (db *Anonymiser) deleteStashIDs() error {
 func (db *Anonymiser) clearOHistory() error {
 	return utils.Do([]func() error{
 		func() error { return db.truncateTable(scenesODatesTable) },
+		func() error { return db.truncateTable(imagesODatesTable) },
+		func() error { return db.truncateTable(galleriesODatesTable) },
 	})
 }
 
 func (db *Anonymiser) clearWatchHistory() error {
 	return utils.Do([]func() error{
 		func() error { return db.truncateTable(scenesViewDatesTable) },
+		func() error { return db.truncateTable(imagesViewDatesTable) },
+		func() error { return db.truncateTable(galleriesViewDatesTable) },
+	})
+}
+
+func (db *Anonymiser) clearJobs() error {
+	return db.truncateTable(jobTable)
+}
+
+func (db *Anonymiser) clearUsers() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(apiKeyTable) },
+		func() error { return db.truncateTable(sceneUserDataTable) },
+		func() error { return db.truncateTable(galleryUserDataTable) },
+		func() error { return db.truncateTable(userTable) },
 	})
 }
 
@@ -331,6 +350,10 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(scenesCustomFieldsTable.GetTable()), "scene_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -446,6 +469,10 @@ func (db *Anonymiser) anonymiseImages(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(imagesCustomFieldsTable.GetTable()), "image_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -517,6 +544,10 @@ func (db *Anonymiser) anonymiseGalleries(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(galleriesCustomFieldsTable.GetTable()), "gallery_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -677,6 +708,10 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(studiosCustomFieldsTable.GetTable()), "studio_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -868,6 +903,10 @@ func (db *Anonymiser) anonymiseTags(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(tagsCustomFieldsTable.GetTable()), "tag_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -943,6 +982,10 @@ func (db *Anonymiser) anonymiseGroups(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseCustomFields(ctx, goqu.T(groupsCustomFieldsTable.GetTable()), "group_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
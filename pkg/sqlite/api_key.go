@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	apiKeyTable = "api_keys"
+)
+
+type apiKeyRow struct {
+	ID         int           `db:"id" goqu:"skipinsert"`
+	Name       string        `db:"name"`
+	UserID     null.Int      `db:"user_id"`
+	Scope      string        `db:"scope"`
+	CreatedAt  Timestamp     `db:"created_at"`
+	ExpiresAt  NullTimestamp `db:"expires_at"`
+	LastUsedAt NullTimestamp `db:"last_used_at"`
+}
+
+func (r *apiKeyRow) fromAPIKey(o models.APIKey) {
+	r.ID = o.ID
+	r.Name = o.Name
+	r.UserID = intFromPtr(o.UserID)
+	r.Scope = o.Scope.String()
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.ExpiresAt = NullTimestampFromTimePtr(o.ExpiresAt)
+	r.LastUsedAt = NullTimestampFromTimePtr(o.LastUsedAt)
+}
+
+func (r *apiKeyRow) resolve() *models.APIKey {
+	return &models.APIKey{
+		ID:         r.ID,
+		Name:       r.Name,
+		UserID:     nullIntPtr(r.UserID),
+		Scope:      models.APIKeyScope(r.Scope),
+		CreatedAt:  r.CreatedAt.Timestamp,
+		ExpiresAt:  r.ExpiresAt.TimePtr(),
+		LastUsedAt: r.LastUsedAt.TimePtr(),
+	}
+}
+
+type APIKeyStore struct {
+	tableMgr *table
+}
+
+func NewAPIKeyStore() *APIKeyStore {
+	return &APIKeyStore{
+		tableMgr: apiKeyTableMgr,
+	}
+}
+
+func (qb *APIKeyStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *APIKeyStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *APIKeyStore) Create(ctx context.Context, newObject *models.APIKey) error {
+	var r apiKeyRow
+	r.fromAPIKey(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *APIKeyStore) UpdateLastUsed(ctx context.Context, id int, lastUsedAt time.Time) error {
+	return qb.tableMgr.updateByID(ctx, id, goqu.Record{
+		"last_used_at": Timestamp{Timestamp: lastUsedAt},
+	})
+}
+
+func (qb *APIKeyStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *APIKeyStore) Find(ctx context.Context, id int) (*models.APIKey, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *APIKeyStore) FindByUserID(ctx context.Context, userID *int) ([]*models.APIKey, error) {
+	table := qb.table()
+
+	// keys of the configured user have a null user id
+	var where exp.Expression = table.Col(userIDColumn).IsNull()
+	if userID != nil {
+		where = table.Col(userIDColumn).Eq(*userID)
+	}
+
+	q := qb.selectDataset().Where(where).Order(table.Col("name").Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *APIKeyStore) All(ctx context.Context) ([]*models.APIKey, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("name").Asc()))
+}
+
+func (qb *APIKeyStore) find(ctx context.Context, id int) (*models.APIKey, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *APIKeyStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.APIKey, error) {
+	const single = false
+	var ret []*models.APIKey
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f apiKeyRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,103 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		u := createTestUser(ctx, t, "apikey user")
+
+		expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		userKey := models.NewAPIKey()
+		userKey.Name = "user key"
+		userKey.UserID = &u.ID
+		userKey.Scope = models.APIKeyScopeReadOnly
+		userKey.ExpiresAt = &expires
+		if err := db.APIKey.Create(ctx, &userKey); err != nil {
+			t.Errorf("APIKeyStore.Create() error = %v", err)
+			return nil
+		}
+
+		configKey := models.NewAPIKey()
+		configKey.Name = "config key"
+		if err := db.APIKey.Create(ctx, &configKey); err != nil {
+			t.Errorf("APIKeyStore.Create() error = %v", err)
+			return nil
+		}
+
+		got, err := db.APIKey.Find(ctx, userKey.ID)
+		if err != nil {
+			t.Errorf("APIKeyStore.Find() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, got) {
+			assert.Equal(t, "user key", got.Name)
+			assert.Equal(t, models.APIKeyScopeReadOnly, got.Scope)
+			assert.Equal(t, &u.ID, got.UserID)
+			if assert.NotNil(t, got.ExpiresAt) {
+				assert.True(t, expires.Equal(*got.ExpiresAt))
+			}
+			assert.Nil(t, got.LastUsedAt)
+		}
+
+		byUser, err := db.APIKey.FindByUserID(ctx, &u.ID)
+		if err != nil {
+			t.Errorf("APIKeyStore.FindByUserID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, byUser, 1)
+
+		byConfig, err := db.APIKey.FindByUserID(ctx, nil)
+		if err != nil {
+			t.Errorf("APIKeyStore.FindByUserID() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, byConfig, 1) {
+			assert.Equal(t, configKey.ID, byConfig[0].ID)
+		}
+
+		used := time.Now().Truncate(time.Second)
+		if err := db.APIKey.UpdateLastUsed(ctx, configKey.ID, used); err != nil {
+			t.Errorf("APIKeyStore.UpdateLastUsed() error = %v", err)
+			return nil
+		}
+
+		got, err = db.APIKey.Find(ctx, configKey.ID)
+		if err != nil {
+			t.Errorf("APIKeyStore.Find() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, got) && assert.NotNil(t, got.LastUsedAt) {
+			assert.True(t, used.Equal(*got.LastUsedAt))
+		}
+
+		// keys are removed with their user
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = db.APIKey.Find(ctx, userKey.ID)
+		if err != nil {
+			t.Errorf("APIKeyStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		if err := db.APIKey.Destroy(ctx, configKey.ID); err != nil {
+			t.Errorf("APIKeyStore.Destroy() error = %v", err)
+		}
+
+		return nil
+	})
+}
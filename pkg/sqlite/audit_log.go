@@ -0,0 +1,412 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/audit"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	auditLogTable = "audit_log"
+)
+
+type auditLogRow struct {
+	ID         int           `db:"id" goqu:"skipinsert"`
+	ObjectType string        `db:"object_type"`
+	ObjectID   int           `db:"object_id"`
+	Action     string        `db:"action"`
+	OldValues  null.String   `db:"old_values"`
+	NewValues  null.String   `db:"new_values"`
+	UserID     null.Int      `db:"user_id"`
+	JobID      null.Int      `db:"job_id"`
+	Source     string        `db:"source"`
+	CreatedAt  Timestamp     `db:"created_at"`
+	RevertedAt NullTimestamp `db:"reverted_at"`
+}
+
+func encodeAuditValues(v map[string]interface{}) null.String {
+	if v == nil {
+		return null.String{}
+	}
+
+	return null.StringFrom(encodeJSONOrEmpty(v))
+}
+
+func decodeAuditValues(s null.String) map[string]interface{} {
+	if !s.Valid {
+		return nil
+	}
+
+	ret := make(map[string]interface{})
+	decodeJSON(s.String, &ret)
+	return ret
+}
+
+func (r *auditLogRow) fromAuditLogEntry(o models.AuditLogEntry) {
+	r.ID = o.ID
+	r.ObjectType = o.ObjectType.String()
+	r.ObjectID = o.ObjectID
+	r.Action = o.Action.String()
+	r.OldValues = encodeAuditValues(o.OldValues)
+	r.NewValues = encodeAuditValues(o.NewValues)
+	r.UserID = intFromPtr(o.UserID)
+	r.JobID = intFromPtr(o.JobID)
+	r.Source = o.Source
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.RevertedAt = NullTimestampFromTimePtr(o.RevertedAt)
+}
+
+func (r *auditLogRow) resolve() *models.AuditLogEntry {
+	return &models.AuditLogEntry{
+		ID:         r.ID,
+		ObjectType: models.AuditObjectType(r.ObjectType),
+		ObjectID:   r.ObjectID,
+		Action:     models.AuditAction(r.Action),
+		OldValues:  decodeAuditValues(r.OldValues),
+		NewValues:  decodeAuditValues(r.NewValues),
+		UserID:     nullIntPtr(r.UserID),
+		JobID:      nullIntPtr(r.JobID),
+		Source:     r.Source,
+		CreatedAt:  r.CreatedAt.Timestamp,
+		RevertedAt: r.RevertedAt.TimePtr(),
+	}
+}
+
+type AuditLogStore struct {
+	tableMgr *table
+
+	repository *storeRepository
+}
+
+func NewAuditLogStore(r *storeRepository) *AuditLogStore {
+	return &AuditLogStore{
+		tableMgr:   auditLogTableMgr,
+		repository: r,
+	}
+}
+
+func (qb *AuditLogStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *AuditLogStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *AuditLogStore) Create(ctx context.Context, newObject *models.AuditLogEntry) error {
+	var r auditLogRow
+	r.fromAuditLogEntry(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+	return nil
+}
+
+func (qb *AuditLogStore) SetReverted(ctx context.Context, id int, revertedAt time.Time) error {
+	q := dialect.Update(qb.table()).Prepared(true).
+		Set(goqu.Record{"reverted_at": Timestamp{Timestamp: revertedAt}}).
+		Where(qb.tableMgr.byID(id))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting audit log entry reverted: %w", err)
+	}
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *AuditLogStore) Find(ctx context.Context, id int) (*models.AuditLogEntry, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// CurrentValues returns the current audited values of the object, in the
+// same form as the values of audit log entries.
+func (qb *AuditLogStore) CurrentValues(ctx context.Context, objectType models.AuditObjectType, id int) (map[string]interface{}, error) {
+	var values auditValuesFunc
+	switch objectType {
+	case models.AuditObjectTypeScene:
+		values = qb.repository.Scene.auditValues
+	case models.AuditObjectTypeImage:
+		values = qb.repository.Image.auditValues
+	case models.AuditObjectTypeGallery:
+		values = qb.repository.Gallery.auditValues
+	case models.AuditObjectTypePerformer:
+		values = qb.repository.Performer.auditValues
+	case models.AuditObjectTypeStudio:
+		values = qb.repository.Studio.auditValues
+	case models.AuditObjectTypeTag:
+		values = qb.repository.Tag.auditValues
+	case models.AuditObjectTypeGroup:
+		values = qb.repository.Group.auditValues
+	default:
+		return nil, fmt.Errorf("unsupported audit object type %s", objectType)
+	}
+
+	current, err := values(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ret, err := audit.Diff(nil, current)
+	return ret, err
+}
+
+func (qb *AuditLogStore) Query(ctx context.Context, filter models.AuditLogFilter, findFilter *models.FindFilterType) ([]*models.AuditLogEntry, int, error) {
+	table := qb.table()
+
+	var where []exp.Expression
+	if filter.ObjectType != nil {
+		where = append(where, table.Col("object_type").Eq(filter.ObjectType.String()))
+	}
+	if filter.ObjectID != nil {
+		where = append(where, table.Col("object_id").Eq(*filter.ObjectID))
+	}
+	if filter.JobID != nil {
+		where = append(where, table.Col("job_id").Eq(*filter.JobID))
+	}
+	if filter.UserID != nil {
+		where = append(where, table.Col(userIDColumn).Eq(*filter.UserID))
+	}
+
+	countQuery := dialect.From(table).Prepared(true).Select(goqu.COUNT("*")).Where(where...)
+	var count int
+	if err := querySimple(ctx, countQuery, &count); err != nil {
+		return nil, 0, fmt.Errorf("counting audit log entries: %w", err)
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(where...).Order(table.Col(idColumn).Desc())
+	if findFilter != nil && !findFilter.IsGetAll() {
+		perPage := findFilter.GetPageSize()
+		q = q.Limit(uint(perPage)).Offset(uint((findFilter.GetPage() - 1) * perPage))
+	}
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ret, count, nil
+}
+
+func (qb *AuditLogStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.AuditLogEntry, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *AuditLogStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.AuditLogEntry, error) {
+	const single = false
+	var ret []*models.AuditLogEntry
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f auditLogRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// auditValuesFunc returns the audited values of the object with the provided
+// id, as a pointer to a partial struct with the audited fields set.
+type auditValuesFunc func(ctx context.Context, id int) (interface{}, error)
+
+// auditRecord records a change to an object in the audit log. A nil
+// auditRecord records nothing.
+type auditRecord struct {
+	objectType models.AuditObjectType
+	id         int
+	values     auditValuesFunc
+	source     string
+	jobID      *int
+	old        interface{}
+}
+
+// beginAudit returns an auditRecord with the values of the object before it
+// is changed. Returns nil if changes are not being recorded.
+func beginAudit(ctx context.Context, objectType models.AuditObjectType, id int, values auditValuesFunc) (*auditRecord, error) {
+	source, jobID, ok := audit.SourceFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	old, err := values(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit values: %w", err)
+	}
+
+	return &auditRecord{
+		objectType: objectType,
+		id:         id,
+		values:     values,
+		source:     source,
+		jobID:      jobID,
+		old:        old,
+	}, nil
+}
+
+// auditCreate records the creation of the object with the provided id.
+func auditCreate(ctx context.Context, objectType models.AuditObjectType, id int, values auditValuesFunc) error {
+	source, jobID, ok := audit.SourceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	r := &auditRecord{
+		objectType: objectType,
+		id:         id,
+		values:     values,
+		source:     source,
+		jobID:      jobID,
+	}
+
+	return r.end(ctx, models.AuditActionCreate)
+}
+
+// auditRecords records the changes to a number of objects in the audit log.
+type auditRecords []*auditRecord
+
+// beginAuditQuery returns auditRecords with the values of the objects whose
+// ids are returned by query, before they are changed. It is used for changes
+// made to many objects at once with sql statements, which bypass the update
+// methods of the stores. Returns nil if changes are not being recorded.
+func beginAuditQuery(ctx context.Context, objectType models.AuditObjectType, values auditValuesFunc, query string, args ...interface{}) (auditRecords, error) {
+	if _, _, ok := audit.SourceFromContext(ctx); !ok {
+		return nil, nil
+	}
+
+	var ids []int
+	if err := dbWrapper.Select(ctx, &ids, query, args...); err != nil {
+		return nil, fmt.Errorf("finding audited %s ids: %w", objectType, err)
+	}
+
+	ret := make(auditRecords, len(ids))
+	for i, id := range ids {
+		var err error
+		ret[i], err = beginAudit(ctx, objectType, id, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// end records the changes made to the objects since beginAuditQuery.
+func (r auditRecords) end(ctx context.Context, action models.AuditAction) error {
+	for _, record := range r {
+		if err := record.end(ctx, action); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// end records the change made to the object since beginAudit. Updates which
+// do not change the audited values are not recorded.
+func (r *auditRecord) end(ctx context.Context, action models.AuditAction) error {
+	if r == nil {
+		return nil
+	}
+
+	var current interface{}
+	if action != models.AuditActionDestroy {
+		var err error
+		current, err = r.values(ctx, r.id)
+		if err != nil {
+			return fmt.Errorf("getting audit values: %w", err)
+		}
+	}
+
+	oldValues, newValues, err := audit.Diff(r.old, current)
+	if err != nil {
+		return err
+	}
+
+	if action == models.AuditActionUpdate && len(newValues) == 0 {
+		return nil
+	}
+
+	entry := models.AuditLogEntry{
+		ObjectType: r.objectType,
+		ObjectID:   r.id,
+		Action:     action,
+		UserID:     models.UserIDFromContext(ctx),
+		JobID:      r.jobID,
+		Source:     r.source,
+		CreatedAt:  time.Now(),
+	}
+
+	if action != models.AuditActionCreate {
+		entry.OldValues = oldValues
+	}
+	if action != models.AuditActionDestroy {
+		entry.NewValues = newValues
+	}
+
+	if err := NewAuditLogStore(nil).Create(ctx, &entry); err != nil {
+		return fmt.Errorf("creating audit log entry: %w", err)
+	}
+
+	return nil
+}
+
+func auditStrings(v []string) *models.UpdateStrings {
+	return &models.UpdateStrings{Values: v, Mode: models.RelationshipUpdateModeSet}
+}
+
+func auditIDs(v []int) *models.UpdateIDs {
+	return &models.UpdateIDs{IDs: v, Mode: models.RelationshipUpdateModeSet}
+}
+
+func auditStashIDs(v []models.StashID) *models.UpdateStashIDs {
+	return &models.UpdateStashIDs{StashIDs: v, Mode: models.RelationshipUpdateModeSet}
+}
+
+// auditCustomFields returns the custom fields of the object as a full
+// replacement, so that removed fields are restored on revert.
+func auditCustomFields(ctx context.Context, r models.CustomFieldsReader, id int) (models.CustomFieldsInput, error) {
+	fields, err := r.GetCustomFields(ctx, id)
+	if err != nil {
+		return models.CustomFieldsInput{}, err
+	}
+
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+
+	return models.CustomFieldsInput{Full: fields}, nil
+}
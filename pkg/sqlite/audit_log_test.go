@@ -0,0 +1,279 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/audit"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogRevert(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// changes are not recorded without a source
+		p := models.NewPerformer()
+		p.Name = "audit log performer"
+		if err := qb.Create(ctx, &models.CreatePerformerInput{Performer: &p}); err != nil {
+			t.Errorf("PerformerStore.Create() error = %v", err)
+			return nil
+		}
+
+		objectType := models.AuditObjectTypePerformer
+		filter := models.AuditLogFilter{
+			ObjectType: &objectType,
+			ObjectID:   &p.ID,
+		}
+
+		_, count, err := db.AuditLog.Query(ctx, filter, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 0, count)
+
+		ctx = audit.WithSource(ctx, "performerUpdate")
+
+		partial := models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString("changed name")
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  []int{tagIDs[tagIdxWithScene], tagIDs[tagIdx1WithScene]},
+			Mode: models.RelationshipUpdateModeSet,
+		}
+		if _, err := qb.UpdatePartial(ctx, p.ID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		entries, count, err := db.AuditLog.Query(ctx, filter, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		if !assert.Equal(t, 1, count) {
+			return nil
+		}
+
+		entry := entries[0]
+		assert.Equal(t, models.AuditActionUpdate, entry.Action)
+		assert.Equal(t, "performerUpdate", entry.Source)
+		assert.Equal(t, "audit log performer", entry.OldValues["name"])
+		assert.Equal(t, "changed name", entry.NewValues["name"])
+		assert.Len(t, entry.OldValues, 2)
+
+		r := audit.NewRepository(db.Repository())
+		if err := audit.Revert(ctx, r, entry); err != nil {
+			t.Errorf("audit.Revert() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Find(ctx, p.ID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, "audit log performer", got.Name)
+
+		tags, err := qb.GetTagIDs(ctx, p.ID)
+		if err != nil {
+			t.Errorf("PerformerStore.GetTagIDs() error = %v", err)
+			return nil
+		}
+		assert.Len(t, tags, 0)
+
+		reverted, err := db.AuditLog.Find(ctx, entry.ID)
+		if err != nil {
+			t.Errorf("AuditLogStore.Find() error = %v", err)
+			return nil
+		}
+		assert.NotNil(t, reverted.RevertedAt)
+
+		// reverting again is an error
+		assert.ErrorIs(t, audit.Revert(ctx, r, reverted), audit.ErrAlreadyReverted)
+
+		return nil
+	})
+}
+
+func TestAuditLogRevertCreate(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		ctx = audit.WithSource(ctx, "tagCreate")
+
+		tag := models.NewTag()
+		tag.Name = "audit log tag"
+		if err := db.Tag.Create(ctx, &tag); err != nil {
+			t.Errorf("TagStore.Create() error = %v", err)
+			return nil
+		}
+
+		objectType := models.AuditObjectTypeTag
+		entries, _, err := db.AuditLog.Query(ctx, models.AuditLogFilter{
+			ObjectType: &objectType,
+			ObjectID:   &tag.ID,
+		}, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		if !assert.Len(t, entries, 1) {
+			return nil
+		}
+
+		entry := entries[0]
+		assert.Equal(t, models.AuditActionCreate, entry.Action)
+		assert.Nil(t, entry.OldValues)
+		assert.Equal(t, "audit log tag", entry.NewValues["name"])
+
+		if err := audit.Revert(ctx, audit.NewRepository(db.Repository()), entry); err != nil {
+			t.Errorf("audit.Revert() error = %v", err)
+			return nil
+		}
+
+		got, err := db.Tag.Find(ctx, tag.ID)
+		if err != nil {
+			t.Errorf("TagStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
+
+func TestAuditLogRevertConflict(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		p := models.NewPerformer()
+		p.Name = "audit conflict performer"
+		p.Details = "old details"
+		if err := qb.Create(ctx, &models.CreatePerformerInput{Performer: &p}); err != nil {
+			t.Errorf("PerformerStore.Create() error = %v", err)
+			return nil
+		}
+
+		auditCtx := audit.WithSource(ctx, "identify")
+
+		partial := models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString("identified name")
+		partial.Details = models.NewOptionalString("identified details")
+		if _, err := qb.UpdatePartial(auditCtx, p.ID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		// later edit of the name, which must not be overwritten
+		partial = models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString("edited name")
+		if _, err := qb.UpdatePartial(ctx, p.ID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		objectType := models.AuditObjectTypePerformer
+		entries, _, err := db.AuditLog.Query(ctx, models.AuditLogFilter{
+			ObjectType: &objectType,
+			ObjectID:   &p.ID,
+		}, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		if !assert.Len(t, entries, 1) {
+			return nil
+		}
+
+		r := audit.NewRepository(db.Repository())
+		if err := audit.Revert(ctx, r, entries[0]); err != nil {
+			t.Errorf("audit.Revert() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Find(ctx, p.ID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, "edited name", got.Name)
+		assert.Equal(t, "old details", got.Details)
+
+		// reverting an entry whose fields have all been changed is a conflict
+		partial = models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString("identified again")
+		if _, err := qb.UpdatePartial(auditCtx, p.ID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		partial = models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString("edited again")
+		if _, err := qb.UpdatePartial(ctx, p.ID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		entries, _, err = db.AuditLog.Query(ctx, models.AuditLogFilter{
+			ObjectType: &objectType,
+			ObjectID:   &p.ID,
+		}, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		if !assert.Len(t, entries, 2) {
+			return nil
+		}
+
+		assert.ErrorIs(t, audit.Revert(ctx, r, entries[0]), audit.ErrConflict)
+
+		got, err = qb.Find(ctx, p.ID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, "edited again", got.Name)
+
+		return nil
+	})
+}
+
+func TestAuditLogPerformerMerge(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		ctx = audit.WithSource(ctx, "performersMerge")
+
+		srcID := performerIDs[performerIdx2WithScene]
+		destID := performerIDs[performerIdx1WithScene]
+		if err := db.Performer.Merge(ctx, []int{srcID}, destID); err != nil {
+			t.Errorf("PerformerStore.Merge() error = %v", err)
+			return nil
+		}
+
+		// the reassigned scene is recorded
+		objectType := models.AuditObjectTypeScene
+		sceneID := sceneIDs[sceneIdxWithTwoPerformers]
+		entries, _, err := db.AuditLog.Query(ctx, models.AuditLogFilter{
+			ObjectType: &objectType,
+			ObjectID:   &sceneID,
+		}, nil)
+		if err != nil {
+			t.Errorf("AuditLogStore.Query() error = %v", err)
+			return nil
+		}
+		if !assert.Len(t, entries, 1) {
+			return nil
+		}
+
+		entry := entries[0]
+		assert.Equal(t, models.AuditActionUpdate, entry.Action)
+		assert.Equal(t, "performersMerge", entry.Source)
+		assert.Contains(t, entry.OldValues, "performer_ids")
+		assert.Contains(t, entry.NewValues, "performer_ids")
+
+		return nil
+	})
+}
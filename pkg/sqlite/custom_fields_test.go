@@ -174,3 +174,160 @@ func TestSetCustomFields(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomFieldsObjectTypes(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		const title = "custom fields object"
+
+		scene := models.NewScene()
+		scene.Title = title
+		if err := db.Scene.Create(ctx, &scene, nil); err != nil {
+			t.Fatalf("SceneStore.Create() error = %v", err)
+		}
+
+		image := models.NewImage()
+		image.Title = title
+		if err := db.Image.Create(ctx, &image, nil); err != nil {
+			t.Fatalf("ImageStore.Create() error = %v", err)
+		}
+
+		gallery := models.NewGallery()
+		gallery.Title = title
+		if err := db.Gallery.Create(ctx, &gallery, nil); err != nil {
+			t.Fatalf("GalleryStore.Create() error = %v", err)
+		}
+
+		studio := models.NewStudio()
+		studio.Name = title
+		if err := db.Studio.Create(ctx, &studio); err != nil {
+			t.Fatalf("StudioStore.Create() error = %v", err)
+		}
+
+		tag := models.NewTag()
+		tag.Name = title
+		if err := db.Tag.Create(ctx, &tag); err != nil {
+			t.Fatalf("TagStore.Create() error = %v", err)
+		}
+
+		group := models.NewGroup()
+		group.Name = title
+		if err := db.Group.Create(ctx, &group); err != nil {
+			t.Fatalf("GroupStore.Create() error = %v", err)
+		}
+
+		type customFieldsReaderWriter interface {
+			models.CustomFieldsReader
+			models.CustomFieldsWriter
+		}
+
+		stores := []struct {
+			name string
+			id   int
+			rw   customFieldsReaderWriter
+		}{
+			{"scene", scene.ID, db.Scene},
+			{"image", image.ID, db.Image},
+			{"gallery", gallery.ID, db.Gallery},
+			{"studio", studio.ID, db.Studio},
+			{"tag", tag.ID, db.Tag},
+			{"group", group.ID, db.Group},
+		}
+
+		for _, s := range stores {
+			if err := s.rw.SetCustomFields(ctx, s.id, models.CustomFieldsInput{
+				Full: map[string]interface{}{
+					"source": "web",
+					"count":  1,
+				},
+			}); err != nil {
+				t.Errorf("%s SetCustomFields() error = %v", s.name, err)
+				continue
+			}
+
+			if err := s.rw.SetCustomFields(ctx, s.id, models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"count": 2,
+				},
+			}); err != nil {
+				t.Errorf("%s SetCustomFields() error = %v", s.name, err)
+				continue
+			}
+
+			expected := map[string]interface{}{
+				"source": "web",
+				"count":  int64(2),
+			}
+
+			got, err := s.rw.GetCustomFields(ctx, s.id)
+			if err != nil {
+				t.Errorf("%s GetCustomFields() error = %v", s.name, err)
+				continue
+			}
+			assert.Equal(t, expected, got, s.name)
+
+			bulk, err := s.rw.GetCustomFieldsBulk(ctx, []int{s.id})
+			if err != nil {
+				t.Errorf("%s GetCustomFieldsBulk() error = %v", s.name, err)
+				continue
+			}
+			assert.Equal(t, []models.CustomFieldMap{expected}, bulk, s.name)
+		}
+
+		// custom fields are set by partial updates
+		partial := models.NewScenePartial()
+		partial.CustomFields = models.CustomFieldsInput{
+			Partial: map[string]interface{}{
+				"quality": "high",
+			},
+		}
+		if _, err := db.Scene.UpdatePartial(ctx, scene.ID, partial); err != nil {
+			t.Fatalf("SceneStore.UpdatePartial() error = %v", err)
+		}
+
+		criterion := []models.CustomFieldCriterionInput{
+			{
+				Field:    "quality",
+				Value:    []any{"high"},
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+
+		scenes := queryScene(ctx, t, db.Scene, &models.SceneFilterType{CustomFields: criterion}, nil)
+		assert.Equal(t, []int{scene.ID}, scenesToIDs(scenes))
+
+		criterion = []models.CustomFieldCriterionInput{
+			{
+				Field:    "source",
+				Value:    []any{"web"},
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+
+		tags := queryTags(ctx, t, db.Tag, &models.TagFilterType{CustomFields: criterion}, nil)
+		if assert.Len(t, tags, 1) {
+			assert.Equal(t, tag.ID, tags[0].ID)
+		}
+
+		studios := queryStudios(ctx, t, &models.StudioFilterType{CustomFields: criterion}, nil)
+		if assert.Len(t, studios, 1) {
+			assert.Equal(t, studio.ID, studios[0].ID)
+		}
+
+		groups := queryGroups(ctx, t, &models.GroupFilterType{CustomFields: criterion}, nil)
+		if assert.Len(t, groups, 1) {
+			assert.Equal(t, group.ID, groups[0].ID)
+		}
+
+		galleries := queryGallery(ctx, t, db.Gallery, &models.GalleryFilterType{CustomFields: criterion}, nil)
+		if assert.Len(t, galleries, 1) {
+			assert.Equal(t, gallery.ID, galleries[0].ID)
+		}
+
+		images := queryImages(ctx, t, db.Image, &models.ImageFilterType{CustomFields: criterion}, nil)
+		if assert.Len(t, images, 1) {
+			assert.Equal(t, image.ID, images[0].ID)
+		}
+
+		return nil
+	})
+}
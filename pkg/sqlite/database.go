@@ -34,7 +34,7 @@ const (
 	cacheSizeEnv = "STASH_SQLITE_CACHE_SIZE"
 )
 
-var appSchemaVersion uint = 71
+var appSchemaVersion uint = 82
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -76,6 +76,10 @@ type storeRepository struct {
 	SceneMarker    *SceneMarkerStore
 	Performer      *PerformerStore
 	SavedFilter    *SavedFilterStore
+	Job            *JobStore
+	User           *UserStore
+	APIKey         *APIKeyStore
+	AuditLog       *AuditLogStore
 	Studio         *StudioStore
 	Tag            *TagStore
 	Group          *GroupStore
@@ -98,9 +102,6 @@ func NewDatabase() *Database {
 	folderStore := NewFolderStore()
 	galleryStore := NewGalleryStore(fileStore, folderStore)
 	blobStore := NewBlobStore(BlobStoreOptions{})
-	performerStore := NewPerformerStore(blobStore)
-	studioStore := NewStudioStore(blobStore)
-	tagStore := NewTagStore(blobStore)
 
 	r := &storeRepository{}
 	*r = storeRepository{
@@ -112,11 +113,15 @@ func NewDatabase() *Database {
 		Image:          NewImageStore(r),
 		Gallery:        galleryStore,
 		GalleryChapter: NewGalleryChapterStore(),
-		Performer:      performerStore,
-		Studio:         studioStore,
-		Tag:            tagStore,
+		Performer:      NewPerformerStore(r, blobStore),
+		Studio:         NewStudioStore(r, blobStore),
+		Tag:            NewTagStore(r, blobStore),
 		Group:          NewGroupStore(blobStore),
 		SavedFilter:    NewSavedFilterStore(),
+		Job:            NewJobStore(),
+		User:           NewUserStore(),
+		APIKey:         NewAPIKeyStore(),
+		AuditLog:       NewAuditLogStore(r),
 	}
 
 	ret := &Database{
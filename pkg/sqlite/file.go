@@ -36,6 +36,7 @@ type basicFileRow struct {
 	ParentFolderID models.FolderID `db:"parent_folder_id"`
 	Size           int64           `db:"size"`
 	ModTime        Timestamp       `db:"mod_time"`
+	MissingSince   NullTimestamp   `db:"missing_since"`
 	CreatedAt      Timestamp       `db:"created_at"`
 	UpdatedAt      Timestamp       `db:"updated_at"`
 }
@@ -47,6 +48,7 @@ func (r *basicFileRow) fromBasicFile(o models.BaseFile) {
 	r.ParentFolderID = o.ParentFolderID
 	r.Size = o.Size
 	r.ModTime = Timestamp{Timestamp: o.ModTime}
+	r.MissingSince = NullTimestampFromTimePtr(o.MissingSince)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 }
@@ -63,6 +65,8 @@ type videoFileRow struct {
 	BitRate          int64         `db:"bit_rate"`
 	Interactive      bool          `db:"interactive"`
 	InteractiveSpeed null.Int      `db:"interactive_speed"`
+	AudioStreams     null.String   `db:"audio_streams"`
+	SubtitleStreams  null.String   `db:"subtitle_streams"`
 }
 
 func (f *videoFileRow) fromVideoFile(ff models.VideoFile) {
@@ -77,6 +81,28 @@ func (f *videoFileRow) fromVideoFile(ff models.VideoFile) {
 	f.BitRate = ff.BitRate
 	f.Interactive = ff.Interactive
 	f.InteractiveSpeed = intFromPtr(ff.InteractiveSpeed)
+	f.AudioStreams = encodeVideoFileStreams(ff.AudioStreams)
+	f.SubtitleStreams = encodeVideoFileStreams(ff.SubtitleStreams)
+}
+
+// encodeVideoFileStreams encodes the streams as json.
+// A nil slice indicates that the streams have not been probed, and is stored as NULL.
+func encodeVideoFileStreams(v []models.VideoFileStream) null.String {
+	if v == nil {
+		return null.String{}
+	}
+
+	return null.StringFrom(encodeJSONOrEmpty(v))
+}
+
+func decodeVideoFileStreams(s null.String) []models.VideoFileStream {
+	if !s.Valid {
+		return nil
+	}
+
+	ret := []models.VideoFileStream{}
+	decodeJSON(s.String, &ret)
+	return ret
 }
 
 type imageFileRow struct {
@@ -107,6 +133,8 @@ type videoFileQueryRow struct {
 	BitRate          null.Int    `db:"bit_rate"`
 	Interactive      null.Bool   `db:"interactive"`
 	InteractiveSpeed null.Int    `db:"interactive_speed"`
+	AudioStreams     null.String `db:"audio_streams"`
+	SubtitleStreams  null.String `db:"subtitle_streams"`
 }
 
 func (f *videoFileQueryRow) resolve() *models.VideoFile {
@@ -121,6 +149,8 @@ func (f *videoFileQueryRow) resolve() *models.VideoFile {
 		BitRate:          f.BitRate.Int64,
 		Interactive:      f.Interactive.Bool,
 		InteractiveSpeed: nullIntPtr(f.InteractiveSpeed),
+		AudioStreams:     decodeVideoFileStreams(f.AudioStreams),
+		SubtitleStreams:  decodeVideoFileStreams(f.SubtitleStreams),
 	}
 }
 
@@ -138,6 +168,8 @@ func videoFileQueryColumns() []interface{} {
 		table.Col("bit_rate"),
 		table.Col("interactive"),
 		table.Col("interactive_speed"),
+		table.Col("audio_streams"),
+		table.Col("subtitle_streams"),
 	}
 }
 
@@ -173,6 +205,7 @@ type fileQueryRow struct {
 	ParentFolderID null.Int      `db:"parent_folder_id"`
 	Size           null.Int      `db:"size"`
 	ModTime        NullTimestamp `db:"mod_time"`
+	MissingSince   NullTimestamp `db:"missing_since"`
 	CreatedAt      NullTimestamp `db:"file_created_at"`
 	UpdatedAt      NullTimestamp `db:"file_updated_at"`
 
@@ -197,6 +230,7 @@ func (r *fileQueryRow) resolve() models.File {
 		ParentFolderID: models.FolderID(r.ParentFolderID.Int64),
 		Basename:       r.Basename.String,
 		Size:           r.Size.Int64,
+		MissingSince:   r.MissingSince.TimePtr(),
 		CreatedAt:      r.CreatedAt.Timestamp,
 		UpdatedAt:      r.UpdatedAt.Timestamp,
 	}
@@ -456,6 +490,7 @@ func (qb *FileStore) selectDataset() *goqu.SelectDataset {
 		table.Col("parent_folder_id"),
 		table.Col("size"),
 		table.Col("mod_time"),
+		table.Col("missing_since"),
 		table.Col("created_at").As("file_created_at"),
 		table.Col("updated_at").As("file_updated_at"),
 		folderTable.Col("path").As("parent_folder_path"),
@@ -252,6 +252,7 @@ func Test_fileStore_Update(t *testing.T) {
 		fileModTime            = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
 		createdAt              = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt              = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+		missingSince           = time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
 		size             int64 = 1234
 
 		duration         = 1.234
@@ -288,8 +289,9 @@ func Test_fileStore_Update(t *testing.T) {
 						Fingerprint: fingerprintValue,
 					},
 				},
-				CreatedAt: createdAt,
-				UpdatedAt: updatedAt,
+				MissingSince: &missingSince,
+				CreatedAt:    createdAt,
+				UpdatedAt:    updatedAt,
 			},
 			false,
 		},
@@ -324,6 +326,11 @@ func Test_fileStore_Update(t *testing.T) {
 				Height:     height,
 				FrameRate:  framerate,
 				BitRate:    bitrate,
+				AudioStreams: []models.VideoFileStream{
+					{Index: 0, Codec: audioCodec, Language: "eng", Default: true},
+					{Index: 1, Codec: audioCodec, Language: "jpn", Title: "Commentary"},
+				},
+				SubtitleStreams: []models.VideoFileStream{},
 			},
 			false,
 		},
@@ -27,6 +27,10 @@ const (
 	galleryIDColumn          = "gallery_id"
 	galleriesURLsTable       = "gallery_urls"
 	galleriesURLColumn       = "url"
+	galleriesViewDatesTable  = "galleries_view_dates"
+	galleryViewDateColumn    = "view_date"
+	galleriesODatesTable     = "galleries_o_dates"
+	galleryODateColumn       = "o_date"
 )
 
 type galleryRow struct {
@@ -67,6 +71,9 @@ type galleryQueryRow struct {
 	PrimaryFileFolderPath zero.String `db:"primary_file_folder_path"`
 	PrimaryFileBasename   zero.String `db:"primary_file_basename"`
 	PrimaryFileChecksum   zero.String `db:"primary_file_checksum"`
+	// the last image index is returned by GetLastImageIndex, since it
+	// depends on the user
+	LastImageIndex null.Int `db:"last_image_index"`
 }
 
 func (r *galleryQueryRow) resolve() *models.Gallery {
@@ -181,7 +188,11 @@ var (
 )
 
 type GalleryStore struct {
+	customFieldsStore
+
 	tableMgr *table
+	oDateManager
+	viewDateManager
 
 	fileStore   *FileStore
 	folderStore *FolderStore
@@ -189,9 +200,15 @@ type GalleryStore struct {
 
 func NewGalleryStore(fileStore *FileStore, folderStore *FolderStore) *GalleryStore {
 	return &GalleryStore{
-		tableMgr:    galleryTableMgr,
-		fileStore:   fileStore,
-		folderStore: folderStore,
+		customFieldsStore: customFieldsStore{
+			table: galleriesCustomFieldsTable,
+			fk:    galleriesCustomFieldsTable.Col(galleryIDColumn),
+		},
+		tableMgr:        galleryTableMgr,
+		oDateManager:    oDateManager{galleriesOTableMgr},
+		viewDateManager: viewDateManager{galleriesViewTableMgr},
+		fileStore:       fileStore,
+		folderStore:     folderStore,
 	}
 }
 
@@ -267,6 +284,10 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 		}
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeGallery, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -278,6 +299,11 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 }
 
 func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Gallery) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeGallery, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r galleryRow
 	r.fromGallery(*updatedObject)
 
@@ -317,10 +343,15 @@ func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Galler
 		}
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial models.GalleryPartial) (*models.Gallery, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeGallery, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := galleryRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -362,11 +393,78 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 		}
 	}
 
+	if err := qb.SetCustomFields(ctx, id, partial.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *GalleryStore) Destroy(ctx context.Context, id int) error {
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	record, err := beginAudit(ctx, models.AuditObjectTypeGallery, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
+}
+
+// auditValues returns the values of the gallery recorded in the audit log.
+func (qb *GalleryStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	g, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.GalleryPartial{
+		Title:        models.NewOptionalString(g.Title),
+		Code:         models.NewOptionalString(g.Code),
+		Date:         models.NewOptionalDatePtr(g.Date),
+		Details:      models.NewOptionalString(g.Details),
+		Photographer: models.NewOptionalString(g.Photographer),
+		Organized:    models.NewOptionalBool(g.Organized),
+		Rating:       models.NewOptionalIntPtr(g.Rating),
+		StudioID:     models.NewOptionalIntPtr(g.StudioID),
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.URLs = auditStrings(urls)
+
+	sceneIDs, err := qb.GetSceneIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.SceneIDs = auditIDs(sceneIDs)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.PerformerIDs = auditIDs(performerIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func (qb *GalleryStore) GetFiles(ctx context.Context, id int) ([]models.File, error) {
@@ -392,20 +490,23 @@ func (qb *GalleryStore) GetManyFileIDs(ctx context.Context, ids []int) ([][]mode
 	return galleryRepository.files.getMany(ctx, ids, primaryOnly)
 }
 
-// returns nil, nil if not found
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *GalleryStore) Find(ctx context.Context, id int) (*models.Gallery, error) {
-	ret, err := qb.find(ctx, id)
+	q := galleryRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the galleries with the provided ids, in the same order. If content
+// is restricted in the context, hidden galleries are returned as nil.
 func (qb *GalleryStore) FindMany(ctx context.Context, ids []int) ([]*models.Gallery, error) {
 	galleries := make([]*models.Gallery, len(ids))
 
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(qb.table().Col(idColumn).In(batch))
+		q := galleryRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(qb.table().Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -421,8 +522,10 @@ func (qb *GalleryStore) FindMany(ctx context.Context, ids []int) ([]*models.Gall
 		return nil, err
 	}
 
+	// hidden galleries are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range galleries {
-		if galleries[i] == nil {
+		if galleries[i] == nil && !restricted {
 			return nil, fmt.Errorf("gallery with id %d not found", ids[i])
 		}
 	}
@@ -451,7 +554,7 @@ func (qb *GalleryStore) findBySubquery(ctx context.Context, sq *goqu.SelectDatas
 		),
 	)
 
-	return qb.getMany(ctx, q)
+	return qb.getMany(ctx, galleryRestrictions.restrictDataset(ctx, q))
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -646,6 +749,7 @@ func (qb *GalleryStore) CountByImageID(ctx context.Context, imageID int) (int, e
 	joinTable := galleriesImagesJoinTable
 
 	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col(imageIDColumn).Eq(imageID))
+	q = galleryRestrictions.restrictIDColumn(ctx, q, joinTable.Col(galleryIDColumn))
 	return count(ctx, q)
 }
 
@@ -670,12 +774,12 @@ func (qb *GalleryStore) FindUserGalleryByTitle(ctx context.Context, title string
 }
 
 func (qb *GalleryStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := galleryRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *GalleryStore) All(ctx context.Context) ([]*models.Gallery, error) {
-	return qb.getMany(ctx, qb.selectDataset())
+	return qb.getMany(ctx, galleryRestrictions.restrictDataset(ctx, qb.selectDataset()))
 }
 
 func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
@@ -732,7 +836,9 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 		return nil, err
 	}
 
-	if err := qb.setGallerySort(&query, findFilter); err != nil {
+	galleryRestrictions.addRestrictions(ctx, &query)
+
+	if err := qb.setGallerySort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -775,6 +881,9 @@ var gallerySortOptions = sortOptions{
 	"file_mod_time",
 	"id",
 	"images_count",
+	"last_o_at",
+	"last_viewed_at",
+	"o_counter",
 	"path",
 	"performer_count",
 	"random",
@@ -782,9 +891,10 @@ var gallerySortOptions = sortOptions{
 	"tag_count",
 	"title",
 	"updated_at",
+	"view_count",
 }
 
-func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *GalleryStore) setGallerySort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -833,6 +943,14 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		query.sortAndPagination += getCountSort(galleryTable, galleriesTagsTable, galleryIDColumn, direction)
 	case "performer_count":
 		query.sortAndPagination += getCountSort(galleryTable, performersGalleriesTable, galleryIDColumn, direction)
+	case "view_count":
+		query.sortAndPagination += getUserCountSort(ctx, galleryTable, galleriesViewDatesTable, galleryIDColumn, direction)
+	case "last_viewed_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", galleriesViewDatesTable, galleryIDColumn, galleryTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
+	case "last_o_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", galleriesODatesTable, galleryIDColumn, galleryTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
+	case "o_counter":
+		query.sortAndPagination += getUserCountSort(ctx, galleryTable, galleriesODatesTable, galleryIDColumn, direction)
 	case "path":
 		// special handling for path
 		addFileTable()
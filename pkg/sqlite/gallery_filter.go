@@ -86,6 +86,19 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		qb.pathCriterionHandler(filter.Path),
 		qb.fileCountCriterionHandler(filter.FileCount),
 		intCriterionHandler(filter.Rating100, "galleries.rating", nil),
+		qb.oCountCriterionHandler(filter.OCounter),
+		qb.viewCountCriterionHandler(filter.ViewCount),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			if filter.LastViewedAt != nil {
+				f.addLeftJoin(
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_viewed_at FROM %s WHERE %s GROUP BY %s)", galleryIDColumn, galleryViewDateColumn, galleriesViewDatesTable, userIDClause(ctx, userIDColumn), galleryIDColumn),
+					"gallery_last_view",
+					fmt.Sprintf("gallery_last_view.%s = galleries.id", galleryIDColumn),
+				)
+				h := timestampCriterionHandler{filter.LastViewedAt, "IFNULL(last_viewed_at, datetime(0))", nil}
+				h.handle(ctx, f)
+			}
+		}),
 		qb.urlsCriterionHandler(filter.URL),
 		boolCriterionHandler(filter.Organized, "galleries.organized", nil),
 		qb.missingCriterionHandler(filter.IsMissing),
@@ -146,6 +159,13 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 				galleryRepository.tags.innerJoin(f, "gallery_tag", "galleries.id")
 			},
 		},
+
+		&customFieldsFilterHandler{
+			table: galleriesCustomFieldsTable.GetTable(),
+			fkCol: galleryIDColumn,
+			c:     filter.CustomFields,
+			idCol: "galleries.id",
+		},
 	}
 }
 
@@ -241,6 +261,32 @@ func (qb *galleryFilterHandler) pathCriterionHandler(c *models.StringCriterionIn
 	}
 }
 
+func (qb *galleryFilterHandler) viewCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: galleryTable,
+			joinTable:    galleriesViewDatesTable,
+			primaryFK:    galleryIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
+
+		h.handler(count)(ctx, f)
+	}
+}
+
+func (qb *galleryFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: galleryTable,
+			joinTable:    galleriesODatesTable,
+			primaryFK:    galleryIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
+
+		h.handler(count)(ctx, f)
+	}
+}
+
 func (qb *galleryFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
 	h := countCriterionHandlerBuilder{
 		primaryTable: galleryTable,
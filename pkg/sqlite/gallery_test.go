@@ -2747,6 +2747,27 @@ func TestGalleryQuerySorting(t *testing.T) {
 			-1,
 			-1,
 		},
+		{
+			"o counter",
+			"o_counter",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
+		{
+			"view count",
+			"view_count",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
+		{
+			"last viewed at",
+			"last_viewed_at",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
 	}
 
 	qb := db.Gallery
@@ -3001,6 +3022,128 @@ func TestGallerySetAndResetCover(t *testing.T) {
 	})
 }
 
+func TestGalleryStore_SaveActivity(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Gallery
+		galleryID := galleryIDs[galleryIdxWithTwoImages]
+
+		got, err := qb.GetLastImageIndex(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetLastImageIndex() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		index := 1
+		if _, err := qb.SaveActivity(ctx, galleryID, &index); err != nil {
+			t.Errorf("GalleryStore.SaveActivity() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.GetLastImageIndex(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetLastImageIndex() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &index, got)
+
+		// other users have their own last image index
+		u := createTestUser(ctx, t, "gallery viewer")
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		got, err = qb.GetLastImageIndex(userCtx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetLastImageIndex() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		for _, userIndex := range []int{0, 2} {
+			if _, err := qb.SaveActivity(userCtx, galleryID, &userIndex); err != nil {
+				t.Errorf("GalleryStore.SaveActivity() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err = qb.GetLastImageIndex(userCtx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetLastImageIndex() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 2, *got)
+
+		got, err = qb.GetLastImageIndex(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetLastImageIndex() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &index, got)
+
+		if _, err := qb.SaveActivity(ctx, invalidID, &index); err == nil {
+			t.Errorf("GalleryStore.SaveActivity() expected error for invalid id")
+		}
+
+		return nil
+	})
+}
+
+func TestGalleryStore_History(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Gallery
+		galleryID := galleryIDs[galleryIdxWithImage]
+
+		views, err := qb.AddViews(ctx, galleryID, nil)
+		if err != nil {
+			t.Errorf("GalleryStore.AddViews() error = %v", err)
+			return nil
+		}
+		assert.Len(t, views, 1)
+
+		os, err := qb.AddO(ctx, galleryID, []time.Time{time.Now(), time.Now()})
+		if err != nil {
+			t.Errorf("GalleryStore.AddO() error = %v", err)
+			return nil
+		}
+		assert.Len(t, os, 2)
+
+		galleries := queryGallery(ctx, t, qb, &models.GalleryFilterType{
+			ID: &models.IntCriterionInput{
+				Value:    galleryID,
+				Modifier: models.CriterionModifierEquals,
+			},
+			OCounter: &models.IntCriterionInput{
+				Value:    2,
+				Modifier: models.CriterionModifierEquals,
+			},
+			ViewCount: &models.IntCriterionInput{
+				Value:    1,
+				Modifier: models.CriterionModifierEquals,
+			},
+			LastViewedAt: &models.TimestampCriterionInput{
+				Value:    "2000-01-01",
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+		assert.Len(t, galleries, 1)
+
+		count, err := qb.ResetO(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.ResetO() error = %v", err)
+			return nil
+		}
+		assert.Zero(t, count)
+
+		count, err = qb.DeleteAllViews(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.DeleteAllViews() error = %v", err)
+			return nil
+		}
+		assert.Zero(t, count)
+
+		return nil
+	})
+}
+
 // TODO Count
 // TODO All
 // TODO Query
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	galleryUserDataTable = "galleries_user_data"
+)
+
+var galleryUserDataTableMgr = &table{
+	table:    goqu.T(galleryUserDataTable),
+	idColumn: goqu.T(galleryUserDataTable).Col(galleryIDColumn),
+}
+
+// SaveActivity sets the last image index viewed in the gallery. The value of
+// the configured user is stored in the galleries table, the values of other
+// users are stored in the galleries_user_data table.
+func (qb *GalleryStore) SaveActivity(ctx context.Context, id int, lastImageIndex *int) (bool, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return false, err
+	}
+
+	if lastImageIndex == nil {
+		return true, nil
+	}
+
+	userID := models.UserIDFromContext(ctx)
+	if userID == nil {
+		if err := qb.tableMgr.updateByID(ctx, id, goqu.Record{"last_image_index": *lastImageIndex}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	q := dialect.Insert(galleryUserDataTableMgr.table).Cols(galleryIDColumn, userIDColumn, "last_image_index").Vals(
+		goqu.Vals{id, *userID, *lastImageIndex},
+	).OnConflict(goqu.DoUpdate("gallery_id, user_id", goqu.Record{"last_image_index": goqu.I("excluded.last_image_index")}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return false, fmt.Errorf("updating gallery user data: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetLastImageIndex returns the last image index viewed in the gallery by
+// the user in the context. Returns nil if the gallery has not been viewed.
+func (qb *GalleryStore) GetLastImageIndex(ctx context.Context, id int) (*int, error) {
+	var q *goqu.SelectDataset
+	if userID := models.UserIDFromContext(ctx); userID != nil {
+		table := galleryUserDataTableMgr.table
+		q = dialect.From(table).Select(table.Col("last_image_index")).Where(
+			table.Col(galleryIDColumn).Eq(id),
+			table.Col(userIDColumn).Eq(*userID),
+		)
+	} else {
+		table := qb.table()
+		q = dialect.From(table).Select(table.Col("last_image_index")).Where(table.Col(idColumn).Eq(id))
+	}
+
+	var ret null.Int
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return nil, err
+	}
+
+	return nullIntPtr(ret), nil
+}
@@ -131,6 +131,7 @@ type GroupStore struct {
 	blobJoinQueryBuilder
 	tagRelationshipStore
 	groupRelationshipStore
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -149,6 +150,10 @@ func NewGroupStore(blobStore *BlobStore) *GroupStore {
 		groupRelationshipStore: groupRelationshipStore{
 			table: groupRelationshipTableMgr,
 		},
+		customFieldsStore: customFieldsStore{
+			table: groupsCustomFieldsTable,
+			fk:    groupsCustomFieldsTable.Col(groupIDColumn),
+		},
 
 		tableMgr: groupTableMgr,
 	}
@@ -190,6 +195,10 @@ func (qb *GroupStore) Create(ctx context.Context, newObject *models.Group) error
 		return err
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeGroup, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -201,6 +210,11 @@ func (qb *GroupStore) Create(ctx context.Context, newObject *models.Group) error
 }
 
 func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.GroupPartial) (*models.Group, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeGroup, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := groupRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -233,10 +247,23 @@ func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.
 		return nil, err
 	}
 
+	if err := qb.SetCustomFields(ctx, id, partial.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeGroup, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r groupRow
 	r.fromGroup(*updatedObject)
 
@@ -262,33 +289,101 @@ func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) e
 		return err
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *GroupStore) Destroy(ctx context.Context, id int) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeGroup, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImages(ctx, id); err != nil {
 		return err
 	}
 
-	return groupRepository.destroyExisting(ctx, []int{id})
+	if err := groupRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
 }
 
-// returns nil, nil if not found
+// auditValues returns the values of the group recorded in the audit log.
+func (qb *GroupStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	g, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.GroupPartial{
+		Name:     models.NewOptionalString(g.Name),
+		Aliases:  models.NewOptionalString(g.Aliases),
+		Duration: models.NewOptionalIntPtr(g.Duration),
+		Date:     models.NewOptionalDatePtr(g.Date),
+		StudioID: models.NewOptionalIntPtr(g.StudioID),
+		Director: models.NewOptionalString(g.Director),
+		Rating:   models.NewOptionalIntPtr(g.Rating),
+		Synopsis: models.NewOptionalString(g.Synopsis),
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.URLs = auditStrings(urls)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	containingGroups, err := qb.GetContainingGroupDescriptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.ContainingGroups = &models.UpdateGroupDescriptions{
+		Groups: containingGroups,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+
+	subGroups, err := qb.GetSubGroupDescriptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.SubGroups = &models.UpdateGroupDescriptions{
+		Groups: subGroups,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *GroupStore) Find(ctx context.Context, id int) (*models.Group, error) {
-	ret, err := qb.find(ctx, id)
+	q := groupRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the groups with the provided ids, in the same order. If content
+// is restricted in the context, hidden groups are returned as nil.
 func (qb *GroupStore) FindMany(ctx context.Context, ids []int) ([]*models.Group, error) {
 	ret := make([]*models.Group, len(ids))
 
 	table := qb.table()
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		q := groupRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -304,8 +399,10 @@ func (qb *GroupStore) FindMany(ctx context.Context, ids []int) ([]*models.Group,
 		return nil, err
 	}
 
+	// hidden groups are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range ret {
-		if ret[i] == nil {
+		if ret[i] == nil && !restricted {
 			return nil, fmt.Errorf("group with id %d not found", ids[i])
 		}
 	}
@@ -405,14 +502,14 @@ func (qb *GroupStore) FindByNames(ctx context.Context, names []string, nocase bo
 }
 
 func (qb *GroupStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := groupRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *GroupStore) All(ctx context.Context) ([]*models.Group, error) {
 	table := qb.table()
 
-	return qb.getMany(ctx, qb.selectDataset().Order(
+	return qb.getMany(ctx, groupRestrictions.restrictDataset(ctx, qb.selectDataset()).Order(
 		table.Col("name").Asc(),
 		table.Col(idColumn).Asc(),
 	))
@@ -442,6 +539,8 @@ func (qb *GroupStore) makeQuery(ctx context.Context, groupFilter *models.GroupFi
 		return nil, err
 	}
 
+	groupRestrictions.addRestrictions(ctx, &query)
+
 	if err := qb.setGroupSort(&query, findFilter); err != nil {
 		return nil, err
 	}
@@ -593,7 +692,7 @@ func (qb *GroupStore) FindByPerformerID(ctx context.Context, performerID int) ([
 FROM groups
 INNER JOIN groups_scenes ON groups.id = groups_scenes.group_id
 INNER JOIN performers_scenes ON performers_scenes.scene_id = groups_scenes.scene_id
-WHERE performers_scenes.performer_id = ?
+WHERE performers_scenes.performer_id = ?` + groupRestrictions.andClauses(ctx) + `
 `
 	args := []interface{}{performerID}
 	return qb.queryGroups(ctx, query, args)
@@ -612,7 +711,7 @@ WHERE performers_scenes.performer_id = ?
 func (qb *GroupStore) FindByStudioID(ctx context.Context, studioID int) ([]*models.Group, error) {
 	query := `SELECT groups.*
 FROM groups
-WHERE groups.studio_id = ?
+WHERE groups.studio_id = ?` + groupRestrictions.andClauses(ctx) + `
 `
 	args := []interface{}{studioID}
 	return qb.queryGroups(ctx, query, args)
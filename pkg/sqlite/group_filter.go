@@ -95,6 +95,13 @@ func (qb *groupFilterHandler) criterionHandler() criterionHandler {
 			relatedRepo:    studioRepository.repository,
 			relatedHandler: &studioFilterHandler{groupFilter.StudiosFilter},
 		},
+
+		&customFieldsFilterHandler{
+			table: groupsCustomFieldsTable.GetTable(),
+			fkCol: groupIDColumn,
+			c:     groupFilter.CustomFields,
+			idCol: "groups.id",
+		},
 	}
 }
 
@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
 
@@ -27,8 +31,35 @@ const (
 	imagesFilesTable      = "images_files"
 	imagesURLsTable       = "image_urls"
 	imageURLColumn        = "url"
+	imagesViewDatesTable  = "images_view_dates"
+	imageViewDateColumn   = "view_date"
+	imagesODatesTable     = "images_o_dates"
+	imageODateColumn      = "o_date"
 )
 
+var findExactImageDuplicateQuery = `
+SELECT GROUP_CONCAT(DISTINCT images.id) as ids
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+%s
+GROUP BY files_fingerprints.fingerprint
+HAVING COUNT(DISTINCT images.id) > 1
+ORDER BY SUM(files.size) DESC;
+`
+
+var findAllImagePhashesQuery = `
+SELECT images.id as id
+    , files_fingerprints.fingerprint as phash
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+%s
+ORDER BY files.size DESC;
+`
+
 type imageRow struct {
 	ID    int         `db:"id" goqu:"skipinsert"`
 	Title zero.String `db:"title"`
@@ -39,7 +70,6 @@ type imageRow struct {
 	Details      zero.String `db:"details"`
 	Photographer zero.String `db:"photographer"`
 	Organized    bool        `db:"organized"`
-	OCounter     int         `db:"o_counter"`
 	StudioID     null.Int    `db:"studio_id,omitempty"`
 	CreatedAt    Timestamp   `db:"created_at"`
 	UpdatedAt    Timestamp   `db:"updated_at"`
@@ -54,7 +84,6 @@ func (r *imageRow) fromImage(i models.Image) {
 	r.Details = zero.StringFrom(i.Details)
 	r.Photographer = zero.StringFrom(i.Photographer)
 	r.Organized = i.Organized
-	r.OCounter = i.OCounter
 	r.StudioID = intFromPtr(i.StudioID)
 	r.CreatedAt = Timestamp{Timestamp: i.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: i.UpdatedAt}
@@ -78,7 +107,6 @@ func (r *imageQueryRow) resolve() *models.Image {
 		Details:      r.Details.String,
 		Photographer: r.Photographer.String,
 		Organized:    r.Organized,
-		OCounter:     r.OCounter,
 		StudioID:     nullIntPtr(r.StudioID),
 
 		PrimaryFileID: nullIntFileIDPtr(r.PrimaryFileID),
@@ -107,7 +135,6 @@ func (r *imageRowRecord) fromPartial(i models.ImagePartial) {
 	r.setNullString("details", i.Details)
 	r.setNullString("photographer", i.Photographer)
 	r.setBool("organized", i.Organized)
-	r.setInt("o_counter", i.OCounter)
 	r.setNullInt("studio_id", i.StudioID)
 	r.setTimestamp("created_at", i.CreatedAt)
 	r.setTimestamp("updated_at", i.UpdatedAt)
@@ -183,16 +210,24 @@ var (
 )
 
 type ImageStore struct {
+	customFieldsStore
+
 	tableMgr *table
-	oCounterManager
+	oDateManager
+	viewDateManager
 
 	repo *storeRepository
 }
 
 func NewImageStore(r *storeRepository) *ImageStore {
 	return &ImageStore{
+		customFieldsStore: customFieldsStore{
+			table: imagesCustomFieldsTable,
+			fk:    imagesCustomFieldsTable.Col(imageIDColumn),
+		},
 		tableMgr:        imageTableMgr,
-		oCounterManager: oCounterManager{imageTableMgr},
+		oDateManager:    oDateManager{imagesOTableMgr},
+		viewDateManager: viewDateManager{imagesViewTableMgr},
 		repo:            r,
 	}
 }
@@ -274,6 +309,10 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.Image, fileI
 		}
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeImage, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -285,6 +324,11 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.Image, fileI
 }
 
 func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.ImagePartial) (*models.Image, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeImage, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := imageRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -327,10 +371,23 @@ func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if err := qb.SetCustomFields(ctx, id, partial.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeImage, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r imageRow
 	r.fromImage(*updatedObject)
 
@@ -372,27 +429,90 @@ func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) e
 			return err
 		}
 	}
-	return nil
+
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *ImageStore) Destroy(ctx context.Context, id int) error {
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	record, err := beginAudit(ctx, models.AuditObjectTypeImage, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
 }
 
-// returns nil, nil if not found
+// auditValues returns the values of the image recorded in the audit log.
+func (qb *ImageStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	i, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.ImagePartial{
+		Title:        models.NewOptionalString(i.Title),
+		Code:         models.NewOptionalString(i.Code),
+		Date:         models.NewOptionalDatePtr(i.Date),
+		Details:      models.NewOptionalString(i.Details),
+		Photographer: models.NewOptionalString(i.Photographer),
+		Organized:    models.NewOptionalBool(i.Organized),
+		Rating:       models.NewOptionalIntPtr(i.Rating),
+		StudioID:     models.NewOptionalIntPtr(i.StudioID),
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.URLs = auditStrings(urls)
+
+	galleryIDs, err := qb.GetGalleryIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.GalleryIDs = auditIDs(galleryIDs)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.PerformerIDs = auditIDs(performerIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *ImageStore) Find(ctx context.Context, id int) (*models.Image, error) {
-	ret, err := qb.find(ctx, id)
+	q := imageRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the images with the provided ids, in the same order. If content
+// is restricted in the context, hidden images are returned as nil.
 func (qb *ImageStore) FindMany(ctx context.Context, ids []int) ([]*models.Image, error) {
 	images := make([]*models.Image, len(ids))
 
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(qb.table().Col(idColumn).In(batch))
+		q := imageRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(qb.table().Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -408,8 +528,10 @@ func (qb *ImageStore) FindMany(ctx context.Context, ids []int) ([]*models.Image,
 		return nil, err
 	}
 
+	// hidden images are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range images {
-		if images[i] == nil {
+		if images[i] == nil && !restricted {
 			return nil, fmt.Errorf("image with id %d not found", ids[i])
 		}
 	}
@@ -438,7 +560,7 @@ func (qb *ImageStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset
 		),
 	)
 
-	return qb.getMany(ctx, q)
+	return qb.getMany(ctx, imageRestrictions.restrictDataset(ctx, q))
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -606,6 +728,90 @@ func (qb *ImageStore) FindByChecksum(ctx context.Context, checksum string) ([]*m
 	})
 }
 
+// FindDuplicates returns groups of images with a phash within the provided
+// distance of each other. Images in zip files are included. Images hidden by
+// the content restrictions in the context are excluded.
+func (qb *ImageStore) FindDuplicates(ctx context.Context, distance int) ([][]*models.Image, error) {
+	where := ""
+	if r := models.RestrictionsFromContext(ctx); r != nil {
+		if clauses := imageRestrictions.clauses(r); len(clauses) > 0 {
+			where = "WHERE " + strings.Join(clauses, " AND ")
+		}
+	}
+
+	var dupeIds [][]int
+	if distance == 0 {
+		var ids []string
+		if err := dbWrapper.Select(ctx, &ids, fmt.Sprintf(findExactImageDuplicateQuery, where)); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			var imageIds []int
+			for _, strId := range strings.Split(id, ",") {
+				if intId, err := strconv.Atoi(strId); err == nil {
+					imageIds = sliceutil.AppendUnique(imageIds, intId)
+				}
+			}
+
+			if len(imageIds) > 1 {
+				dupeIds = append(dupeIds, imageIds)
+			}
+		}
+	} else {
+		var hashes []*utils.Phash
+
+		if err := imageRepository.queryFunc(ctx, fmt.Sprintf(findAllImagePhashesQuery, where), nil, false, func(rows *sqlx.Rows) error {
+			phash := utils.Phash{
+				Bucket:   -1,
+				Duration: -1,
+			}
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+
+			hashes = append(hashes, &phash)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		// negative duration difference disables the duration check
+		dupeIds = utils.FindDuplicates(hashes, distance, -1)
+	}
+
+	var duplicates [][]*models.Image
+	for _, imageIds := range dupeIds {
+		// duplicates hidden by content restrictions are omitted
+		if images, err := qb.FindMany(ctx, imageIds); err == nil {
+			images = sliceutil.OmitNil(images)
+			if len(images) > 1 {
+				duplicates = append(duplicates, images)
+			}
+		}
+	}
+
+	sortImagesByPath(duplicates)
+
+	return duplicates, nil
+}
+
+func sortImagesByPath(images [][]*models.Image) {
+	firstPath := func(images []*models.Image) string {
+		var ret string
+		for i, image := range images {
+			if i == 0 || image.Path < ret {
+				ret = image.Path
+			}
+		}
+		return ret
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return firstPath(images[i]) < firstPath(images[j])
+	})
+}
+
 var defaultGalleryOrder = []exp.OrderedExpression{
 	goqu.L("COALESCE(folders.path, '') || COALESCE(files.basename, '') COLLATE NATURAL_CI").Asc(),
 	goqu.L("COALESCE(images.title, images.id) COLLATE NATURAL_CI").Asc(),
@@ -666,26 +872,29 @@ func (qb *ImageStore) CountByGalleryID(ctx context.Context, galleryID int) (int,
 	joinTable := goqu.T(galleriesImagesTable)
 
 	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col("gallery_id").Eq(galleryID))
+	q = imageRestrictions.restrictIDColumn(ctx, q, joinTable.Col(imageIDColumn))
 	return count(ctx, q)
 }
 
 func (qb *ImageStore) OCountByPerformerID(ctx context.Context, performerID int) (int, error) {
 	table := qb.table()
 	joinTable := performersImagesJoinTable
-	q := dialect.Select(goqu.COALESCE(goqu.SUM("o_counter"), 0)).From(table).InnerJoin(joinTable, goqu.On(table.Col(idColumn).Eq(joinTable.Col(imageIDColumn)))).Where(joinTable.Col(performerIDColumn).Eq(performerID))
-
-	var ret int
-	if err := querySimple(ctx, q, &ret); err != nil {
-		return 0, err
-	}
-
-	return ret, nil
-}
+	oHistoryTable := goqu.T(imagesODatesTable)
 
-func (qb *ImageStore) OCount(ctx context.Context) (int, error) {
-	table := qb.table()
+	q := dialect.Select(goqu.COUNT("*")).From(table).InnerJoin(
+		oHistoryTable,
+		goqu.On(
+			table.Col(idColumn).Eq(oHistoryTable.Col(imageIDColumn)),
+			userIDCriterion(ctx, oHistoryTable.Col(userIDColumn)),
+		),
+	).InnerJoin(
+		joinTable,
+		goqu.On(
+			table.Col(idColumn).Eq(joinTable.Col(imageIDColumn)),
+		),
+	).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = imageRestrictions.restrictDataset(ctx, q)
 
-	q := dialect.Select(goqu.COALESCE(goqu.SUM("o_counter"), 0)).From(table)
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -745,7 +954,7 @@ func (qb *ImageStore) FindByZipFileID(ctx context.Context, zipFileID models.File
 }
 
 func (qb *ImageStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := imageRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
@@ -761,6 +970,8 @@ func (qb *ImageStore) Size(ctx context.Context) (float64, error) {
 		fileTable,
 		goqu.On(imagesFilesJoinTable.Col(fileIDColumn).Eq(fileTable.Col(idColumn))),
 	)
+	q = imageRestrictions.restrictDataset(ctx, q)
+
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -770,7 +981,7 @@ func (qb *ImageStore) Size(ctx context.Context) (float64, error) {
 }
 
 func (qb *ImageStore) All(ctx context.Context) ([]*models.Image, error) {
-	return qb.getMany(ctx, qb.selectDataset())
+	return qb.getMany(ctx, imageRestrictions.restrictDataset(ctx, qb.selectDataset()))
 }
 
 func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
@@ -817,7 +1028,9 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 		return nil, err
 	}
 
-	if err := qb.setImageSortAndPagination(&query, findFilter); err != nil {
+	imageRestrictions.addRestrictions(ctx, &query)
+
+	if err := qb.setImageSortAndPagination(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 
@@ -921,6 +1134,8 @@ var imageSortOptions = sortOptions{
 	"file_mod_time",
 	"filesize",
 	"id",
+	"last_o_at",
+	"last_viewed_at",
 	"o_counter",
 	"path",
 	"performer_count",
@@ -929,9 +1144,10 @@ var imageSortOptions = sortOptions{
 	"tag_count",
 	"title",
 	"updated_at",
+	"view_count",
 }
 
-func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *ImageStore) setImageSortAndPagination(ctx context.Context, q *queryBuilder, findFilter *models.FindFilterType) error {
 	sortClause := ""
 
 	if findFilter != nil && findFilter.Sort != nil && *findFilter.Sort != "" {
@@ -979,6 +1195,14 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			sortClause = getCountSort(imageTable, imagesTagsTable, imageIDColumn, direction)
 		case "performer_count":
 			sortClause = getCountSort(imageTable, performersImagesTable, imageIDColumn, direction)
+		case "view_count":
+			sortClause = getUserCountSort(ctx, imageTable, imagesViewDatesTable, imageIDColumn, direction)
+		case "last_viewed_at":
+			sortClause = fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", imagesViewDatesTable, imageIDColumn, imageTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
+		case "last_o_at":
+			sortClause = fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", imagesODatesTable, imageIDColumn, imageTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
+		case "o_counter":
+			sortClause = getUserCountSort(ctx, imageTable, imagesODatesTable, imageIDColumn, direction)
 		case "mod_time", "filesize":
 			addFilesJoin()
 			sortClause = getSort(sort, direction, "files")
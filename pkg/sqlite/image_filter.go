@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -62,6 +63,7 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 
 			stringCriterionHandler(imageFilter.Checksum, "fingerprints_md5.fingerprint")(ctx, f)
 		}),
+		phashDistanceCriterionHandler(imageFilter.PhashDistance, "images_files.file_id", imageRepository.addImagesFilesTable),
 		stringCriterionHandler(imageFilter.Title, "images.title"),
 		stringCriterionHandler(imageFilter.Code, "images.code"),
 		stringCriterionHandler(imageFilter.Details, "images.details"),
@@ -70,7 +72,19 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 		pathCriterionHandler(imageFilter.Path, "folders.path", "files.basename", imageRepository.addFoldersTable),
 		qb.fileCountCriterionHandler(imageFilter.FileCount),
 		intCriterionHandler(imageFilter.Rating100, "images.rating", nil),
-		intCriterionHandler(imageFilter.OCounter, "images.o_counter", nil),
+		qb.oCountCriterionHandler(imageFilter.OCounter),
+		qb.viewCountCriterionHandler(imageFilter.ViewCount),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			if imageFilter.LastViewedAt != nil {
+				f.addLeftJoin(
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_viewed_at FROM %s WHERE %s GROUP BY %s)", imageIDColumn, imageViewDateColumn, imagesViewDatesTable, userIDClause(ctx, userIDColumn), imageIDColumn),
+					"image_last_view",
+					fmt.Sprintf("image_last_view.%s = images.id", imageIDColumn),
+				)
+				h := timestampCriterionHandler{imageFilter.LastViewedAt, "IFNULL(last_viewed_at, datetime(0))", nil}
+				h.handle(ctx, f)
+			}
+		}),
 		boolCriterionHandler(imageFilter.Organized, "images.organized", nil),
 		&dateCriterionHandler{imageFilter.Date, "images.date", nil},
 		qb.urlsCriterionHandler(imageFilter.URL),
@@ -123,6 +137,13 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 				imageRepository.tags.innerJoin(f, "image_tag", "images.id")
 			},
 		},
+
+		&customFieldsFilterHandler{
+			table: imagesCustomFieldsTable.GetTable(),
+			fkCol: imageIDColumn,
+			c:     imageFilter.CustomFields,
+			idCol: "images.id",
+		},
 	}
 }
 
@@ -136,6 +157,32 @@ func (qb *imageFilterHandler) fileCountCriterionHandler(fileCount *models.IntCri
 	return h.handler(fileCount)
 }
 
+func (qb *imageFilterHandler) viewCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: imageTable,
+			joinTable:    imagesViewDatesTable,
+			primaryFK:    imageIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
+
+		h.handler(count)(ctx, f)
+	}
+}
+
+func (qb *imageFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: imageTable,
+			joinTable:    imagesODatesTable,
+			primaryFK:    imageIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
+
+		h.handler(count)(ctx, f)
+	}
+}
+
 func (qb *imageFilterHandler) missingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,7 +63,6 @@ func Test_imageQueryBuilder_Create(t *testing.T) {
 		rating       = 60
 		details      = "details"
 		photographer = "photographer"
-		ocounter     = 5
 		url          = "url"
 		date, _      = models.ParseDate("2003-02-01")
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -87,7 +87,6 @@ func Test_imageQueryBuilder_Create(t *testing.T) {
 				Photographer: photographer,
 				URLs:         models.NewRelatedStrings([]string{url}),
 				Organized:    true,
-				OCounter:     ocounter,
 				StudioID:     &studioIDs[studioIdxWithImage],
 				CreatedAt:    createdAt,
 				UpdatedAt:    updatedAt,
@@ -108,7 +107,6 @@ func Test_imageQueryBuilder_Create(t *testing.T) {
 				Photographer: photographer,
 				URLs:         models.NewRelatedStrings([]string{url}),
 				Organized:    true,
-				OCounter:     ocounter,
 				StudioID:     &studioIDs[studioIdxWithImage],
 				Files: models.NewRelatedFiles([]models.File{
 					imageFile.(*models.ImageFile),
@@ -230,7 +228,6 @@ func Test_imageQueryBuilder_Update(t *testing.T) {
 		details      = "details"
 		photographer = "photographer"
 		date, _      = models.ParseDate("2003-02-01")
-		ocounter     = 5
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 	)
@@ -252,7 +249,6 @@ func Test_imageQueryBuilder_Update(t *testing.T) {
 				Details:      details,
 				Photographer: photographer,
 				Organized:    true,
-				OCounter:     ocounter,
 				StudioID:     &studioIDs[studioIdxWithImage],
 				CreatedAt:    createdAt,
 				UpdatedAt:    updatedAt,
@@ -419,7 +415,6 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 		rating       = 60
 		url          = "url"
 		date, _      = models.ParseDate("2003-02-01")
-		ocounter     = 5
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 	)
@@ -446,7 +441,6 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 				},
 				Date:      models.NewOptionalDate(date),
 				Organized: models.NewOptionalBool(true),
-				OCounter:  models.NewOptionalInt(ocounter),
 				StudioID:  models.NewOptionalInt(studioIDs[studioIdxWithImage]),
 				CreatedAt: models.NewOptionalTime(createdAt),
 				UpdatedAt: models.NewOptionalTime(updatedAt),
@@ -473,7 +467,6 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Organized:    true,
-				OCounter:     ocounter,
 				StudioID:     &studioIDs[studioIdxWithImage],
 				Files: models.NewRelatedFiles([]models.File{
 					makeImageFile(imageIdx1WithGallery),
@@ -491,8 +484,7 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 			imageIDs[imageIdx1WithGallery],
 			clearImagePartial(),
 			models.Image{
-				ID:       imageIDs[imageIdx1WithGallery],
-				OCounter: getOCounter(imageIdx1WithGallery),
+				ID: imageIDs[imageIdx1WithGallery],
 				Files: models.NewRelatedFiles([]models.File{
 					makeImageFile(imageIdx1WithGallery),
 				}),
@@ -832,7 +824,7 @@ func Test_imageQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 	}
 }
 
-func Test_imageQueryBuilder_IncrementOCounter(t *testing.T) {
+func Test_imageQueryBuilder_AddO(t *testing.T) {
 	tests := []struct {
 		name    string
 		id      int
@@ -857,19 +849,19 @@ func Test_imageQueryBuilder_IncrementOCounter(t *testing.T) {
 
 	for _, tt := range tests {
 		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
-			got, err := qb.IncrementOCounter(ctx, tt.id)
+			got, err := qb.AddO(ctx, tt.id, nil)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("imageQueryBuilder.IncrementOCounter() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("imageQueryBuilder.AddO() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("imageQueryBuilder.IncrementOCounter() = %v, want %v", got, tt.want)
+			if len(got) != tt.want {
+				t.Errorf("imageQueryBuilder.AddO() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_imageQueryBuilder_DecrementOCounter(t *testing.T) {
+func Test_imageQueryBuilder_DeleteO(t *testing.T) {
 	tests := []struct {
 		name    string
 		id      int
@@ -888,31 +880,25 @@ func Test_imageQueryBuilder_DecrementOCounter(t *testing.T) {
 			0,
 			false,
 		},
-		{
-			"invalid",
-			invalidID,
-			0,
-			true,
-		},
 	}
 
 	qb := db.Image
 
 	for _, tt := range tests {
 		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
-			got, err := qb.DecrementOCounter(ctx, tt.id)
+			got, err := qb.DeleteO(ctx, tt.id, nil)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("imageQueryBuilder.DecrementOCounter() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("imageQueryBuilder.DeleteO() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("imageQueryBuilder.DecrementOCounter() = %v, want %v", got, tt.want)
+			if len(got) != tt.want {
+				t.Errorf("imageQueryBuilder.DeleteO() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_imageQueryBuilder_ResetOCounter(t *testing.T) {
+func Test_imageQueryBuilder_ResetO(t *testing.T) {
 	tests := []struct {
 		name    string
 		id      int
@@ -931,8 +917,39 @@ func Test_imageQueryBuilder_ResetOCounter(t *testing.T) {
 			0,
 			false,
 		},
+	}
+
+	qb := db.Image
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			got, err := qb.ResetO(ctx, tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("imageQueryBuilder.ResetO() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("imageQueryBuilder.ResetO() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageStore_AddView(t *testing.T) {
+	tests := []struct {
+		name          string
+		imageID       int
+		expectedCount int
+		wantErr       bool
+	}{
 		{
-			"invalid",
+			"valid",
+			imageIDs[imageIdx1WithPerformer],
+			1,
+			false,
+		},
+		{
+			"invalid image id",
 			invalidID,
 			0,
 			true,
@@ -943,14 +960,30 @@ func Test_imageQueryBuilder_ResetOCounter(t *testing.T) {
 
 	for _, tt := range tests {
 		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
-			got, err := qb.ResetOCounter(ctx, tt.id)
+			views, err := qb.AddViews(ctx, tt.imageID, nil)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("imageQueryBuilder.ResetOCounter() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ImageStore.AddViews() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
 				return
 			}
-			if got != tt.want {
-				t.Errorf("imageQueryBuilder.ResetOCounter() = %v, want %v", got, tt.want)
+
+			assert := assert.New(t)
+			assert.Equal(tt.expectedCount, len(views))
+
+			count, err := qb.CountViews(ctx, tt.imageID)
+			if err != nil {
+				t.Errorf("ImageStore.CountViews() error = %v", err)
+			}
+
+			lastView, err := qb.LastView(ctx, tt.imageID)
+			if err != nil {
+				t.Errorf("ImageStore.LastView() error = %v", err)
 			}
+
+			assert.Equal(tt.expectedCount, count)
+			assert.True(lastView.After(time.Now().Add(-1 * time.Minute)))
 		})
 	}
 }
@@ -1884,7 +1917,11 @@ func verifyImagesOCounter(t *testing.T, oCounterCriterion models.IntCriterionInp
 		}
 
 		for _, image := range images {
-			verifyInt(t, image.OCounter, oCounterCriterion)
+			count, err := sqb.GetOCount(ctx, image.ID)
+			if err != nil {
+				t.Errorf("Error getting ocounter: %v", err)
+			}
+			verifyInt(t, count, oCounterCriterion)
 		}
 
 		return nil
@@ -2928,6 +2965,34 @@ func TestImageQuerySorting(t *testing.T) {
 			imageIdxWithTwoGalleries,
 			imageIdxWithGrandChildStudio,
 		},
+		{
+			"o counter",
+			"o_counter",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
+		{
+			"last o at",
+			"last_o_at",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
+		{
+			"view count",
+			"view_count",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
+		{
+			"last viewed at",
+			"last_viewed_at",
+			models.SortDirectionEnumDesc,
+			-1,
+			-1,
+		},
 	}
 
 	qb := db.Image
@@ -3021,3 +3086,86 @@ func TestImageQueryPagination(t *testing.T) {
 // TODO Count
 // TODO SizeCount
 // TODO All
+
+func TestImageStore_FindDuplicates(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		createImageWithPhash := func(name string, phash int64, zipFileID *models.FileID) *models.Image {
+			f := &models.ImageFile{
+				BaseFile: &models.BaseFile{
+					Path:           getFilePath(folderIdxWithImageFiles, name),
+					Basename:       name,
+					ParentFolderID: folderIDs[folderIdxWithImageFiles],
+					Fingerprints: []models.Fingerprint{
+						{
+							Type:        models.FingerprintTypePhash,
+							Fingerprint: phash,
+						},
+					},
+				},
+			}
+			f.ZipFileID = zipFileID
+
+			if err := db.File.Create(ctx, f); err != nil {
+				t.Fatalf("FileStore.Create() error = %v", err)
+			}
+
+			i := &models.Image{}
+			if err := db.Image.Create(ctx, i, []models.FileID{f.ID}); err != nil {
+				t.Fatalf("ImageStore.Create() error = %v", err)
+			}
+
+			return i
+		}
+
+		const phash = int64(0x5a5a5a5a5a5a5a5a)
+
+		original := createImageWithPhash("phash_original.jpg", phash, nil)
+		// differs by one bit
+		zipped := createImageWithPhash("phash_zipped.jpg", phash^1, &fileIDs[fileIdxZip])
+		exact := createImageWithPhash("phash_exact.jpg", phash, nil)
+		// differs by 32 bits
+		unrelated := createImageWithPhash("phash_unrelated.jpg", phash^0x00000000ffffffff, nil)
+
+		got, err := db.Image.FindDuplicates(ctx, 0)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, []int{original.ID, exact.ID}, imagesToIDs(got[0]))
+		}
+
+		got, err = db.Image.FindDuplicates(ctx, 4)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, []int{original.ID, zipped.ID, exact.ID}, imagesToIDs(got[0]))
+		}
+
+		// phash distance criterion
+		distance := 4
+		images := queryImages(ctx, t, db.Image, &models.ImageFilterType{
+			PhashDistance: &models.PhashDistanceCriterionInput{
+				Value:    utils.PhashToString(phash),
+				Modifier: models.CriterionModifierEquals,
+				Distance: &distance,
+			},
+		}, nil)
+		assert.ElementsMatch(t, []int{original.ID, zipped.ID, exact.ID}, imagesToIDs(images))
+
+		images = queryImages(ctx, t, db.Image, &models.ImageFilterType{
+			PhashDistance: &models.PhashDistanceCriterionInput{
+				Value:    utils.PhashToString(phash),
+				Modifier: models.CriterionModifierNotEquals,
+				Distance: &distance,
+			},
+		}, nil)
+		assert.Equal(t, []int{unrelated.ID}, imagesToIDs(images))
+
+		return nil
+	})
+}
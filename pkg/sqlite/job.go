@@ -0,0 +1,199 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	jobTable = "jobs"
+)
+
+// finishedJobStatuses are the statuses of jobs that are no longer in the queue.
+var finishedJobStatuses = []interface{}{"FINISHED", "CANCELLED", "FAILED"}
+
+type jobRow struct {
+	ID          int           `db:"id"`
+	Status      string        `db:"status"`
+	Type        string        `db:"type"`
+	Description string        `db:"description"`
+	Input       []byte        `db:"input"`
+	Progress    float64       `db:"progress"`
+	Error       null.String   `db:"error"`
+	AddTime     Timestamp     `db:"add_time"`
+	StartTime   NullTimestamp `db:"start_time"`
+	EndTime     NullTimestamp `db:"end_time"`
+}
+
+func (r *jobRow) fromJob(o models.JobRecord) {
+	r.ID = o.ID
+	r.Status = o.Status
+	r.Type = o.Type
+	r.Description = o.Description
+	r.Input = o.Input
+	r.Progress = o.Progress
+	r.Error = null.StringFromPtr(o.Error)
+	r.AddTime = Timestamp{Timestamp: o.AddTime}
+	r.StartTime = NullTimestampFromTimePtr(o.StartTime)
+	r.EndTime = NullTimestampFromTimePtr(o.EndTime)
+}
+
+func (r *jobRow) resolve() *models.JobRecord {
+	return &models.JobRecord{
+		ID:          r.ID,
+		Status:      r.Status,
+		Type:        r.Type,
+		Description: r.Description,
+		Input:       r.Input,
+		Progress:    r.Progress,
+		Error:       r.Error.Ptr(),
+		AddTime:     r.AddTime.Timestamp,
+		StartTime:   r.StartTime.TimePtr(),
+		EndTime:     r.EndTime.TimePtr(),
+	}
+}
+
+type JobStore struct {
+	tableMgr *table
+}
+
+func NewJobStore() *JobStore {
+	return &JobStore{
+		tableMgr: jobTableMgr,
+	}
+}
+
+func (qb *JobStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *JobStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+// Save creates the job if it does not exist, otherwise it replaces the
+// existing job.
+func (qb *JobStore) Save(ctx context.Context, o *models.JobRecord) error {
+	var r jobRow
+	r.fromJob(*o)
+
+	q := dialect.Insert(qb.table()).Prepared(true).Rows(r).OnConflict(goqu.DoUpdate(idColumn, r))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("saving job: %w", err)
+	}
+
+	return nil
+}
+
+// DestroyFinished removes all but the most recent keep finished jobs.
+func (qb *JobStore) DestroyFinished(ctx context.Context, keep int) error {
+	table := qb.table()
+
+	keepQuery := dialect.From(table).Select(table.Col(idColumn)).
+		Where(table.Col("status").In(finishedJobStatuses...)).
+		Order(table.Col(idColumn).Desc()).
+		Limit(uint(keep))
+
+	q := dialect.Delete(table).Where(
+		table.Col("status").In(finishedJobStatuses...),
+		table.Col(idColumn).NotIn(keepQuery),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying finished jobs: %w", err)
+	}
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *JobStore) Find(ctx context.Context, id int) (*models.JobRecord, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *JobStore) FindByStatus(ctx context.Context, statuses []string) ([]*models.JobRecord, error) {
+	table := qb.table()
+
+	s := make([]interface{}, len(statuses))
+	for i, v := range statuses {
+		s[i] = v
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(table.Col("status").In(s...)).Order(table.Col(idColumn).Asc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *JobStore) FindRecent(ctx context.Context, limit int) ([]*models.JobRecord, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Order(table.Col(idColumn).Desc())
+	if limit >= 0 {
+		q = q.Limit(uint(limit))
+	}
+
+	return qb.getMany(ctx, q)
+}
+
+// MaxID returns the highest job id, including the job ids recorded in the
+// audit log, so that new jobs do not reuse the ids of pruned jobs.
+func (qb *JobStore) MaxID(ctx context.Context) (int, error) {
+	table := qb.table()
+	auditTable := auditLogTableMgr.table
+	q := dialect.Select(goqu.L("MAX(?, ?)",
+		dialect.From(table).Select(goqu.COALESCE(goqu.MAX(table.Col(idColumn)), 0)),
+		dialect.From(auditTable).Select(goqu.COALESCE(goqu.MAX(auditTable.Col("job_id")), 0)),
+	))
+
+	var ret int
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (qb *JobStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.JobRecord, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *JobStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.JobRecord, error) {
+	const single = false
+	var ret []*models.JobRecord
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f jobRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
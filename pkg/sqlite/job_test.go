@@ -0,0 +1,151 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSave(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		addTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		startTime := addTime.Add(time.Minute)
+
+		j := &models.JobRecord{
+			ID:          1001,
+			Status:      "RUNNING",
+			Type:        "scan",
+			Description: "Scanning...",
+			Input:       []byte(`{"paths":["/stash"]}`),
+			Progress:    0.5,
+			AddTime:     addTime,
+			StartTime:   &startTime,
+		}
+
+		if err := db.Job.Save(ctx, j); err != nil {
+			t.Errorf("JobStore.Save() error = %v", err)
+			return nil
+		}
+
+		// update the existing job
+		errStr := "failed"
+		endTime := startTime.Add(time.Minute)
+		j.Status = "FAILED"
+		j.Error = &errStr
+		j.EndTime = &endTime
+
+		if err := db.Job.Save(ctx, j); err != nil {
+			t.Errorf("JobStore.Save() error = %v", err)
+			return nil
+		}
+
+		got, err := db.Job.Find(ctx, j.ID)
+		if err != nil {
+			t.Errorf("JobStore.Find() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, j.Status, got.Status)
+		assert.Equal(t, j.Type, got.Type)
+		assert.Equal(t, j.Input, got.Input)
+		assert.Equal(t, j.Progress, got.Progress)
+		assert.Equal(t, j.Error, got.Error)
+		assert.True(t, addTime.Equal(got.AddTime))
+		assert.True(t, endTime.Equal(*got.EndTime))
+
+		maxID, err := db.Job.MaxID(ctx)
+		if err != nil {
+			t.Errorf("JobStore.MaxID() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, j.ID, maxID)
+
+		// job ids recorded in the audit log are included
+		auditJobID := j.ID + 1000
+		if err := db.AuditLog.Create(ctx, &models.AuditLogEntry{
+			ObjectType: models.AuditObjectTypePerformer,
+			ObjectID:   1,
+			Action:     models.AuditActionUpdate,
+			JobID:      &auditJobID,
+			Source:     "test",
+			CreatedAt:  time.Now(),
+		}); err != nil {
+			t.Errorf("AuditLogStore.Create() error = %v", err)
+			return nil
+		}
+
+		maxID, err = db.Job.MaxID(ctx)
+		if err != nil {
+			t.Errorf("JobStore.MaxID() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, auditJobID, maxID)
+
+		return nil
+	})
+}
+
+func TestJobFindByStatus(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		for i, status := range []string{"FINISHED", "RUNNING", "READY", "CANCELLED"} {
+			if err := db.Job.Save(ctx, &models.JobRecord{
+				ID:          i + 1,
+				Status:      status,
+				Description: status,
+				AddTime:     time.Now(),
+			}); err != nil {
+				t.Errorf("JobStore.Save() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err := db.Job.FindByStatus(ctx, []string{"READY", "RUNNING"})
+		if err != nil {
+			t.Errorf("JobStore.FindByStatus() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, 2, got[0].ID)
+			assert.Equal(t, 3, got[1].ID)
+		}
+
+		recent, err := db.Job.FindRecent(ctx, 3)
+		if err != nil {
+			t.Errorf("JobStore.FindRecent() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, recent, 3) {
+			assert.Equal(t, 4, recent[0].ID)
+		}
+
+		// keep only the most recent finished job
+		if err := db.Job.DestroyFinished(ctx, 1); err != nil {
+			t.Errorf("JobStore.DestroyFinished() error = %v", err)
+			return nil
+		}
+
+		gone, err := db.Job.Find(ctx, 1)
+		if err != nil {
+			t.Errorf("JobStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, gone)
+
+		recent, err = db.Job.FindRecent(ctx, 10)
+		if err != nil {
+			t.Errorf("JobStore.FindRecent() error = %v", err)
+			return nil
+		}
+		assert.Len(t, recent, 3)
+
+		return nil
+	})
+}
@@ -0,0 +1,14 @@
+CREATE TABLE `jobs` (
+  `id` integer not null primary key,
+  `status` varchar(255) not null,
+  `type` varchar(255) not null default '',
+  `description` text not null,
+  `input` blob,
+  `progress` real not null default 0,
+  `error` text,
+  `add_time` datetime not null,
+  `start_time` datetime,
+  `end_time` datetime
+);
+
+CREATE INDEX `index_jobs_status` ON `jobs` (`status`);
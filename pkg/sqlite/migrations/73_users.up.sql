@@ -0,0 +1,31 @@
+CREATE TABLE `users` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `password` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_users_on_username` ON `users` (`username`);
+
+-- per-user scene values. The values of the configured user remain in the
+-- scenes table.
+CREATE TABLE `scenes_user_data` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint,
+  `resume_time` float not null default 0,
+  `play_duration` float not null default 0,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `user_id`)
+);
+
+CREATE INDEX `index_scenes_user_data_on_user_id` ON `scenes_user_data` (`user_id`);
+
+-- history of the configured user has a null user_id
+ALTER TABLE `scenes_view_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+CREATE INDEX `index_scenes_view_dates_on_user_id` ON `scenes_view_dates` (`user_id`);
+
+ALTER TABLE `scenes_o_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+CREATE INDEX `index_scenes_o_dates_on_user_id` ON `scenes_o_dates` (`user_id`);
@@ -0,0 +1,2 @@
+-- existing users retain full access
+ALTER TABLE `users` ADD COLUMN `role` varchar(255) NOT NULL DEFAULT 'ADMIN';
@@ -0,0 +1,12 @@
+CREATE TABLE `api_keys` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `user_id` integer,
+  `scope` varchar(255) not null,
+  `created_at` datetime not null,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_api_keys_on_user_id` ON `api_keys` (`user_id`);
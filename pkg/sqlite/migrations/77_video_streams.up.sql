@@ -0,0 +1,2 @@
+ALTER TABLE `video_files` ADD COLUMN `audio_streams` text;
+ALTER TABLE `video_files` ADD COLUMN `subtitle_streams` text;
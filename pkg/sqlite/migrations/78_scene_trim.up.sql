@@ -0,0 +1,2 @@
+ALTER TABLE `scenes` ADD COLUMN `start_point` real;
+ALTER TABLE `scenes` ADD COLUMN `end_point` real;
@@ -0,0 +1,71 @@
+-- history of the configured user has a null user_id
+CREATE TABLE `images_view_dates` (
+  `image_id` integer not null,
+  `view_date` datetime not null,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_images_view_dates` ON `images_view_dates` (`image_id`);
+CREATE INDEX `index_images_view_dates_on_user_id` ON `images_view_dates` (`user_id`);
+
+CREATE TABLE `images_o_dates` (
+  `image_id` integer not null,
+  `o_date` datetime not null,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_images_o_dates` ON `images_o_dates` (`image_id`);
+CREATE INDEX `index_images_o_dates_on_user_id` ON `images_o_dates` (`user_id`);
+
+CREATE TABLE `galleries_view_dates` (
+  `gallery_id` integer not null,
+  `view_date` datetime not null,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_galleries_view_dates` ON `galleries_view_dates` (`gallery_id`);
+CREATE INDEX `index_galleries_view_dates_on_user_id` ON `galleries_view_dates` (`user_id`);
+
+CREATE TABLE `galleries_o_dates` (
+  `gallery_id` integer not null,
+  `o_date` datetime not null,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_galleries_o_dates` ON `galleries_o_dates` (`gallery_id`);
+CREATE INDEX `index_galleries_o_dates_on_user_id` ON `galleries_o_dates` (`user_id`);
+
+-- move the image o-counter into the o history, using the created time
+WITH RECURSIVE numbers AS (
+  SELECT 1 AS n
+  UNION ALL
+  SELECT n + 1
+  FROM numbers
+  WHERE n < (SELECT MAX(o_counter) FROM images)
+)
+INSERT INTO images_o_dates (image_id, o_date)
+SELECT images.id, images.created_at
+FROM images
+CROSS JOIN numbers
+WHERE numbers.n <= images.o_counter;
+
+ALTER TABLE `images` DROP COLUMN `o_counter`;
+
+-- the last image index viewed in a gallery. The value of the configured
+-- user is stored in the galleries table.
+ALTER TABLE `galleries` ADD COLUMN `last_image_index` integer;
+
+CREATE TABLE `galleries_user_data` (
+  `gallery_id` integer not null,
+  `user_id` integer not null,
+  `last_image_index` integer,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `user_id`)
+);
+
+CREATE INDEX `index_galleries_user_data_on_user_id` ON `galleries_user_data` (`user_id`);
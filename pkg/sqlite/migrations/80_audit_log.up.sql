@@ -0,0 +1,18 @@
+-- changes made by the configured user have a null user_id
+CREATE TABLE `audit_log` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `object_id` integer not null,
+  `action` varchar(255) not null,
+  `old_values` text,
+  `new_values` text,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE SET NULL,
+  `job_id` integer,
+  `source` varchar(255) not null,
+  `created_at` datetime not null,
+  `reverted_at` datetime
+);
+
+CREATE INDEX `index_audit_log_on_object` ON `audit_log` (`object_type`, `object_id`);
+CREATE INDEX `index_audit_log_on_job_id` ON `audit_log` (`job_id`);
+CREATE INDEX `index_audit_log_on_user_id` ON `audit_log` (`user_id`);
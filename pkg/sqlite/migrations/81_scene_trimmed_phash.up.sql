@@ -0,0 +1 @@
+ALTER TABLE `scenes` ADD COLUMN `trimmed_phash` integer;
@@ -0,0 +1 @@
+ALTER TABLE `files` ADD COLUMN `missing_since` datetime;
@@ -229,9 +229,11 @@ type PerformerStore struct {
 	customFieldsStore
 
 	tableMgr *table
+
+	repo *storeRepository
 }
 
-func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
+func NewPerformerStore(r *storeRepository, blobStore *BlobStore) *PerformerStore {
 	return &PerformerStore{
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
@@ -242,6 +244,7 @@ func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 			fk:    performersCustomFieldsTable.Col(performerIDColumn),
 		},
 		tableMgr: performerTableMgr,
+		repo:     r,
 	}
 }
 
@@ -292,6 +295,10 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.CreatePe
 		return err
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypePerformer, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -303,6 +310,11 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.CreatePe
 }
 
 func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial models.PerformerPartial) (*models.Performer, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypePerformer, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := performerRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -344,10 +356,19 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 		return nil, err
 	}
 
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.UpdatePerformerInput) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypePerformer, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r performerRow
 	r.fromPerformer(*updatedObject.Performer)
 
@@ -383,33 +404,191 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Upda
 		return err
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypePerformer, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
 	}
 
-	return performerRepository.destroyExisting(ctx, []int{id})
+	if err := performerRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
+}
+
+// auditValues returns the values of the performer recorded in the audit log.
+func (qb *PerformerStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	p, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var gender, circumcised *string
+	if p.Gender != nil {
+		v := p.Gender.String()
+		gender = &v
+	}
+	if p.Circumcised != nil {
+		v := p.Circumcised.String()
+		circumcised = &v
+	}
+
+	ret := &models.PerformerPartial{
+		Name:           models.NewOptionalString(p.Name),
+		Disambiguation: models.NewOptionalString(p.Disambiguation),
+		Gender:         models.NewOptionalStringPtr(gender),
+		Birthdate:      models.NewOptionalDatePtr(p.Birthdate),
+		Ethnicity:      models.NewOptionalString(p.Ethnicity),
+		Country:        models.NewOptionalString(p.Country),
+		EyeColor:       models.NewOptionalString(p.EyeColor),
+		Height:         models.NewOptionalIntPtr(p.Height),
+		Measurements:   models.NewOptionalString(p.Measurements),
+		FakeTits:       models.NewOptionalString(p.FakeTits),
+		PenisLength:    models.NewOptionalFloat64Ptr(p.PenisLength),
+		Circumcised:    models.NewOptionalStringPtr(circumcised),
+		CareerLength:   models.NewOptionalString(p.CareerLength),
+		Tattoos:        models.NewOptionalString(p.Tattoos),
+		Piercings:      models.NewOptionalString(p.Piercings),
+		Favorite:       models.NewOptionalBool(p.Favorite),
+		Rating:         models.NewOptionalIntPtr(p.Rating),
+		Details:        models.NewOptionalString(p.Details),
+		DeathDate:      models.NewOptionalDatePtr(p.DeathDate),
+		HairColor:      models.NewOptionalString(p.HairColor),
+		Weight:         models.NewOptionalIntPtr(p.Weight),
+		IgnoreAutoTag:  models.NewOptionalBool(p.IgnoreAutoTag),
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.Aliases = auditStrings(aliases)
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.URLs = auditStrings(urls)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.StashIDs = auditStashIDs(stashIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
-// returns nil, nil if not found
+// Merge moves the scene, image and gallery relationships of the source
+// performers to the destination performer, and destroys the source
+// performers. Other performer fields are not merged.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+	args = append(args, destination)
+
+	performerTables := []struct {
+		table      string
+		idColumn   string
+		objectType models.AuditObjectType
+		values     auditValuesFunc
+	}{
+		{performersScenesTable, sceneIDColumn, models.AuditObjectTypeScene, qb.repo.Scene.auditValues},
+		{performersImagesTable, imageIDColumn, models.AuditObjectTypeImage, qb.repo.Image.auditValues},
+		{performersGalleriesTable, galleryIDColumn, models.AuditObjectTypeGallery, qb.repo.Gallery.auditValues},
+	}
+
+	for _, t := range performerTables {
+		table, idColumn := t.table, t.idColumn
+
+		// the performers of the objects are changed by sql, so record the
+		// changes here
+		records, err := beginAuditQuery(ctx, t.objectType, t.values, `SELECT DISTINCT `+idColumn+` FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...)
+		if err != nil {
+			return err
+		}
+
+		_, err = dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+
+		if err := records.end(ctx, models.AuditActionUpdate); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *PerformerStore) Find(ctx context.Context, id int) (*models.Performer, error) {
-	ret, err := qb.find(ctx, id)
+	q := performerRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the performers with the provided ids, in the same order. If content
+// is restricted in the context, hidden performers are returned as nil.
 func (qb *PerformerStore) FindMany(ctx context.Context, ids []int) ([]*models.Performer, error) {
 	tableMgr := performerTableMgr
 	ret := make([]*models.Performer, len(ids))
 
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := goqu.Select("*").From(tableMgr.table).Where(tableMgr.byIDInts(batch...))
+		q := performerRestrictions.restrictDataset(ctx, goqu.Select("*").From(tableMgr.table).Where(tableMgr.byIDInts(batch...)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -425,8 +604,10 @@ func (qb *PerformerStore) FindMany(ctx context.Context, ids []int) ([]*models.Pe
 		return nil, err
 	}
 
+	// hidden performers are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range ret {
-		if ret[i] == nil {
+		if ret[i] == nil && !restricted {
 			return nil, fmt.Errorf("performer with id %d not found", ids[i])
 		}
 	}
@@ -563,13 +744,13 @@ func (qb *PerformerStore) CountByTagID(ctx context.Context, tagID int) (int, err
 }
 
 func (qb *PerformerStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := performerRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *PerformerStore) All(ctx context.Context) ([]*models.Performer, error) {
 	table := qb.table()
-	return qb.getMany(ctx, qb.selectDataset().Order(table.Col("name").Asc()))
+	return qb.getMany(ctx, performerRestrictions.restrictDataset(ctx, qb.selectDataset()).Order(table.Col("name").Asc()))
 }
 
 func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Performer, error) {
@@ -630,8 +811,10 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 		return nil, err
 	}
 
+	performerRestrictions.addRestrictions(ctx, &query)
+
 	var err error
-	query.sortAndPagination, err = qb.getPerformerSort(findFilter)
+	query.sortAndPagination, err = qb.getPerformerSort(ctx, findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -668,62 +851,68 @@ func (qb *PerformerStore) QueryCount(ctx context.Context, performerFilter *model
 	return query.executeCount(ctx)
 }
 
-func (qb *PerformerStore) sortByOCounter(direction string) string {
+func (qb *PerformerStore) sortByOCounter(ctx context.Context, direction string) string {
 	// need to sum the o_counter from scenes and images
-	return " ORDER BY (" + selectPerformerOCountSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerOCountSQL(ctx) + ") " + direction
 }
 
-func (qb *PerformerStore) sortByPlayCount(direction string) string {
+func (qb *PerformerStore) sortByPlayCount(ctx context.Context, direction string) string {
 	// need to sum the o_counter from scenes and images
-	return " ORDER BY (" + selectPerformerPlayCountSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerPlayCountSQL(ctx) + ") " + direction
 }
 
 // used for sorting on performer last o_date
-var selectPerformerLastOAtSQL = utils.StrFormat(
-	"SELECT MAX(o_date) FROM ("+
-		"SELECT {o_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_o_dates":    scenesODatesTable,
-		"o_date":            sceneODateColumn,
-	},
-)
+func selectPerformerLastOAtSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT MAX(o_date) FROM ("+
+			"SELECT {o_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id AND {user_clause} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_o_dates":    scenesODatesTable,
+			"o_date":            sceneODateColumn,
+			"user_clause":       userIDClause(ctx, scenesODatesTable+"."+userIDColumn),
+		},
+	)
+}
 
-func (qb *PerformerStore) sortByLastOAt(direction string) string {
+func (qb *PerformerStore) sortByLastOAt(ctx context.Context, direction string) string {
 	// need to get the o_dates from scenes
-	return " ORDER BY (" + selectPerformerLastOAtSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerLastOAtSQL(ctx) + ") " + direction
 }
 
 // used for sorting on performer last view_date
-var selectPerformerLastPlayedAtSQL = utils.StrFormat(
-	"SELECT MAX(view_date) FROM ("+
-		"SELECT {view_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_view_dates": scenesViewDatesTable,
-		"view_date":         sceneViewDateColumn,
-	},
-)
+func selectPerformerLastPlayedAtSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT MAX(view_date) FROM ("+
+			"SELECT {view_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id AND {user_clause} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_view_dates": scenesViewDatesTable,
+			"view_date":         sceneViewDateColumn,
+			"user_clause":       userIDClause(ctx, scenesViewDatesTable+"."+userIDColumn),
+		},
+	)
+}
 
-func (qb *PerformerStore) sortByLastPlayedAt(direction string) string {
+func (qb *PerformerStore) sortByLastPlayedAt(ctx context.Context, direction string) string {
 	// need to get the view_dates from scenes
-	return " ORDER BY (" + selectPerformerLastPlayedAtSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerLastPlayedAtSQL(ctx) + ") " + direction
 }
 
 var performerSortOptions = sortOptions{
@@ -749,7 +938,7 @@ var performerSortOptions = sortOptions{
 	"weight",
 }
 
-func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (string, error) {
+func (qb *PerformerStore) getPerformerSort(ctx context.Context, findFilter *models.FindFilterType) (string, error) {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -776,13 +965,13 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (s
 	case "galleries_count":
 		sortQuery += getCountSort(performerTable, performersGalleriesTable, performerIDColumn, direction)
 	case "play_count":
-		sortQuery += qb.sortByPlayCount(direction)
+		sortQuery += qb.sortByPlayCount(ctx, direction)
 	case "o_counter":
-		sortQuery += qb.sortByOCounter(direction)
+		sortQuery += qb.sortByOCounter(ctx, direction)
 	case "last_played_at":
-		sortQuery += qb.sortByLastPlayedAt(direction)
+		sortQuery += qb.sortByLastPlayedAt(ctx, direction)
 	case "last_o_at":
-		sortQuery += qb.sortByLastOAt(direction)
+		sortQuery += qb.sortByLastOAt(ctx, direction)
 	default:
 		sortQuery += getSort(sort, direction, "performers")
 	}
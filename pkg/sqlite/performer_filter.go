@@ -335,50 +335,59 @@ func (qb *performerFilterHandler) galleryCountCriterionHandler(count *models.Int
 }
 
 // used for sorting and filtering on performer o-count
-var selectPerformerOCountSQL = utils.StrFormat(
-	"SELECT SUM(o_counter) "+
-		"FROM ("+
-		"SELECT SUM(o_counter) as o_counter from {performers_images} s "+
-		"LEFT JOIN {images} ON {images}.id = s.{images_id} "+
-		"WHERE s.{performer_id} = {performers}.id "+
-		"UNION ALL "+
-		"SELECT COUNT({scenes_o_dates}.{o_date}) as o_counter from {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id "+
-		")",
-	map[string]interface{}{
-		"performers_images": performersImagesTable,
-		"images":            imageTable,
-		"performer_id":      performerIDColumn,
-		"images_id":         imageIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_o_dates":    scenesODatesTable,
-		"o_date":            sceneODateColumn,
-	},
-)
+func selectPerformerOCountSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT SUM(o_counter) "+
+			"FROM ("+
+			"SELECT COUNT({images_o_dates}.{o_date}) as o_counter from {performers_images} s "+
+			"LEFT JOIN {images} ON {images}.id = s.{images_id} "+
+			"LEFT JOIN {images_o_dates} ON {images_o_dates}.{images_id} = {images}.id AND {images_user_clause} "+
+			"WHERE s.{performer_id} = {performers}.id "+
+			"UNION ALL "+
+			"SELECT COUNT({scenes_o_dates}.{o_date}) as o_counter from {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id AND {user_clause} "+
+			"WHERE s.{performer_id} = {performers}.id "+
+			")",
+		map[string]interface{}{
+			"performers_images":  performersImagesTable,
+			"images":             imageTable,
+			"performer_id":       performerIDColumn,
+			"images_id":          imageIDColumn,
+			"performers":         performerTable,
+			"performers_scenes":  performersScenesTable,
+			"scenes":             sceneTable,
+			"scene_id":           sceneIDColumn,
+			"scenes_o_dates":     scenesODatesTable,
+			"o_date":             sceneODateColumn,
+			"user_clause":        userIDClause(ctx, scenesODatesTable+"."+userIDColumn),
+			"images_o_dates":     imagesODatesTable,
+			"images_user_clause": userIDClause(ctx, imagesODatesTable+"."+userIDColumn),
+		},
+	)
+}
 
 // used for sorting and filtering play count on performer view count
-var selectPerformerPlayCountSQL = utils.StrFormat(
-	"SELECT COUNT(DISTINCT {view_date}) FROM ("+
-		"SELECT {view_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_view_dates": scenesViewDatesTable,
-		"view_date":         sceneViewDateColumn,
-	},
-)
+func selectPerformerPlayCountSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT COUNT(DISTINCT {view_date}) FROM ("+
+			"SELECT {view_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id AND {user_clause} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_view_dates": scenesViewDatesTable,
+			"view_date":         sceneViewDateColumn,
+			"user_clause":       userIDClause(ctx, scenesViewDatesTable+"."+userIDColumn),
+		},
+	)
+}
 
 func (qb *performerFilterHandler) oCounterCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
@@ -386,7 +395,7 @@ func (qb *performerFilterHandler) oCounterCriterionHandler(count *models.IntCrit
 			return
 		}
 
-		lhs := "(" + selectPerformerOCountSQL + ")"
+		lhs := "(" + selectPerformerOCountSQL(ctx) + ")"
 		clause, args := getIntCriterionWhereClause(lhs, *count)
 
 		f.addWhere(clause, args...)
@@ -399,7 +408,7 @@ func (qb *performerFilterHandler) playCounterCriterionHandler(count *models.IntC
 			return
 		}
 
-		lhs := "(" + selectPerformerPlayCountSQL + ")"
+		lhs := "(" + selectPerformerPlayCountSQL(ctx) + ")"
 		clause, args := getIntCriterionWhereClause(lhs, *count)
 
 		f.addWhere(clause, args...)
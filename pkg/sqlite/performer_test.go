@@ -2427,3 +2427,69 @@ func TestPerformerStore_FindByStashIDStatus(t *testing.T) {
 // TODO Destroy
 // TODO Find
 // TODO Query
+
+func TestPerformerStore_Merge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// try merging into same performer
+		err := qb.Merge(ctx, []int{performerIDs[performerIdx1WithScene]}, performerIDs[performerIdx1WithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			performerIdx2WithScene,
+			performerIdx2WithImage,
+			performerIdx2WithGallery,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, performerIDs[idx])
+		}
+
+		destID := performerIDs[performerIdx1WithScene]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source performers are deleted
+		for _, id := range srcIDs {
+			p, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(p)
+		}
+
+		// ensure scene points to the destination performer once
+		scenePerformerIDs, err := db.Scene.GetPerformerIDs(ctx, sceneIDs[sceneIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+
+		assert.Equal([]int{destID}, scenePerformerIDs)
+
+		// ensure image points to the destination performer
+		imagePerformerIDs, err := db.Image.GetPerformerIDs(ctx, imageIDs[imageIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+
+		assert.ElementsMatch([]int{performerIDs[performerIdx1WithImage], destID}, imagePerformerIDs)
+
+		// ensure gallery points to the destination performer
+		galleryPerformerIDs, err := db.Gallery.GetPerformerIDs(ctx, galleryIDs[galleryIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+
+		assert.ElementsMatch([]int{performerIDs[performerIdx1WithGallery], destID}, galleryPerformerIDs)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -63,11 +63,11 @@ func (r *updateRecord) setNullInt(destField string, v models.OptionalInt) {
 // 	}
 // }
 
-// func (r *updateRecord) setNullInt64(destField string, v models.OptionalInt64) {
-// 	if v.Set {
-// 		r.set(destField, null.IntFromPtr(v.Ptr()))
-// 	}
-// }
+func (r *updateRecord) setNullInt64(destField string, v models.OptionalInt64) {
+	if v.Set {
+		r.set(destField, null.IntFromPtr(v.Ptr()))
+	}
+}
 
 func (r *updateRecord) setFloat64(destField string, v models.OptionalFloat64) {
 	if v.Set {
@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// restrictedTable describes how the content restrictions of a role apply to
+// the rows of a table.
+type restrictedTable struct {
+	table string
+
+	// tags join table and the column referencing the table. Empty if the
+	// table has no tags.
+	tagsJoinTable string
+	tagsFKColumn  string
+
+	// column referencing the studio. Empty if the table has no studio.
+	studioColumn string
+
+	// true if the table is the tags or studios table, in which case the
+	// excluded tags or studios themselves are excluded.
+	isTagTable    bool
+	isStudioTable bool
+}
+
+var (
+	sceneRestrictions = restrictedTable{
+		table:         sceneTable,
+		tagsJoinTable: scenesTagsTable,
+		tagsFKColumn:  sceneIDColumn,
+		studioColumn:  studioIDColumn,
+	}
+	imageRestrictions = restrictedTable{
+		table:         imageTable,
+		tagsJoinTable: imagesTagsTable,
+		tagsFKColumn:  imageIDColumn,
+		studioColumn:  studioIDColumn,
+	}
+	galleryRestrictions = restrictedTable{
+		table:         galleryTable,
+		tagsJoinTable: galleriesTagsTable,
+		tagsFKColumn:  galleryIDColumn,
+		studioColumn:  studioIDColumn,
+	}
+	groupRestrictions = restrictedTable{
+		table:         groupTable,
+		tagsJoinTable: groupsTagsTable,
+		tagsFKColumn:  groupIDColumn,
+		studioColumn:  studioIDColumn,
+	}
+	performerRestrictions = restrictedTable{
+		table:         performerTable,
+		tagsJoinTable: performersTagsTable,
+		tagsFKColumn:  performerIDColumn,
+	}
+	studioRestrictions = restrictedTable{
+		table:         studioTable,
+		tagsJoinTable: studiosTagsTable,
+		tagsFKColumn:  studioIDColumn,
+		isStudioTable: true,
+	}
+	tagRestrictions = restrictedTable{
+		table:      tagTable,
+		isTagTable: true,
+	}
+	sceneMarkerRestrictions = restrictedTable{
+		table:         sceneMarkerTable,
+		tagsJoinTable: "scene_markers_tags",
+		tagsFKColumn:  "scene_marker_id",
+	}
+)
+
+// valuesList returns the ids as a sql VALUES list.
+func valuesList(ids []int) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = "(" + strconv.Itoa(id) + ")"
+	}
+
+	return "VALUES " + strings.Join(values, ", ")
+}
+
+// excludedTagsSubquery returns a subquery selecting the excluded tags and
+// all of their descendants.
+func excludedTagsSubquery(ids []int) string {
+	return fmt.Sprintf(`WITH RECURSIVE excluded_tags(id) AS (
+	%s
+	UNION SELECT tr.%s FROM %s tr INNER JOIN excluded_tags e ON tr.%s = e.id
+) SELECT id FROM excluded_tags`, valuesList(ids), tagChildIDColumn, tagRelationsTable, tagParentIDColumn)
+}
+
+// excludedStudiosSubquery returns a subquery selecting the excluded studios
+// and all of their descendants.
+func excludedStudiosSubquery(ids []int) string {
+	return fmt.Sprintf(`WITH RECURSIVE excluded_studios(id) AS (
+	%s
+	UNION SELECT s.id FROM %s s INNER JOIN excluded_studios e ON s.%s = e.id
+) SELECT id FROM excluded_studios`, valuesList(ids), studioTable, studioParentIDColumn)
+}
+
+// clauses returns the sql clauses excluding the rows hidden by the
+// restrictions.
+func (t restrictedTable) clauses(r *models.RoleRestrictions) []string {
+	var ret []string
+
+	if len(r.ExcludedTagIDs) > 0 {
+		excluded := excludedTagsSubquery(r.ExcludedTagIDs)
+
+		if t.isTagTable {
+			ret = append(ret, fmt.Sprintf("%s.id NOT IN (%s)", t.table, excluded))
+		}
+
+		if t.tagsJoinTable != "" {
+			ret = append(ret, fmt.Sprintf("%s.id NOT IN (SELECT %s FROM %s WHERE %s IN (%s))", t.table, t.tagsFKColumn, t.tagsJoinTable, tagIDColumn, excluded))
+		}
+
+		if t.table == sceneMarkerTable {
+			ret = append(ret, fmt.Sprintf("%s.primary_tag_id NOT IN (%s)", t.table, excluded))
+		}
+	}
+
+	if len(r.ExcludedStudioIDs) > 0 {
+		excluded := excludedStudiosSubquery(r.ExcludedStudioIDs)
+
+		if t.isStudioTable {
+			ret = append(ret, fmt.Sprintf("%s.id NOT IN (%s)", t.table, excluded))
+		}
+
+		if t.studioColumn != "" {
+			ret = append(ret, fmt.Sprintf("(%[1]s.%[2]s IS NULL OR %[1]s.%[2]s NOT IN (%[3]s))", t.table, t.studioColumn, excluded))
+		}
+	}
+
+	if t.table == sceneMarkerTable {
+		// markers are hidden with their scenes
+		if sceneClauses := sceneRestrictions.clauses(r); len(sceneClauses) > 0 {
+			ret = append(ret, fmt.Sprintf("%s.scene_id IN (SELECT %s.id FROM %s WHERE %s)", t.table, sceneTable, sceneTable, strings.Join(sceneClauses, " AND ")))
+		}
+	}
+
+	return ret
+}
+
+// addRestrictions adds the clauses excluding rows hidden by the content
+// restrictions in the context to the query.
+func (t restrictedTable) addRestrictions(ctx context.Context, query *queryBuilder) {
+	if r := models.RestrictionsFromContext(ctx); r != nil {
+		query.addWhere(t.clauses(r)...)
+	}
+}
+
+// andClauses returns the sql clauses excluding rows hidden by the content
+// restrictions in the context, for appending to the WHERE clause of a raw sql
+// query. Returns an empty string if the context has no restrictions.
+func (t restrictedTable) andClauses(ctx context.Context) string {
+	r := models.RestrictionsFromContext(ctx)
+	if r == nil {
+		return ""
+	}
+
+	clauses := t.clauses(r)
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return " AND " + strings.Join(clauses, " AND ")
+}
+
+// restrictDataset adds the criteria excluding rows hidden by the content
+// restrictions in the context to the dataset.
+func (t restrictedTable) restrictDataset(ctx context.Context, q *goqu.SelectDataset) *goqu.SelectDataset {
+	r := models.RestrictionsFromContext(ctx)
+	if r == nil {
+		return q
+	}
+
+	for _, c := range t.clauses(r) {
+		q = q.Where(goqu.L(c))
+	}
+
+	return q
+}
+
+// restrictIDColumn adds a criterion to the dataset excluding rows where col
+// references a row of the table hidden by the content restrictions in the
+// context. It is used for queries on join tables, where the restricted table
+// is not part of the query.
+func (t restrictedTable) restrictIDColumn(ctx context.Context, q *goqu.SelectDataset, col exp.IdentifierExpression) *goqu.SelectDataset {
+	r := models.RestrictionsFromContext(ctx)
+	if r == nil {
+		return q
+	}
+
+	clauses := t.clauses(r)
+	if len(clauses) == 0 {
+		return q
+	}
+
+	return q.Where(goqu.L(fmt.Sprintf("? IN (SELECT %[1]s.id FROM %[1]s WHERE %[2]s)", t.table, strings.Join(clauses, " AND ")), col))
+}
@@ -0,0 +1,228 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleRestrictions(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		parentTag := models.NewTag()
+		parentTag.Name = "restricted parent"
+		if err := db.Tag.Create(ctx, &parentTag); err != nil {
+			t.Fatalf("TagStore.Create() error = %v", err)
+		}
+
+		childTag := models.NewTag()
+		childTag.Name = "restricted child"
+		childTag.ParentIDs = models.NewRelatedIDs([]int{parentTag.ID})
+		if err := db.Tag.Create(ctx, &childTag); err != nil {
+			t.Fatalf("TagStore.Create() error = %v", err)
+		}
+
+		topStudio := models.NewStudio()
+		topStudio.Name = "visible top studio"
+		if err := db.Studio.Create(ctx, &topStudio); err != nil {
+			t.Fatalf("StudioStore.Create() error = %v", err)
+		}
+
+		parentStudio := models.NewStudio()
+		parentStudio.Name = "restricted parent"
+		parentStudio.ParentID = &topStudio.ID
+		if err := db.Studio.Create(ctx, &parentStudio); err != nil {
+			t.Fatalf("StudioStore.Create() error = %v", err)
+		}
+
+		childStudio := models.NewStudio()
+		childStudio.Name = "restricted child"
+		childStudio.ParentID = &parentStudio.ID
+		if err := db.Studio.Create(ctx, &childStudio); err != nil {
+			t.Fatalf("StudioStore.Create() error = %v", err)
+		}
+
+		performer := models.NewPerformer()
+		performer.Name = "restricted performer"
+		if err := db.Performer.Create(ctx, &models.CreatePerformerInput{Performer: &performer}); err != nil {
+			t.Fatalf("PerformerStore.Create() error = %v", err)
+		}
+
+		taggedPerformer := models.NewPerformer()
+		taggedPerformer.Name = "restricted tagged performer"
+		taggedPerformer.TagIDs = models.NewRelatedIDs([]int{childTag.ID})
+		if err := db.Performer.Create(ctx, &models.CreatePerformerInput{Performer: &taggedPerformer}); err != nil {
+			t.Fatalf("PerformerStore.Create() error = %v", err)
+		}
+
+		group := models.NewGroup()
+		group.Name = "restricted group"
+		group.StudioID = &topStudio.ID
+		group.TagIDs = models.NewRelatedIDs([]int{childTag.ID})
+		if err := db.Group.Create(ctx, &group); err != nil {
+			t.Fatalf("GroupStore.Create() error = %v", err)
+		}
+
+		createScene := func(title string, tagIDs []int, studioID *int) *models.Scene {
+			s := models.NewScene()
+			s.Title = title
+			s.TagIDs = models.NewRelatedIDs(tagIDs)
+			s.PerformerIDs = models.NewRelatedIDs([]int{performer.ID})
+			s.StudioID = studioID
+			if err := db.Scene.Create(ctx, &s, nil); err != nil {
+				t.Fatalf("SceneStore.Create() error = %v", err)
+			}
+			return &s
+		}
+
+		taggedScene := createScene("restricted tagged", []int{childTag.ID}, nil)
+		studioScene := createScene("restricted studio", nil, &childStudio.ID)
+		visibleScene := createScene("restricted visible", nil, nil)
+
+		restricted := models.WithRole(ctx, models.UserRoleViewer, &models.RoleRestrictions{
+			Role:              models.UserRoleViewer,
+			ExcludedTagIDs:    []int{parentTag.ID},
+			ExcludedStudioIDs: []int{parentStudio.ID},
+		})
+
+		q := "restricted"
+		findFilter := &models.FindFilterType{Q: &q}
+
+		// scenes with excluded child tags and studios are hidden
+		scenes := queryScene(restricted, t, db.Scene, nil, findFilter)
+		assert.Equal(t, []int{visibleScene.ID}, scenesToIDs(scenes))
+
+		scenes = queryScene(ctx, t, db.Scene, nil, findFilter)
+		assert.ElementsMatch(t, []int{taggedScene.ID, studioScene.ID, visibleScene.ID}, scenesToIDs(scenes))
+
+		for _, id := range []int{taggedScene.ID, studioScene.ID} {
+			got, err := db.Scene.Find(restricted, id)
+			if err != nil {
+				t.Errorf("SceneStore.Find() error = %v", err)
+			}
+			assert.Nil(t, got)
+		}
+
+		got, err := db.Scene.Find(restricted, visibleScene.ID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+		}
+		assert.NotNil(t, got)
+
+		// scenes reached through relationships are hidden
+		scenes, err = db.Scene.FindByPerformerID(restricted, performer.ID)
+		if err != nil {
+			t.Errorf("SceneStore.FindByPerformerID() error = %v", err)
+		}
+		assert.Equal(t, []int{visibleScene.ID}, scenesToIDs(scenes))
+
+		sceneCount, err := db.Scene.CountByPerformerID(restricted, performer.ID)
+		if err != nil {
+			t.Errorf("SceneStore.CountByPerformerID() error = %v", err)
+		}
+		assert.Equal(t, 1, sceneCount)
+
+		sceneCount, err = db.Scene.CountByPerformerID(ctx, performer.ID)
+		if err != nil {
+			t.Errorf("SceneStore.CountByPerformerID() error = %v", err)
+		}
+		assert.Equal(t, 3, sceneCount)
+
+		// hidden scenes are returned as nil by FindMany
+		scenes, err = db.Scene.FindMany(restricted, []int{taggedScene.ID, visibleScene.ID, studioScene.ID})
+		if err != nil {
+			t.Errorf("SceneStore.FindMany() error = %v", err)
+		}
+		if assert.Len(t, scenes, 3) {
+			assert.Nil(t, scenes[0])
+			assert.Equal(t, visibleScene.ID, scenes[1].ID)
+			assert.Nil(t, scenes[2])
+		}
+
+		// related objects loaded by id are hidden
+		tags, err := db.Tag.FindMany(restricted, []int{parentTag.ID, childTag.ID})
+		if err != nil {
+			t.Errorf("TagStore.FindMany() error = %v", err)
+		}
+		assert.Equal(t, []*models.Tag{nil, nil}, tags)
+
+		studios, err := db.Studio.FindMany(restricted, []int{topStudio.ID, parentStudio.ID})
+		if err != nil {
+			t.Errorf("StudioStore.FindMany() error = %v", err)
+		}
+		if assert.Len(t, studios, 2) {
+			assert.Equal(t, topStudio.ID, studios[0].ID)
+			assert.Nil(t, studios[1])
+		}
+
+		performers, err := db.Performer.FindMany(restricted, []int{performer.ID, taggedPerformer.ID})
+		if err != nil {
+			t.Errorf("PerformerStore.FindMany() error = %v", err)
+		}
+		if assert.Len(t, performers, 2) {
+			assert.Equal(t, performer.ID, performers[0].ID)
+			assert.Nil(t, performers[1])
+		}
+
+		groups, err := db.Group.FindMany(restricted, []int{group.ID})
+		if err != nil {
+			t.Errorf("GroupStore.FindMany() error = %v", err)
+		}
+		assert.Equal(t, []*models.Group{nil}, groups)
+
+		// hidden objects are not returned through relationships
+		studios, err = db.Studio.FindChildren(restricted, topStudio.ID)
+		if err != nil {
+			t.Errorf("StudioStore.FindChildren() error = %v", err)
+		}
+		assert.Len(t, studios, 0)
+
+		studios, err = db.Studio.FindChildren(ctx, topStudio.ID)
+		if err != nil {
+			t.Errorf("StudioStore.FindChildren() error = %v", err)
+		}
+		assert.Len(t, studios, 1)
+
+		groups, err = db.Group.FindByStudioID(restricted, topStudio.ID)
+		if err != nil {
+			t.Errorf("GroupStore.FindByStudioID() error = %v", err)
+		}
+		assert.Len(t, groups, 0)
+
+		groups, err = db.Group.FindByStudioID(ctx, topStudio.ID)
+		if err != nil {
+			t.Errorf("GroupStore.FindByStudioID() error = %v", err)
+		}
+		assert.Len(t, groups, 1)
+
+		// excluded tags and studios are hidden themselves
+		tags = queryTags(restricted, t, db.Tag, nil, findFilter)
+		assert.Len(t, tags, 0)
+
+		studios = queryStudios(restricted, t, nil, findFilter)
+		assert.Len(t, studios, 0)
+
+		// viewer restrictions do not apply to other contexts
+		studios = queryStudios(ctx, t, nil, findFilter)
+		assert.Len(t, studios, 2)
+
+		// a duplicate pair with a hidden scene is not a duplicate
+		partial := models.NewScenePartial()
+		partial.TagIDs = &models.UpdateIDs{IDs: []int{childTag.ID}, Mode: models.RelationshipUpdateModeAdd}
+		if _, err := db.Scene.UpdatePartial(ctx, sceneIDs[0], partial); err != nil {
+			t.Fatalf("SceneStore.UpdatePartial() error = %v", err)
+		}
+
+		dupes, err := db.Scene.FindDuplicates(restricted, 0, -1)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicates() error = %v", err)
+		}
+		assert.Len(t, dupes, dupeScenePhashes-1)
+
+		return nil
+	})
+}
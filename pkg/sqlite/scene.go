@@ -40,19 +40,36 @@ const (
 	sceneCoverBlobColumn = "cover_blob"
 )
 
+// scenePhashesQuery selects the phash and duration of each scene file. The
+// primary file of a trimmed scene uses the phash and duration of the trimmed
+// range, if its phash has been generated.
+var scenePhashesQuery = `
+SELECT scenes.id as id
+	, files.size as size
+	, CASE WHEN scenes_files."primary" = 1 AND scenes.trimmed_phash IS NOT NULL
+		THEN scenes.trimmed_phash
+		ELSE files_fingerprints.fingerprint
+	END as phash
+	, CASE WHEN scenes_files."primary" = 1 AND scenes.trimmed_phash IS NOT NULL
+		THEN min(coalesce(scenes.end_point, video_files.duration), video_files.duration) - coalesce(scenes.start_point, 0)
+		ELSE video_files.duration
+	END as duration
+FROM scenes
+INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id)
+INNER JOIN files ON (scenes_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+INNER JOIN video_files ON (files.id == video_files.file_id)
+`
+
 var findExactDuplicateQuery = `
 SELECT GROUP_CONCAT(DISTINCT scene_id) as ids
 FROM (
-	SELECT scenes.id as scene_id
-		, video_files.duration as file_duration
-		, files.size as file_size
-		, files_fingerprints.fingerprint as phash
-		, abs(max(video_files.duration) OVER (PARTITION by files_fingerprints.fingerprint) - video_files.duration) as durationDiff
-	FROM scenes
-	INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id)
-	INNER JOIN files ON (scenes_files.file_id = files.id)
-	INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
-	INNER JOIN video_files ON (files.id == video_files.file_id)
+	SELECT id as scene_id
+		, duration as file_duration
+		, size as file_size
+		, phash
+		, abs(max(duration) OVER (PARTITION by phash) - duration) as durationDiff
+	FROM (` + scenePhashesQuery + `)
 )
 WHERE durationDiff <= ?1
     OR ?1 < 0   --  Always TRUE if the parameter is negative.
@@ -64,15 +81,9 @@ ORDER BY SUM(file_size) DESC;
 `
 
 var findAllPhashesQuery = `
-SELECT scenes.id as id
-    , files_fingerprints.fingerprint as phash
-    , video_files.duration as duration
-FROM scenes
-INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id)
-INNER JOIN files ON (scenes_files.file_id = files.id)
-INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
-INNER JOIN video_files ON (files.id == video_files.file_id)
-ORDER BY files.size DESC;
+SELECT id, phash, duration
+FROM (` + scenePhashesQuery + `)
+ORDER BY size DESC;
 `
 
 type sceneRow struct {
@@ -83,13 +94,16 @@ type sceneRow struct {
 	Director zero.String `db:"director"`
 	Date     NullDate    `db:"date"`
 	// expressed as 1-100
-	Rating       null.Int  `db:"rating"`
-	Organized    bool      `db:"organized"`
-	StudioID     null.Int  `db:"studio_id,omitempty"`
-	CreatedAt    Timestamp `db:"created_at"`
-	UpdatedAt    Timestamp `db:"updated_at"`
-	ResumeTime   float64   `db:"resume_time"`
-	PlayDuration float64   `db:"play_duration"`
+	Rating       null.Int   `db:"rating"`
+	Organized    bool       `db:"organized"`
+	StudioID     null.Int   `db:"studio_id,omitempty"`
+	CreatedAt    Timestamp  `db:"created_at"`
+	UpdatedAt    Timestamp  `db:"updated_at"`
+	ResumeTime   float64    `db:"resume_time"`
+	PlayDuration float64    `db:"play_duration"`
+	StartPoint   null.Float `db:"start_point"`
+	EndPoint     null.Float `db:"end_point"`
+	TrimmedPhash null.Int   `db:"trimmed_phash"`
 
 	// not used in resolutions or updates
 	CoverBlob zero.String `db:"cover_blob"`
@@ -109,6 +123,9 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 	r.ResumeTime = o.ResumeTime
 	r.PlayDuration = o.PlayDuration
+	r.StartPoint = null.FloatFromPtr(o.StartPoint)
+	r.EndPoint = null.FloatFromPtr(o.EndPoint)
+	r.TrimmedPhash = null.IntFromPtr(o.TrimmedPhash)
 }
 
 type sceneQueryRow struct {
@@ -141,6 +158,9 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 
 		ResumeTime:   r.ResumeTime,
 		PlayDuration: r.PlayDuration,
+		StartPoint:   nullFloatPtr(r.StartPoint),
+		EndPoint:     nullFloatPtr(r.EndPoint),
+		TrimmedPhash: nullInt64Ptr(r.TrimmedPhash),
 	}
 
 	if r.PrimaryFileFolderPath.Valid && r.PrimaryFileBasename.Valid {
@@ -167,6 +187,9 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setTimestamp("updated_at", o.UpdatedAt)
 	r.setFloat64("resume_time", o.ResumeTime)
 	r.setFloat64("play_duration", o.PlayDuration)
+	r.setNullFloat64("start_point", o.StartPoint)
+	r.setNullFloat64("end_point", o.EndPoint)
+	r.setNullInt64("trimmed_phash", o.TrimmedPhash)
 }
 
 type sceneRepositoryType struct {
@@ -231,6 +254,7 @@ var (
 
 type SceneStore struct {
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
 	oDateManager
@@ -245,6 +269,10 @@ func NewSceneStore(r *storeRepository, blobStore *BlobStore) *SceneStore {
 			blobStore: blobStore,
 			joinTable: sceneTable,
 		},
+		customFieldsStore: customFieldsStore{
+			table: scenesCustomFieldsTable,
+			fk:    scenesCustomFieldsTable.Col(sceneIDColumn),
+		},
 
 		tableMgr:        sceneTableMgr,
 		viewDateManager: viewDateManager{scenesViewTableMgr},
@@ -302,11 +330,21 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 	var r sceneRow
 	r.fromScene(*newObject)
 
+	const isNew = true
+	userData, err := qb.splitRowUserData(ctx, &r, isNew)
+	if err != nil {
+		return err
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if err := qb.saveUserData(ctx, id, userData); err != nil {
+		return err
+	}
+
 	if len(fileIDs) > 0 {
 		const firstPrimary = true
 		if err := scenesFilesTableMgr.insertJoins(ctx, id, firstPrimary, fileIDs); err != nil {
@@ -350,6 +388,10 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 		}
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeScene, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -361,6 +403,11 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 }
 
 func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeScene, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := sceneRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -369,6 +416,17 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	userData := splitUserData(ctx, r.Record)
+	if userData != nil {
+		if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+			return nil, err
+		}
+
+		if err := qb.saveUserData(ctx, id, userData); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -411,17 +469,40 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if err := qb.SetCustomFields(ctx, id, partial.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeScene, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r sceneRow
 	r.fromScene(*updatedObject)
 
+	const isNew = false
+	userData, err := qb.splitRowUserData(ctx, &r, isNew)
+	if err != nil {
+		return err
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if err := qb.saveUserData(ctx, updatedObject.ID, userData); err != nil {
+		return err
+	}
+
 	if updatedObject.URLs.Loaded() {
 		if err := scenesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
 			return err
@@ -469,10 +550,15 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 		}
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *SceneStore) Destroy(ctx context.Context, id int) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeScene, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyCover(ctx, id); err != nil {
 		return err
@@ -481,24 +567,98 @@ func (qb *SceneStore) Destroy(ctx context.Context, id int) error {
 	// scene markers should be handled prior to calling destroy
 	// galleries should be handled prior to calling destroy
 
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
+}
+
+// auditValues returns the values of the scene recorded in the audit log.
+// User data such as the rating and resume time is not recorded.
+func (qb *SceneStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	s, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.ScenePartial{
+		Title:      models.NewOptionalString(s.Title),
+		Code:       models.NewOptionalString(s.Code),
+		Details:    models.NewOptionalString(s.Details),
+		Director:   models.NewOptionalString(s.Director),
+		Date:       models.NewOptionalDatePtr(s.Date),
+		Organized:  models.NewOptionalBool(s.Organized),
+		StudioID:   models.NewOptionalIntPtr(s.StudioID),
+		StartPoint: models.NewOptionalFloat64Ptr(s.StartPoint),
+		EndPoint:   models.NewOptionalFloat64Ptr(s.EndPoint),
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.URLs = auditStrings(urls)
+
+	galleryIDs, err := qb.GetGalleryIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.GalleryIDs = auditIDs(galleryIDs)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.PerformerIDs = auditIDs(performerIDs)
+
+	groups, err := qb.GetGroups(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.GroupIDs = &models.UpdateGroupIDs{
+		Groups: groups,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.StashIDs = auditStashIDs(stashIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
-// returns nil, nil if not found
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *SceneStore) Find(ctx context.Context, id int) (*models.Scene, error) {
-	ret, err := qb.find(ctx, id)
+	q := sceneRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the scenes with the provided ids, in the same order. If content
+// is restricted in the context, hidden scenes are returned as nil.
 func (qb *SceneStore) FindMany(ctx context.Context, ids []int) ([]*models.Scene, error) {
 	scenes := make([]*models.Scene, len(ids))
 
 	table := qb.table()
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		q := sceneRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -514,8 +674,10 @@ func (qb *SceneStore) FindMany(ctx context.Context, ids []int) ([]*models.Scene,
 		return nil, err
 	}
 
+	// hidden scenes are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range scenes {
-		if scenes[i] == nil {
+		if scenes[i] == nil && !restricted {
 			return nil, fmt.Errorf("scene with id %d not found", ids[i])
 		}
 	}
@@ -544,7 +706,7 @@ func (qb *SceneStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset
 		),
 	)
 
-	return qb.getMany(ctx, q)
+	return qb.getMany(ctx, sceneRestrictions.restrictDataset(ctx, q))
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -583,6 +745,10 @@ func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := qb.loadUserData(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
@@ -753,6 +919,7 @@ func (qb *SceneStore) CountByPerformerID(ctx context.Context, performerID int) (
 	joinTable := scenesPerformersJoinTable
 
 	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = sceneRestrictions.restrictIDColumn(ctx, q, joinTable.Col(sceneIDColumn))
 	return count(ctx, q)
 }
 
@@ -763,13 +930,17 @@ func (qb *SceneStore) OCountByPerformerID(ctx context.Context, performerID int)
 
 	q := dialect.Select(goqu.COUNT("*")).From(table).InnerJoin(
 		oHistoryTable,
-		goqu.On(table.Col(idColumn).Eq(oHistoryTable.Col(sceneIDColumn))),
+		goqu.On(
+			table.Col(idColumn).Eq(oHistoryTable.Col(sceneIDColumn)),
+			userIDCriterion(ctx, oHistoryTable.Col(userIDColumn)),
+		),
 	).InnerJoin(
 		joinTable,
 		goqu.On(
 			table.Col(idColumn).Eq(joinTable.Col(sceneIDColumn)),
 		),
 	).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = sceneRestrictions.restrictDataset(ctx, q)
 
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -793,7 +964,7 @@ func (qb *SceneStore) FindByGroupID(ctx context.Context, groupID int) ([]*models
 }
 
 func (qb *SceneStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := sceneRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
@@ -809,6 +980,8 @@ func (qb *SceneStore) Size(ctx context.Context) (float64, error) {
 		fileTable,
 		goqu.On(scenesFilesJoinTable.Col(fileIDColumn).Eq(fileTable.Col(idColumn))),
 	)
+	q = sceneRestrictions.restrictDataset(ctx, q)
+
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -830,6 +1003,7 @@ func (qb *SceneStore) Duration(ctx context.Context) (float64, error) {
 		videoFileTable,
 		goqu.On(videoFileTable.Col("file_id").Eq(scenesFilesJoinTable.Col("file_id"))),
 	)
+	q = sceneRestrictions.restrictDataset(ctx, q)
 
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -843,6 +1017,10 @@ func (qb *SceneStore) PlayDuration(ctx context.Context) (float64, error) {
 	table := qb.table()
 
 	q := dialect.Select(goqu.COALESCE(goqu.SUM("play_duration"), 0)).From(table)
+	if userID := models.UserIDFromContext(ctx); userID != nil {
+		userTable := sceneUserDataTableMgr.table
+		q = dialect.Select(goqu.COALESCE(goqu.SUM("play_duration"), 0)).From(userTable).Where(userTable.Col(userIDColumn).Eq(*userID))
+	}
 
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -857,6 +1035,7 @@ func (qb *SceneStore) CountByStudioID(ctx context.Context, studioID int) (int, e
 	table := qb.table()
 
 	q := dialect.Select(goqu.COUNT("*")).From(table).Where(table.Col(studioIDColumn).Eq(studioID))
+	q = sceneRestrictions.restrictDataset(ctx, q)
 	return count(ctx, q)
 }
 
@@ -900,7 +1079,7 @@ func (qb *SceneStore) All(ctx context.Context) ([]*models.Scene, error) {
 	fileTable := fileTableMgr.table
 	folderTable := folderTableMgr.table
 
-	return qb.getMany(ctx, qb.selectDataset().Order(
+	return qb.getMany(ctx, sceneRestrictions.restrictDataset(ctx, qb.selectDataset()).Order(
 		folderTable.Col("path").Asc(),
 		fileTable.Col("basename").Asc(),
 		table.Col("date").Asc(),
@@ -955,7 +1134,9 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		return nil, err
 	}
 
-	if err := qb.setSceneSort(&query, findFilter); err != nil {
+	sceneRestrictions.addRestrictions(ctx, &query)
+
+	if err := qb.setSceneSort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -1084,7 +1265,7 @@ var sceneSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *SceneStore) setSceneSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -1184,13 +1365,16 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
-		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getUserCountSort(ctx, sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
 	case "last_played_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesViewDatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", scenesViewDatesTable, sceneIDColumn, sceneTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
 	case "last_o_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", scenesODatesTable, sceneIDColumn, sceneTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
 	case "o_counter":
-		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getUserCountSort(ctx, sceneTable, scenesODatesTable, sceneIDColumn, direction)
+	case "rating", "resume_time", "play_duration":
+		col := sceneUserColumn(ctx, sort, query.join)
+		query.sortAndPagination += " ORDER BY " + col + " " + getSortDirection(direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1216,6 +1400,12 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 		record["play_duration"] = goqu.L("play_duration + ?", playDuration)
 	}
 
+	if userData := splitUserData(ctx, record); userData != nil {
+		if err := qb.saveUserData(ctx, id, userData); err != nil {
+			return false, err
+		}
+	}
+
 	if len(record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, record); err != nil {
 			return false, err
@@ -1240,6 +1430,12 @@ func (qb *SceneStore) ResetActivity(ctx context.Context, id int, resetResume boo
 		record["play_duration"] = 0.0
 	}
 
+	if userData := splitUserData(ctx, record); userData != nil {
+		if err := qb.saveUserData(ctx, id, userData); err != nil {
+			return false, err
+		}
+	}
+
 	if len(record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, record); err != nil {
 			return false, err
@@ -1372,8 +1568,12 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 
 	var duplicates [][]*models.Scene
 	for _, sceneIds := range dupeIds {
+		// duplicates hidden by content restrictions are omitted
 		if scenes, err := qb.FindMany(ctx, sceneIds); err == nil {
-			duplicates = append(duplicates, scenes)
+			scenes = sliceutil.OmitNil(scenes)
+			if len(scenes) > 1 {
+				duplicates = append(duplicates, scenes)
+			}
 		}
 	}
 
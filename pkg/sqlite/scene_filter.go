@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
-	"github.com/stashapp/stash/pkg/utils"
 )
 
 type sceneFilterHandler struct {
@@ -83,16 +82,16 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.Phash != nil {
 				// backwards compatibility
-				qb.phashDistanceCriterionHandler(&models.PhashDistanceCriterionInput{
+				phashDistanceCriterionHandler(&models.PhashDistanceCriterionInput{
 					Value:    sceneFilter.Phash.Value,
 					Modifier: sceneFilter.Phash.Modifier,
-				})(ctx, f)
+				}, "scenes_files.file_id", qb.addSceneFilesTable)(ctx, f)
 			}
 		}),
 
-		qb.phashDistanceCriterionHandler(sceneFilter.PhashDistance),
+		phashDistanceCriterionHandler(sceneFilter.PhashDistance, "scenes_files.file_id", qb.addSceneFilesTable),
 
-		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
+		qb.userIntCriterionHandler(sceneFilter.Rating100, "rating", intCriterionHandler),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
 
@@ -127,13 +126,13 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.captionCriterionHandler(sceneFilter.Captions),
 
-		floatIntCriterionHandler(sceneFilter.ResumeTime, "scenes.resume_time", nil),
-		floatIntCriterionHandler(sceneFilter.PlayDuration, "scenes.play_duration", nil),
+		qb.userIntCriterionHandler(sceneFilter.ResumeTime, "resume_time", floatIntCriterionHandler),
+		qb.userIntCriterionHandler(sceneFilter.PlayDuration, "play_duration", floatIntCriterionHandler),
 		qb.playCountCriterionHandler(sceneFilter.PlayCount),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.LastPlayedAt != nil {
 				f.addLeftJoin(
-					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, sceneIDColumn),
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s WHERE %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, userIDClause(ctx, userIDColumn), sceneIDColumn),
 					"scene_last_view",
 					fmt.Sprintf("scene_last_view.%s = scenes.id", sceneIDColumn),
 				)
@@ -210,6 +209,13 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 				f.addInnerJoin("scene_markers", "", "scenes.id")
 			},
 		},
+
+		&customFieldsFilterHandler{
+			table: scenesCustomFieldsTable.GetTable(),
+			fkCol: sceneIDColumn,
+			c:     sceneFilter.CustomFields,
+			idCol: "scenes.id",
+		},
 	}
 }
 
@@ -232,24 +238,43 @@ func (qb *sceneFilterHandler) addVideoFilesTable(f *filterBuilder) {
 	f.addLeftJoin(videoFileTable, "", "video_files.file_id = scenes_files.file_id")
 }
 
-func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesViewDatesTable,
-		primaryFK:    sceneIDColumn,
+// userIntCriterionHandler returns a handler for a scene column which is
+// stored per user.
+func (qb *sceneFilterHandler) userIntCriterionHandler(c *models.IntCriterionInput, column string, handler func(*models.IntCriterionInput, string, func(f *filterBuilder)) criterionHandlerFunc) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c != nil {
+			col := sceneUserColumn(ctx, column, func(table, as, onClause string) {
+				f.addLeftJoin(table, as, onClause)
+			})
+			handler(c, col, nil)(ctx, f)
+		}
 	}
+}
 
-	return h.handler(count)
+func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    scenesViewDatesTable,
+			primaryFK:    sceneIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
+
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesODatesTable,
-		primaryFK:    sceneIDColumn,
-	}
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    scenesODatesTable,
+			primaryFK:    sceneIDColumn,
+			joinWhere:    userIDClause(ctx, "s."+userIDColumn),
+		}
 
-	return h.handler(count)
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
@@ -527,42 +552,3 @@ func (qb *sceneFilterHandler) performerTagsCriterionHandler(tags *models.Hierarc
 		joinPrimaryKey: sceneIDColumn,
 	}
 }
-
-func (qb *sceneFilterHandler) phashDistanceCriterionHandler(phashDistance *models.PhashDistanceCriterionInput) criterionHandlerFunc {
-	return func(ctx context.Context, f *filterBuilder) {
-		if phashDistance != nil {
-			qb.addSceneFilesTable(f)
-			f.addLeftJoin(fingerprintTable, "fingerprints_phash", "scenes_files.file_id = fingerprints_phash.file_id AND fingerprints_phash.type = 'phash'")
-
-			value, _ := utils.StringToPhash(phashDistance.Value)
-			distance := 0
-			if phashDistance.Distance != nil {
-				distance = *phashDistance.Distance
-			}
-
-			if distance == 0 {
-				// use the default handler
-				intCriterionHandler(&models.IntCriterionInput{
-					Value:    int(value),
-					Modifier: phashDistance.Modifier,
-				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
-			}
-
-			switch {
-			case phashDistance.Modifier == models.CriterionModifierEquals && distance > 0:
-				// needed to avoid a type mismatch
-				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
-				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) < ?", value, distance)
-			case phashDistance.Modifier == models.CriterionModifierNotEquals && distance > 0:
-				// needed to avoid a type mismatch
-				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
-				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) > ?", value, distance)
-			default:
-				intCriterionHandler(&models.IntCriterionInput{
-					Value:    int(value),
-					Modifier: phashDistance.Modifier,
-				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
-			}
-		}
-	}
-}
@@ -175,9 +175,10 @@ func (qb *SceneMarkerStore) Destroy(ctx context.Context, id int) error {
 	return sceneMarkerRepository.destroyExisting(ctx, []int{id})
 }
 
-// returns nil, nil if not found
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *SceneMarkerStore) Find(ctx context.Context, id int) (*models.SceneMarker, error) {
-	ret, err := qb.find(ctx, id)
+	q := sceneMarkerRestrictions.restrictDataset(ctx, qb.selectDataset().Where(sceneMarkerTableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -257,7 +258,7 @@ func (qb *SceneMarkerStore) getMany(ctx context.Context, q *goqu.SelectDataset)
 func (qb *SceneMarkerStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneMarker, error) {
 	query := `
 		SELECT scene_markers.* FROM scene_markers
-		WHERE scene_markers.scene_id = ?
+		WHERE scene_markers.scene_id = ?` + sceneMarkerRestrictions.andClauses(ctx) + `
 		GROUP BY scene_markers.id
 		ORDER BY scene_markers.seconds ASC
 	`
@@ -322,6 +323,8 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 		return nil, err
 	}
 
+	sceneMarkerRestrictions.addRestrictions(ctx, &query)
+
 	if err := qb.setSceneMarkerSort(&query, findFilter); err != nil {
 		return nil, err
 	}
@@ -451,10 +454,10 @@ func (qb *SceneMarkerStore) UpdateTags(ctx context.Context, id int, tagIDs []int
 }
 
 func (qb *SceneMarkerStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := sceneMarkerRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *SceneMarkerStore) All(ctx context.Context) ([]*models.SceneMarker, error) {
-	return qb.getMany(ctx, qb.selectDataset())
+	return qb.getMany(ctx, sceneMarkerRestrictions.restrictDataset(ctx, qb.selectDataset()))
 }
@@ -85,6 +85,8 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 		rating       = 60
 		resumeTime   = 10.0
 		playDuration = 34.0
+		startPoint   = 5.0
+		endPoint     = 120.0
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		sceneIndex   = 123
@@ -143,6 +145,8 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				}),
 				ResumeTime:   float64(resumeTime),
 				PlayDuration: playDuration,
+				StartPoint:   &startPoint,
+				EndPoint:     &endPoint,
 			},
 			false,
 		},
@@ -508,6 +512,8 @@ func clearScenePartial() models.ScenePartial {
 		Date:         models.OptionalDate{Set: true, Null: true},
 		Rating:       models.OptionalInt{Set: true, Null: true},
 		StudioID:     models.OptionalInt{Set: true, Null: true},
+		StartPoint:   models.OptionalFloat64{Set: true, Null: true},
+		EndPoint:     models.OptionalFloat64{Set: true, Null: true},
 		GalleryIDs:   &models.UpdateIDs{Mode: models.RelationshipUpdateModeSet},
 		TagIDs:       &models.UpdateIDs{Mode: models.RelationshipUpdateModeSet},
 		PerformerIDs: &models.UpdateIDs{Mode: models.RelationshipUpdateModeSet},
@@ -525,6 +531,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 		rating       = 60
 		resumeTime   = 10.0
 		playDuration = 34.0
+		startPoint   = 5.0
+		endPoint     = 120.0
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		sceneIndex   = 123
@@ -602,6 +610,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				},
 				ResumeTime:   models.NewOptionalFloat64(resumeTime),
 				PlayDuration: models.NewOptionalFloat64(playDuration),
+				StartPoint:   models.NewOptionalFloat64(startPoint),
+				EndPoint:     models.NewOptionalFloat64(endPoint),
 			},
 			models.Scene{
 				ID: sceneIDs[sceneIdxWithSpacedName],
@@ -644,6 +654,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				}),
 				ResumeTime:   resumeTime,
 				PlayDuration: playDuration,
+				StartPoint:   &startPoint,
+				EndPoint:     &endPoint,
 			},
 			false,
 		},
@@ -4509,6 +4521,40 @@ func TestSceneStore_FindDuplicates(t *testing.T) {
 	})
 }
 
+func TestSceneStore_FindDuplicatesTrimmed(t *testing.T) {
+	qb := db.Scene
+
+	withRollbackTxn(func(ctx context.Context) error {
+		// the first scene shares its file phash with a later scene
+		partial := models.NewScenePartial()
+		partial.TrimmedPhash = models.NewOptionalInt64(-1)
+		if _, err := qb.UpdatePartial(ctx, sceneIDs[0], partial); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		s, err := qb.Find(ctx, sceneIDs[0])
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		if assert.NotNil(t, s.TrimmedPhash) {
+			assert.Equal(t, int64(-1), *s.TrimmedPhash)
+		}
+
+		got, err := qb.FindDuplicates(ctx, 0, -1)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, dupeScenePhashes-1)
+
+		return nil
+	})
+}
+
 func TestSceneStore_AssignFiles(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,209 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	sceneUserDataTable = "scenes_user_data"
+)
+
+var sceneUserDataTableMgr = &table{
+	table:    goqu.T(sceneUserDataTable),
+	idColumn: goqu.T(sceneUserDataTable).Col(sceneIDColumn),
+}
+
+// sceneUserDataColumns are the scene columns which are stored per user. The
+// values of the configured user are stored in the scenes table, the values of
+// other users are stored in the scenes_user_data table.
+var sceneUserDataColumns = []string{
+	"rating",
+	"resume_time",
+	"play_duration",
+}
+
+type sceneUserDataRow struct {
+	SceneID      int      `db:"scene_id"`
+	Rating       null.Int `db:"rating"`
+	ResumeTime   float64  `db:"resume_time"`
+	PlayDuration float64  `db:"play_duration"`
+}
+
+// sceneUserColumn returns the sql expression for a per-user scene column,
+// adding the join to the user data table if needed.
+func sceneUserColumn(ctx context.Context, column string, addJoin func(table, as, onClause string)) string {
+	userID := models.UserIDFromContext(ctx)
+	if userID == nil {
+		return sceneTable + "." + column
+	}
+
+	addJoin(sceneUserDataTable, "", fmt.Sprintf("%[1]s.scene_id = scenes.id AND %[1]s.user_id = %[2]d", sceneUserDataTable, *userID))
+
+	if column == "rating" {
+		return sceneUserDataTable + "." + column
+	}
+
+	return fmt.Sprintf("COALESCE(%s.%s, 0)", sceneUserDataTable, column)
+}
+
+// splitUserData moves the per-user values from the record into a separate
+// record if the context has a user. Returns nil if there is no user or no
+// per-user values in the record.
+func splitUserData(ctx context.Context, record exp.Record) exp.Record {
+	if models.UserIDFromContext(ctx) == nil {
+		return nil
+	}
+
+	var ret exp.Record
+	for _, c := range sceneUserDataColumns {
+		if v, found := record[c]; found {
+			if ret == nil {
+				ret = make(exp.Record)
+			}
+			ret[c] = v
+			delete(record, c)
+		}
+	}
+
+	return ret
+}
+
+// saveUserData sets the per-user values of the scene for the user in the
+// context. Values may be expressions of existing columns of the user data
+// row; these are evaluated against zero values if the row does not exist.
+func (qb *SceneStore) saveUserData(ctx context.Context, sceneID int, record exp.Record) error {
+	userID := models.UserIDFromContext(ctx)
+	if userID == nil || len(record) == 0 {
+		return nil
+	}
+
+	// ensure the row exists so that expressions are evaluated against it
+	insert := dialect.Insert(sceneUserDataTableMgr.table).Cols(sceneIDColumn, userIDColumn).Vals(
+		goqu.Vals{sceneID, *userID},
+	).OnConflict(goqu.DoNothing())
+
+	if _, err := exec(ctx, insert); err != nil {
+		return fmt.Errorf("inserting scene user data: %w", err)
+	}
+
+	q := dialect.Update(sceneUserDataTableMgr.table).Prepared(true).Set(record).Where(
+		sceneUserDataTableMgr.idColumn.Eq(sceneID),
+		sceneUserDataTableMgr.table.Col(userIDColumn).Eq(*userID),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating scene user data: %w", err)
+	}
+
+	return nil
+}
+
+// loadUserData replaces the per-user values of the scenes with the values of
+// the user in the context.
+func (qb *SceneStore) loadUserData(ctx context.Context, scenes []*models.Scene) error {
+	userID := models.UserIDFromContext(ctx)
+	if userID == nil || len(scenes) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(scenes))
+	byID := make(map[int]*models.Scene, len(scenes))
+	for i, s := range scenes {
+		ids[i] = s.ID
+		byID[s.ID] = s
+
+		s.Rating = nil
+		s.ResumeTime = 0
+		s.PlayDuration = 0
+	}
+
+	table := sceneUserDataTableMgr.table
+
+	return batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.From(table).Prepared(true).Select(
+			table.Col(sceneIDColumn),
+			table.Col("rating"),
+			table.Col("resume_time"),
+			table.Col("play_duration"),
+		).Where(
+			table.Col(sceneIDColumn).In(batch),
+			table.Col(userIDColumn).Eq(*userID),
+		)
+
+		const single = false
+		return queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+			var r sceneUserDataRow
+			if err := rows.StructScan(&r); err != nil {
+				return err
+			}
+
+			if s := byID[r.SceneID]; s != nil {
+				s.Rating = nullIntPtr(r.Rating)
+				s.ResumeTime = r.ResumeTime
+				s.PlayDuration = r.PlayDuration
+			}
+
+			return nil
+		})
+	})
+}
+
+// sharedUserData returns the per-user values of the configured user for the
+// scene, so that they are retained when the scene is updated by another user.
+func (qb *SceneStore) sharedUserData(ctx context.Context, sceneID int) (*sceneUserDataRow, error) {
+	table := qb.table()
+	q := dialect.From(table).Select(
+		table.Col(idColumn).As(sceneIDColumn),
+		table.Col("rating"),
+		table.Col("resume_time"),
+		table.Col("play_duration"),
+	).Where(table.Col(idColumn).Eq(sceneID))
+
+	var ret sceneUserDataRow
+	const single = true
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		return rows.StructScan(&ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// splitRowUserData moves the per-user values from the row into a separate
+// record if the context has a user. The values in the row are replaced with
+// the existing values of the configured user, or reset if the scene is new.
+func (qb *SceneStore) splitRowUserData(ctx context.Context, r *sceneRow, isNew bool) (exp.Record, error) {
+	if models.UserIDFromContext(ctx) == nil {
+		return nil, nil
+	}
+
+	ret := exp.Record{
+		"rating":        r.Rating,
+		"resume_time":   r.ResumeTime,
+		"play_duration": r.PlayDuration,
+	}
+
+	var shared sceneUserDataRow
+	if !isNew {
+		existing, err := qb.sharedUserData(ctx, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		shared = *existing
+	}
+
+	r.Rating = shared.Rating
+	r.ResumeTime = shared.ResumeTime
+	r.PlayDuration = shared.PlayDuration
+
+	return ret, nil
+}
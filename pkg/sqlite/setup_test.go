@@ -1212,7 +1212,6 @@ func makeImage(i int) *models.Image {
 		URLs: models.NewRelatedStrings([]string{
 			getImageEmptyString(i, urlField),
 		}),
-		OCounter:     getOCounter(i),
 		StudioID:     studioID,
 		GalleryIDs:   models.NewRelatedIDs(gids),
 		PerformerIDs: models.NewRelatedIDs(pids),
@@ -1244,6 +1243,27 @@ func createImages(ctx context.Context, n int) error {
 		}
 
 		imageIDs = append(imageIDs, image.ID)
+
+		if err := addImageODates(ctx, image.ID, getOCounter(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addImageODates(ctx context.Context, id int, count int) error {
+	if count == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, count)
+	for i := range dates {
+		dates[i] = time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if _, err := db.Image.AddO(ctx, id, dates); err != nil {
+		return fmt.Errorf("adding o dates to image %d: %w", id, err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"regexp"
@@ -137,6 +138,11 @@ func getCountSort(primaryTable, joinTable, primaryFK, direction string) string {
 	return fmt.Sprintf(" ORDER BY (SELECT COUNT(*) FROM %s AS sort WHERE sort.%s = %s.id) %s", joinTable, primaryFK, primaryTable, getSortDirection(direction))
 }
 
+// getUserCountSort is getCountSort for join tables with per-user rows.
+func getUserCountSort(ctx context.Context, primaryTable, joinTable, primaryFK, direction string) string {
+	return fmt.Sprintf(" ORDER BY (SELECT COUNT(*) FROM %s AS sort WHERE sort.%s = %s.id AND %s) %s", joinTable, primaryFK, primaryTable, userIDClause(ctx, "sort."+userIDColumn), getSortDirection(direction))
+}
+
 func getStringSearchClause(columns []string, q string, not bool) sqlClause {
 	var likeClauses []string
 	var args []interface{}
@@ -350,8 +356,13 @@ func getMultiCriterionClause(primaryTable, foreignTable, joinTable, primaryFK, f
 	return whereClause, havingClause
 }
 
-func getCountCriterionClause(primaryTable, joinTable, primaryFK string, criterion models.IntCriterionInput) (string, []interface{}) {
-	lhs := fmt.Sprintf("(SELECT COUNT(*) FROM %s s WHERE s.%s = %s.id)", joinTable, primaryFK, primaryTable)
+func getCountCriterionClause(primaryTable, joinTable, primaryFK, joinWhere string, criterion models.IntCriterionInput) (string, []interface{}) {
+	where := fmt.Sprintf("s.%s = %s.id", primaryFK, primaryTable)
+	if joinWhere != "" {
+		where += " AND " + joinWhere
+	}
+
+	lhs := fmt.Sprintf("(SELECT COUNT(*) FROM %s s WHERE %s)", joinTable, where)
 	return getIntCriterionWhereClause(lhs, criterion)
 }
 
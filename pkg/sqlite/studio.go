@@ -141,11 +141,14 @@ var (
 type StudioStore struct {
 	blobJoinQueryBuilder
 	tagRelationshipStore
+	customFieldsStore
 
 	tableMgr *table
+
+	repo *storeRepository
 }
 
-func NewStudioStore(blobStore *BlobStore) *StudioStore {
+func NewStudioStore(r *storeRepository, blobStore *BlobStore) *StudioStore {
 	return &StudioStore{
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
@@ -156,8 +159,13 @@ func NewStudioStore(blobStore *BlobStore) *StudioStore {
 				joinTable: studiosTagsTableMgr,
 			},
 		},
+		customFieldsStore: customFieldsStore{
+			table: studiosCustomFieldsTable,
+			fk:    studiosCustomFieldsTable.Col(studioIDColumn),
+		},
 
 		tableMgr: studioTableMgr,
+		repo:     r,
 	}
 }
 
@@ -200,6 +208,10 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 		}
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeStudio, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -210,6 +222,11 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 }
 
 func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPartial) (*models.Studio, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeStudio, input.ID, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := studioRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -244,11 +261,24 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if err := qb.SetCustomFields(ctx, input.ID, input.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.Find(ctx, input.ID)
 }
 
 // This is only used by the Import/Export functionality
 func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeStudio, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r studioRow
 	r.fromStudio(*updatedObject)
 
@@ -272,33 +302,160 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		}
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeStudio, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
 	}
 
-	return studioRepository.destroyExisting(ctx, []int{id})
+	if err := studioRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
+}
+
+// auditValues returns the values of the studio recorded in the audit log.
+func (qb *StudioStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	s, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.StudioPartial{
+		Name:          models.NewOptionalString(s.Name),
+		URL:           models.NewOptionalString(s.URL),
+		ParentID:      models.NewOptionalIntPtr(s.ParentID),
+		Favorite:      models.NewOptionalBool(s.Favorite),
+		Rating:        models.NewOptionalIntPtr(s.Rating),
+		Details:       models.NewOptionalString(s.Details),
+		IgnoreAutoTag: models.NewOptionalBool(s.IgnoreAutoTag),
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.Aliases = auditStrings(aliases)
+
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.TagIDs = auditIDs(tagIDs)
+
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.StashIDs = auditStashIDs(stashIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Merge moves the scenes, images, galleries, groups and child studios of the
+// source studios to the destination studio, and destroys the source studios.
+// The destination studio is not moved if it is a child of a source studio.
+// Other studio fields are not merged.
+func (qb *StudioStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args := append([]interface{}{destination}, srcArgs...)
+
+	studioTables := []struct {
+		table      string
+		objectType models.AuditObjectType
+		values     auditValuesFunc
+	}{
+		{sceneTable, models.AuditObjectTypeScene, qb.repo.Scene.auditValues},
+		{imageTable, models.AuditObjectTypeImage, qb.repo.Image.auditValues},
+		{galleryTable, models.AuditObjectTypeGallery, qb.repo.Gallery.auditValues},
+		{groupTable, models.AuditObjectTypeGroup, qb.repo.Group.auditValues},
+	}
+
+	for _, t := range studioTables {
+		// the studios of the objects are changed by sql, so record the
+		// changes here
+		records, err := beginAuditQuery(ctx, t.objectType, t.values, `SELECT id FROM `+t.table+` WHERE studio_id IN `+inBinding, srcArgs...)
+		if err != nil {
+			return err
+		}
+
+		if _, err := dbWrapper.Exec(ctx, `UPDATE `+t.table+` SET studio_id = ? WHERE studio_id IN `+inBinding, args...); err != nil {
+			return err
+		}
+
+		if err := records.end(ctx, models.AuditActionUpdate); err != nil {
+			return err
+		}
+	}
+
+	// move child studios, excluding the destination studio
+	records, err := beginAuditQuery(ctx, models.AuditObjectTypeStudio, qb.auditValues, `SELECT id FROM `+studioTable+` WHERE parent_id IN `+inBinding+` AND id != ?`, append(srcArgs, destination)...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dbWrapper.Exec(ctx, `UPDATE `+studioTable+` SET parent_id = ? WHERE parent_id IN `+inBinding+` AND id != ?`, append(args, destination)...); err != nil {
+		return err
+	}
+
+	if err := records.end(ctx, models.AuditActionUpdate); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// returns nil, nil if not found
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *StudioStore) Find(ctx context.Context, id int) (*models.Studio, error) {
-	ret, err := qb.find(ctx, id)
+	q := studioRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the studios with the provided ids, in the same order. If content
+// is restricted in the context, hidden studios are returned as nil.
 func (qb *StudioStore) FindMany(ctx context.Context, ids []int) ([]*models.Studio, error) {
 	ret := make([]*models.Studio, len(ids))
 
 	table := qb.table()
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		q := studioRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -314,8 +471,10 @@ func (qb *StudioStore) FindMany(ctx context.Context, ids []int) ([]*models.Studi
 		return nil, err
 	}
 
+	// hidden studios are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range ret {
-		if ret[i] == nil {
+		if ret[i] == nil && !restricted {
 			return nil, fmt.Errorf("studio with id %d not found", ids[i])
 		}
 	}
@@ -384,7 +543,7 @@ func (qb *StudioStore) findBySubquery(ctx context.Context, sq *goqu.SelectDatase
 func (qb *StudioStore) FindChildren(ctx context.Context, id int) ([]*models.Studio, error) {
 	// SELECT studios.* FROM studios WHERE studios.parent_id = ?
 	table := qb.table()
-	sq := qb.selectDataset().Where(table.Col(studioParentIDColumn).Eq(id))
+	sq := studioRestrictions.restrictDataset(ctx, qb.selectDataset().Where(table.Col(studioParentIDColumn).Eq(id)))
 	ret, err := qb.getMany(ctx, sq)
 
 	if err != nil {
@@ -474,13 +633,13 @@ func (qb *StudioStore) FindByStashIDStatus(ctx context.Context, hasStashID bool,
 }
 
 func (qb *StudioStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := studioRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *StudioStore) All(ctx context.Context) ([]*models.Studio, error) {
 	table := qb.table()
-	return qb.getMany(ctx, qb.selectDataset().Order(table.Col(studioNameColumn).Asc()))
+	return qb.getMany(ctx, studioRestrictions.restrictDataset(ctx, qb.selectDataset()).Order(table.Col(studioNameColumn).Asc()))
 }
 
 func (qb *StudioStore) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Studio, error) {
@@ -538,6 +697,8 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 		return nil, err
 	}
 
+	studioRestrictions.addRestrictions(ctx, &query)
+
 	var err error
 	query.sortAndPagination, err = qb.getStudioSort(findFilter)
 	if err != nil {
@@ -111,6 +111,13 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 				studioRepository.galleries.innerJoin(f, "", "studios.id")
 			},
 		},
+
+		&customFieldsFilterHandler{
+			table: studiosCustomFieldsTable.GetTable(),
+			fkCol: studioIDColumn,
+			c:     studioFilter.CustomFields,
+			idCol: "studios.id",
+		},
 	}
 }
 
@@ -1131,3 +1131,82 @@ func TestStudioQueryFast(t *testing.T) {
 // TODO All
 // TODO AllSlim
 // TODO Query
+
+func TestStudioMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		// try merging into same studio
+		err := qb.Merge(ctx, []int{studioIDs[studioIdxWithScene]}, studioIDs[studioIdxWithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			studioIdxWithTwoScenes,
+			studioIdxWithGroup,
+			studioIdxWithImage,
+			studioIdxWithGallery,
+			studioIdxWithParentAndChild,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, studioIDs[idx])
+		}
+
+		destID := studioIDs[studioIdxWithScene]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source studios are deleted
+		for _, id := range srcIDs {
+			s, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(s)
+		}
+
+		// ensure scene points to new studio
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdx1WithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, s.StudioID)
+
+		// ensure image points to new studio
+		i, err := db.Image.Find(ctx, imageIDs[imageIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, i.StudioID)
+
+		// ensure gallery points to new studio
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, g.StudioID)
+
+		// ensure group points to new studio
+		m, err := db.Group.Find(ctx, groupIDs[groupIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, m.StudioID)
+
+		// ensure child studio points to new studio
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithGrandParent])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, child.ParentID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -853,6 +853,13 @@ func (t *relatedFilesTable) setPrimary(ctx context.Context, id int, fileID model
 type viewHistoryTable struct {
 	table
 	dateColumn exp.IdentifierExpression
+	// userColumn is the user that the history belongs to. History of the
+	// configured user has a null user id.
+	userColumn exp.IdentifierExpression
+}
+
+func (t *viewHistoryTable) userCriterion(ctx context.Context) exp.Expression {
+	return userIDCriterion(ctx, t.userColumn)
 }
 
 func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, error) {
@@ -862,6 +869,7 @@ func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, e
 		t.dateColumn,
 	).From(table).Where(
 		t.idColumn.Eq(id),
+		t.userCriterion(ctx),
 	).Order(t.dateColumn.Desc())
 
 	const single = false
@@ -888,6 +896,7 @@ func (t *viewHistoryTable) getManyDates(ctx context.Context, ids []int) ([][]tim
 		t.dateColumn,
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCriterion(ctx),
 	).Order(t.dateColumn.Desc())
 
 	ret := make([][]time.Time, len(ids))
@@ -915,6 +924,7 @@ func (t *viewHistoryTable) getLastDate(ctx context.Context, id int) (*time.Time,
 	table := t.table.table
 	q := dialect.Select(t.dateColumn).From(table).Where(
 		t.idColumn.Eq(id),
+		t.userCriterion(ctx),
 	).Order(t.dateColumn.Desc()).Limit(1)
 
 	var date NullTimestamp
@@ -933,6 +943,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 		goqu.MAX(t.dateColumn),
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCriterion(ctx),
 	).GroupBy(t.idColumn)
 
 	ret := make([]*time.Time, len(ids))
@@ -966,7 +977,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 
 func (t *viewHistoryTable) getCount(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.idColumn.Eq(id))
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.idColumn.Eq(id), t.userCriterion(ctx))
 
 	const single = true
 	var ret int
@@ -990,6 +1001,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 		goqu.COUNT(t.dateColumn),
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCriterion(ctx),
 	).GroupBy(t.idColumn)
 
 	ret := make([]int, len(ids))
@@ -1015,7 +1027,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 
 func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table)
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.userCriterion(ctx))
 
 	const single = true
 	var ret int
@@ -1033,7 +1045,7 @@ func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 
 func (t *viewHistoryTable) getUniqueCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table)
+	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table).Where(t.userCriterion(ctx))
 
 	const single = true
 	var ret int
@@ -1056,10 +1068,12 @@ func (t *viewHistoryTable) addDates(ctx context.Context, id int, dates []time.Ti
 		dates = []time.Time{time.Now()}
 	}
 
+	userID := models.UserIDFromContext(ctx)
+
 	for _, d := range dates {
-		q := dialect.Insert(table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol()).Vals(
+		q := dialect.Insert(table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol(), t.userColumn.GetCol()).Vals(
 			// convert all dates to UTC
-			goqu.Vals{id, UTCTimestamp{Timestamp{d}}},
+			goqu.Vals{id, UTCTimestamp{Timestamp{d}}, userID},
 		)
 
 		if _, err := exec(ctx, q); err != nil {
@@ -1085,10 +1099,12 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 			// delete the most recent
 			subquery = dialect.Select("rowid").From(table).Where(
 				t.idColumn.Eq(id),
+				t.userCriterion(ctx),
 			).Order(t.dateColumn.Desc()).Limit(1)
 		} else {
 			subquery = dialect.Select("rowid").From(table).Where(
 				t.idColumn.Eq(id),
+				t.userCriterion(ctx),
 				t.dateColumn.Eq(UTCTimestamp{Timestamp{date}}),
 			).Limit(1)
 		}
@@ -1105,7 +1121,7 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 
 func (t *viewHistoryTable) deleteAllDates(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Delete(table).Where(t.idColumn.Eq(id))
+	q := dialect.Delete(table).Where(t.idColumn.Eq(id), t.userCriterion(ctx))
 
 	if _, err := exec(ctx, q); err != nil {
 		return 0, fmt.Errorf("resetting dates for id %v: %w", id, err)
@@ -14,12 +14,14 @@ var (
 	performersImagesJoinTable = goqu.T(performersImagesTable)
 	imagesFilesJoinTable      = goqu.T(imagesFilesTable)
 	imagesURLsJoinTable       = goqu.T(imagesURLsTable)
+	imagesCustomFieldsTable   = goqu.T("image_custom_fields")
 
 	galleriesFilesJoinTable      = goqu.T(galleriesFilesTable)
 	galleriesTagsJoinTable       = goqu.T(galleriesTagsTable)
 	performersGalleriesJoinTable = goqu.T(performersGalleriesTable)
 	galleriesScenesJoinTable     = goqu.T(galleriesScenesTable)
 	galleriesURLsJoinTable       = goqu.T(galleriesURLsTable)
+	galleriesCustomFieldsTable   = goqu.T("gallery_custom_fields")
 
 	scenesFilesJoinTable      = goqu.T(scenesFilesTable)
 	scenesTagsJoinTable       = goqu.T(scenesTagsTable)
@@ -27,6 +29,7 @@ var (
 	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
 	scenesGroupsJoinTable     = goqu.T(groupsScenesTable)
 	scenesURLsJoinTable       = goqu.T(scenesURLsTable)
+	scenesCustomFieldsTable   = goqu.T("scene_custom_fields")
 
 	performersAliasesJoinTable  = goqu.T(performersAliasesTable)
 	performersURLsJoinTable     = goqu.T(performerURLsTable)
@@ -37,13 +40,16 @@ var (
 	studiosAliasesJoinTable  = goqu.T(studioAliasesTable)
 	studiosTagsJoinTable     = goqu.T(studiosTagsTable)
 	studiosStashIDsJoinTable = goqu.T("studio_stash_ids")
+	studiosCustomFieldsTable = goqu.T("studio_custom_fields")
 
 	groupsURLsJoinTable     = goqu.T(groupURLsTable)
 	groupsTagsJoinTable     = goqu.T(groupsTagsTable)
 	groupRelationsJoinTable = goqu.T(groupRelationsTable)
+	groupsCustomFieldsTable = goqu.T("group_custom_fields")
 
 	tagsAliasesJoinTable  = goqu.T(tagAliasesTable)
 	tagRelationsJoinTable = goqu.T(tagRelationsTable)
+	tagsCustomFieldsTable = goqu.T("tag_custom_fields")
 )
 
 var (
@@ -92,6 +98,24 @@ var (
 		},
 		valueColumn: imagesURLsJoinTable.Col(imageURLColumn),
 	}
+
+	imagesViewTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(imagesViewDatesTable),
+			idColumn: goqu.T(imagesViewDatesTable).Col(imageIDColumn),
+		},
+		dateColumn: goqu.T(imagesViewDatesTable).Col(imageViewDateColumn),
+		userColumn: goqu.T(imagesViewDatesTable).Col(userIDColumn),
+	}
+
+	imagesOTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(imagesODatesTable),
+			idColumn: goqu.T(imagesODatesTable).Col(imageIDColumn),
+		},
+		dateColumn: goqu.T(imagesODatesTable).Col(imageODateColumn),
+		userColumn: goqu.T(imagesODatesTable).Col(userIDColumn),
+	}
 )
 
 var (
@@ -143,6 +167,24 @@ var (
 		},
 		valueColumn: galleriesURLsJoinTable.Col(galleriesURLColumn),
 	}
+
+	galleriesViewTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(galleriesViewDatesTable),
+			idColumn: goqu.T(galleriesViewDatesTable).Col(galleryIDColumn),
+		},
+		dateColumn: goqu.T(galleriesViewDatesTable).Col(galleryViewDateColumn),
+		userColumn: goqu.T(galleriesViewDatesTable).Col(userIDColumn),
+	}
+
+	galleriesOTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(galleriesODatesTable),
+			idColumn: goqu.T(galleriesODatesTable).Col(galleryIDColumn),
+		},
+		dateColumn: goqu.T(galleriesODatesTable).Col(galleryODateColumn),
+		userColumn: goqu.T(galleriesODatesTable).Col(userIDColumn),
+	}
 )
 
 var (
@@ -209,6 +251,7 @@ var (
 			idColumn: goqu.T(scenesViewDatesTable).Col(sceneIDColumn),
 		},
 		dateColumn: goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		userColumn: goqu.T(scenesViewDatesTable).Col(userIDColumn),
 	}
 
 	scenesOTableMgr = &viewHistoryTable{
@@ -217,6 +260,7 @@ var (
 			idColumn: goqu.T(scenesODatesTable).Col(sceneIDColumn),
 		},
 		dateColumn: goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		userColumn: goqu.T(scenesODatesTable).Col(userIDColumn),
 	}
 )
 
@@ -384,3 +428,29 @@ var (
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
 )
+
+var (
+	jobTableMgr = &table{
+		table:    goqu.T(jobTable),
+		idColumn: goqu.T(jobTable).Col(idColumn),
+	}
+)
+
+var (
+	auditLogTableMgr = &table{
+		table:    goqu.T(auditLogTable),
+		idColumn: goqu.T(auditLogTable).Col(idColumn),
+	}
+)
+
+var (
+	userTableMgr = &table{
+		table:    goqu.T(userTable),
+		idColumn: goqu.T(userTable).Col(idColumn),
+	}
+
+	apiKeyTableMgr = &table{
+		table:    goqu.T(apiKeyTable),
+		idColumn: goqu.T(apiKeyTable).Col(idColumn),
+	}
+)
@@ -146,17 +146,25 @@ var (
 
 type TagStore struct {
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
+
+	repo *storeRepository
 }
 
-func NewTagStore(blobStore *BlobStore) *TagStore {
+func NewTagStore(r *storeRepository, blobStore *BlobStore) *TagStore {
 	return &TagStore{
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: tagTable,
 		},
+		customFieldsStore: customFieldsStore{
+			table: tagsCustomFieldsTable,
+			fk:    tagsCustomFieldsTable.Col(tagIDColumn),
+		},
 		tableMgr: tagTableMgr,
+		repo:     r,
 	}
 }
 
@@ -195,6 +203,10 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 		}
 	}
 
+	if err := auditCreate(ctx, models.AuditObjectTypeTag, id, qb.auditValues); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -206,6 +218,11 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 }
 
 func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.TagPartial) (*models.Tag, error) {
+	record, err := beginAudit(ctx, models.AuditObjectTypeTag, id, qb.auditValues)
+	if err != nil {
+		return nil, err
+	}
+
 	r := tagRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -238,10 +255,23 @@ func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.Ta
 		}
 	}
 
+	if err := qb.SetCustomFields(ctx, id, partial.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := record.end(ctx, models.AuditActionUpdate); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeTag, updatedObject.ID, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	var r tagRow
 	r.fromTag(*updatedObject)
 
@@ -267,10 +297,15 @@ func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error
 		}
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *TagStore) Destroy(ctx context.Context, id int) error {
+	record, err := beginAudit(ctx, models.AuditObjectTypeTag, id, qb.auditValues)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
@@ -288,24 +323,71 @@ func (qb *TagStore) Destroy(ctx context.Context, id int) error {
 		return errors.New("cannot delete tag used as a primary tag in scene markers")
 	}
 
-	return tagRepository.destroyExisting(ctx, []int{id})
+	if err := tagRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return record.end(ctx, models.AuditActionDestroy)
+}
+
+// auditValues returns the values of the tag recorded in the audit log.
+func (qb *TagStore) auditValues(ctx context.Context, id int) (interface{}, error) {
+	t, err := qb.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.TagPartial{
+		Name:          models.NewOptionalString(t.Name),
+		Description:   models.NewOptionalString(t.Description),
+		Favorite:      models.NewOptionalBool(t.Favorite),
+		IgnoreAutoTag: models.NewOptionalBool(t.IgnoreAutoTag),
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.Aliases = auditStrings(aliases)
+
+	parentIDs, err := qb.GetParentIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.ParentIDs = auditIDs(parentIDs)
+
+	childIDs, err := qb.GetChildIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ret.ChildIDs = auditIDs(childIDs)
+
+	ret.CustomFields, err = auditCustomFields(ctx, qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
-// returns nil, nil if not found
+// returns nil, nil if not found or hidden by content restrictions
 func (qb *TagStore) Find(ctx context.Context, id int) (*models.Tag, error) {
-	ret, err := qb.find(ctx, id)
+	q := tagRestrictions.restrictDataset(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+	ret, err := qb.get(ctx, q)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	return ret, err
 }
 
+// FindMany returns the tags with the provided ids, in the same order. If content
+// is restricted in the context, hidden tags are returned as nil.
 func (qb *TagStore) FindMany(ctx context.Context, ids []int) ([]*models.Tag, error) {
 	ret := make([]*models.Tag, len(ids))
 
 	table := qb.table()
 	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
-		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		q := tagRestrictions.restrictDataset(ctx, qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch)))
 		unsorted, err := qb.getMany(ctx, q)
 		if err != nil {
 			return err
@@ -321,8 +403,10 @@ func (qb *TagStore) FindMany(ctx context.Context, ids []int) ([]*models.Tag, err
 		return nil, err
 	}
 
+	// hidden tags are left nil
+	restricted := models.RestrictionsFromContext(ctx) != nil
 	for i := range ret {
-		if ret[i] == nil {
+		if ret[i] == nil && !restricted {
 			return nil, fmt.Errorf("tag with id %d not found", ids[i])
 		}
 	}
@@ -440,7 +524,7 @@ func (qb *TagStore) FindBySceneMarkerID(ctx context.Context, sceneMarkerID int)
 	query := `
 		SELECT tags.* FROM tags
 		LEFT JOIN scene_markers_tags as scene_markers_join on scene_markers_join.tag_id = tags.id
-		WHERE scene_markers_join.scene_marker_id = ?
+		WHERE scene_markers_join.scene_marker_id = ?` + tagRestrictions.andClauses(ctx) + `
 		GROUP BY tags.id
 	`
 	query += qb.getDefaultTagSort()
@@ -550,14 +634,14 @@ func (qb *TagStore) CountByChildTagID(ctx context.Context, childID int) (int, er
 }
 
 func (qb *TagStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := tagRestrictions.restrictDataset(ctx, dialect.Select(goqu.COUNT("*")).From(qb.table()))
 	return count(ctx, q)
 }
 
 func (qb *TagStore) All(ctx context.Context) ([]*models.Tag, error) {
 	table := qb.table()
 
-	return qb.getMany(ctx, qb.selectDataset().Order(
+	return qb.getMany(ctx, tagRestrictions.restrictDataset(ctx, qb.selectDataset()).Order(
 		table.Col("name").Asc(),
 		table.Col(idColumn).Asc(),
 	))
@@ -615,6 +699,8 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 		return nil, 0, err
 	}
 
+	tagRestrictions.addRestrictions(ctx, &query)
+
 	var err error
 	query.sortAndPagination, err = qb.getTagSort(&query, findFilter)
 	if err != nil {
@@ -777,17 +863,42 @@ func (qb *TagStore) Merge(ctx context.Context, source []int, destination int) er
 
 	args = append(args, srcArgs...)
 
-	tagTables := map[string]string{
-		scenesTagsTable:      sceneIDColumn,
-		"scene_markers_tags": "scene_marker_id",
-		galleriesTagsTable:   galleryIDColumn,
-		imagesTagsTable:      imageIDColumn,
-		"performers_tags":    "performer_id",
-		"studios_tags":       "studio_id",
+	// scene markers are not recorded in the audit log
+	tagTables := []struct {
+		table      string
+		idColumn   string
+		objectType models.AuditObjectType
+		values     auditValuesFunc
+	}{
+		{scenesTagsTable, sceneIDColumn, models.AuditObjectTypeScene, qb.repo.Scene.auditValues},
+		{"scene_markers_tags", "scene_marker_id", "", nil},
+		{galleriesTagsTable, galleryIDColumn, models.AuditObjectTypeGallery, qb.repo.Gallery.auditValues},
+		{imagesTagsTable, imageIDColumn, models.AuditObjectTypeImage, qb.repo.Image.auditValues},
+		{"performers_tags", "performer_id", models.AuditObjectTypePerformer, qb.repo.Performer.auditValues},
+		{"studios_tags", "studio_id", models.AuditObjectTypeStudio, qb.repo.Studio.auditValues},
+	}
+
+	// the destination tag gains the names of the source tags as aliases, and
+	// loses any relationships with them
+	record, err := beginAudit(ctx, models.AuditObjectTypeTag, destination, qb.auditValues)
+	if err != nil {
+		return err
 	}
 
 	args = append(args, destination)
-	for table, idColumn := range tagTables {
+	for _, t := range tagTables {
+		table, idColumn := t.table, t.idColumn
+
+		// the tags of the objects are changed by sql, so record the changes
+		// here
+		var records auditRecords
+		if t.values != nil {
+			records, err = beginAuditQuery(ctx, t.objectType, t.values, `SELECT DISTINCT `+idColumn+` FROM `+table+` WHERE tag_id IN `+inBinding, srcArgs...)
+			if err != nil {
+				return err
+			}
+		}
+
 		_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
 SET tag_id = ?
 WHERE tag_id IN `+inBinding+`
@@ -802,9 +913,13 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE tag_id IN `+inBinding, srcArgs...); err != nil {
 			return err
 		}
+
+		if err := records.end(ctx, models.AuditActionUpdate); err != nil {
+			return err
+		}
 	}
 
-	_, err := dbWrapper.Exec(ctx, "UPDATE "+sceneMarkerTable+" SET primary_tag_id = ? WHERE primary_tag_id IN "+inBinding, args...)
+	_, err = dbWrapper.Exec(ctx, "UPDATE "+sceneMarkerTable+" SET primary_tag_id = ? WHERE primary_tag_id IN "+inBinding, args...)
 	if err != nil {
 		return err
 	}
@@ -826,7 +941,7 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		}
 	}
 
-	return nil
+	return record.end(ctx, models.AuditActionUpdate)
 }
 
 func (qb *TagStore) UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error {
@@ -112,6 +112,13 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 				tagRepository.galleries.innerJoin(f, "", "tags.id")
 			},
 		},
+
+		&customFieldsFilterHandler{
+			table: tagsCustomFieldsTable.GetTable(),
+			fkCol: tagIDColumn,
+			c:     tagFilter.CustomFields,
+			idCol: "tags.id",
+		},
 	}
 }
 
@@ -131,5 +131,9 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		Job:            db.Job,
+		User:           db.User,
+		APIKey:         db.APIKey,
+		AuditLog:       db.AuditLog,
 	}
 }
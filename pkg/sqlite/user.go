@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "user_id"
+)
+
+type userRow struct {
+	ID        int       `db:"id" goqu:"skipinsert"`
+	Username  string    `db:"username"`
+	Password  string    `db:"password"`
+	Role      string    `db:"role"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *userRow) fromUser(o models.User) {
+	r.ID = o.ID
+	r.Username = o.Username
+	r.Password = o.Password
+	r.Role = o.Role.String()
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *userRow) resolve() *models.User {
+	return &models.User{
+		ID:        r.ID,
+		Username:  r.Username,
+		Password:  r.Password,
+		Role:      models.UserRole(r.Role),
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+type UserStore struct {
+	tableMgr *table
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{
+		tableMgr: userTableMgr,
+	}
+}
+
+func (qb *UserStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *UserStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *UserStore) Create(ctx context.Context, newObject *models.User) error {
+	var r userRow
+	r.fromUser(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *UserStore) Update(ctx context.Context, updatedObject *models.User) error {
+	var r userRow
+	r.fromUser(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *UserStore) Destroy(ctx context.Context, id int) error {
+	// history and user data are removed by cascade
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) Find(ctx context.Context, id int) (*models.User, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	q := qb.selectDataset().Prepared(true).Where(qb.table().Col("username").Eq(username))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *UserStore) All(ctx context.Context) ([]*models.User, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("username").Asc()))
+}
+
+func (qb *UserStore) find(ctx context.Context, id int) (*models.User, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	return qb.get(ctx, q)
+}
+
+func (qb *UserStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.User, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *UserStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.User, error) {
+	const single = false
+	var ret []*models.User
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f userRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// userIDCriterion returns an expression matching rows of the current user.
+// Rows of the configured user have a null user id.
+func userIDCriterion(ctx context.Context, col exp.IdentifierExpression) exp.Expression {
+	if userID := models.UserIDFromContext(ctx); userID != nil {
+		return col.Eq(*userID)
+	}
+
+	return col.IsNull()
+}
+
+// userIDClause returns a sql clause matching rows of the current user.
+func userIDClause(ctx context.Context, col string) string {
+	if userID := models.UserIDFromContext(ctx); userID != nil {
+		return fmt.Sprintf("%s = %d", col, *userID)
+	}
+
+	return col + " IS NULL"
+}
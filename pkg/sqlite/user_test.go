@@ -0,0 +1,211 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUser(ctx context.Context, t *testing.T, username string) *models.User {
+	u := models.NewUser()
+	u.Username = username
+	u.Password = "hash"
+
+	if err := db.User.Create(ctx, &u); err != nil {
+		t.Fatalf("UserStore.Create() error = %v", err)
+	}
+
+	return &u
+}
+
+func TestUserStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		u := createTestUser(ctx, t, "alice")
+
+		got, err := db.User.FindByUsername(ctx, "alice")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, got) {
+			assert.Equal(t, u.ID, got.ID)
+			assert.Equal(t, "hash", got.Password)
+			assert.Equal(t, models.UserRoleViewer, got.Role)
+		}
+
+		missing, err := db.User.FindByUsername(ctx, "bob")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, missing)
+
+		dupe := models.NewUser()
+		dupe.Username = "alice"
+		dupe.Password = "hash"
+		if err := db.User.Create(ctx, &dupe); err == nil {
+			t.Errorf("UserStore.Create() expected error for duplicate username")
+		}
+
+		u.Username = "carol"
+		if err := db.User.Update(ctx, u); err != nil {
+			t.Errorf("UserStore.Update() error = %v", err)
+			return nil
+		}
+
+		all, err := db.User.All(ctx)
+		if err != nil {
+			t.Errorf("UserStore.All() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, all, 1) {
+			assert.Equal(t, "carol", all[0].Username)
+		}
+
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = db.User.Find(ctx, u.ID)
+		if err != nil {
+			t.Errorf("UserStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
+
+func TestSceneStore_UserData(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		shared, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		sharedViews, err := qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+
+		u := createTestUser(ctx, t, "alice")
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		// new users have no history or ratings
+		got, err := qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got.Rating)
+		assert.Zero(t, got.ResumeTime)
+		assert.Zero(t, got.PlayDuration)
+
+		rating := 80
+		if _, err := qb.UpdatePartial(userCtx, sceneID, models.ScenePartial{
+			Rating: models.NewOptionalInt(rating),
+			Title:  models.NewOptionalString("user title"),
+		}); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		resumeTime := 12.5
+		playDuration := 30.0
+		for i := 0; i < 2; i++ {
+			if _, err := qb.SaveActivity(userCtx, sceneID, &resumeTime, &playDuration); err != nil {
+				t.Errorf("SceneStore.SaveActivity() error = %v", err)
+				return nil
+			}
+		}
+
+		if _, err := qb.AddViews(userCtx, sceneID, nil); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+		if _, err := qb.AddO(userCtx, sceneID, nil); err != nil {
+			t.Errorf("SceneStore.AddO() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &rating, got.Rating)
+		assert.Equal(t, resumeTime, got.ResumeTime)
+		assert.Equal(t, playDuration*2, got.PlayDuration)
+		assert.Equal(t, "user title", got.Title)
+
+		views, err := qb.CountViews(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 1, views)
+
+		oCount, err := qb.GetOCount(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetOCount() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 1, oCount)
+
+		// the shared values are unchanged, apart from the title
+		got, err = qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, shared.Rating, got.Rating)
+		assert.Equal(t, shared.ResumeTime, got.ResumeTime)
+		assert.Equal(t, shared.PlayDuration, got.PlayDuration)
+		assert.Equal(t, "user title", got.Title)
+
+		views, err = qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, sharedViews, views)
+
+		// filters use the values of the user
+		playCount := models.IntCriterionInput{Value: 1, Modifier: models.CriterionModifierEquals}
+		ratingCriterion := models.IntCriterionInput{Value: rating, Modifier: models.CriterionModifierEquals}
+		scenes := queryScene(userCtx, t, qb, &models.SceneFilterType{
+			PlayCount: &playCount,
+			Rating100: &ratingCriterion,
+		}, nil)
+		if assert.Len(t, scenes, 1) {
+			assert.Equal(t, sceneID, scenes[0].ID)
+		}
+
+		// history is removed with the user
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		views, err = qb.CountViews(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Zero(t, views)
+
+		return nil
+	})
+}
@@ -24,6 +24,15 @@ func nullIntPtr(i null.Int) *int {
 	return &v
 }
 
+func nullInt64Ptr(i null.Int) *int64 {
+	if !i.Valid {
+		return nil
+	}
+
+	v := i.Int64
+	return &v
+}
+
 func nullFloatPtr(i null.Float) *float64 {
 	if !i.Valid {
 		return nil
@@ -15,6 +15,7 @@ type FinderImageStashIDGetter interface {
 	models.StudioGetter
 	models.AliasLoader
 	models.StashIDLoader
+	models.CustomFieldsReader
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 }
 
@@ -64,5 +65,10 @@ func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models
 		newStudioJSON.Image = utils.GetBase64StringFromData(image)
 	}
 
+	newStudioJSON.CustomFields, err = reader.GetCustomFields(ctx, studio.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting studio custom fields: %v", err)
+	}
+
 	return &newStudioJSON, nil
 }
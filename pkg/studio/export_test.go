@@ -8,6 +8,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"testing"
 	"time"
@@ -175,6 +176,7 @@ func TestToJSON(t *testing.T) {
 	db.Studio.On("GetImage", testCtx, errImageID).Return(nil, imageErr).Once()
 	db.Studio.On("GetImage", testCtx, missingParentStudioID).Return(imageBytes, nil).Maybe()
 	db.Studio.On("GetImage", testCtx, errStudioID).Return(imageBytes, nil).Maybe()
+	db.Studio.On("GetCustomFields", testCtx, mock.Anything).Return(nil, nil).Maybe()
 
 	parentStudioErr := errors.New("error getting parent studio")
 
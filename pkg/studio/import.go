@@ -199,6 +199,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating studio: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.studio.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.studio.ID
 	return &id, nil
 }
@@ -211,6 +215,24 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing studio: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting studio custom fields: %v", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,240 @@
+package studio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+var ErrMergeDestinationInSource = errors.New("destination studio cannot be in source list")
+
+// Merge merges the source studios into the destination studio, and destroys
+// the source studios.
+//
+// The scenes, images, galleries, groups and child studios of the source
+// studios are moved to the destination studio. The aliases, tags and stash
+// IDs of all studios are combined, and the names of the source studios are
+// added as aliases. If the destination studio has no URL or image, the first
+// URL or image of the source studios is used. Custom fields of the source
+// studios are added where they are not set on the destination studio.
+//
+// If the destination studio is a descendant of a source studio, the
+// destination studio takes the place of the source studio in the hierarchy.
+// Returns ErrStudioOwnAncestor if the merge would otherwise create a cycle.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, qb models.StudioReaderWriter) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	if slices.Contains(sourceIDs, destinationID) {
+		return ErrMergeDestinationInSource
+	}
+
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	dest, err := qb.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination studio %d: %w", destinationID, err)
+	}
+	if dest == nil {
+		return fmt.Errorf("studio with id %d not found", destinationID)
+	}
+
+	sources, err := qb.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source studios: %w", err)
+	}
+
+	// studios hidden by content restrictions are not found
+	for i, src := range sources {
+		if src == nil {
+			return fmt.Errorf("studio with id %d not found", sourceIDs[i])
+		}
+	}
+
+	partial, err := mergeValues(ctx, dest, sources, qb)
+	if err != nil {
+		return err
+	}
+
+	image, err := mergeImage(ctx, dest, sources, qb)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging studios: %w", err)
+	}
+
+	// validate after the source studios are destroyed, so that their names
+	// may be used as aliases, and so that the moved children are considered
+	// when checking for cycles
+	if err := ValidateModify(ctx, partial, qb); err != nil {
+		return err
+	}
+
+	if _, err := qb.UpdatePartial(ctx, partial); err != nil {
+		return fmt.Errorf("updating studio: %w", err)
+	}
+
+	if image != nil {
+		if err := qb.UpdateImage(ctx, destinationID, image); err != nil {
+			return fmt.Errorf("updating studio image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeParentID returns the parent of the destination studio after the merge.
+// If the parent of the destination studio is a source studio, the first
+// ancestor which is not a source studio is returned.
+func mergeParentID(dest *models.Studio, sources []*models.Studio) *int {
+	byID := make(map[int]*models.Studio)
+	for _, s := range sources {
+		byID[s.ID] = s
+	}
+
+	parentID := dest.ParentID
+	for parentID != nil {
+		s, found := byID[*parentID]
+		if !found {
+			break
+		}
+
+		// prevent infinite loops in an existing cyclic hierarchy
+		delete(byID, *parentID)
+		parentID = s.ParentID
+	}
+
+	return parentID
+}
+
+// mergeValues returns a partial to apply to the destination studio, with the
+// values of the destination and source studios combined.
+func mergeValues(ctx context.Context, dest *models.Studio, sources []*models.Studio, qb models.StudioReader) (models.StudioPartial, error) {
+	ret := models.NewStudioPartial()
+	ret.ID = dest.ID
+
+	all := append([]*models.Studio{dest}, sources...)
+	for _, s := range all {
+		if err := s.LoadAliases(ctx, qb); err != nil {
+			return ret, fmt.Errorf("loading studio aliases from %d: %w", s.ID, err)
+		}
+		if err := s.LoadTagIDs(ctx, qb); err != nil {
+			return ret, fmt.Errorf("loading studio tags from %d: %w", s.ID, err)
+		}
+		if err := s.LoadStashIDs(ctx, qb); err != nil {
+			return ret, fmt.Errorf("loading studio stash ids from %d: %w", s.ID, err)
+		}
+	}
+
+	ret.ParentID = models.NewOptionalIntPtr(mergeParentID(dest, sources))
+
+	var aliases []string
+	var tagIDs []int
+	stashIDs := &models.UpdateStashIDs{
+		Mode: models.RelationshipUpdateModeSet,
+	}
+
+	for _, s := range all {
+		if s != dest {
+			aliases = append(aliases, s.Name)
+		}
+		aliases = append(aliases, s.Aliases.List()...)
+		tagIDs = sliceutil.AppendUniques(tagIDs, s.TagIDs.List())
+		for _, stashID := range s.StashIDs.List() {
+			stashIDs.AddUnique(stashID)
+		}
+
+		if !ret.URL.Set && s.URL != "" {
+			ret.URL = models.NewOptionalString(s.URL)
+		}
+	}
+
+	// remove the name of the destination studio from its aliases
+	aliases = slices.DeleteFunc(stringslice.UniqueFold(aliases), func(alias string) bool {
+		return strings.EqualFold(alias, dest.Name)
+	})
+
+	ret.Aliases = &models.UpdateStrings{
+		Values: aliases,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	ret.TagIDs = &models.UpdateIDs{
+		IDs:  tagIDs,
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	ret.StashIDs = stashIDs
+
+	customFields, err := mergeCustomFields(ctx, dest, sources, qb)
+	if err != nil {
+		return ret, err
+	}
+	ret.CustomFields.Partial = customFields
+
+	return ret, nil
+}
+
+// mergeCustomFields returns the custom fields of the source studios which are
+// not set on the destination studio. If a field is set on more than one
+// source studio, the value of the first is used.
+func mergeCustomFields(ctx context.Context, dest *models.Studio, sources []*models.Studio, qb models.CustomFieldsReader) (map[string]interface{}, error) {
+	destFields, err := qb.GetCustomFields(ctx, dest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting custom fields for studio %d: %w", dest.ID, err)
+	}
+
+	ret := make(map[string]interface{})
+	for _, src := range sources {
+		fields, err := qb.GetCustomFields(ctx, src.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting custom fields for studio %d: %w", src.ID, err)
+		}
+
+		for k, v := range fields {
+			if _, found := destFields[k]; found {
+				continue
+			}
+			if _, found := ret[k]; found {
+				continue
+			}
+
+			ret[k] = v
+		}
+	}
+
+	return ret, nil
+}
+
+// mergeImage returns the image of the first source studio with an image, if
+// the destination studio has no image. Returns nil otherwise.
+func mergeImage(ctx context.Context, dest *models.Studio, sources []*models.Studio, qb models.StudioReader) ([]byte, error) {
+	hasImage, err := qb.HasImage(ctx, dest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("checking image for studio %d: %w", dest.ID, err)
+	}
+	if hasImage {
+		return nil, nil
+	}
+
+	for _, src := range sources {
+		image, err := qb.GetImage(ctx, src.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting image for studio %d: %w", src.ID, err)
+		}
+
+		if len(image) > 0 {
+			return image, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,72 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeParentID(t *testing.T) {
+	intPtr := func(v int) *int {
+		return &v
+	}
+
+	newStudio := func(id int, parentID *int) *models.Studio {
+		return &models.Studio{
+			ID:       id,
+			ParentID: parentID,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		dest    *models.Studio
+		sources []*models.Studio
+		want    *int
+	}{
+		{
+			"no parent",
+			newStudio(1, nil),
+			[]*models.Studio{newStudio(2, nil)},
+			nil,
+		},
+		{
+			"parent not in source",
+			newStudio(1, intPtr(3)),
+			[]*models.Studio{newStudio(2, nil)},
+			intPtr(3),
+		},
+		{
+			"parent in source",
+			newStudio(1, intPtr(2)),
+			[]*models.Studio{newStudio(2, intPtr(4))},
+			intPtr(4),
+		},
+		{
+			"ancestors in source",
+			newStudio(1, intPtr(2)),
+			[]*models.Studio{newStudio(2, intPtr(3)), newStudio(3, intPtr(4))},
+			intPtr(4),
+		},
+		{
+			"root in source",
+			newStudio(1, intPtr(2)),
+			[]*models.Studio{newStudio(2, nil)},
+			nil,
+		},
+		{
+			"cyclic sources",
+			newStudio(1, intPtr(2)),
+			[]*models.Studio{newStudio(2, intPtr(3)), newStudio(3, intPtr(2))},
+			intPtr(2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeParentID(tt.dest, tt.sources)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 )
 
 type FinderAliasImageGetter interface {
+	models.CustomFieldsReader
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
@@ -51,6 +52,11 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 
 	newTagJSON.Parents = GetNames(parents)
 
+	newTagJSON.CustomFields, err = reader.GetCustomFields(ctx, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tag custom fields: %v", err)
+	}
+
 	return &newTagJSON, nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"testing"
 	"time"
@@ -135,6 +136,8 @@ func TestToJSON(t *testing.T) {
 	db.Tag.On("FindByChildTagID", testCtx, errParentsID).Return(nil, parentsErr).Once()
 	db.Tag.On("FindByChildTagID", testCtx, errImageID).Return(nil, nil).Once()
 
+	db.Tag.On("GetCustomFields", testCtx, mock.Anything).Return(nil, nil).Maybe()
+
 	for i, s := range scenarios {
 		tag := s.tag
 		json, err := ToJSON(testCtx, db.Tag, &tag)
@@ -104,6 +104,10 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 		return nil, fmt.Errorf("error creating tag: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, i.tag.ID); err != nil {
+		return nil, err
+	}
+
 	id := i.tag.ID
 	return &id, nil
 }
@@ -116,6 +120,24 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 		return fmt.Errorf("error updating existing tag: %v", err)
 	}
 
+	if err := i.setCustomFields(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *Importer) setCustomFields(ctx context.Context, id int) error {
+	if i.Input.CustomFields == nil {
+		return nil
+	}
+
+	if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+		Full: i.Input.CustomFields,
+	}); err != nil {
+		return fmt.Errorf("error setting tag custom fields: %v", err)
+	}
+
 	return nil
 }
 
@@ -314,3 +314,42 @@ func TestUpdate(t *testing.T) {
 
 	db.AssertExpectations(t)
 }
+
+func TestUpdateCustomFields(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	customFields := map[string]interface{}{
+		"field": "value",
+	}
+
+	tag := models.Tag{
+		Name: tagName,
+	}
+
+	i := Importer{
+		ReaderWriter: db.Tag,
+		Input: jsonschema.Tag{
+			Name:         tagName,
+			CustomFields: customFields,
+		},
+		tag: tag,
+	}
+
+	tag.ID = tagID
+	db.Tag.On("Update", testCtx, &tag).Return(nil).Once()
+	db.Tag.On("SetCustomFields", testCtx, tagID, models.CustomFieldsInput{
+		Full: customFields,
+	}).Return(nil).Once()
+
+	err := i.Update(testCtx, tagID)
+	assert.Nil(t, err)
+
+	errSet := errors.New("SetCustomFields error")
+	db.Tag.On("Update", testCtx, mock.Anything).Return(nil).Once()
+	db.Tag.On("SetCustomFields", testCtx, errImageID, mock.Anything).Return(errSet).Once()
+
+	err = i.Update(testCtx, errImageID)
+	assert.NotNil(t, err)
+
+	db.AssertExpectations(t)
+}
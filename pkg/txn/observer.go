@@ -0,0 +1,39 @@
+package txn
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Observation contains the details of a completed transaction.
+type Observation struct {
+	Writable  bool
+	Committed bool
+	Duration  time.Duration
+}
+
+// Observer is called when a transaction completes.
+type Observer func(o Observation)
+
+var observer atomic.Pointer[Observer]
+
+// SetObserver sets the function that is called when a transaction completes.
+// Set to nil to stop observing transactions.
+func SetObserver(o Observer) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+
+	observer.Store(&o)
+}
+
+func observe(writable bool, committed bool, start time.Time) {
+	if o := observer.Load(); o != nil {
+		(*o)(Observation{
+			Writable:  writable,
+			Committed: committed,
+			Duration:  time.Since(start),
+		})
+	}
+}
@@ -4,6 +4,7 @@ package txn
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type Manager interface {
@@ -50,6 +51,8 @@ func WithReadTxn(ctx context.Context, m Manager, fn TxnFunc) error {
 }
 
 func withTxn(ctx context.Context, m Manager, fn TxnFunc, writable bool, execCompleteOnLocked bool) error {
+	start := time.Now()
+
 	// post-hooks should be executed with the outside context
 	txnCtx, err := begin(ctx, m, writable)
 	if err != nil {
@@ -62,12 +65,14 @@ func withTxn(ctx context.Context, m Manager, fn TxnFunc, writable bool, execComp
 		if p := recover(); p != nil {
 			// a panic occurred, rollback and repanic
 			rollback(txnCtx, m)
+			observe(writable, false, start)
 			panic(p)
 		}
 
 		if err != nil {
 			// something went wrong, rollback
 			rollback(txnCtx, m)
+			observe(writable, false, start)
 
 			// execute post-hooks with outside context
 			hookMgr.executePostRollbackHooks(ctx)
@@ -78,6 +83,7 @@ func withTxn(ctx context.Context, m Manager, fn TxnFunc, writable bool, execComp
 		} else {
 			// all good, commit
 			err = commit(txnCtx, m)
+			observe(writable, err == nil, start)
 
 			// execute post-hooks with outside context
 			hookMgr.executePostCommitHooks(ctx)
@@ -0,0 +1,87 @@
+// Package user provides the application logic for user accounts.
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrEmptyUsername = errors.New("username cannot be empty")
+	ErrEmptyPassword = errors.New("password cannot be empty")
+)
+
+type UsernameInUseError struct {
+	Username string
+}
+
+func (e *UsernameInUseError) Error() string {
+	return fmt.Sprintf("username %q is already in use", e.Username)
+}
+
+type Finder interface {
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+}
+
+// HashPassword returns the bcrypt hash of the password.
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", ErrEmptyPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// Authenticate returns the user with the provided username and password.
+// Returns nil if the user does not exist or the password is incorrect.
+func Authenticate(ctx context.Context, r Finder, username string, password string) (*models.User, error) {
+	u, err := r.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if u == nil {
+		return nil, nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+		return nil, nil
+	}
+
+	return u, nil
+}
+
+// ValidateUsername returns an error if the username is empty, matches the
+// reserved username of the configured user, or is used by a user other than
+// the user with the provided id.
+func ValidateUsername(ctx context.Context, r Finder, username string, reserved string, id int) error {
+	if strings.TrimSpace(username) == "" {
+		return ErrEmptyUsername
+	}
+
+	if username == reserved {
+		return &UsernameInUseError{Username: username}
+	}
+
+	existing, err := r.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return &UsernameInUseError{Username: username}
+	}
+
+	return nil
+}
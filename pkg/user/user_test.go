@@ -0,0 +1,115 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	existingUserID   = 1
+	existingUsername = "existing"
+	existingPassword = "password"
+	missingUsername  = "missing"
+	errUsername      = "error"
+	configUsername   = "admin"
+)
+
+var testCtx = context.Background()
+
+func newMockRepository(t *testing.T) *mocks.UserReaderWriter {
+	hash, err := HashPassword(existingPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	r := &mocks.UserReaderWriter{}
+	r.On("FindByUsername", testCtx, existingUsername).Return(&models.User{
+		ID:       existingUserID,
+		Username: existingUsername,
+		Password: hash,
+	}, nil)
+	r.On("FindByUsername", testCtx, missingUsername).Return(nil, nil)
+	r.On("FindByUsername", testCtx, errUsername).Return(nil, errors.New("find error"))
+
+	return r
+}
+
+func TestHashPassword(t *testing.T) {
+	if _, err := HashPassword(""); !errors.Is(err, ErrEmptyPassword) {
+		t.Errorf("HashPassword(\"\") error = %v, want %v", err, ErrEmptyPassword)
+	}
+
+	hash, err := HashPassword(existingPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	assert.NotEqual(t, existingPassword, hash)
+}
+
+func TestAuthenticate(t *testing.T) {
+	r := newMockRepository(t)
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantID   *int
+		wantErr  bool
+	}{
+		{"valid", existingUsername, existingPassword, &[]int{existingUserID}[0], false},
+		{"wrong password", existingUsername, "wrong", nil, false},
+		{"missing user", missingUsername, existingPassword, nil, false},
+		{"error", errUsername, existingPassword, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Authenticate(testCtx, r, tt.username, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantID == nil {
+				assert.Nil(t, got)
+			} else if assert.NotNil(t, got) {
+				assert.Equal(t, *tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	r := newMockRepository(t)
+
+	var usernameInUse *UsernameInUseError
+
+	tests := []struct {
+		name     string
+		username string
+		id       int
+		wantErr  func(err error) bool
+	}{
+		{"new", missingUsername, 0, func(err error) bool { return err == nil }},
+		{"empty", " ", 0, func(err error) bool { return errors.Is(err, ErrEmptyUsername) }},
+		{"config user", configUsername, 0, func(err error) bool { return errors.As(err, &usernameInUse) }},
+		{"existing", existingUsername, 0, func(err error) bool { return errors.As(err, &usernameInUse) }},
+		{"same user", existingUsername, existingUserID, func(err error) bool { return err == nil }},
+		{"error", errUsername, 0, func(err error) bool { return err != nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUsername(testCtx, r, tt.username, configUsername, tt.id)
+			if !tt.wantErr(err) {
+				t.Errorf("ValidateUsername() unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import {
   mutateMetadataScan,
   useImageDecrementO,
   useImageResetO,
+  mutateImageAddView,
 } from "src/core/StashService";
 import { ErrorMessage } from "src/components/Shared/ErrorMessage";
 import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
@@ -257,6 +258,11 @@ const ImagePage: React.FC<IProps> = ({ image }) => {
   }
 
   // set up hotkeys
+  // record a view when the image page is opened
+  useEffect(() => {
+    mutateImageAddView(image.id);
+  }, [image.id]);
+
   useEffect(() => {
     Mousetrap.bind("a", () => setActiveTabKey("image-details-panel"));
     Mousetrap.bind("e", () => setActiveTabKey("image-edit-panel"));
@@ -1,6 +1,6 @@
 import React, { useEffect, useMemo, useState } from "react";
-import { Tabs, Tab, Col, Row } from "react-bootstrap";
-import { useIntl } from "react-intl";
+import { Tabs, Tab, Col, Row, Dropdown } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
 import { useHistory, Redirect, RouteComponentProps } from "react-router-dom";
 import { Helmet } from "react-helmet";
 import cx from "classnames";
@@ -29,6 +29,7 @@ import { PerformerImagesPanel } from "./PerformerImagesPanel";
 import { PerformerAppearsWithPanel } from "./performerAppearsWithPanel";
 import { PerformerEditPanel } from "./PerformerEditPanel";
 import { PerformerSubmitButton } from "./PerformerSubmitButton";
+import { PerformerMergeModal } from "./PerformerMergeDialog";
 import { useRatingKeybinds } from "src/hooks/keybinds";
 import { DetailImage } from "src/components/Shared/DetailImage";
 import { useLoadStickyHeader } from "src/hooks/detailsPanel";
@@ -45,6 +46,8 @@ import { FavoriteIcon } from "src/components/Shared/FavoriteIcon";
 import { AliasList } from "src/components/Shared/DetailsPage/AliasList";
 import { HeaderImage } from "src/components/Shared/DetailsPage/HeaderImage";
 import { LightboxLink } from "src/hooks/Lightbox/LightboxLink";
+import { Icon } from "src/components/Shared/Icon";
+import { faSignInAlt, faSignOutAlt } from "@fortawesome/free-solid-svg-icons";
 
 interface IProps {
   performer: GQL.PerformerDataFragment;
@@ -218,6 +221,7 @@ const PerformerPage: React.FC<IProps> = ({ performer, tabKey }) => {
   const [isEditing, setIsEditing] = useState<boolean>(false);
   const [image, setImage] = useState<string | null>();
   const [encodingImage, setEncodingImage] = useState<boolean>(false);
+  const [mergeType, setMergeType] = useState<"from" | "into" | undefined>();
   const loadStickyHeader = useLoadStickyHeader();
 
   const activeImage = useMemo(() => {
@@ -308,6 +312,50 @@ const PerformerPage: React.FC<IProps> = ({ performer, tabKey }) => {
     setImage(undefined);
   }
 
+  function renderMergeButton() {
+    return (
+      <Dropdown>
+        <Dropdown.Toggle variant="secondary">
+          <FormattedMessage id="actions.merge" />
+          ...
+        </Dropdown.Toggle>
+        <Dropdown.Menu
+          className="bg-secondary text-white"
+          id="performer-merge-menu"
+        >
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            onClick={() => setMergeType("from")}
+          >
+            <Icon icon={faSignInAlt} />
+            <FormattedMessage id="actions.merge_from" />
+            ...
+          </Dropdown.Item>
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            onClick={() => setMergeType("into")}
+          >
+            <Icon icon={faSignOutAlt} />
+            <FormattedMessage id="actions.merge_into" />
+            ...
+          </Dropdown.Item>
+        </Dropdown.Menu>
+      </Dropdown>
+    );
+  }
+
+  function renderMergeDialog() {
+    if (!mergeType) return;
+    return (
+      <PerformerMergeModal
+        performer={performer}
+        onClose={() => setMergeType(undefined)}
+        show={!!mergeType}
+        mergeType={mergeType}
+      />
+    );
+  }
+
   function setFavorite(v: boolean) {
     if (performer.id) {
       updatePerformer({
@@ -433,9 +481,12 @@ const PerformerPage: React.FC<IProps> = ({ performer, tabKey }) => {
                       onImageChange={() => {}}
                       classNames="mb-2"
                       customButtons={
-                        <div>
-                          <PerformerSubmitButton performer={performer} />
-                        </div>
+                        <>
+                          <div>
+                            <PerformerSubmitButton performer={performer} />
+                          </div>
+                          {renderMergeButton()}
+                        </>
                       }
                     ></DetailsEditNavbar>
                   </Row>
@@ -463,6 +514,7 @@ const PerformerPage: React.FC<IProps> = ({ performer, tabKey }) => {
           </div>
         </div>
       </div>
+      {renderMergeDialog()}
     </div>
   );
 };
@@ -514,6 +514,20 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
 
     function timeupdate(this: VideoJsPlayer) {
       if (this.paused()) return;
+
+      // the direct stream is not trimmed, so keep it within the trimmed range
+      const src = this.currentSrc();
+      if (src && new URL(src).pathname.endsWith("/stream")) {
+        const time = this.currentTime();
+        if (scene.start_point != null && time < scene.start_point) {
+          this.currentTime(scene.start_point);
+        } else if (scene.end_point != null && time >= scene.end_point) {
+          this.pause();
+          this.trigger("ended");
+          return;
+        }
+      }
+
       if (scene.interactive && interactiveReady.current) {
         interactiveClient.ensurePlaying(this.currentTime());
       }
@@ -570,15 +584,23 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       player.mobileUi(mobileUiOptions);
     }
 
+    function isUntrimmed(src: URL) {
+      return src.pathname.endsWith("/stream");
+    }
+
     function isDirect(src: URL) {
       return (
         src.pathname.endsWith("/stream") ||
         src.pathname.endsWith("/stream.mpd") ||
-        src.pathname.endsWith("/stream.m3u8")
+        src.pathname.endsWith("/stream.m3u8") ||
+        src.pathname.endsWith("/stream_copy.m3u8")
       );
     }
 
-    const { duration } = file;
+    // streams other than the direct stream are trimmed by the server
+    const trimmed = scene.start_point != null || scene.end_point != null;
+    const trimStart = trimmed ? scene.start_point ?? 0 : undefined;
+    const duration = Math.min(scene.end_point ?? Infinity, file.duration);
     const sourceSelector = player.sourceSelector();
     sourceSelector.setSources(
       scene.sceneStreams
@@ -597,6 +619,7 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
             label: stream.label ?? undefined,
             offset: !isDirect(src),
             duration,
+            trimStart: isUntrimmed(src) ? undefined : trimStart,
           };
         })
     );
@@ -657,10 +680,11 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
     if (
       !startPosition &&
       !alwaysStartFromBeginning &&
-      file.duration > resumeTime
+      duration > resumeTime
     ) {
       startPosition = resumeTime;
     }
+    startPosition = Math.max(startPosition, scene.start_point ?? 0);
 
     setTime(startPosition);
 
@@ -4,6 +4,9 @@ import videojs, { VideoJsPlayer } from "video.js";
 export interface ISource extends videojs.Tech.SourceObject {
   offset?: boolean;
   duration?: number;
+  // time in the video file that the source starts at, for sources
+  // trimmed by the server
+  trimStart?: number;
 }
 
 interface ICue extends TextTrackCue {
@@ -57,7 +60,9 @@ function offsetMiddleware(player: VideoJsPlayer) {
   const loadSource = debounce(
     (seconds: number) => {
       const srcUrl = new URL(source.src);
-      srcUrl.searchParams.set("start", seconds.toString());
+      // the start parameter is relative to the start of the trimmed range
+      const start = seconds - (source.trimStart ?? 0);
+      srcUrl.searchParams.set("start", start.toString());
       source.src = srcUrl.toString();
 
       const poster = player.poster();
@@ -124,9 +129,9 @@ function offsetMiddleware(player: VideoJsPlayer) {
       next: (err: unknown, src: videojs.Tech.SourceObject) => void
     ) {
       if (srcObj.offset && srcObj.duration) {
-        updateOffsetStart(0);
+        updateOffsetStart(srcObj.trimStart ?? 0);
       } else {
-        updateOffsetStart(undefined);
+        updateOffsetStart(srcObj.trimStart);
       }
       source = srcObj;
       next(null, srcObj);
@@ -163,6 +168,14 @@ function offsetMiddleware(player: VideoJsPlayer) {
         return seconds;
       }
 
+      // trimmed sources cannot play before the start of the trimmed range
+      seconds = Math.max(seconds, source.trimStart ?? 0);
+
+      // sources which are not reloaded on seek have a fixed offset
+      if (!source.offset || !source.duration) {
+        return seconds - offsetStart;
+      }
+
       const offsetSeconds = seconds - offsetStart;
       const buffers = tech.buffered() as TimeRanges;
       for (let i = 0; i < buffers.length; i++) {
@@ -13,6 +13,7 @@ import { Helmet } from "react-helmet";
 import * as GQL from "src/core/generated-graphql";
 import {
   mutateMetadataScan,
+  mutateSceneExportTrimmed,
   useFindScene,
   useSceneIncrementO,
   useSceneGenerateScreenshot,
@@ -313,6 +314,11 @@ const ScenePage: React.FC<IProps> = ({
     );
   }
 
+  async function onExportTrimmed() {
+    await mutateSceneExportTrimmed(scene.id);
+    Toast.success(intl.formatMessage({ id: "toast.exporting_trimmed_scene" }));
+  }
+
   async function onGenerateScreenshot(at?: number) {
     await generateScreenshot({
       variables: {
@@ -393,6 +399,16 @@ const ScenePage: React.FC<IProps> = ({
         >
           <FormattedMessage id="actions.generate_thumb_default" />
         </Dropdown.Item>
+        {!!scene.files.length &&
+          (scene.start_point != null || scene.end_point != null) && (
+            <Dropdown.Item
+              key="export-trimmed"
+              className="bg-secondary text-white"
+              onClick={() => onExportTrimmed()}
+            >
+              <FormattedMessage id="actions.export_trimmed_file" />
+            </Dropdown.Item>
+          )}
         {boxes.length > 0 && (
           <Dropdown.Item
             key="submit"
@@ -114,6 +114,23 @@ export const SceneEditPanel: React.FC<IProps> = ({
     urls: yupUniqueStringList(intl),
     date: yupDateString(intl),
     director: yup.string().ensure(),
+    start_point: yup.number().min(0).nullable().defined(),
+    end_point: yup
+      .number()
+      .min(0)
+      .nullable()
+      .defined()
+      .test(
+        "is-greater-than-start-point",
+        intl.formatMessage({ id: "validation.end_point_before_start_point" }),
+        function (value) {
+          return (
+            value === null ||
+            this.parent.start_point === null ||
+            value > this.parent.start_point
+          );
+        }
+      ),
     gallery_ids: yup.array(yup.string().required()).defined(),
     studio_id: yup.string().required().nullable(),
     performer_ids: yup.array(yup.string().required()).defined(),
@@ -138,6 +155,8 @@ export const SceneEditPanel: React.FC<IProps> = ({
       urls: scene.urls ?? [],
       date: scene.date ?? "",
       director: scene.director ?? "",
+      start_point: scene.start_point ?? null,
+      end_point: scene.end_point ?? null,
       gallery_ids: (scene.galleries ?? []).map((g) => g.id),
       studio_id: scene.studio?.id ?? null,
       performer_ids: (scene.performers ?? []).map((p) => p.id),
@@ -594,6 +613,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
   const {
     renderField,
     renderInputField,
+    renderDurationField,
     renderDateField,
     renderURLListField,
     renderStashIDsField,
@@ -737,6 +757,8 @@ export const SceneEditPanel: React.FC<IProps> = ({
 
             {renderDateField("date")}
             {renderInputField("director")}
+            {renderDurationField("start_point")}
+            {renderDurationField("end_point")}
 
             {renderGalleriesField()}
             {renderStudioField()}
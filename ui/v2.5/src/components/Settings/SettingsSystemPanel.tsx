@@ -320,6 +320,14 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <NumberSetting
+          id="transcode-cache-size"
+          headingID="config.general.transcode_cache_max_size_head"
+          subHeadingID="config.general.transcode_cache_max_size_desc"
+          value={general.transcodeCacheMaxSize ?? undefined}
+          onChange={(v) => saveGeneral({ transcodeCacheMaxSize: v })}
+        />
+
         <BooleanSetting
           id="hardware-encoding"
           headingID="config.general.ffmpeg.hardware_acceleration.heading"
@@ -1,4 +1,4 @@
-import { Tabs, Tab, Form } from "react-bootstrap";
+import { Tabs, Tab, Form, Dropdown } from "react-bootstrap";
 import React, { useEffect, useMemo, useState } from "react";
 import { useHistory, Redirect, RouteComponentProps } from "react-router-dom";
 import { FormattedMessage, useIntl } from "react-intl";
@@ -30,7 +30,13 @@ import {
   StudioDetailsPanel,
 } from "./StudioDetailsPanel";
 import { StudioGroupsPanel } from "./StudioGroupsPanel";
-import { faTrashAlt } from "@fortawesome/free-solid-svg-icons";
+import { StudioMergeModal } from "./StudioMergeDialog";
+import {
+  faSignInAlt,
+  faSignOutAlt,
+  faTrashAlt,
+} from "@fortawesome/free-solid-svg-icons";
+import { Icon } from "src/components/Shared/Icon";
 import { RatingSystem } from "src/components/Shared/Rating/RatingSystem";
 import { DetailImage } from "src/components/Shared/DetailImage";
 import { useRatingKeybinds } from "src/hooks/keybinds";
@@ -280,6 +286,7 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
   // Editing studio state
   const [image, setImage] = useState<string | null>();
   const [encodingImage, setEncodingImage] = useState<boolean>(false);
+  const [mergeType, setMergeType] = useState<"from" | "into" | undefined>();
 
   const [updateStudio] = useStudioUpdate();
   const [deleteStudio] = useStudioDestroy({ id: studio.id });
@@ -416,6 +423,50 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
     setImage(undefined);
   }
 
+  function renderMergeButton() {
+    return (
+      <Dropdown>
+        <Dropdown.Toggle variant="secondary">
+          <FormattedMessage id="actions.merge" />
+          ...
+        </Dropdown.Toggle>
+        <Dropdown.Menu
+          className="bg-secondary text-white"
+          id="studio-merge-menu"
+        >
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            onClick={() => setMergeType("from")}
+          >
+            <Icon icon={faSignInAlt} />
+            <FormattedMessage id="actions.merge_from" />
+            ...
+          </Dropdown.Item>
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            onClick={() => setMergeType("into")}
+          >
+            <Icon icon={faSignOutAlt} />
+            <FormattedMessage id="actions.merge_into" />
+            ...
+          </Dropdown.Item>
+        </Dropdown.Menu>
+      </Dropdown>
+    );
+  }
+
+  function renderMergeDialog() {
+    if (!mergeType) return;
+    return (
+      <StudioMergeModal
+        studio={studio}
+        onClose={() => setMergeType(undefined)}
+        show={!!mergeType}
+        mergeType={mergeType}
+      />
+    );
+  }
+
   function setRating(v: number | null) {
     if (studio.id) {
       updateStudio({
@@ -511,6 +562,7 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
                   onAutoTag={onAutoTag}
                   autoTagDisabled={studio.ignore_auto_tag}
                   onDelete={onDelete}
+                  customButtons={renderMergeButton()}
                 />
               )}
             </div>
@@ -537,6 +589,7 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
         </div>
       </div>
       {renderDeleteAlert()}
+      {renderMergeDialog()}
     </div>
   );
 };
@@ -0,0 +1,144 @@
+import { Form, Col, Row } from "react-bootstrap";
+import React, { useState } from "react";
+import * as GQL from "src/core/generated-graphql";
+import { ModalComponent } from "src/components/Shared/Modal";
+import * as FormUtils from "src/utils/form";
+import { useStudiosMerge } from "src/core/StashService";
+import { useIntl } from "react-intl";
+import { useToast } from "src/hooks/Toast";
+import { useHistory } from "react-router-dom";
+import { faSignInAlt, faSignOutAlt } from "@fortawesome/free-solid-svg-icons";
+import { Studio, StudioSelect } from "../StudioSelect";
+
+interface IStudioMergeModalProps {
+  show: boolean;
+  onClose: () => void;
+  studio: Pick<GQL.Studio, "id">;
+  mergeType: "from" | "into";
+}
+
+export const StudioMergeModal: React.FC<IStudioMergeModalProps> = ({
+  show,
+  onClose,
+  studio,
+  mergeType,
+}) => {
+  const [src, setSrc] = useState<Studio[]>([]);
+  const [dest, setDest] = useState<Studio | null>(null);
+
+  const [running, setRunning] = useState(false);
+
+  const [mergeStudios] = useStudiosMerge();
+
+  const intl = useIntl();
+  const Toast = useToast();
+  const history = useHistory();
+
+  const title = intl.formatMessage({
+    id: mergeType === "from" ? "actions.merge_from" : "actions.merge_into",
+  });
+
+  async function onMerge() {
+    const source = mergeType === "from" ? src.map((s) => s.id) : [studio.id];
+    const destination = mergeType === "from" ? studio.id : dest?.id ?? null;
+
+    if (!destination) return;
+
+    try {
+      setRunning(true);
+      const result = await mergeStudios({
+        variables: {
+          source,
+          destination,
+        },
+      });
+      if (result.data?.studiosMerge) {
+        Toast.success(intl.formatMessage({ id: "toast.merged_studios" }));
+        onClose();
+        history.push(`/studios/${destination}`);
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setRunning(false);
+    }
+  }
+
+  function canMerge() {
+    return (
+      (mergeType === "from" && src.length > 0) ||
+      (mergeType === "into" && dest !== null)
+    );
+  }
+
+  return (
+    <ModalComponent
+      show={show}
+      header={title}
+      icon={mergeType === "from" ? faSignInAlt : faSignOutAlt}
+      accept={{
+        text: intl.formatMessage({ id: "actions.merge" }),
+        onClick: () => onMerge(),
+      }}
+      disabled={!canMerge()}
+      cancel={{
+        variant: "secondary",
+        onClick: () => onClose(),
+      }}
+      isRunning={running}
+    >
+      <div className="form-container row px-3">
+        <div className="col-12 col-lg-6 col-xl-12">
+          {mergeType === "from" && (
+            <Form.Group controlId="source" as={Row}>
+              {FormUtils.renderLabel({
+                title: intl.formatMessage({
+                  id: "dialogs.merge_studios.source",
+                }),
+                labelProps: {
+                  column: true,
+                  sm: 3,
+                  xl: 12,
+                },
+              })}
+              <Col sm={9} xl={12}>
+                <StudioSelect
+                  isMulti
+                  creatable={false}
+                  onSelect={(items) => setSrc(items)}
+                  values={src}
+                  excludeIds={studio?.id ? [studio.id] : []}
+                  menuPortalTarget={document.body}
+                />
+              </Col>
+            </Form.Group>
+          )}
+          {mergeType === "into" && (
+            <Form.Group controlId="destination" as={Row}>
+              {FormUtils.renderLabel({
+                title: intl.formatMessage({
+                  id: "dialogs.merge_studios.destination",
+                }),
+                labelProps: {
+                  column: true,
+                  sm: 3,
+                  xl: 12,
+                },
+              })}
+              <Col sm={9} xl={12}>
+                <StudioSelect
+                  isMulti={false}
+                  creatable={false}
+                  onSelect={(items) => setDest(items[0])}
+                  values={dest ? [dest] : undefined}
+                  excludeIds={studio?.id ? [studio.id] : []}
+                  menuPortalTarget={document.body}
+                />
+              </Col>
+            </Form.Group>
+          )}
+        </div>
+      </div>
+    </ModalComponent>
+  );
+};
@@ -804,6 +804,12 @@ export const useSceneResetActivity = (
 export const useSceneGenerateScreenshot = () =>
   GQL.useSceneGenerateScreenshotMutation();
 
+export const mutateSceneExportTrimmed = (id: string) =>
+  client.mutate<GQL.SceneExportTrimmedMutation>({
+    mutation: GQL.SceneExportTrimmedDocument,
+    variables: { id },
+  });
+
 export const mutateSceneSetPrimaryFile = (id: string, fileID: string) =>
   client.mutate<GQL.SceneUpdateMutation>({
     mutation: GQL.SceneUpdateDocument,
@@ -1274,6 +1280,45 @@ export const mutateImageResetO = (id: string) =>
     update: updateImageResetO(id),
   });
 
+function updateViewHistory(
+  typename: "Image" | "Gallery",
+  id: string,
+  history: unknown[]
+) {
+  return (cache: ApolloCache<Record<string, StoreObject>>) => {
+    cache.modify({
+      id: cache.identify({ __typename: typename, id }),
+      fields: {
+        view_count() {
+          return history.length;
+        },
+        last_viewed_at() {
+          // assume only one entry - or the first is the most recent
+          return history[0] ?? null;
+        },
+        view_history() {
+          return history;
+        },
+      },
+    });
+  };
+}
+
+export const mutateImageAddView = (id: string) =>
+  client.mutate<GQL.ImageAddViewMutation>({
+    mutation: GQL.ImageAddViewDocument,
+    variables: { id },
+    update(cache, result) {
+      const mutationResult = result.data?.imageAddView;
+      if (!mutationResult) return;
+
+      updateViewHistory("Image", id, mutationResult.history)(cache);
+      evictQueries(cache, [
+        GQL.FindImagesDocument, // filter by view count
+      ]);
+    },
+  });
+
 export const mutateImageSetPrimaryFile = (id: string, fileID: string) =>
   client.mutate<GQL.ImageUpdateMutation>({
     mutation: GQL.ImageUpdateDocument,
@@ -1585,6 +1630,42 @@ export const useGalleryDestroy = (input: GQL.GalleryDestroyInput) =>
     },
   });
 
+export const mutateGalleryAddView = (id: string) =>
+  client.mutate<GQL.GalleryAddViewMutation>({
+    mutation: GQL.GalleryAddViewDocument,
+    variables: { id },
+    update(cache, result) {
+      const mutationResult = result.data?.galleryAddView;
+      if (!mutationResult) return;
+
+      updateViewHistory("Gallery", id, mutationResult.history)(cache);
+      evictQueries(cache, [
+        GQL.FindGalleriesDocument, // filter by view count
+      ]);
+    },
+  });
+
+export const mutateGallerySaveActivity = (
+  id: string,
+  lastImageIndex: number
+) =>
+  client.mutate<GQL.GallerySaveActivityMutation>({
+    mutation: GQL.GallerySaveActivityDocument,
+    variables: { id, last_image_index: lastImageIndex },
+    update(cache, result) {
+      if (!result.data?.gallerySaveActivity) return;
+
+      cache.modify({
+        id: cache.identify({ __typename: "Gallery", id }),
+        fields: {
+          last_image_index() {
+            return lastImageIndex;
+          },
+        },
+      });
+    },
+  });
+
 export const mutateAddGalleryImages = (input: GQL.GalleryAddInput) =>
   client.mutate<GQL.AddGalleryImagesMutation>({
     mutation: GQL.AddGalleryImagesDocument,
@@ -1843,6 +1924,39 @@ export const usePerformersDestroy = (
     },
   });
 
+export const usePerformersMerge = () =>
+  GQL.usePerformersMergeMutation({
+    update(cache, result, { variables }) {
+      if (!result.data?.performersMerge || !variables) return;
+
+      const { source, destination } = variables.input;
+
+      const sourceIDs = Array.isArray(source) ? source : [source];
+      for (const id of sourceIDs) {
+        const obj = { __typename: "Performer", id };
+        deleteObject(cache, obj, GQL.FindPerformerDocument);
+      }
+
+      updateStats(cache, "performer_count", -sourceIDs.length);
+
+      const obj = { __typename: "Performer", id: destination };
+      evictTypeFields(
+        cache,
+        {
+          ...performerMutationImpactedTypeFields,
+          Performer: ["performer_count"],
+          Studio: ["performer_count"],
+        },
+        cache.identify(obj) // don't evict destination performer
+      );
+      evictQueries(cache, [
+        ...performerMutationImpactedQueries,
+        GQL.FindGroupsDocument, // filter by performers
+        GQL.FindSceneMarkersDocument, // filter by performers
+      ]);
+    },
+  });
+
 const studioMutationImpactedTypeFields = {
   Studio: ["child_studios"],
 };
@@ -1936,6 +2050,25 @@ export const useStudiosDestroy = (input: GQL.StudiosDestroyMutationVariables) =>
     },
   });
 
+export const useStudiosMerge = () =>
+  GQL.useStudiosMergeMutation({
+    update(cache, result, { variables }) {
+      if (!result.data?.studiosMerge || !variables) return;
+
+      const { source } = variables;
+
+      for (const id of source) {
+        const obj = { __typename: "Studio", id };
+        deleteObject(cache, obj, GQL.FindStudioDocument);
+      }
+
+      updateStats(cache, "studio_count", -source.length);
+
+      evictTypeFields(cache, studioMutationImpactedTypeFields);
+      evictQueries(cache, studioMutationImpactedQueries);
+    },
+  });
+
 const tagMutationImpactedTypeFields = {
   Tag: ["parents", "children"],
 };
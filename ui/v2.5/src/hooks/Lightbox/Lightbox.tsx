@@ -90,6 +90,7 @@ interface IProps {
   pageCallback?: (props: { direction?: number; page?: number }) => void;
   chapters?: IChapter[];
   hide: () => void;
+  onIndexChange?: (index: number) => void;
 }
 
 export const LightboxComponent: React.FC<IProps> = ({
@@ -105,6 +106,7 @@ export const LightboxComponent: React.FC<IProps> = ({
   pageCallback,
   chapters = [],
   hide,
+  onIndexChange,
 }) => {
   const [updateImage] = useImageUpdate();
 
@@ -489,6 +491,20 @@ export const LightboxComponent: React.FC<IProps> = ({
 
   const currentIndex = index === null ? initialIndex : index;
 
+  useEffect(() => {
+    if (!onIndexChange || isSwitchingPage || !images.length) return;
+    if (currentIndex < 0 || currentIndex >= images.length) return;
+
+    onIndexChange((page ? (page - 1) * pageSize : 0) + currentIndex);
+  }, [
+    onIndexChange,
+    isSwitchingPage,
+    images.length,
+    currentIndex,
+    page,
+    pageSize,
+  ]);
+
   function gotoPage(imageIndex: number) {
     const indexInPage = (imageIndex - 1) % pageSize;
     if (pageCallback) {
@@ -17,6 +17,8 @@ export interface IState {
   pageSize?: number;
   slideshowEnabled: boolean;
   onClose?: () => void;
+  // called with the index of the current image across all pages
+  onIndexChange?: (index: number) => void;
 }
 interface IContext {
   lightboxState: IState;
@@ -1,5 +1,10 @@
 import { useCallback, useEffect, useMemo, useState } from "react";
 import * as GQL from "src/core/generated-graphql";
+import {
+  mutateGalleryAddView,
+  mutateGallerySaveActivity,
+} from "src/core/StashService";
+import { useDebounce } from "../debounce";
 import { IState, useLightboxContext } from "./context";
 import { IChapter } from "./types";
 
@@ -19,6 +24,7 @@ export const useLightbox = (
       pageSize: state.pageSize,
       slideshowEnabled: state.slideshowEnabled,
       onClose: state.onClose,
+      onIndexChange: state.onIndexChange,
     });
   }, [
     setLightboxState,
@@ -30,6 +36,7 @@ export const useLightbox = (
     state.pageSize,
     state.slideshowEnabled,
     state.onClose,
+    state.onIndexChange,
   ]);
 
   const show = useCallback(
@@ -106,6 +113,12 @@ export const useGalleryLightbox = (id: string, chapters: IChapter[] = []) => {
     [page, pages]
   );
 
+  // save the last image viewed, so that viewing can be resumed
+  const onIndexChange = useDebounce(
+    (index: number) => mutateGallerySaveActivity(id, index),
+    1000
+  );
+
   useEffect(() => {
     if (data)
       setLightboxState({
@@ -115,10 +128,13 @@ export const useGalleryLightbox = (id: string, chapters: IChapter[] = []) => {
         pageCallback: pages > 1 ? handleLightBoxPage : undefined,
         page,
         pages,
+        onIndexChange,
       });
-  }, [setLightboxState, data, handleLightBoxPage, page, pages]);
+  }, [setLightboxState, data, handleLightBoxPage, page, pages, onIndexChange]);
 
   const show = (index: number = 0) => {
+    mutateGalleryAddView(id);
+
     if (index > pageSize) {
       setPage(Math.floor(index / pageSize) + 1);
       index = index % pageSize;
@@ -136,6 +152,7 @@ export const useGalleryLightbox = (id: string, chapters: IChapter[] = []) => {
         pages,
         pageSize,
         chapters: chapters,
+        onIndexChange,
       });
     else {
       setLightboxState({
@@ -146,6 +163,7 @@ export const useGalleryLightbox = (id: string, chapters: IChapter[] = []) => {
         page: undefined,
         pageSize,
         chapters: chapters,
+        onIndexChange,
       });
       fetchGallery();
     }
@@ -24,9 +24,19 @@ import { PathCriterionOption } from "./criteria/path";
 
 const defaultSortBy = "path";
 
-const sortByOptions = ["date", ...MediaSortByOptions]
+const sortByOptions = [
+  "date",
+  "last_viewed_at",
+  "last_o_at",
+  "view_count",
+  ...MediaSortByOptions,
+]
   .map(ListFilterOptions.createSortBy)
   .concat([
+    {
+      messageID: "o_count",
+      value: "o_counter",
+    },
     {
       messageID: "image_count",
       value: "images_count",
@@ -52,6 +62,9 @@ const criterionOptions = [
   createStringCriterionOption("checksum", "media_info.checksum"),
   RatingCriterionOption,
   OrganizedCriterionOption,
+  createMandatoryNumberCriterionOption("o_counter", "o_count"),
+  createMandatoryNumberCriterionOption("view_count"),
+  createMandatoryTimestampCriterionOption("last_viewed_at"),
   AverageResolutionCriterionOption,
   GalleryIsMissingCriterionOption,
   TagsCriterionOption,
@@ -25,7 +25,15 @@ import { GalleriesCriterionOption } from "./criteria/galleries";
 
 const defaultSortBy = "path";
 
-const sortByOptions = ["filesize", "file_count", "date", ...MediaSortByOptions]
+const sortByOptions = [
+  "filesize",
+  "file_count",
+  "date",
+  "last_viewed_at",
+  "last_o_at",
+  "view_count",
+  ...MediaSortByOptions,
+]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {
@@ -44,6 +52,8 @@ const criterionOptions = [
   GalleriesCriterionOption,
   OrganizedCriterionOption,
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
+  createMandatoryNumberCriterionOption("view_count"),
+  createMandatoryTimestampCriterionOption("last_viewed_at"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
   ImageIsMissingCriterionOption,